@@ -0,0 +1,274 @@
+package main
+
+import (
+        "errors"
+        "fmt"
+        "io/fs"
+        "path"
+        "path/filepath"
+        "sort"
+        "strings"
+        "sync"
+
+        "github.com/openhands/mcp-generator/internal/hardhat"
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/spf13/cobra"
+)
+
+// batchResult is the outcome of generating the server of one contract of a batch
+type batchResult struct {
+        artifact    string
+        destination string
+        contractIR  *ir.ContractIR
+        err         error
+}
+
+// expandArtifacts expands the globs among artifact paths, and reports whether they make a batch: several artifacts,
+// or any glob, even one matching a single file
+// Globs match with ** across directories, skip sidecar annotation files and Hardhat's .dbg.json files, and must match
+// at least one file. The deployments directory of a hardhat-deploy network, deployments/<network>, stands for its
+// deployment files.
+func expandArtifacts(patterns []string) ([]string, bool, error) {
+        batch := len(patterns) > 1
+        var paths []string
+        seen := map[string]bool{}
+        for _, pattern := range patterns {
+                if pattern == stdinPath && len(patterns) > 1 {
+                        return nil, false, errors.New("stdin (-) can only be the one artifact of a generation")
+                }
+                matches := []string{pattern}
+                if hardhat.IsNetworkDir(pattern) {
+                        // The deployments directory of a hardhat-deploy network generates each deployed contract
+                        batch = true
+                        var err error
+                        if matches, err = hardhat.Contracts(pattern); err != nil {
+                                return nil, false, err
+                        }
+                        if len(matches) == 0 {
+                                return nil, false, fmt.Errorf("no deployments in %s", pattern)
+                        }
+                } else if strings.ContainsAny(pattern, "*?[") {
+                        batch = true
+                        var err error
+                        if matches, err = globArtifacts(pattern); err != nil {
+                                return nil, false, fmt.Errorf("invalid artifact glob %s: %w", pattern, err)
+                        }
+                        if len(matches) == 0 {
+                                return nil, false, fmt.Errorf("no artifacts match %s", pattern)
+                        }
+                }
+                for _, match := range matches {
+                        if !seen[match] {
+                                seen[match] = true
+                                paths = append(paths, match)
+                        }
+                }
+        }
+        return paths, batch, nil
+}
+
+// globArtifacts returns the artifact files matching a glob, sorted
+func globArtifacts(pattern string) ([]string, error) {
+        var matches []string
+        if !strings.Contains(pattern, "**") {
+                var err error
+                if matches, err = filepath.Glob(pattern); err != nil {
+                        return nil, err
+                }
+        } else {
+                // Directories are walked from the longest prefix of the glob without wildcards
+                segments := strings.Split(path.Clean(filepath.ToSlash(pattern)), "/")
+                root := 0
+                for root < len(segments) && !strings.ContainsAny(segments[root], "*?[") {
+                        root++
+                }
+                dir := filepath.FromSlash(strings.Join(segments[:root], "/"))
+                if dir == "" {
+                        dir = "."
+                }
+                err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+                        if err != nil {
+                                return err
+                        }
+                        if !entry.IsDir() && matchSegments(segments[root:], strings.Split(filepath.ToSlash(relativeTo(dir, file)), "/")) {
+                                matches = append(matches, file)
+                        }
+                        return nil
+                })
+                if err != nil && !errors.Is(err, fs.ErrNotExist) {
+                        return nil, err
+                }
+        }
+
+        artifacts := matches[:0]
+        for _, match := range matches {
+                if !ir.IsAnnotationsFile(match) && !strings.HasSuffix(match, ".dbg.json") {
+                        artifacts = append(artifacts, match)
+                }
+        }
+        sort.Strings(artifacts)
+        return artifacts, nil
+}
+
+// matchSegments matches path segments against glob segments, where ** matches any number of segments
+func matchSegments(pattern, segments []string) bool {
+        if len(pattern) == 0 {
+                return len(segments) == 0
+        }
+        if pattern[0] == "**" {
+                for i := 0; i <= len(segments); i++ {
+                        if matchSegments(pattern[1:], segments[i:]) {
+                                return true
+                        }
+                }
+                return false
+        }
+        if len(segments) == 0 {
+                return false
+        }
+        matched, err := path.Match(pattern[0], segments[0])
+        return err == nil && matched && matchSegments(pattern[1:], segments[1:])
+}
+
+// relativeTo returns a path relative to a directory it is in
+func relativeTo(dir, file string) string {
+        rel, err := filepath.Rel(dir, file)
+        if err != nil {
+                return file
+        }
+        return rel
+}
+
+// generateBatch generates a server per artifact into subdirectories of --output mirroring the artifacts' paths below
+// their common directory, e.g. ./mcp-server/tokens/Token for artifacts/tokens/Token.json, then reports the outcome
+// of each. Contracts are generated concurrently by --jobs workers; a failed contract does not stop the others, but
+// fails the batch.
+func generateBatch(cmd *cobra.Command, paths []string, filter *ir.Projection, logger *logging.Logger, report *generationReport) error {
+        if outputFormat == output.FormatStdout {
+                return errors.New("--output-format stdout streams a single server; generate a batch as directories or archives")
+        }
+        for _, name := range []string{"name", "address"} {
+                if cmd.Flags().Changed(name) {
+                        return fmt.Errorf("--%s applies to a single contract; set it per contract in IR files or annotations", name)
+                }
+        }
+
+        base := filepath.Dir(paths[0])
+        for _, file := range paths[1:] {
+                for base != "." && base != string(filepath.Separator) && strings.HasPrefix(relativeTo(base, file), "..") {
+                        base = filepath.Dir(base)
+                }
+        }
+        results := make([]batchResult, len(paths))
+        destinations := map[string]string{}
+        for i, file := range paths {
+                rel := relativeTo(base, file)
+                dir := filepath.Join(outputDir, strings.TrimSuffix(rel, filepath.Ext(rel)))
+                if other, ok := destinations[dir]; ok {
+                        return fmt.Errorf("%s and %s would both be generated in %s", other, file, dir)
+                }
+                destinations[dir] = file
+                results[i] = batchResult{artifact: file, destination: dir}
+        }
+
+        // Contracts are parsed and rendered by a pool of workers, each logging a contract's messages once it is done, or
+        // with plain output once the contracts before it are, so that CI logs are in artifact order; prompts for new tool
+        // names are answered one contract at a time
+        workers := jobs
+        if workers < 1 {
+                return usageError(fmt.Errorf("--jobs must be at least 1, got %d", jobs))
+        }
+        if onCollision == collisionsPrompt {
+                workers = 1
+        }
+        if workers > len(results) {
+                workers = len(results)
+        }
+        indexes := make(chan int)
+        var wg sync.WaitGroup
+        var flushing sync.Mutex
+        loggers := make([]*logging.Logger, len(results))
+        flushed := 0
+        for w := 0; w < workers; w++ {
+                wg.Add(1)
+                go func() {
+                        defer wg.Done()
+                        for i := range indexes {
+                                result := &results[i]
+                                contractLogger := logger
+                                if workers > 1 {
+                                        contractLogger = logger.Buffered()
+                                }
+                                contractLogger.Infof("[%d/%d] %s", i+1, len(results), result.artifact)
+                                entry := report.contract(i, result.artifact, result.destination)
+                                result.contractIR, result.err = generate(cmd, result.artifact, result.destination, filter, entry.logger(contractLogger), entry)
+                                entry.finish(result.contractIR, result.err)
+                                if result.err != nil {
+                                        contractLogger.Errorf("%v", result.err)
+                                }
+                                if !plainOutput || workers == 1 {
+                                        contractLogger.Flush()
+                                        continue
+                                }
+                                flushing.Lock()
+                                loggers[i] = contractLogger
+                                for flushed < len(loggers) && loggers[flushed] != nil {
+                                        loggers[flushed].Flush()
+                                        flushed++
+                                }
+                                flushing.Unlock()
+                        }
+                }()
+        }
+        for i := range results {
+                indexes <- i
+        }
+        close(indexes)
+        wg.Wait()
+
+        failed := 0
+        for _, result := range results {
+                if result.err != nil {
+                        failed++
+                }
+        }
+
+        logger.Infof("Batch: %d of %d servers generated in %s", len(results)-failed, len(results), outputDir)
+        for _, result := range results {
+                if result.err != nil {
+                        message, _, _ := strings.Cut(strings.TrimPrefix(result.err.Error(), result.artifact+": "), "\n")
+                        logger.Infof("  failed     %s: %s", result.artifact, message)
+                        continue
+                }
+                destination, _ := output.Path(outputFormat, result.destination)
+                logger.Infof("  generated  %s -> %s (%s: %d functions, %d events)", result.artifact, destination,
+                        result.contractIR.Metadata.Name, len(result.contractIR.Functions), len(result.contractIR.Events))
+        }
+        if failed > 0 {
+                return batchError(results, failed)
+        }
+        return nil
+}
+
+// batchError reports the failed contracts of a batch, with the cause they share or else a general failure, and
+// their artifacts, causes and messages as details
+func batchError(results []batchResult, failed int) error {
+        failure := &cliError{err: fmt.Errorf("%d of %d contracts failed to generate", failed, len(results))}
+        var details []map[string]interface{}
+        for _, result := range results {
+                if result.err == nil {
+                        continue
+                }
+                cause := classify(result.err)
+                if failure.cause == "" {
+                        failure.cause, failure.exitCode = cause.cause, cause.exitCode
+                } else if failure.cause != cause.cause {
+                        failure.cause, failure.exitCode = "error", exitFailure
+                }
+                details = append(details, map[string]interface{}{"artifact": result.artifact, "cause": cause.cause, "message": result.err.Error()})
+        }
+        failure.details = details
+        return failure
+}
\ No newline at end of file
@@ -0,0 +1,39 @@
+package main
+
+import (
+        "fmt"
+
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/npm"
+        "github.com/spf13/cobra"
+)
+
+// newCacheCommand returns the cache command, which manages the cache of ABIs fetched from explorers and of npm packages
+func newCacheCommand() *cobra.Command {
+        cacheCmd := &cobra.Command{
+                Use:   "cache",
+                Short: "Manage the cache of ABIs fetched from Sourcify and block explorers, and of npm packages",
+        }
+        cacheCmd.AddCommand(&cobra.Command{
+                Use:   "clear",
+                Short: "Remove the cached ABIs and packages, so that explorer and npm artifacts are fetched again",
+                Long: `Remove the verified ABIs of explorer:<network>/<address> artifacts cached in --fetch-cache-dir, and the packages
+of npm:<package>/<file> artifacts cached in --npm-cache-dir. Cached ABIs are otherwise fetched again once older than
+--fetch-cache-ttl, and cached packages are kept, as their versions never change; both are fetched again on every
+generation with --no-cache.`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        count, err := explorer.ClearCache(fetchOptions.CacheDir)
+                        if err != nil {
+                                return ioError(fmt.Errorf("failed to clear %s: %w", fetchOptions.CacheDir, err))
+                        }
+                        fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached ABIs from %s\n", count, fetchOptions.CacheDir)
+                        if count, err = npm.ClearCache(npmOptions.CacheDir); err != nil {
+                                return ioError(fmt.Errorf("failed to clear %s: %w", npmOptions.CacheDir, err))
+                        }
+                        fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached npm packages from %s\n", count, npmOptions.CacheDir)
+                        return nil
+                },
+        })
+        return cacheCmd
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package main
+
+import (
+        "fmt"
+
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/spf13/cobra"
+)
+
+// newCleanCommand returns the command removing the generated files of a project
+func newCleanCommand() *cobra.Command {
+        var force, dryRun bool
+        cleanCmd := &cobra.Command{
+                Use:   "clean [dir]",
+                Short: "Remove the generated files of a project, keeping files added by users",
+                Long: `Remove exactly the files listed in the lock file (mcpgen.lock.json) of a generated project directory
+(default: ./mcp-server), then the lock file and the directories left empty, so that servers generated into shared
+directories can be cleaned or regenerated safely. Files the lock file does not list were added by users and are kept.
+
+Generated files edited since they were generated are kept too, with the lock file, unless --force is given;
+--dry-run lists the files that would be removed.`,
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        dir := "./mcp-server"
+                        if len(args) > 0 {
+                                dir = args[0]
+                        }
+                        lock, err := output.ReadLock(dir)
+                        if err != nil {
+                                return ioError(err)
+                        }
+                        if lock == nil {
+                                return usageError(fmt.Errorf("%s has no %s, so which of its files were generated is unknown", dir, output.LockFile))
+                        }
+
+                        removed, kept, err := lock.Clean(dir, force, dryRun)
+                        if err != nil {
+                                return ioError(err)
+                        }
+                        out := cmd.OutOrStdout()
+                        verb := "Removed"
+                        if dryRun {
+                                verb = "Would remove"
+                        }
+                        for _, path := range removed {
+                                fmt.Fprintf(out, "%s %s\n", verb, path)
+                        }
+                        for _, path := range kept {
+                                if path != output.LockFile {
+                                        fmt.Fprintf(out, "Kept %s (modified since it was generated; --force removes it)\n", path)
+                                }
+                        }
+                        fmt.Fprintf(out, "%s %d generated files from %s\n", verb, len(removed), dir)
+                        return nil
+                },
+        }
+        cleanCmd.Flags().BoolVar(&force, "force", false, "Also remove generated files that were modified since they were generated")
+        cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the files that would be removed without removing them")
+        return cleanCmd
+}
\ No newline at end of file
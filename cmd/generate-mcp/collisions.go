@@ -0,0 +1,157 @@
+package main
+
+import (
+        "bufio"
+        "errors"
+        "fmt"
+        "io"
+        "strconv"
+        "strings"
+
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/spf13/cobra"
+)
+
+// How tool name collisions are handled, set with --on-collision
+const (
+        // collisionsFail fails generation with a report of the collisions
+        collisionsFail = "fail"
+
+        // collisionsPrompt asks on stdin for new names of the colliding tools
+        collisionsPrompt = "prompt"
+
+        // collisionsSuffix keeps the numeric suffixes of overloads whose names are taken, with a warning
+        collisionsSuffix = "suffix"
+)
+
+// prompts reads the answers to prompts, buffered across the contracts of a batch
+var prompts struct {
+        in     io.Reader
+        reader *bufio.Reader
+}
+
+// checkCollisions checks the tool names of a contract once its IR is final, before its server is generated: tools
+// mapping to the same identifier, and overloads named with a numeric suffix because their name was taken
+// Collisions fail generation unless they are renamed at the prompt; suffixed overloads are kept with --on-collision
+// suffix.
+func checkCollisions(cmd *cobra.Command, contractIR *ir.ContractIR, artifactPath string, logger *logging.Logger) error {
+        collisions := ir.FindCollisions(contractIR, enableWrites)
+        if collisions.Empty() {
+                return nil
+        }
+
+        switch onCollision {
+        case collisionsFail:
+        case collisionsSuffix:
+                for _, suffixed := range collisions.Suffixed {
+                        logger.Warnf("overload %s is named %s, as %s is taken by another function", suffixed.Signature, suffixed.Name, suffixed.Taken)
+                }
+                collisions.Suffixed = nil
+        case collisionsPrompt:
+                if artifactPath == stdinPath {
+                        return usageError(errors.New("--on-collision prompt reads new names from stdin, which holds the artifact"))
+                }
+                if plainOutput {
+                        return usageError(errors.New("--on-collision prompt asks for new names, which plain output does not (as when stdout is not a terminal); use --on-collision fail or suffix, or --plain=false"))
+                }
+                if err := promptRenames(cmd, contractIR, collisions, logger); err != nil {
+                        return err
+                }
+                // Overloads whose suffixed names were kept at the prompt are accepted as named
+                collisions = ir.FindCollisions(contractIR, enableWrites)
+                collisions.Suffixed = nil
+        default:
+                return usageError(fmt.Errorf("unsupported collision handling: %s (expected fail, prompt or suffix)", onCollision))
+        }
+        if collisions.Empty() {
+                return nil
+        }
+
+        var lines []string
+        for _, suffixed := range collisions.Suffixed {
+                lines = append(lines, fmt.Sprintf("overload %s would be named %s, as %s is taken by another function", suffixed.Signature, suffixed.Name, suffixed.Taken))
+        }
+        for _, collision := range collisions.Tools {
+                lines = append(lines, fmt.Sprintf("tools %s map to the same name %s", strings.Join(collision.Names, ", "), collision.Key))
+        }
+        message := fmt.Sprintf("%d tool name collisions (rename the functions in an overlay, choose another --overload-naming, or rename them with --on-collision prompt):", len(lines))
+        return validationError(errors.New(message+"\n  "+strings.Join(lines, "\n  ")), collisions)
+}
+
+// promptRenames asks for new names of suffixed overloads and colliding functions, renames them as an overlay would,
+// and logs the overlay keeping the names when regenerating
+func promptRenames(cmd *cobra.Command, contractIR *ir.ContractIR, collisions *ir.Collisions, logger *logging.Logger) error {
+        if prompts.in != cmd.InOrStdin() {
+                prompts.in = cmd.InOrStdin()
+                prompts.reader = bufio.NewReader(prompts.in)
+        }
+        out := cmd.ErrOrStderr()
+        overlay := &ir.Overlay{Functions: map[string]ir.FunctionOverlay{}}
+        var keys []string
+        asked := map[string]bool{}
+        ask := func(f *ir.Function, question string) error {
+                key := f.Name
+                if f.Overload != nil {
+                        key = f.Overload.Signature
+                }
+                if asked[key] {
+                        return nil
+                }
+                asked[key] = true
+                fmt.Fprintf(out, "%s\n  New name for %s [%s]: ", question, key, f.Name)
+                answer, err := prompts.reader.ReadString('\n')
+                if err != nil && (answer == "" || !errors.Is(err, io.EOF)) {
+                        return usageError(fmt.Errorf("no new name for %s on stdin: %w", key, err))
+                }
+                if name := strings.TrimSpace(answer); name != "" && name != f.Name {
+                        overlay.Functions[key] = ir.FunctionOverlay{Name: name}
+                        keys = append(keys, key)
+                }
+                return nil
+        }
+
+        function := func(name string) *ir.Function {
+                for i := range contractIR.Functions {
+                        if contractIR.Functions[i].Name == name {
+                                return &contractIR.Functions[i]
+                        }
+                }
+                return nil
+        }
+        for _, suffixed := range collisions.Suffixed {
+                if f := function(suffixed.Name); f != nil {
+                        if err := ask(f, fmt.Sprintf("Overload %s is named %s, as %s is taken by another function.", suffixed.Signature, suffixed.Name, suffixed.Taken)); err != nil {
+                                return err
+                        }
+                }
+        }
+        for _, collision := range collisions.Tools {
+                // One of the functions keeps its name, unless an event tool has it
+                names := collision.Functions
+                if len(names) == len(collision.Names) {
+                        names = names[1:]
+                }
+                for _, name := range names {
+                        if f := function(name); f != nil {
+                                question := fmt.Sprintf("Tools %s map to the same name %s.", strings.Join(collision.Names, ", "), collision.Key)
+                                if err := ask(f, question); err != nil {
+                                        return err
+                                }
+                        }
+                }
+        }
+        if len(keys) == 0 {
+                return nil
+        }
+
+        if _, err := overlay.Apply(contractIR); err != nil {
+                return usageError(err)
+        }
+        snippet := "functions:"
+        for _, key := range keys {
+                snippet += fmt.Sprintf("\n  %s:\n    name: %s", strconv.Quote(key), overlay.Functions[key].Name)
+        }
+        logger.Infof("Renamed %d tools; keep the names when regenerating with an overlay (--overlay):\n%s", len(keys), snippet)
+        return nil
+}
\ No newline at end of file
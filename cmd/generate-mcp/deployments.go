@@ -0,0 +1,132 @@
+package main
+
+import (
+        "fmt"
+        "os"
+        "path/filepath"
+        "sort"
+        "strconv"
+
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/foundry"
+        "github.com/openhands/mcp-generator/internal/hardhat"
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/npm"
+)
+
+// applyBroadcasts adds the deployments forge script recorded for a contract in the broadcast directory of its Foundry
+// project (--broadcast, or else the project containing the artifact or the working directory) to its IR
+// Without --address, the contract's address becomes its deployment on the recorded network, or on the one chain it
+// was deployed on.
+func applyBroadcasts(contractIR *ir.ContractIR, artifactPath string, logger *logging.Logger) error {
+        dir := broadcastDir
+        if dir != "" {
+                if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+                        return usageError(fmt.Errorf("broadcast directory %s does not exist", dir))
+                }
+        } else {
+                root := ""
+                if artifactPath != stdinPath && !explorer.IsReference(artifactPath) && !npm.IsReference(artifactPath) {
+                        root = foundry.ProjectRoot(filepath.Dir(artifactPath))
+                }
+                if root == "" {
+                        root = foundry.ProjectRoot(".")
+                }
+                if root == "" {
+                        return nil
+                }
+                dir = filepath.Join(root, foundry.BroadcastDirName)
+        }
+
+        // Contracts are broadcast under their Solidity name, which --name may have changed
+        name := contractIR.Metadata.Name
+        deployments, err := foundry.Deployments(dir, name)
+        if err == nil && len(deployments) == 0 && artifactPath != stdinPath && contractFileName(artifactPath) != name {
+                name = contractFileName(artifactPath)
+                deployments, err = foundry.Deployments(dir, name)
+        }
+        if err != nil {
+                return ioError(fmt.Errorf("failed to read Foundry broadcasts: %w", err))
+        }
+        found := map[uint64]ir.Deployment{}
+        sources := map[uint64]string{}
+        for chainID, deployment := range deployments {
+                found[chainID] = ir.Deployment{Address: deployment.Address, Block: deployment.Block}
+                sources[chainID] = "broadcast by " + deployment.Script
+        }
+        var defaultChain uint64
+        if len(deployments) == 1 {
+                defaultChain = foundry.ChainIDs(deployments)[0]
+        }
+        addDeployments(contractIR, name, found, sources, defaultChain, logger)
+        return nil
+}
+
+// applyHardhatDeployments adds the deployments of a contract generated from a hardhat-deploy deployment file,
+// deployments/<network>/<contract>.json, to its IR: its deployment on that network and on every other network of
+// the deployments directory
+// Without --address, the contract's address becomes its deployment on the recorded network, or else on the network
+// of the file.
+func applyHardhatDeployments(contractIR *ir.ContractIR, artifactPath string, logger *logging.Logger) error {
+        own, err := hardhat.ReadDeployment(artifactPath)
+        if err != nil {
+                return parseError(err)
+        }
+        name := contractFileName(artifactPath)
+        deployments, err := hardhat.Deployments(filepath.Dir(filepath.Dir(artifactPath)), name)
+        if err != nil {
+                return parseError(fmt.Errorf("failed to read hardhat-deploy deployments: %w", err))
+        }
+        deployments[own.ChainID] = *own
+        found := map[uint64]ir.Deployment{}
+        sources := map[uint64]string{}
+        for chainID, deployment := range deployments {
+                found[chainID] = ir.Deployment{Address: deployment.Address, Block: deployment.Block}
+                sources[chainID] = "deployed to " + deployment.Network + " by hardhat-deploy"
+        }
+        addDeployments(contractIR, name, found, sources, own.ChainID, logger)
+        return nil
+}
+
+// addDeployments adds the deployments of a contract found in the output of a deployment tool to its IR, by chain ID,
+// keeping the deployments it already has, such as those given with --deployment
+// Without --address, the contract's address becomes its deployment on the recorded network, or else on the default
+// chain, whose network is then recorded as with --network.
+func addDeployments(contractIR *ir.ContractIR, name string, found map[uint64]ir.Deployment, sources map[uint64]string, defaultChain uint64, logger *logging.Logger) {
+        chainIDs := make([]uint64, 0, len(found))
+        for chainID := range found {
+                chainIDs = append(chainIDs, chainID)
+        }
+        sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+        for _, chainID := range chainIDs {
+                if _, ok := contractIR.Metadata.DeploymentOn(chainID); ok {
+                        continue
+                }
+                if contractIR.Metadata.Deployments == nil {
+                        contractIR.Metadata.Deployments = map[string]ir.Deployment{}
+                }
+                deployment := found[chainID]
+                contractIR.Metadata.Deployments[strconv.FormatUint(chainID, 10)] = deployment
+                logger.Infof("Deployment: %s at %s on chain %d, %s", name, deployment.Address, chainID, sources[chainID])
+        }
+
+        if contractIR.Metadata.Address != "" || len(chainIDs) == 0 {
+                return
+        }
+        chainID := uint64(contractIR.Metadata.Network().ChainID)
+        if chainID == 0 && defaultChain != 0 {
+                chainID = defaultChain
+                preset, ok := ir.NetworkByChainID(int(chainID))
+                if !ok {
+                        preset = ir.Network{ChainID: int(chainID)}
+                }
+                contractIR.Metadata.SetNetwork(preset)
+        }
+        if deployment, ok := contractIR.Metadata.DeploymentOn(chainID); ok && chainID > 0 {
+                contractIR.Metadata.Address = deployment.Address
+                logger.Infof("Address: %s, deployed on chain %d", deployment.Address, chainID)
+        } else if chainID == 0 {
+                logger.Infof("Address: %s was deployed to %d chains; pass --network or --address to choose its address", name, len(chainIDs))
+        }
+}
\ No newline at end of file
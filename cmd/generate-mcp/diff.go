@@ -0,0 +1,43 @@
+package main
+
+import (
+        "errors"
+        "fmt"
+        "io"
+
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/spf13/cobra"
+)
+
+// diffDir prints the unified diff of a generated project against its output directory, and fails with the changed
+// files if there are any
+func diffDir(cmd *cobra.Command, files map[string][]byte, dir string, logger *logging.Logger) error {
+        changes, err := output.DiffDir(files, dir)
+        if err != nil {
+                return ioError(err)
+        }
+        if len(changes) == 0 {
+                logger.Infof("Generated files are up to date in %s", dir)
+                return nil
+        }
+
+        out := cmd.OutOrStdout()
+        color := colorEnabled(out)
+        counts := map[string]int{}
+        for _, change := range changes {
+                counts[change.Kind]++
+                if change.Diff == "" {
+                        fmt.Fprintf(out, "Binary file %s %s\n", change.Path, change.Kind)
+                        continue
+                }
+                if color {
+                        io.WriteString(out, colorDiff(change.Diff))
+                        continue
+                }
+                io.WriteString(out, change.Diff)
+        }
+        message := fmt.Sprintf("%d generated files differ from %s (%d modified, %d added, %d removed); regenerate to update them",
+                len(changes), dir, counts[output.ChangeModified], counts[output.ChangeAdded], counts[output.ChangeRemoved])
+        return changesError(errors.New(message), changes)
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package main
+
+import (
+        "encoding/json"
+        "errors"
+        "fmt"
+        "io"
+)
+
+// Exit codes of generate-mcp by cause of failure, so that wrappers and CI can branch on them
+const (
+        // exitFailure is the exit code of failures of no more specific cause
+        exitFailure = 1
+
+        // exitUsage is the exit code of invalid flags and arguments
+        exitUsage = 2
+
+        // exitParse is the exit code of artifacts, API descriptions and IR files that cannot be parsed
+        exitParse = 3
+
+        // exitValidation is the exit code of IRs failing validation (errors, or warnings with --strict)
+        exitValidation = 4
+
+        // exitRender is the exit code of failures to render the server from its templates
+        exitRender = 5
+
+        // exitIO is the exit code of files that cannot be read or written
+        exitIO = 6
+
+        // exitChanges is the exit code of --diff finding generated files that differ from the output directory
+        exitChanges = 7
+
+        // exitVerify is the exit code of generated servers failing --verify, e.g. not type-checking
+        exitVerify = 8
+
+        // exitConflict is the exit code of generations that would overwrite edited or user files of the output directory
+        exitConflict = 9
+)
+
+// Error formats of failures
+const (
+        errorFormatText = "text"
+        errorFormatJSON = "json"
+)
+
+// cliError is a failure with its cause, which sets the exit code, and optional details such as validation findings
+type cliError struct {
+        cause    string
+        exitCode int
+        err      error
+        details  interface{}
+}
+
+// Error returns the message of the failure
+func (e *cliError) Error() string {
+        return e.err.Error()
+}
+
+// Unwrap returns the failure's underlying error
+func (e *cliError) Unwrap() error {
+        return e.err
+}
+
+// usageError, parseError, renderError and ioError mark an error with its cause
+func usageError(err error) error  { return &cliError{cause: "usage", exitCode: exitUsage, err: err} }
+func parseError(err error) error  { return &cliError{cause: "parse", exitCode: exitParse, err: err} }
+func renderError(err error) error { return &cliError{cause: "render", exitCode: exitRender, err: err} }
+func ioError(err error) error     { return &cliError{cause: "io", exitCode: exitIO, err: err} }
+
+// changesError marks the changes --diff found, with the changed files as details
+func changesError(err error, changes interface{}) error {
+        return &cliError{cause: "changes", exitCode: exitChanges, err: err, details: changes}
+}
+
+// verifyError marks the failure of a generated server's verification
+func verifyError(err error) error { return &cliError{cause: "verify", exitCode: exitVerify, err: err} }
+
+// conflictError marks existing files a generation would clobber, with the conflicting files as details
+func conflictError(err error, conflicts interface{}) error {
+        return &cliError{cause: "conflict", exitCode: exitConflict, err: err, details: conflicts}
+}
+
+// validationError marks an error as a validation failure, with its findings as details
+func validationError(err error, findings interface{}) error {
+        return &cliError{cause: "validation", exitCode: exitValidation, err: err, details: findings}
+}
+
+// classify returns the cause of an error, found anywhere in its chain of wrapped errors; errors of no known cause
+// are general failures
+func classify(err error) *cliError {
+        var failure *cliError
+        if errors.As(err, &failure) {
+                return failure
+        }
+        return &cliError{cause: "error", exitCode: exitFailure, err: err}
+}
+
+// writeError writes a failure as text, or as a JSON object with its cause, exit code, message and details, e.g.
+// {"error": {"cause": "validation", "exitCode": 4, "message": "invalid IR: ...", "details": [...]}}
+func writeError(out io.Writer, err error, format string) {
+        if format != errorFormatJSON {
+                fmt.Fprintln(out, err)
+                return
+        }
+        failure := classify(err)
+        entry := map[string]interface{}{
+                "cause":    failure.cause,
+                "exitCode": failure.exitCode,
+                "message":  err.Error(),
+        }
+        if failure.details != nil {
+                entry["details"] = failure.details
+        }
+        encoder := json.NewEncoder(out)
+        encoder.SetIndent("", "  ")
+        encoder.Encode(map[string]interface{}{"error": entry})
+}
\ No newline at end of file
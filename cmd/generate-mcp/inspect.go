@@ -0,0 +1,104 @@
+package main
+
+import (
+        "encoding/json"
+        "fmt"
+        "io"
+        "strings"
+
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/spf13/cobra"
+)
+
+// newInspectCommand returns the command summarizing an artifact's interface
+func newInspectCommand() *cobra.Command {
+        var chain string
+        var asJSON bool
+        inspectCmd := &cobra.Command{
+                Use:   "inspect <artifact>",
+                Short: "Summarize the functions, events, errors and types of an artifact",
+                Long: `Parse a contract artifact (ABI/IDL), API description or IR file and print a readable summary of its interface:
+functions grouped by state mutability with their tool names, signatures, selectors, inputs and outputs, then events with their
+topics, custom errors with their selectors, and the tuple types declared once for all functions and events using them.
+Annotations are applied as for generation. --json writes the summary for scripts.`,
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        contractIR, err := loadContract(args[0], ir.ContractMetadata{Chain: chain})
+                        if err == nil {
+                                err = applyAnnotations(contractIR, args[0], "", commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+                        ir.DeduplicateTuples(contractIR)
+
+                        inspection := ir.Inspect(contractIR)
+                        if asJSON {
+                                encoder := json.NewEncoder(cmd.OutOrStdout())
+                                encoder.SetIndent("", "  ")
+                                return encoder.Encode(inspection)
+                        }
+                        writeInspection(cmd.OutOrStdout(), inspection)
+                        return nil
+                },
+        }
+        inspectCmd.Flags().StringVarP(&chain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        inspectCmd.Flags().BoolVar(&asJSON, "json", false, "Write the summary as JSON")
+        return inspectCmd
+}
+
+// writeInspection writes a contract summary as text, one member per line under a heading per section
+// Functions are listed by tool name in a column of their own, before their signature in the ABI.
+func writeInspection(out io.Writer, inspection *ir.Inspection) {
+        fmt.Fprintf(out, "%s (%s)\n", inspection.Name, inspection.Chain)
+        toolWidth := 0
+        for _, group := range inspection.Functions {
+                for _, f := range group.Functions {
+                        if len(f.Name) > toolWidth {
+                                toolWidth = len(f.Name)
+                        }
+                }
+        }
+        member := func(tool string, m ir.MemberSummary) {
+                line := "  "
+                if tool != "" {
+                        line += fmt.Sprintf("%-*s  ", toolWidth, tool)
+                }
+                line += m.ABIName + "(" + strings.Join(m.Inputs, ", ") + ")"
+                if len(m.Outputs) > 0 {
+                        line += " returns (" + strings.Join(m.Outputs, ", ") + ")"
+                }
+                if m.Selector != "" {
+                        line += "  " + m.Selector
+                }
+                if len(m.Flags) > 0 {
+                        line += "  [" + strings.Join(m.Flags, ", ") + "]"
+                }
+                fmt.Fprintln(out, line)
+        }
+
+        for _, group := range inspection.Functions {
+                fmt.Fprintf(out, "\nFunctions (%s): %d\n", group.Mutability, len(group.Functions))
+                for _, f := range group.Functions {
+                        member(f.Name, f)
+                }
+        }
+        if len(inspection.Events) > 0 {
+                fmt.Fprintf(out, "\nEvents: %d\n", len(inspection.Events))
+                for _, e := range inspection.Events {
+                        member("", e)
+                }
+        }
+        if len(inspection.Errors) > 0 {
+                fmt.Fprintf(out, "\nErrors: %d\n", len(inspection.Errors))
+                for _, e := range inspection.Errors {
+                        member("", e)
+                }
+        }
+        if len(inspection.Types) > 0 {
+                fmt.Fprintf(out, "\nTypes: %d\n", len(inspection.Types))
+                for _, t := range inspection.Types {
+                        fmt.Fprintf(out, "  %s { %s }\n", t.Name, strings.Join(t.Fields, "; "))
+                }
+        }
+}
\ No newline at end of file
@@ -0,0 +1,929 @@
+package main
+
+import (
+        "bytes"
+        "context"
+        "encoding/json"
+        "errors"
+        "fmt"
+        "io"
+        "os"
+        "path/filepath"
+        "strings"
+        "sync"
+
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/jsonschema"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/markdown"
+        "github.com/openhands/mcp-generator/internal/npm"
+        "github.com/openhands/mcp-generator/internal/parser"
+        "github.com/openhands/mcp-generator/internal/parser/apispec"
+        "github.com/openhands/mcp-generator/internal/standards"
+        "github.com/spf13/cobra"
+)
+
+// newIRCommand returns the ir command, which works on contract IRs
+func newIRCommand() *cobra.Command {
+        irCmd := &cobra.Command{
+                Use:   "ir",
+                Short: "Work with contract IRs",
+        }
+
+        var format, chain, name string
+        diffCmd := &cobra.Command{
+                Use:   "diff <old> <new>",
+                Short: "Structurally diff two IRs",
+                Long: `Structurally diff two contract IRs and report added, removed and changed functions, events and errors,
+parameter type changes and description changes.
+
+Each side is a JSON or YAML IR file, validated against the IR schema, or a contract artifact (ABI/IDL), which is parsed first.`,
+                Args: cobra.ExactArgs(2),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if format != "text" && format != "json" {
+                                return fmt.Errorf("unsupported diff format: %s (expected text or json)", format)
+                        }
+                        old, err := loadContract(args[0], ir.ContractMetadata{Name: name, Chain: chain})
+                        if err == nil {
+                                err = applyAnnotations(old, args[0], "", commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+                        new, err := loadContract(args[1], ir.ContractMetadata{Name: name, Chain: chain})
+                        if err == nil {
+                                err = applyAnnotations(new, args[1], "", commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+                        changes := ir.Diff(old, new)
+
+                        out := cmd.OutOrStdout()
+                        if format == "json" {
+                                encoder := json.NewEncoder(out)
+                                encoder.SetIndent("", "  ")
+                                return encoder.Encode(map[string]interface{}{"old": args[0], "new": args[1], "changes": changes})
+                        }
+
+                        if len(changes) == 0 {
+                                fmt.Fprintln(out, "No differences")
+                                return nil
+                        }
+                        counts := map[string]int{}
+                        for _, change := range changes {
+                                fmt.Fprintln(out, change)
+                                counts[change.Kind]++
+                        }
+                        fmt.Fprintf(out, "\n%d added, %d removed, %d changed\n", counts[ir.Added], counts[ir.Removed], counts[ir.Changed])
+                        return nil
+                },
+        }
+        diffCmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+        diffCmd.Flags().StringVarP(&chain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        diffCmd.Flags().StringVarP(&name, "name", "n", "", "Contract name of both sides (default: the name in IR files, or the file name of artifacts)")
+        irCmd.AddCommand(diffCmd)
+
+        var schemaOutput string
+        schemaCmd := &cobra.Command{
+                Use:   "schema",
+                Short: "Print the JSON Schema of IR files",
+                Args:  cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        content, err := json.MarshalIndent(jsonschema.ForContractIR(), "", "  ")
+                        if err != nil {
+                                return err
+                        }
+                        content = append(content, '\n')
+                        if schemaOutput == "" {
+                                _, err = cmd.OutOrStdout().Write(content)
+                                return err
+                        }
+                        return os.WriteFile(schemaOutput, content, 0644)
+                },
+        }
+        schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "File to write the schema to (default: stdout)")
+        irCmd.AddCommand(schemaCmd)
+
+        irCmd.AddCommand(newValidateCommand(false))
+
+        var hashChain string
+        hashCmd := &cobra.Command{
+                Use:   "hash <file>...",
+                Short: "Print the content hash of IRs or artifacts",
+                Long: `Print the content hash of contract IRs or artifacts (ABI/IDL), the SHA-256 of their canonical JSON IR.
+
+The hash does not depend on the order of functions, events and errors, and is the one stamped into generated
+servers as CONTRACT_IR_HASH, so it tells whether a server is up to date with an IR.`,
+                Args: cobra.MinimumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        for _, path := range args {
+                                contractIR, err := loadContract(path, ir.ContractMetadata{Chain: hashChain})
+                                if err != nil {
+                                        return err
+                                }
+                                hash, err := ir.ContentHash(contractIR)
+                                if err != nil {
+                                        return err
+                                }
+                                fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", hash, path)
+                        }
+                        return nil
+                },
+        }
+        hashCmd.Flags().StringVarP(&hashChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        irCmd.AddCommand(hashCmd)
+
+        irCmd.AddCommand(newExportCommand("export <file>"))
+
+        var mergeOutput, mergeFormat, mergeChain, mergeName, mergePolicy string
+        mergeCmd := &cobra.Command{
+                Use:   "merge <base> <extension>...",
+                Short: "Merge IRs or artifacts into one IR",
+                Long: `Merge contract IRs or artifacts (ABI/IDL) into one IR, e.g. a proxy or diamond with its implementations or facets.
+
+Extensions are merged into the base in order. Functions and events are matched by signature, and errors and types by name;
+members defined differently are conflicts, resolved by --on-conflict: error (fail), prefer-base or prefer-extension.
+The base's metadata is kept, with missing fields taken from the extensions.`,
+                Args: cobra.MinimumNArgs(2),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        merged, err := loadContract(args[0], ir.ContractMetadata{Name: mergeName, Chain: mergeChain})
+                        if err != nil {
+                                return err
+                        }
+                        for _, path := range args[1:] {
+                                extension, err := loadContract(path, ir.ContractMetadata{Chain: mergeChain})
+                                if err != nil {
+                                        return err
+                                }
+                                if merged, err = ir.Merge(merged, extension, ir.MergeOptions{OnConflict: ir.MergePolicy(mergePolicy)}); err != nil {
+                                        return fmt.Errorf("failed to merge %s: %w", path, err)
+                                }
+                        }
+                        return writeContractIR(cmd.OutOrStdout(), merged, mergeOutput, mergeFormat)
+                },
+        }
+        mergeCmd.Flags().StringVar(&mergePolicy, "on-conflict", string(ir.MergeError), "How to resolve members defined differently (error, prefer-base, prefer-extension)")
+        mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "File to write the merged IR to (default: stdout)")
+        mergeCmd.Flags().StringVar(&mergeFormat, "format", "", "IR format (json, yaml; default: yaml for .yaml and .yml output files, json otherwise)")
+        mergeCmd.Flags().StringVarP(&mergeChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        mergeCmd.Flags().StringVarP(&mergeName, "name", "n", "", "Contract name (default: the name of the base)")
+        irCmd.AddCommand(mergeCmd)
+
+        var projectOutput, projectFormat, projectChain, projectName, projectAnnotations, projectMaxDanger string
+        var projection ir.Projection
+        projectCmd := &cobra.Command{
+                Use:   "project <file>",
+                Short: "Write a filtered copy of an IR or artifact",
+                Long: `Write a filtered copy of a contract IR or artifact (ABI/IDL), e.g. to share an IR without its internals or to
+generate a restricted read-only server from the same source IR as a full one.
+
+Functions are kept or dropped by state mutability, danger level, access restriction, tag, or name or signature
+(--include, --exclude); custom types left unused are dropped with them. --strip-chain-data removes
+chain data generated code does not depend on, such as NatSpec and parser bookkeeping.`,
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        contractIR, err := loadContract(args[0], ir.ContractMetadata{Name: projectName, Chain: projectChain})
+                        if err == nil {
+                                err = applyAnnotations(contractIR, args[0], projectAnnotations, commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+                        projection.MaxDanger = ir.DangerLevel(projectMaxDanger)
+                        projected, err := ir.Project(contractIR, projection)
+                        if err != nil {
+                                return err
+                        }
+                        return writeContractIR(cmd.OutOrStdout(), projected, projectOutput, projectFormat)
+                },
+        }
+        projectCmd.Flags().BoolVar(&projection.ReadOnly, "read-only", false, "Keep only view and pure functions")
+        projectCmd.Flags().StringVar(&projectMaxDanger, "max-danger", "", "Drop functions annotated as more dangerous (low, medium, high)")
+        projectCmd.Flags().BoolVar(&projection.Unrestricted, "unrestricted", false, "Drop functions only the owner, role holders or allowlisted accounts may call")
+        projectCmd.Flags().StringArrayVar(&projection.Tags, "tag", nil, "Keep only functions and events with one of these tags; repeatable")
+        projectCmd.Flags().StringArrayVar(&projection.Include, "include", nil, "Keep only these functions, by name or signature; repeatable")
+        projectCmd.Flags().StringArrayVar(&projection.Exclude, "exclude", nil, "Drop these functions, by name or signature; repeatable")
+        projectCmd.Flags().BoolVar(&projection.DropHidden, "drop-hidden", false, "Drop hidden functions and events instead of keeping them hidden")
+        projectCmd.Flags().BoolVar(&projection.NoEvents, "no-events", false, "Drop all events")
+        projectCmd.Flags().BoolVar(&projection.StripChainData, "strip-chain-data", false, "Drop chain data generated code does not depend on")
+        projectCmd.Flags().BoolVar(&projection.StripSource, "strip-source", false, "Drop the source code information of the metadata")
+        projectCmd.Flags().StringVarP(&projectOutput, "output", "o", "", "File to write the IR to (default: stdout)")
+        projectCmd.Flags().StringVar(&projectFormat, "format", "", "IR format (json, yaml; default: yaml for .yaml and .yml output files, json otherwise)")
+        projectCmd.Flags().StringVarP(&projectChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        projectCmd.Flags().StringVar(&projectAnnotations, "annotations", "", "Annotations file applied to the IR (default: <file>.annotations.yaml next to it, if present)")
+        projectCmd.Flags().StringVarP(&projectName, "name", "n", "", "Contract name (default: the name in IR files, or the file name of artifacts)")
+        irCmd.AddCommand(projectCmd)
+
+        var docsOutput, docsChain, docsName, docsAnnotations, docsLocale string
+        docsCmd := &cobra.Command{
+                Use:   "docs <file>",
+                Short: "Render an IR or artifact as Markdown API documentation",
+                Long: `Render a contract IR or artifact (ABI/IDL) as standalone Markdown API documentation, e.g. for a contract
+documentation site: its functions, events, errors and types with their signatures, selectors, parameters and
+descriptions. Hidden functions and events are left out.`,
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        contractIR, err := loadContract(args[0], ir.ContractMetadata{Name: docsName, Chain: docsChain})
+                        if err == nil {
+                                err = applyAnnotations(contractIR, args[0], docsAnnotations, commandLogger(cmd))
+                        }
+                        if err == nil {
+                                err = localize(contractIR, docsLocale, commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+
+                        content := markdown.Generate(contractIR)
+                        if docsOutput == "" {
+                                _, err = cmd.OutOrStdout().Write(content)
+                                return err
+                        }
+                        return os.WriteFile(docsOutput, content, 0644)
+                },
+        }
+        docsCmd.Flags().StringVarP(&docsOutput, "output", "o", "", "File to write the documentation to (default: stdout)")
+        docsCmd.Flags().StringVarP(&docsChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        docsCmd.Flags().StringVar(&docsAnnotations, "annotations", "", "Annotations file applied to the IR (default: <file>.annotations.yaml next to it, if present)")
+        docsCmd.Flags().StringVar(&docsLocale, "locale", "", "Language tag whose translated descriptions replace the default ones, e.g. ja (default: untranslated)")
+        docsCmd.Flags().StringVarP(&docsName, "name", "n", "", "Contract name (default: the name in IR files, or the file name of artifacts)")
+        irCmd.AddCommand(docsCmd)
+
+        var statsFormat, statsChain string
+        statsCmd := &cobra.Command{
+                Use:   "stats <file>...",
+                Short: "Report the size and complexity of IRs or artifacts",
+                Long: `Report statistics of contract IRs or artifacts (ABI/IDL), to judge before generating a server for a sprawling
+contract whether it has too many tools: function counts by state mutability, the most inputs, outputs and event
+parameters, the deepest tuple nesting, the estimated number of generated tools with and without --enable-writes,
+and tool names that collide in generated code. --format json writes a machine-readable report.`,
+                Args: cobra.MinimumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if statsFormat != "text" && statsFormat != "json" {
+                                return fmt.Errorf("unsupported stats format: %s (expected text or json)", statsFormat)
+                        }
+                        type fileReport struct {
+                                File string `json:"file"`
+                                *ir.Stats
+                        }
+                        reports := make([]fileReport, 0, len(args))
+                        for _, path := range args {
+                                contractIR, err := loadContract(path, ir.ContractMetadata{Chain: statsChain})
+                                if err == nil {
+                                        err = applyAnnotations(contractIR, path, "", commandLogger(cmd))
+                                }
+                                if err != nil {
+                                        return err
+                                }
+                                reports = append(reports, fileReport{File: path, Stats: ir.ComputeStats(contractIR)})
+                        }
+
+                        out := cmd.OutOrStdout()
+                        if statsFormat == "json" {
+                                encoder := json.NewEncoder(out)
+                                encoder.SetIndent("", "  ")
+                                return encoder.Encode(map[string]interface{}{"files": reports})
+                        }
+                        extreme := func(e ir.Extreme) string {
+                                if e.Path == "" {
+                                        return fmt.Sprint(e.Value)
+                                }
+                                return fmt.Sprintf("%d (%s)", e.Value, e.Path)
+                        }
+                        for i, report := range reports {
+                                if i > 0 {
+                                        fmt.Fprintln(out)
+                                }
+                                var mutabilities []string
+                                for _, mutability := range report.Mutabilities() {
+                                        mutabilities = append(mutabilities, fmt.Sprintf("%d %s", report.ByMutability[mutability], mutability))
+                                }
+                                fmt.Fprintf(out, "%s:\n", report.File)
+                                fmt.Fprintf(out, "  Functions:            %d", report.Functions)
+                                if len(mutabilities) > 0 {
+                                        fmt.Fprintf(out, " (%s)", strings.Join(mutabilities, ", "))
+                                }
+                                fmt.Fprintln(out)
+                                fmt.Fprintf(out, "  Events:               %d\n", report.Events)
+                                fmt.Fprintf(out, "  Errors:               %d\n", report.Errors)
+                                fmt.Fprintf(out, "  Types:                %d\n", report.Types)
+                                fmt.Fprintf(out, "  Hidden:               %d\n", report.Hidden)
+                                fmt.Fprintf(out, "  Max inputs:           %s\n", extreme(report.MaxInputs))
+                                fmt.Fprintf(out, "  Max outputs:          %s\n", extreme(report.MaxOutputs))
+                                fmt.Fprintf(out, "  Max event parameters: %s\n", extreme(report.MaxEventParameters))
+                                fmt.Fprintf(out, "  Max tuple depth:      %s\n", extreme(report.MaxTupleDepth))
+                                fmt.Fprintf(out, "  Tools:                %d read-only, %d with writes (%d reads, %d writes, %d simulations, %d event logs)\n",
+                                        report.Tools.ReadOnly, report.Tools.WithWrites, report.Tools.Reads, report.Tools.Writes, report.Tools.Simulations, report.Tools.EventLogs)
+                                for _, collision := range report.Collisions {
+                                        fmt.Fprintf(out, "  Collision:            %s (%s)\n", strings.Join(collision.Names, ", "), collision.Key)
+                                }
+                        }
+                        return nil
+                },
+        }
+        statsCmd.Flags().StringVar(&statsFormat, "format", "text", "Output format (text, json)")
+        statsCmd.Flags().StringVarP(&statsChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        irCmd.AddCommand(statsCmd)
+
+        var lintFormat, lintConfig, lintChain, lintTarget string
+        var lintRules []string
+        var lintMaxLength int
+        lintCmd := &cobra.Command{
+                Use:   "lint <file>...",
+                Short: "Check IRs or artifacts against configurable lint rules",
+                Long: `Check contract IRs or artifacts against lint rules, reporting findings as errors, warnings or info.
+
+Rules: ` + strings.Join(ir.LintRules(), ", ") + `. Their severities (error, warning, info, off) are set in a JSON or YAML
+configuration file and overridden with --rule. The command fails if any finding is an error, so it can gate CI;
+--format json writes a machine-readable report.`,
+                Args: cobra.MinimumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if lintFormat != "text" && lintFormat != "json" {
+                                return fmt.Errorf("unsupported lint format: %s (expected text or json)", lintFormat)
+                        }
+                        config := &ir.LintConfig{}
+                        if lintConfig != "" {
+                                loaded, err := ir.LoadLintConfig(lintConfig)
+                                if err != nil {
+                                        return err
+                                }
+                                config = loaded
+                        }
+                        for _, rule := range lintRules {
+                                name, severity, ok := strings.Cut(rule, "=")
+                                if !ok {
+                                        return fmt.Errorf("invalid --rule %q (expected <rule>=<severity>)", rule)
+                                }
+                                if config.Rules == nil {
+                                        config.Rules = map[string]ir.Severity{}
+                                }
+                                config.Rules[name] = ir.Severity(severity)
+                        }
+                        if lintTarget != "" {
+                                config.Target = lintTarget
+                        }
+                        if lintMaxLength != 0 {
+                                config.MaxToolNameLength = lintMaxLength
+                        }
+                        if err := config.Check(); err != nil {
+                                return err
+                        }
+
+                        type fileReport struct {
+                                File     string           `json:"file"`
+                                Findings []ir.LintFinding `json:"findings"`
+                        }
+                        reports := make([]fileReport, 0, len(args))
+                        counts := map[ir.Severity]int{}
+                        for _, path := range args {
+                                contractIR, err := loadContract(path, ir.ContractMetadata{Chain: lintChain})
+                                if err == nil {
+                                        err = applyAnnotations(contractIR, path, "", commandLogger(cmd))
+                                }
+                                if err != nil {
+                                        return err
+                                }
+                                findings := ir.Lint(contractIR, *config)
+                                for _, finding := range findings {
+                                        counts[finding.Severity]++
+                                }
+                                reports = append(reports, fileReport{File: path, Findings: findings})
+                        }
+
+                        out := cmd.OutOrStdout()
+                        if lintFormat == "json" {
+                                encoder := json.NewEncoder(out)
+                                encoder.SetIndent("", "  ")
+                                err := encoder.Encode(map[string]interface{}{
+                                        "files":    reports,
+                                        "errors":   counts[ir.SeverityError],
+                                        "warnings": counts[ir.SeverityWarning],
+                                        "infos":    counts[ir.SeverityInfo],
+                                })
+                                if err != nil {
+                                        return err
+                                }
+                        } else {
+                                for _, report := range reports {
+                                        for _, finding := range report.Findings {
+                                                fmt.Fprintf(out, "%s: %s\n", report.File, finding)
+                                        }
+                                }
+                                fmt.Fprintf(out, "%d errors, %d warnings, %d infos\n", counts[ir.SeverityError], counts[ir.SeverityWarning], counts[ir.SeverityInfo])
+                        }
+                        if counts[ir.SeverityError] > 0 {
+                                return fmt.Errorf("lint failed with %d errors", counts[ir.SeverityError])
+                        }
+                        return nil
+                },
+        }
+        lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format (text, json)")
+        lintCmd.Flags().StringVar(&lintConfig, "config", "", "JSON or YAML lint configuration: rules (severities by rule), maxToolNameLength and target")
+        lintCmd.Flags().StringArrayVar(&lintRules, "rule", nil, "Rule severity override, e.g. prohibited-write=error; repeatable")
+        lintCmd.Flags().StringVar(&lintTarget, "target", "", "Generator parameter types are checked against (default: typescript)")
+        lintCmd.Flags().IntVar(&lintMaxLength, "max-tool-name-length", 0, "Longest accepted tool name (default: 64)")
+        lintCmd.Flags().StringVarP(&lintChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        irCmd.AddCommand(lintCmd)
+
+        return irCmd
+}
+
+// newExportCommand returns the command writing the IR of an artifact or IR file: ir export, or parse at the top level
+func newExportCommand(use string) *cobra.Command {
+        var exportOutput, exportFormat, exportChain, exportName, exportAnnotations string
+        var exportCanonical bool
+        exportCmd := &cobra.Command{
+                Use:   use,
+                Short: "Write the IR of an artifact or IR file as JSON or YAML",
+                Long: `Write the IR of a contract artifact (ABI/IDL) or IR file as JSON or YAML, e.g. to hand-edit descriptions.
+
+When a YAML output file already exists, its comments are carried over to the matching entries of the new IR,
+so regenerating it from an updated artifact keeps them. The file - reads the artifact or IR from stdin, so that
+IRs can be piped through other tools, e.g. jq, and then to generate-mcp render -.`,
+                Args: cobra.ExactArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        contractIR, err := loadContract(args[0], ir.ContractMetadata{Name: exportName, Chain: exportChain})
+                        if err == nil {
+                                err = applyAnnotations(contractIR, args[0], exportAnnotations, commandLogger(cmd))
+                        }
+                        if err != nil {
+                                return err
+                        }
+                        if exportCanonical {
+                                ir.Canonicalize(contractIR)
+                        }
+
+                        return writeContractIR(cmd.OutOrStdout(), contractIR, exportOutput, exportFormat)
+                },
+        }
+        exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write the IR to (default: stdout)")
+        exportCmd.Flags().StringVar(&exportFormat, "format", "", "IR format (json, yaml; default: yaml for .yaml and .yml output files, json otherwise)")
+        exportCmd.Flags().StringVarP(&exportChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        exportCmd.Flags().StringVar(&exportAnnotations, "annotations", "", "Annotations file applied to the IR (default: <file>.annotations.yaml next to it, if present)")
+        exportCmd.Flags().BoolVar(&exportCanonical, "canonical", false, "Sort functions, events, errors and types by name, so exports of the same contract diff cleanly")
+        exportCmd.Flags().StringVarP(&exportName, "name", "n", "", "Contract name (default: the name in IR files, or the file name of artifacts)")
+        return exportCmd
+}
+
+// newValidateCommand returns the command validating IR files and artifacts: ir validate, or with artifactFlag the
+// top-level validate command, which also takes the files to validate as --artifact like generation does
+func newValidateCommand(artifactFlag bool) *cobra.Command {
+        var validateFormat, validateChain string
+        var validateStrict bool
+        var artifacts []string
+        validateCmd := &cobra.Command{
+                Use:   "validate <file>...",
+                Short: "Validate IR files or artifacts, reporting errors and warnings",
+                Long: `Validate contract IR files against the IR JSON Schema, then validate their contents, as for contract artifacts (ABI/IDL).
+
+Findings are errors, which make an IR unusable, or warnings, such as functions without descriptions, each with a code
+(IR0xx for warnings, IR1xx for errors). The command fails on errors, and with --strict on warnings too;
+--format json writes a report per file for CI.`,
+                Args: cobra.MinimumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if validateFormat != "text" && validateFormat != "json" {
+                                return fmt.Errorf("unsupported validation format: %s (expected text or json)", validateFormat)
+                        }
+                        files := append(append([]string{}, artifacts...), args...)
+                        if len(files) == 0 {
+                                return errors.New("no files to validate (pass --artifact or file arguments)")
+                        }
+                        type fileReport struct {
+                                File  string `json:"file"`
+                                Valid bool   `json:"valid"`
+                                *ir.ValidationReport
+                        }
+                        var reports []fileReport
+                        invalid := 0
+                        for _, path := range files {
+                                report, err := validationReport(path, validateChain, commandLogger(cmd))
+                                if err != nil {
+                                        return err
+                                }
+                                valid := !report.Failed(validateStrict)
+                                if !valid {
+                                        invalid++
+                                }
+                                reports = append(reports, fileReport{File: path, Valid: valid, ValidationReport: report})
+                        }
+
+                        out := cmd.OutOrStdout()
+                        if validateFormat == "json" {
+                                encoder := json.NewEncoder(out)
+                                encoder.SetIndent("", "  ")
+                                if err := encoder.Encode(map[string]interface{}{"strict": validateStrict, "files": reports}); err != nil {
+                                        return err
+                                }
+                        } else {
+                                for _, report := range reports {
+                                        for _, finding := range report.Errors {
+                                                fmt.Fprintf(out, "%s: error %s\n", report.File, finding)
+                                        }
+                                        for _, finding := range report.Warnings {
+                                                fmt.Fprintf(out, "%s: warning %s\n", report.File, finding)
+                                        }
+                                        if report.Valid {
+                                                fmt.Fprintf(out, "%s: valid\n", report.File)
+                                        }
+                                }
+                        }
+                        if invalid > 0 {
+                                return validationError(fmt.Errorf("%d of %d IR files are invalid", invalid, len(files)), nil)
+                        }
+                        return nil
+                },
+        }
+        validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Fail on warnings as well as errors")
+        validateCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format (text, json)")
+        validateCmd.Flags().StringVarP(&validateChain, "chain", "c", "ethereum", "Blockchain type of artifacts ("+chainList()+")")
+        if artifactFlag {
+                validateCmd.Use = "validate [file]..."
+                validateCmd.Args = cobra.ArbitraryArgs
+                validateCmd.Flags().StringArrayVarP(&artifacts, "artifact", "a", nil, "Contract artifact (ABI/IDL) or IR file to validate; repeatable")
+        }
+        return validateCmd
+}
+
+// loadContract parses a contract artifact or an OpenAPI or OpenRPC document, or reads a JSON or YAML IR file after
+// validating it against the IR schema
+// The metadata is that of parsed artifacts, whose name defaults to the file name (or the title of API descriptions, or
+// the contractName of artifacts read from stdin);
+// its non-empty name and address override those of IR files. npm references load the file they resolve to.
+func loadContract(path string, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+        if explorer.IsReference(path) {
+                return loadFetchedContract(path, metadata)
+        }
+        if standards.IsReference(path) {
+                return loadStandardContract(path, metadata)
+        }
+        if npm.IsReference(path) {
+                resolved, err := resolveNPMArtifact(path)
+                if err != nil {
+                        return nil, err
+                }
+                path = resolved
+        }
+        content, err := readContractFile(path)
+        if err != nil {
+                return nil, err
+        }
+
+        // OpenAPI and OpenRPC documents, in JSON or YAML, are recognized whatever the chain
+        if chain := apispec.Detect(content); chain != "" {
+                metadata.Chain = chain
+                contractIR, err := parseArtifact(bytes.NewReader(content), metadata)
+                if err != nil {
+                        return nil, parseError(fmt.Errorf("%s: %w", path, err))
+                }
+                if contractIR.Metadata.Name == "" {
+                        contractIR.Metadata.Name = contractFileName(path)
+                }
+                return contractIR, nil
+        }
+
+        if !ir.IsYAMLFile(path) && !isContractIR(content) {
+                if metadata.Name == "" && path != stdinPath {
+                        metadata.Name = contractFileName(path)
+                }
+                contractIR, err := parseArtifact(bytes.NewReader(content), metadata)
+                if err != nil {
+                        return nil, parseError(fmt.Errorf("%s: %w", path, err))
+                }
+                if contractIR.Metadata.Name == "" {
+                        contractIR.Metadata.Name = defaultStdinName
+                }
+                return contractIR, nil
+        }
+
+        if err := validateContractIR(path, content); err != nil {
+                return nil, err
+        }
+        contractIR := &ir.ContractIR{}
+        if err := json.Unmarshal(content, contractIR); err != nil {
+                return nil, parseError(fmt.Errorf("failed to parse IR %s: %w", path, err))
+        }
+        if metadata.Name != "" {
+                contractIR.Metadata.Name = metadata.Name
+        }
+        if metadata.Address != "" {
+                contractIR.Metadata.Address = metadata.Address
+        }
+        return contractIR, nil
+}
+
+// loadFetchedContract parses the verified ABI of a contract fetched from an explorer, named as verified and deployed
+// at the reference's address on its network unless --name, --address or --network say otherwise
+func loadFetchedContract(path string, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+        ref, contract, err := fetchContract(path)
+        if err != nil {
+                return nil, err
+        }
+        if metadata.Name == "" {
+                metadata.Name = contract.Name
+        }
+        if metadata.Name == "" {
+                metadata.Name = defaultStdinName
+        }
+        if metadata.Address == "" {
+                metadata.Address = ref.Address
+        }
+        contractIR, err := parseArtifact(bytes.NewReader(contract.ABI), metadata)
+        if err != nil {
+                return nil, parseError(fmt.Errorf("%s: %w", path, err))
+        }
+        contractIR.Metadata.SetNetwork(ref.Network)
+        return contractIR, nil
+}
+
+// loadStandardContract loads the bundled ABI of a standard reference, described by its curated overlays and named
+// after the standard unless --name is given
+func loadStandardContract(path string, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+        s, err := standards.ParseReference(path)
+        if err != nil {
+                return nil, usageError(err)
+        }
+        if registration, ok := parser.Lookup(metadata.Chain); ok && registration.Chain != "ethereum" {
+                return nil, usageError(fmt.Errorf("%s is an EVM ABI, which --chain %s does not parse", s.Title, metadata.Chain))
+        }
+        contractIR, err := s.Load(metadata)
+        if err != nil {
+                return nil, parseError(fmt.Errorf("%s: %w", path, err))
+        }
+        return contractIR, nil
+}
+
+// fetchContract returns the contract of an explorer reference, fetched or cached
+func fetchContract(path string) (explorer.Reference, *explorer.Contract, error) {
+        ref, err := explorer.ParseReference(path)
+        if err != nil {
+                return ref, nil, usageError(err)
+        }
+        options, err := explorerOptions()
+        if err != nil {
+                return ref, nil, err
+        }
+        contract, _, err := explorer.Fetch(context.Background(), ref, options)
+        if err != nil {
+                return ref, nil, ioError(err)
+        }
+        return ref, contract, nil
+}
+
+// explorerOptions returns the options of fetching from explorers, with the keys of --explorer-key, which are read when
+// fetching as the project config may set them
+func explorerOptions() (explorer.Options, error) {
+        options := fetchOptions
+        options.Credentials.Keys = map[int]string{}
+        for _, value := range explorerKeys {
+                chainID, key, err := explorer.ParseKey(value)
+                if err != nil {
+                        return options, usageError(err)
+                }
+                options.Credentials.Keys[chainID] = key
+        }
+        return options, nil
+}
+
+// resolveNPMArtifact returns the file of an npm reference on disk: in the package installed in node_modules, or else
+// in the package fetched from the registry or cached
+func resolveNPMArtifact(path string) (string, error) {
+        ref, err := npm.ParseReference(path)
+        if err != nil {
+                return "", usageError(err)
+        }
+        options := npmOptions
+        options.NoCache = fetchOptions.NoCache
+        resolved, err := npm.Resolve(context.Background(), ref, options)
+        if err != nil {
+                return "", ioError(err)
+        }
+        return resolved.Path, nil
+}
+
+// contractFileName returns the name of contracts read from a file: the file name without its extension, or
+// defaultStdinName for stdin
+func contractFileName(path string) string {
+        if path == stdinPath {
+                return defaultStdinName
+        }
+        return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// applyAnnotations applies an annotations file to a contract: the given one, or else the sidecar file next to
+// the artifact if there is one. Annotations matching nothing in the contract are reported as warnings.
+func applyAnnotations(contractIR *ir.ContractIR, artifact, path string, logger *logging.Logger) error {
+        if path == "" && artifact == stdinPath {
+                return nil
+        }
+        if path == "" {
+                if path = ir.SidecarAnnotationsPath(artifact); path == "" {
+                        return nil
+                }
+        }
+        annotations, err := ir.LoadAnnotations(path)
+        if err != nil {
+                return err
+        }
+        unmatched, err := annotations.Apply(contractIR)
+        if err != nil {
+                return fmt.Errorf("failed to apply annotations %s: %w", path, err)
+        }
+        for _, key := range unmatched {
+                logger.Warnf("annotations %s: %s matches nothing in the contract", path, key)
+        }
+        return nil
+}
+
+// localize replaces a contract's descriptions by their translations to a locale, if one is given
+// Descriptions without a translation keep their default text; a locale the contract has no translations for is
+// reported as a warning, with the locales it has.
+func localize(contractIR *ir.ContractIR, locale string, logger *logging.Logger) error {
+        if locale == "" {
+                return nil
+        }
+        available := ir.Locales(contractIR)
+        translated, err := ir.Localize(contractIR, locale)
+        if err != nil {
+                return err
+        }
+        if translated == 0 {
+                if len(available) == 0 {
+                        available = []string{"none"}
+                }
+                logger.Warnf("no descriptions are translated to %s (translations: %s)", locale, strings.Join(available, ", "))
+                return nil
+        }
+        logger.Infof("Locale: %d descriptions translated to %s", translated, locale)
+        return nil
+}
+
+// writeContractIR writes an IR as JSON or YAML to a file, or to out if no file is given
+// When a YAML file already exists, its comments are carried over to the matching entries of the IR
+func writeContractIR(out io.Writer, contractIR *ir.ContractIR, output, format string) error {
+        if format == "" {
+                format = "json"
+                if ir.IsYAMLFile(output) {
+                        format = "yaml"
+                }
+        }
+        if format != "json" && format != "yaml" {
+                return fmt.Errorf("unsupported IR format: %s (expected json or yaml)", format)
+        }
+
+        var content []byte
+        var err error
+        if format == "yaml" {
+                var previous []byte
+                if output != "" {
+                        if previous, err = os.ReadFile(output); err != nil && !errors.Is(err, os.ErrNotExist) {
+                                return fmt.Errorf("failed to read %s: %w", output, err)
+                        }
+                }
+                if content, err = ir.MarshalYAML(contractIR, previous); err != nil {
+                        return fmt.Errorf("failed to encode IR as YAML: %w", err)
+                }
+        } else {
+                if content, err = json.MarshalIndent(contractIR, "", "  "); err != nil {
+                        return fmt.Errorf("failed to encode IR as JSON: %w", err)
+                }
+                content = append(content, '\n')
+        }
+
+        if output == "" {
+                _, err = out.Write(content)
+                return err
+        }
+        return os.WriteFile(output, content, 0644)
+}
+
+// validationReport validates an IR file against the IR schema, reporting mismatches as errors, and the contents of
+// schema-valid IR files and artifacts with their annotations applied
+func validationReport(path, chain string, logger *logging.Logger) (*ir.ValidationReport, error) {
+        content, err := readContractFile(path)
+        if err != nil {
+                return nil, err
+        }
+        if isContractIR(content) {
+                mismatches, err := jsonschema.ValidateContractIR(content)
+                if err != nil {
+                        mismatches = []jsonschema.ValidationError{{Message: err.Error()}}
+                }
+                if len(mismatches) > 0 {
+                        report := &ir.ValidationReport{Warnings: []ir.ValidationError{}}
+                        for _, mismatch := range mismatches {
+                                field := mismatch.Pointer
+                                if field == "" {
+                                        field = "(root)"
+                                }
+                                report.Errors = append(report.Errors, ir.ValidationError{Code: ir.CodeSchemaMismatch, Field: field, Message: mismatch.Message})
+                        }
+                        return report, nil
+                }
+        }
+
+        contractIR, err := loadContract(path, ir.ContractMetadata{Chain: chain})
+        if err == nil {
+                err = applyAnnotations(contractIR, path, "", logger)
+        }
+        if err != nil {
+                return nil, err
+        }
+        return contractIR.Report(), nil
+}
+
+// stdinPath is the file name reading an artifact or IR from stdin, e.g. --artifact -
+const stdinPath = "-"
+
+// defaultStdinName is the name of contracts read from stdin without a name, which --name or the artifact's
+// contractName sets
+const defaultStdinName = "Contract"
+
+// stdin holds stdin once read, as commands may read their artifact more than once (e.g. to validate, then parse it)
+var stdin struct {
+        once    sync.Once
+        content []byte
+        err     error
+}
+
+// readContractFile reads an artifact, API description or IR file, stdin for -, the ABI of an explorer reference or
+// bundled standard, or the file of an npm reference, converting YAML files to JSON
+// YAML on stdin is recognized by its content, which unlike JSON does not start with { or [.
+func readContractFile(path string) ([]byte, error) {
+        if npm.IsReference(path) {
+                resolved, err := resolveNPMArtifact(path)
+                if err != nil {
+                        return nil, err
+                }
+                path = resolved
+        }
+        if explorer.IsReference(path) {
+                _, contract, err := fetchContract(path)
+                if err != nil {
+                        return nil, err
+                }
+                return contract.ABI, nil
+        }
+        if standards.IsReference(path) {
+                s, err := standards.ParseReference(path)
+                if err != nil {
+                        return nil, usageError(err)
+                }
+                return s.ABI(), nil
+        }
+        if path == stdinPath {
+                stdin.once.Do(func() { stdin.content, stdin.err = io.ReadAll(os.Stdin) })
+                if stdin.err != nil {
+                        return nil, ioError(fmt.Errorf("failed to read stdin: %w", stdin.err))
+                }
+                content := bytes.TrimSpace(stdin.content)
+                if len(content) > 0 && content[0] != '{' && content[0] != '[' {
+                        converted, err := ir.YAMLToJSON(content)
+                        if err != nil {
+                                return nil, parseError(fmt.Errorf("failed to parse YAML from stdin: %w", err))
+                        }
+                        return converted, nil
+                }
+                return content, nil
+        }
+
+        content, err := os.ReadFile(path)
+        if err != nil {
+                return nil, ioError(fmt.Errorf("failed to read %s: %w", path, err))
+        }
+        if ir.IsYAMLFile(path) {
+                if content, err = ir.YAMLToJSON(content); err != nil {
+                        return nil, parseError(fmt.Errorf("failed to parse YAML %s: %w", path, err))
+                }
+        }
+        return content, nil
+}
+
+// isContractIR reports whether a file holds an IR, an object with metadata and functions unlike ABIs and build artifacts
+func isContractIR(content []byte) bool {
+        var fields map[string]json.RawMessage
+        return json.Unmarshal(content, &fields) == nil && fields["metadata"] != nil && fields["functions"] != nil
+}
+
+// validateContractIR validates an IR document against the IR schema, listing every mismatch with its JSON pointer
+func validateContractIR(path string, content []byte) error {
+        mismatches, err := jsonschema.ValidateContractIR(content)
+        if err != nil {
+                return fmt.Errorf("failed to validate IR %s: %w", path, err)
+        }
+        if len(mismatches) == 0 {
+                return nil
+        }
+        var message strings.Builder
+        fmt.Fprintf(&message, "invalid IR %s:", path)
+        findings := make([]ir.ValidationError, len(mismatches))
+        for i, mismatch := range mismatches {
+                fmt.Fprintf(&message, "\n  %s", mismatch)
+                findings[i] = ir.ValidationError{Code: ir.CodeSchemaMismatch, Field: mismatch.Pointer, Message: mismatch.Message}
+                if findings[i].Field == "" {
+                        findings[i].Field = "(root)"
+                }
+        }
+        return validationError(errors.New(message.String()), findings)
+}
\ No newline at end of file
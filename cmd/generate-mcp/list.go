@@ -0,0 +1,172 @@
+package main
+
+import (
+        "encoding/json"
+        "fmt"
+        "io"
+        "strings"
+
+        "github.com/openhands/mcp-generator/internal/parser"
+        "github.com/openhands/mcp-generator/internal/template"
+        "github.com/spf13/cobra"
+        "github.com/spf13/pflag"
+)
+
+// flagInfo describes a generate-mcp flag supported by a parser or generator
+type flagInfo struct {
+        Name    string `json:"name"`
+        Usage   string `json:"usage"`
+        Default string `json:"default,omitempty"`
+}
+
+// newListChainsCommand returns the command listing the chains of the registered parsers
+func newListChainsCommand() *cobra.Command {
+        var asJSON bool
+        listCmd := &cobra.Command{
+                Use:   "list-chains",
+                Short: "List the chains artifacts can be parsed for, with their chain-specific flags",
+                Long: `List the chains of the parsers registered in this build, as selected with --chain, with the artifacts they
+parse and the generate-mcp flags specific to their contracts. Parsers marked detected recognize their artifacts
+by content, whatever --chain is set to.`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        type chainInfo struct {
+                                parser.Registration
+                                Flags []flagInfo `json:"flags"`
+                        }
+                        var chains []chainInfo
+                        for _, registration := range parser.Registrations() {
+                                chains = append(chains, chainInfo{Registration: registration, Flags: flagInfos(cmd.Root().Flags(), registration.Flags)})
+                        }
+
+                        out := cmd.OutOrStdout()
+                        if asJSON {
+                                return writeJSON(out, map[string]interface{}{"chains": chains})
+                        }
+                        for i, chain := range chains {
+                                if i > 0 {
+                                        fmt.Fprintln(out)
+                                }
+                                name := chain.Chain
+                                if len(chain.Aliases) > 0 {
+                                        name += " (" + strings.Join(chain.Aliases, ", ") + ")"
+                                }
+                                if chain.Detected {
+                                        name += " [detected]"
+                                }
+                                fmt.Fprintf(out, "%s\n  %s\n", name, chain.Description)
+                                if chain.Plugin != "" {
+                                        fmt.Fprintf(out, "  Plugin: %s\n", chain.Plugin)
+                                }
+                                writeFlagInfos(out, chain.Flags)
+                        }
+                        return nil
+                },
+        }
+        listCmd.Flags().BoolVar(&asJSON, "json", false, "Write the list as JSON")
+        return listCmd
+}
+
+// newListGeneratorsCommand returns the command listing the registered generators and template packs
+func newListGeneratorsCommand() *cobra.Command {
+        var asJSON bool
+        listCmd := &cobra.Command{
+                Use:   "list-generators",
+                Short: "List the languages servers can be generated in, with their flags and template packs",
+                Long: `List the generators registered in this build, as selected with --lang, with the generate-mcp flags they support
+and the registered template packs of their language (selected with --template-pack).`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        type generatorInfo struct {
+                                template.Generator
+                                Flags         []flagInfo `json:"flags"`
+                                TemplatePacks []string   `json:"templatePacks"`
+                        }
+                        var generators []generatorInfo
+                        for _, generator := range template.Generators() {
+                                info := generatorInfo{Generator: generator, Flags: flagInfos(cmd.Root().Flags(), generator.Flags), TemplatePacks: []string{}}
+                                for _, name := range template.TemplatePacks() {
+                                        pack, _ := template.LookupTemplatePack(name)
+                                        if pack.Manifest.Language == "" || pack.Manifest.Language == generator.Language {
+                                                info.TemplatePacks = append(info.TemplatePacks, name)
+                                        }
+                                }
+                                generators = append(generators, info)
+                        }
+
+                        out := cmd.OutOrStdout()
+                        if asJSON {
+                                return writeJSON(out, map[string]interface{}{"generators": generators})
+                        }
+                        for i, generator := range generators {
+                                if i > 0 {
+                                        fmt.Fprintln(out)
+                                }
+                                name := generator.Language
+                                if len(generator.Aliases) > 0 {
+                                        name += " (" + strings.Join(generator.Aliases, ", ") + ")"
+                                }
+                                fmt.Fprintf(out, "%s\n  %s\n", name, generator.Description)
+                                if generator.Plugin != "" {
+                                        fmt.Fprintf(out, "  Plugin: %s\n", generator.Plugin)
+                                }
+                                writeFlagInfos(out, generator.Flags)
+                                packs := "none registered"
+                                if len(generator.TemplatePacks) > 0 {
+                                        packs = strings.Join(generator.TemplatePacks, ", ")
+                                }
+                                fmt.Fprintf(out, "  Template packs: %s\n", packs)
+                        }
+                        return nil
+                },
+        }
+        listCmd.Flags().BoolVar(&asJSON, "json", false, "Write the list as JSON")
+        return listCmd
+}
+
+// chainList lists the chains of the registered parsers, e.g. "ethereum, openapi, openrpc"
+func chainList() string {
+        return strings.Join(parser.Chains(), ", ")
+}
+
+// languageList lists the languages of the registered generators, e.g. "ts"
+func languageList() string {
+        var languages []string
+        for _, generator := range template.Generators() {
+                languages = append(languages, generator.Language)
+        }
+        return strings.Join(languages, ", ")
+}
+
+// flagInfos describes the named flags of a flag set, skipping names the set does not have
+func flagInfos(flags *pflag.FlagSet, names []string) []flagInfo {
+        infos := []flagInfo{}
+        for _, name := range names {
+                if flag := flags.Lookup(name); flag != nil {
+                        infos = append(infos, flagInfo{Name: name, Usage: flag.Usage, Default: flag.DefValue})
+                }
+        }
+        return infos
+}
+
+// writeFlagInfos writes flags as indented lines of their names, defaults and usage
+func writeFlagInfos(out io.Writer, flags []flagInfo) {
+        if len(flags) == 0 {
+                return
+        }
+        fmt.Fprintln(out, "  Flags:")
+        for _, flag := range flags {
+                name := "--" + flag.Name
+                if flag.Default != "" && flag.Default != "false" && flag.Default != "[]" {
+                        name += "=" + flag.Default
+                }
+                fmt.Fprintf(out, "    %-28s %s\n", name, flag.Usage)
+        }
+}
+
+// writeJSON writes a value as indented JSON
+func writeJSON(out io.Writer, value interface{}) error {
+        encoder := json.NewEncoder(out)
+        encoder.SetIndent("", "  ")
+        return encoder.Encode(value)
+}
\ No newline at end of file
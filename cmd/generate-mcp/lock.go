@@ -0,0 +1,133 @@
+package main
+
+import (
+        "fmt"
+
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/npm"
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/openhands/mcp-generator/internal/standards"
+        "github.com/openhands/mcp-generator/internal/template"
+        "github.com/spf13/cobra"
+        "github.com/spf13/pflag"
+)
+
+// unlockedFlags are the flags that change how generate-mcp runs but not what it generates, left out of lock files
+var unlockedFlags = map[string]bool{
+        "help": true, "quiet": true, "verbose": true, "log-format": true, "error-format": true, "plugins-dir": true,
+        "jobs": true, "offline": true, "fetch-cache-dir": true, "fetch-cache-ttl": true, "no-cache": true,
+        "explorer-key": true, "npm-registry": true, "npm-cache-dir": true, "force": true, "merge": true,
+        "report": true, "plain": true,
+}
+
+// generationLock returns the lock file of a generated project: the generator, the inputs and options it was generated
+// from, the final IR's hash and the hashes of its files
+func generationLock(cmd *cobra.Command, contractIR *ir.ContractIR, artifactPath, outputDir string, pack *template.TemplatePack, files map[string][]byte) (*output.Lock, error) {
+        irHash, err := ir.ContentHash(contractIR)
+        if err != nil {
+                return nil, err
+        }
+        lock := &output.Lock{
+                LockVersion: output.LockVersion,
+                Generator:   output.LockGenerator{Name: "generate-mcp", Version: serverVersion()},
+                Language:    lang,
+                IRHash:      irHash,
+                Options:     map[string]interface{}{},
+        }
+        if pack != nil {
+                hash, err := output.HashFS(pack.Templates)
+                if err != nil {
+                        return nil, fmt.Errorf("failed to hash template pack %s: %w", pack.Manifest.Name, err)
+                }
+                lock.TemplatePack = &output.LockPack{Name: pack.Manifest.Name, Version: pack.Manifest.Version, Hash: hash}
+        }
+
+        // Inputs are hashed as read, so a lock records the files of the generation even if they change later
+        addInput := func(kind, path string) error {
+                hash := output.Hash(stdin.content)
+                var err error
+                if path != stdinPath {
+                        hash, err = inputHash(kind, path)
+                }
+                if err != nil {
+                        return fmt.Errorf("failed to hash %s %s: %w", kind, path, err)
+                }
+                lock.Inputs = append(lock.Inputs, output.LockInput{Kind: kind, Path: path, Hash: hash})
+                return nil
+        }
+        inputs := [][2]string{{"artifact", artifactPath}}
+        if configPath != "" {
+                inputs = append(inputs, [2]string{"config", configPath})
+        }
+        sidecar := annotations
+        if sidecar == "" && artifactPath != stdinPath {
+                sidecar = ir.SidecarAnnotationsPath(artifactPath)
+        }
+        if sidecar != "" {
+                inputs = append(inputs, [2]string{"annotations", sidecar})
+        }
+        for _, overlay := range overlays {
+                inputs = append(inputs, [2]string{"overlay", overlay})
+        }
+        if templatesDir != "" {
+                inputs = append(inputs, [2]string{"templates", templatesDir})
+        }
+        for _, input := range inputs {
+                if err := addInput(input[0], input[1]); err != nil {
+                        return nil, err
+                }
+        }
+
+        // Options are those given on the command line or set by the project config, which sets them as flags
+        cmd.Flags().Visit(func(flag *pflag.Flag) {
+                if unlockedFlags[flag.Name] {
+                        return
+                }
+                if values, ok := flag.Value.(pflag.SliceValue); ok {
+                        lock.Options[flag.Name] = values.GetSlice()
+                        return
+                }
+                lock.Options[flag.Name] = flag.Value.String()
+        })
+        // Each contract of a batch gets its own output, recorded as such
+        if _, ok := lock.Options["artifact"]; ok {
+                lock.Options["artifact"] = []string{artifactPath}
+        }
+        if _, ok := lock.Options["output"]; ok {
+                lock.Options["output"] = outputDir
+        }
+
+        lock.SetFiles(files)
+        return lock, nil
+}
+
+// inputHash returns the hash of an input file, of the files of a templates directory, of the ABI of an explorer
+// reference, or of the ABI and descriptions of a bundled standard; npm references hash the file they resolve to
+func inputHash(kind, path string) (string, error) {
+        if npm.IsReference(path) {
+                resolved, err := resolveNPMArtifact(path)
+                if err != nil {
+                        return "", err
+                }
+                path = resolved
+        }
+        if explorer.IsReference(path) {
+                _, contract, err := fetchContract(path)
+                if err != nil {
+                        return "", err
+                }
+                return output.Hash(contract.ABI), nil
+        }
+        if standards.IsReference(path) {
+                s, err := standards.ParseReference(path)
+                if err != nil {
+                        return "", usageError(err)
+                }
+                return s.Hash(), nil
+        }
+        if kind == "templates" {
+                return output.HashDir(path)
+        }
+        return output.HashFile(path)
+}
\ No newline at end of file
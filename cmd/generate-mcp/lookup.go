@@ -0,0 +1,105 @@
+package main
+
+import (
+        "context"
+        "errors"
+        "fmt"
+
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/offline"
+        "github.com/openhands/mcp-generator/internal/onchain"
+        "github.com/openhands/mcp-generator/internal/standards"
+)
+
+// applyLookup looks up the contract at the IR's address on its recorded network: the token metadata and EIP-1967
+// proxy slots it tells over RPC, and whether its source is verified by Sourcify or the network's explorer, recording
+// them in the IR's metadata
+// Contracts whose name defaulted to their artifact's are named after their token symbol, or else their verified
+// name, and described as tokens unless described. Lookups are best-effort: failures are warnings, and offline
+// nothing is looked up.
+func applyLookup(contractIR *ir.ContractIR, artifactPath string, logger *logging.Logger) {
+        address := contractIR.Metadata.Address
+        network := contractIR.Metadata.Network()
+        if address == "" || network.ChainID <= 0 {
+                return
+        }
+        if offline.Enabled() {
+                logger.Debugf("Lookup: skipped offline")
+                return
+        }
+        // Networks recorded by chain ID alone, as from broadcasts, use the RPC and explorer of their preset
+        if preset, ok := ir.NetworkByChainID(network.ChainID); ok {
+                if network.RPCURL == "" {
+                        network.RPCURL = preset.RPCURL
+                }
+                if network.ExplorerAPI == "" {
+                        network.ExplorerAPI = preset.ExplorerAPI
+                }
+        }
+
+        found := contractIR.Metadata.OnChain()
+        ctx := context.Background()
+        if network.RPCURL != "" {
+                info, err := onchain.Lookup(ctx, network.RPCURL, address, onchain.Options{})
+                if err != nil {
+                        logger.Warnf("could not look up %s on chain %d: %v", address, network.ChainID, err)
+                } else {
+                        found.TokenName, found.TokenSymbol, found.TokenDecimals = info.Name, info.Symbol, info.Decimals
+                        found.Implementation, found.ProxyAdmin = info.Implementation, info.Admin
+                        if info.IsToken() {
+                                logger.Infof("Token: %s (%s)%s", info.Name, info.Symbol, decimalsNote(info.Decimals))
+                        }
+                        if info.IsProxy() {
+                                logger.Infof("Proxy: EIP-1967 proxy of %s; generate from the implementation's ABI for its functions", info.Implementation)
+                        }
+                }
+        }
+
+        verifiedName := ""
+        options, err := explorerOptions()
+        if err == nil {
+                var contract *explorer.Contract
+                contract, _, err = explorer.Fetch(ctx, explorer.Reference{Network: network, Address: address}, options)
+                if err == nil {
+                        found.Verification, verifiedName = contract.Source, contract.Name
+                        logger.Infof("Verification: verified by %s", contract.Source)
+                } else if errors.Is(err, explorer.ErrNotVerified) {
+                        found.Verification = ir.Unverified
+                        logger.Infof("Verification: not verified by Sourcify or the network's explorer")
+                }
+        }
+        if err != nil && !errors.Is(err, explorer.ErrNotVerified) {
+                logger.Warnf("could not check whether %s is verified: %v", address, err)
+        }
+        contractIR.Metadata.SetOnChain(found)
+
+        if contractName == "" && defaultedName(artifactPath, contractIR.Metadata.Name) {
+                name := found.TokenSymbol
+                if name == "" {
+                        name = verifiedName
+                }
+                if name != "" && name != contractIR.Metadata.Name {
+                        logger.Infof("Name: %s, as looked up (pass --name to override)", name)
+                        contractIR.Metadata.Name = name
+                }
+        }
+        if contractIR.Metadata.Description == "" && found.TokenName != "" && found.TokenSymbol != "" {
+                contractIR.Metadata.Description = fmt.Sprintf("%s (%s) token", found.TokenName, found.TokenSymbol)
+        }
+}
+
+// defaultedName reports whether a contract's name is the default of its artifact rather than one given by an IR file:
+// the file name, or the name of an explorer's verified contract or a bundled standard
+func defaultedName(artifactPath, name string) bool {
+        return explorer.IsReference(artifactPath) || standards.IsReference(artifactPath) || name == contractFileName(artifactPath)
+}
+
+// decimalsNote returns the decimals of a token for logs, or "" if unknown
+func decimalsNote(decimals *int) string {
+        if decimals == nil {
+                return ""
+        }
+        return fmt.Sprintf(", %d decimals", *decimals)
+}
\ No newline at end of file
@@ -1,132 +1,817 @@
 package main
 
 import (
+        "encoding/json"
+        "errors"
         "fmt"
+        "io"
         "os"
-        "path/filepath"
+        goruntime "runtime"
+        "strings"
+        "time"
 
+        "github.com/openhands/mcp-generator/internal/config"
+        "github.com/openhands/mcp-generator/internal/enrich"
+        "github.com/openhands/mcp-generator/internal/explorer"
+        "github.com/openhands/mcp-generator/internal/hardhat"
         "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/npm"
+        "github.com/openhands/mcp-generator/internal/offline"
+        "github.com/openhands/mcp-generator/internal/openapi"
+        "github.com/openhands/mcp-generator/internal/openrpc"
+        "github.com/openhands/mcp-generator/internal/output"
         "github.com/openhands/mcp-generator/internal/parser"
+        "github.com/openhands/mcp-generator/internal/plugin"
+        "github.com/openhands/mcp-generator/internal/standards"
         "github.com/openhands/mcp-generator/internal/template"
         "github.com/spf13/cobra"
+        "github.com/spf13/pflag"
 )
 
 var (
-        artifactPath string
+        artifacts    []string
+        standardName string
         outputDir    string
+        outputFormat string
         lang         string
+        runtime      string
+        templatesDir string
+        templatePack string
         chainType    string
         contractName string
         contractAddr string
+        deployments  []string
+        broadcastDir string
+        noBroadcast  bool
+        lookupContract bool
+        network      string
+        rpcURL       string
         generateTests bool
+        docker       bool
+        ci           string
+        enableWrites bool
+        transport    string
+        subscriptions bool
+        detectAmounts bool
+        detectAccess bool
+        detectExamples bool
+        dedupeTuples bool
+        overloadNaming string
+        locale       string
+        configPath   string
+        profile      string
+        cache        bool
+        accessLists  bool
+        signers      []string
+        openAPI      bool
+        openRPC      bool
+        mode         string
+        pkg          template.PackageInfo
+        overlays     []string
+        annotations  string
+        llm          enrich.Options
+        noLLM        bool
+        strict       bool
+        verbose      bool
+        quiet        bool
+        logFormat    string
+        errorFormat  string
+        pluginsDir   string
+        templateVars []string
+        onCollision  string
+        diffOutput   bool
+        jobs         int
+        offlineMode  bool
+        fetchOptions explorer.Options
+        explorerKeys []string
+        npmOptions   npm.Options
+        verify       bool
+        overwrite    bool
+        mergeOutput  bool
+        reportPath   string
+        plainOutput  bool
 )
 
 func main() {
+        rootCmd := newRootCommand()
+
+        // Failures exit with the code of their cause: usage, parse, validation, render or IO (see errors.go)
+        if cmd, err := rootCmd.ExecuteC(); err != nil {
+                failure := classify(err)
+                if logger, _ := newLogger(os.Stderr); logger != nil && logFormat == logging.FormatJSON && errorFormat != errorFormatJSON {
+                        logger.Errorf("%v", err)
+                } else {
+                        // Only usage errors are helped by the usage of the command
+                        if failure.exitCode == exitUsage && errorFormat != errorFormatJSON {
+                                fmt.Fprintln(os.Stderr, cmd.UsageString())
+                        }
+                        writeError(os.Stderr, err, errorFormat)
+                }
+                os.Exit(failure.exitCode)
+        }
+}
+
+// newRootCommand returns the generate-mcp command with its subcommands; defining its flags resets them to their
+// defaults, so that serve-mcp runs each tool call on a fresh command
+func newRootCommand() *cobra.Command {
         rootCmd := &cobra.Command{
                 Use:   "generate-mcp",
                 Short: "Generate MCP servers for smart contracts",
                 Long:  `A tool that generates typed MCP (Model Context Protocol) servers for any deployed smart contract.`,
                 RunE:  run,
+                // main prints the usage for usage errors only, not for failures such as unreadable artifacts
+                SilenceUsage: true,
+        }
+
+        rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log details such as the time each phase takes")
+        rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Log only warnings and errors")
+        rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatText, "Log format (text, json); json writes an object per message for CI and automation")
+        rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "Plain output for CI logs: no colors, no interactive prompts, and the logs of a batch in artifact order (default: when stdout is not a terminal)")
+        rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", errorFormatText, "Format of failures on stderr (text, json); json writes an object with the cause, exit code, message and details such as validation findings")
+        rootCmd.PersistentFlags().StringVar(&pluginsDir, "plugins-dir", plugin.DefaultDir(), "Directory of plugin executables providing parsers and generators for more chains and languages (see Plugins in the README); empty disables plugins")
+        rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "Make no network requests beyond localhost, failing LLM enrichment with remote providers (plugins get "+offline.EnvVar+"=1), for air-gapped and compliance-sensitive builds")
+        rootCmd.PersistentFlags().StringVar(&fetchOptions.CacheDir, "fetch-cache-dir", explorer.DefaultCacheDir(), "Directory the verified ABIs of explorer:<network>/<address> artifacts are cached in; empty disables caching")
+        rootCmd.PersistentFlags().DurationVar(&fetchOptions.TTL, "fetch-cache-ttl", explorer.DefaultTTL, "How long fetched ABIs are cached before being fetched again")
+        rootCmd.PersistentFlags().BoolVar(&fetchOptions.NoCache, "no-cache", false, "Fetch the ABIs of explorer artifacts and the packages of npm artifacts even if cached, refreshing the cache")
+        rootCmd.PersistentFlags().StringArrayVar(&explorerKeys, "explorer-key", nil, "API key of Etherscan-compatible explorers as <network or chain ID>=<key>, or <key> for every chain; repeatable (default: the explorer's variable such as BASESCAN_API_KEY, the keychain, or "+explorer.GenericKeyEnv+")")
+        rootCmd.PersistentFlags().StringVar(&npmOptions.Registry, "npm-registry", "", "Registry npm:<package>[@<version>]/<file> artifacts are fetched from when not installed in node_modules (default: $"+npm.RegistryEnv+" or "+npm.DefaultRegistry+")")
+        rootCmd.PersistentFlags().StringVar(&npmOptions.CacheDir, "npm-cache-dir", npm.DefaultCacheDir(), "Directory the packages of npm artifacts are cached in; empty disables caching")
+        rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+                return usageError(err)
+        })
+        rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+                resolvePlain(cmd)
+                if _, err := newLogger(io.Discard); err != nil {
+                        return usageError(err)
+                }
+                if errorFormat != errorFormatText && errorFormat != errorFormatJSON {
+                        return usageError(fmt.Errorf("unsupported error format: %s (expected text or json)", errorFormat))
+                }
+                // Offline mode holds for the rest of the process, plugins included
+                if offlineMode {
+                        offline.Enable()
+                }
+                if err := plugin.Load(pluginsDir, commandLogger(cmd)); err != nil {
+                        return ioError(err)
+                }
+                // JSON logs and errors report the failure themselves, without cobra's text
+                if logFormat == logging.FormatJSON || errorFormat == errorFormatJSON {
+                        cmd.Root().SilenceErrors = true
+                }
+                return nil
         }
+        addGenerateFlags(rootCmd.Flags())
 
-        rootCmd.Flags().StringVarP(&artifactPath, "artifact", "a", "", "Path to the contract artifact (ABI/IDL)")
-        rootCmd.Flags().StringVarP(&outputDir, "output", "o", "./mcp-server", "Output directory for the generated MCP server")
-        rootCmd.Flags().StringVarP(&lang, "lang", "l", "ts", "Output language (ts, python)")
-        rootCmd.Flags().StringVarP(&chainType, "chain", "c", "ethereum", "Blockchain type (ethereum, solana)")
-        rootCmd.Flags().StringVarP(&contractName, "name", "n", "", "Contract name")
-        rootCmd.Flags().StringVarP(&contractAddr, "address", "d", "", "Contract address")
-        rootCmd.Flags().BoolVarP(&generateTests, "tests", "t", true, "Generate e2e tests for the MCP server")
+        renderCmd := &cobra.Command{
+                Use:   "render [file]",
+                Short: "Generate an MCP server from an IR or artifact file, or from stdin with -",
+                Long: `Generate an MCP server like generate-mcp itself, from the IR or artifact given as argument instead of --artifact.
+With - the IR (JSON or YAML) or artifact is read from stdin, so that IRs can be composed in a pipeline:
 
-        rootCmd.MarkFlagRequired("artifact")
+  curl <explorer-abi-url> | generate-mcp parse - | jq ... | generate-mcp render - -o ./my-mcp-server`,
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        if len(args) == 1 {
+                                if cmd.Flags().Changed("artifact") {
+                                        return errors.New("give the file to render either as argument or as --artifact, not both")
+                                }
+                                if err := cmd.Flags().Set("artifact", args[0]); err != nil {
+                                        return err
+                                }
+                        }
+                        return run(cmd, nil)
+                },
+        }
+        addGenerateFlags(renderCmd.Flags())
+
+        parseCmd := newExportCommand("parse <file>")
+        parseCmd.Short = "Parse an artifact, or - for stdin, and write its IR to stdout"
+
+        rootCmd.AddCommand(newIRCommand())
+        rootCmd.AddCommand(parseCmd)
+        rootCmd.AddCommand(renderCmd)
+        rootCmd.AddCommand(newValidateCommand(true))
+        rootCmd.AddCommand(newInspectCommand())
+        rootCmd.AddCommand(newListChainsCommand())
+        rootCmd.AddCommand(newListGeneratorsCommand())
+        rootCmd.AddCommand(newCleanCommand())
+        rootCmd.AddCommand(newUpgradeCommand())
+        rootCmd.AddCommand(newCacheCommand())
+        rootCmd.AddCommand(newTemplateCommand())
+        rootCmd.AddCommand(newServeMCPCommand())
+        rootCmd.AddCommand(newServeHTTPCommand())
+        // Wrong arguments are usage errors, like unknown flags
+        markArgsErrors(rootCmd)
+        return rootCmd
+}
+
+// markArgsErrors marks the failures of the argument checks of a command and its subcommands as usage errors
+func markArgsErrors(cmd *cobra.Command) {
+        if check := cmd.Args; check != nil {
+                cmd.Args = func(cmd *cobra.Command, args []string) error {
+                        if err := check(cmd, args); err != nil {
+                                return usageError(err)
+                        }
+                        return nil
+                }
+        }
+        for _, sub := range cmd.Commands() {
+                markArgsErrors(sub)
+        }
+}
 
-        if err := rootCmd.Execute(); err != nil {
-                fmt.Println(err)
-                os.Exit(1)
+// newLogger returns a logger writing to out at the verbosity and in the format of the logging flags
+func newLogger(out io.Writer) (*logging.Logger, error) {
+        level, err := logging.LevelOf(verbose, quiet)
+        if err != nil {
+                return nil, err
+        }
+        logger, err := logging.New(out, level, logFormat)
+        if err != nil {
+                return nil, err
         }
+        logger.SetColor(colorEnabled(out))
+        return logger, nil
+}
+
+// commandLogger returns the logger of subcommands, which log to stderr; the logging flags are checked before any
+// command runs
+func commandLogger(cmd *cobra.Command) *logging.Logger {
+        logger, _ := newLogger(cmd.ErrOrStderr())
+        return logger
+}
+
+// addGenerateFlags adds the flags of generation, shared by generate-mcp and its render command
+func addGenerateFlags(flags *pflag.FlagSet) {
+        flags.StringVar(&configPath, "config", "", "Project config (YAML or JSON) whose options, keyed by flag name, are used for flags not given on the command line (default: mcpgen.yaml in the working directory, if present)")
+        flags.StringVar(&profile, "profile", "", "Profile of the project config whose options (e.g. address, network, rpc-url, filter) override the config's, e.g. staging (default: the config's profile option)")
+        flags.StringArrayVarP(&artifacts, "artifact", "a", nil, "Path to the contract artifact (ABI/IDL) or IR file, or - to read it from stdin (required, on the command line or in the project config); repeatable, and globs such as 'artifacts/**/*.json' generate one server per contract into subdirectories of --output")
+        flags.StringVar(&standardName, "standard", "", "Generate from the bundled canonical ABI of a token standard ("+strings.Join(standards.Names(), ", ")+"), with curated descriptions, instead of --artifact; give the token's --address and --network")
+        flags.StringVarP(&outputDir, "output", "o", "./mcp-server", "Output directory for the generated MCP server")
+        flags.StringVar(&outputFormat, "output-format", output.FormatDir, "How the generated project is emitted: dir, zip (<output>.zip), tar (<output>.tar.gz), or stdout to stream a tar archive")
+        flags.IntVarP(&jobs, "jobs", "j", goruntime.NumCPU(), "Number of contracts of a batch parsed and rendered concurrently; their logs are written per contract once it is done")
+        flags.StringVarP(&lang, "lang", "l", "ts", "Output language ("+languageList()+"; see list-generators)")
+        flags.StringVar(&mode, "mode", "server", "Output mode for TypeScript output: server, or types for only a publishable package of the contract ABI, factory and types")
+        flags.StringVar(&runtime, "runtime", "node", "JavaScript runtime for TypeScript output (node, deno, bun)")
+        flags.StringVar(&templatesDir, "templates", "", "Directory of template overrides; files not present fall back to the built-in templates")
+        flags.StringArrayVar(&templateVars, "set", nil, "Template variable as <name>=<value>, e.g. org=acme, available to templates as {{ .Vars.org }}; repeatable")
+        flags.StringVar(&templatePack, "template-pack", "", "Name of a registered template pack, or path to a pack directory or archive (.zip, .tar.gz)")
+        flags.StringArrayVar(&overlays, "overlay", nil, "JSON or YAML overlay merged onto the parsed IR (descriptions, tool renames, hidden functions and events); repeatable, applied in order")
+        flags.StringVar(&annotations, "annotations", "", "JSON or YAML annotations file documenting functions and events (descriptions, tags, examples, danger levels) (default: <artifact>.annotations.yaml next to the artifact, if present)")
+        flags.StringVar(&locale, "locale", "", "Language tag whose translated descriptions (descriptions in the IR, annotations or overlays) replace the default ones in tools and docs, e.g. ja; untranslated descriptions keep their default text")
+        flags.StringVar(&llm.Provider, "llm-provider", "", "LLM that rewrites function and event descriptions from their signatures and NatSpec (openai, anthropic, local); off unless set")
+        flags.StringVar(&llm.Model, "llm-model", "", "LLM model (default: gpt-4o-mini, claude-3-5-haiku-latest or llama3.1)")
+        flags.StringVar(&llm.Endpoint, "llm-endpoint", "", "Base URL of the LLM API, e.g. an OpenAI-compatible local server (default: the provider's, or http://localhost:11434/v1 for local)")
+        flags.StringVar(&llm.CacheDir, "llm-cache-dir", enrich.DefaultCacheDir(), "Directory LLM replies are cached in; empty disables caching")
+        flags.BoolVar(&noLLM, "no-llm", false, "Never call an LLM, even if --llm-provider is set")
+        flags.StringVarP(&chainType, "chain", "c", "ethereum", "Blockchain type ("+chainList()+"; see list-chains)")
+        flags.StringVarP(&contractName, "name", "n", "", "Contract name")
+        flags.StringVarP(&contractAddr, "address", "d", "", "Contract address")
+        flags.StringArrayVar(&deployments, "deployment", nil, "Address of the contract on another network as <chainId>=<address>[@<deploy block>], e.g. 10=0x...@1234567; repeatable, added to the IR's deployments")
+        flags.StringVar(&broadcastDir, "broadcast", "", "Foundry broadcast directory whose run-latest.json files record where forge script deployed the contract, added to the IR's deployments (default: broadcast/ of the Foundry project containing the artifact or the working directory)")
+        flags.BoolVar(&noBroadcast, "no-broadcast", false, "Ignore the deployments recorded in Foundry broadcasts")
+        flags.StringVar(&network, "network", "", "Network preset ("+strings.Join(ir.NetworkNames(), ", ")+") whose chain ID, public RPC and block explorer are recorded in the IR and used as the generated server's defaults; --address becomes the deployment on its chain")
+        flags.BoolVar(&lookupContract, "lookup", false, "Look up the contract at --address on the recorded network: the name, symbol and decimals of tokens and the EIP-1967 implementation and admin of proxies over RPC, and its verification by Sourcify or the network's explorer, recorded in the IR; unless --name is given, the contract is named after its token symbol or verified name instead of the artifact's file name")
+        flags.StringVar(&rpcURL, "rpc-url", "", "RPC URL recorded in the IR as the generated server's default (default: the public RPC of --network, if given)")
+        flags.BoolVarP(&generateTests, "tests", "t", true, "Generate e2e tests for the MCP server")
+        flags.StringVar(&ci, "ci", "none", "CI workflow to generate for the MCP server (github, gitlab, none)")
+        flags.StringVar(&transport, "transport", "stdio", "MCP transport of the generated server (stdio, http)")
+        flags.BoolVar(&enableWrites, "enable-writes", false, "Expose payable and nonpayable functions as tools that build and optionally send transactions")
+        flags.BoolVar(&subscriptions, "enable-subscriptions", false, "Generate per-event subscribe/unsubscribe tools that push decoded events as MCP notifications (stdio transport only)")
+        flags.BoolVar(&accessLists, "enable-access-lists", false, "With --enable-writes, have write tools attach the access list eth_createAccessList returns to the transactions they build when it saves gas, and simulations report it")
+        flags.StringSliceVar(&signers, "signers", nil, "Signers the server supports besides private keys and mnemonics, selected at runtime with SIGNER_MODE: ledger (a Ledger over USB, node runtime only), walletconnect (a wallet app paired over WalletConnect v2); requires --enable-writes")
+        flags.BoolVar(&cache, "enable-cache", false, "Cache view and pure function results in memory with per-tool TTLs (immutable values like name() are cached indefinitely)")
+        flags.BoolVar(&detectAmounts, "detect-amounts", true, "Treat amount parameters of contracts with a decimals() function as token amounts given and returned in token units, and detect durations, timestamps and basis points by name")
+        flags.BoolVar(&detectAccess, "detect-access", true, "Restrict owner and role administration functions of Ownable and AccessControl contracts, and functions named after their roles (e.g. mint for MINTER_ROLE), in tool descriptions and pre-send checks")
+        flags.BoolVar(&detectExamples, "detect-examples", true, "Give function inputs without annotated examples one guessed from their type, unit and name (the zero address, one token, 1e18 amounts, a recent block), used in tool schemas, documentation and test fixtures")
+        flags.BoolVar(&diffOutput, "diff", false, "Render in memory and print a unified diff against the output directory instead of writing it, failing with exit code 7 if generated files differ (e.g. to check in CI that a server is up to date with its ABI)")
+        flags.BoolVar(&overwrite, "force", false, "Overwrite files of the output directory that were edited outside their protected regions since they were generated, or that were not generated, instead of failing with exit code 9")
+        flags.BoolVar(&mergeOutput, "merge", false, "Merge the generation into files of the output directory that were edited since they were generated, or that were not generated, marking where they differ with conflict markers (<<<<<<< current, >>>>>>> generated) to resolve, instead of failing with exit code 9")
+        flags.StringVar(&reportPath, "report", "", "Write a JSON summary of the generation to a file, e.g. report.json: each server's tools, the functions and events left without one and why, warnings, generated files and phase timings; written whether generation succeeds or fails")
+        flags.BoolVar(&verify, "verify", false, "After writing, check that the server builds by installing its dependencies and type-checking it (npm install and tsc --noEmit, or the runtime's or plugin's equivalent) in a temporary copy, failing with exit code 8 if it does not")
+        flags.StringVar(&onCollision, "on-collision", collisionsFail, "How colliding tool names are handled, e.g. overloads whose name is taken by another function: fail with a report, prompt for new names on stdin, or suffix to keep numbering such overloads (transfer_1_1) with a warning")
+        flags.StringVar(&overloadNaming, "overload-naming", string(ir.OverloadSuffix), "How tools of overloaded functions are named: suffix (transfer, transfer_1), types (transferAddressUint256) or params (transferByToAmount); IR files keep their names unless set")
+        flags.BoolVar(&dedupeTuples, "dedupe-tuples", true, "Declare each distinct tuple shape once as a named type (named after its Solidity struct if known) instead of repeating it inline")
+        flags.BoolVar(&openAPI, "openapi", false, "Also write an OpenAPI 3.1 document (openapi.json) describing the same operations as the MCP tools")
+        flags.BoolVar(&openRPC, "openrpc", false, "Also write an OpenRPC document (openrpc.json) describing the same operations as JSON-RPC methods")
+        flags.StringVar(&pkg.Scope, "package-scope", "", "npm (or JSR for Deno) scope of the generated package, e.g. @acme")
+        flags.StringVar(&pkg.Version, "package-version", template.DefaultPackageVersion, "Semantic version of the generated package")
+        flags.StringVar(&pkg.License, "license", "", "SPDX license expression of the generated package, e.g. MIT")
+        flags.StringVar(&pkg.Author, "author", "", "Author of the generated package, e.g. \"Jane Doe <jane@example.com>\"")
+        flags.StringVar(&pkg.Repository, "repository", "", "Source repository of the generated package, e.g. github:acme/token-mcp-server")
+        flags.BoolVar(&strict, "strict", false, "Fail on IR validation warnings (e.g. functions without descriptions) as well as errors")
+        flags.BoolVar(&docker, "docker", false, "Generate a Dockerfile, .dockerignore and docker-compose.yml for the MCP server")
 }
 
 func run(cmd *cobra.Command, args []string) error {
-        // Open the artifact file
-        file, err := os.Open(artifactPath)
+        // Settings committed in the project config apply unless given on the command line
+        filter, err := applyConfig(cmd)
         if err != nil {
-                return fmt.Errorf("failed to open artifact file: %w", err)
+                return err
+        }
+        if standardName != "" {
+                if len(artifacts) > 0 {
+                        return usageError(errors.New("--standard generates from a bundled ABI instead of --artifact; pass only one"))
+                }
+                s, err := standards.Lookup(standardName)
+                if err != nil {
+                        return usageError(err)
+                }
+                artifacts = []string{s.Reference()}
+        }
+        if len(artifacts) == 0 {
+                return usageError(errors.New(`required flag "artifact" not set (pass --artifact or --standard, or set artifact in mcpgen.yaml)`))
+        }
+        if verify && diffOutput {
+                return usageError(errors.New("--verify checks the written server, but --diff writes nothing"))
+        }
+        if verify && offlineMode {
+                return usageError(errors.New("--verify installs the server's dependencies, which --offline forbids"))
+        }
+        if overwrite && mergeOutput {
+                return usageError(errors.New("--force overwrites conflicting files and --merge merges into them; pass only one"))
+        }
+        if diffOutput && outputFormat != output.FormatDir {
+                return usageError(fmt.Errorf("--diff compares with an output directory, not --output-format %s", outputFormat))
+        }
+
+        // Progress goes to stderr when the project or its diff is written to stdout
+        log := cmd.OutOrStdout()
+        if outputFormat == output.FormatStdout || diffOutput {
+                log = cmd.ErrOrStderr()
+        }
+        logger, err := newLogger(log)
+        if err != nil {
+                return usageError(err)
+        }
+        if configPath != "" && profile != "" {
+                logger.Infof("Config: %s (profile %s)", configPath, profile)
+        } else if configPath != "" {
+                logger.Infof("Config: %s", configPath)
+        }
+
+        // Several artifacts, or any glob, generate a server per contract
+        paths, batch, err := expandArtifacts(artifacts)
+        if err != nil {
+                return usageError(err)
+        }
+        report := newGenerationReport()
+        if batch {
+                err = generateBatch(cmd, paths, filter, logger, report)
+        } else {
+                entry := report.contract(0, paths[0], outputDir)
+                contractIR, generateErr := generate(cmd, paths[0], outputDir, filter, entry.logger(logger), entry)
+                entry.finish(contractIR, generateErr)
+                err = generateErr
+        }
+        // The report is written whether generation succeeds or fails, but failing to write it does not hide the failure
+        if reportErr := report.write(err); reportErr != nil && err == nil {
+                err = reportErr
         }
-        defer file.Close()
+        return err
+}
 
-        // If contract name is not provided, use the filename
-        if contractName == "" {
-                contractName = filepath.Base(artifactPath)
-                contractName = contractName[:len(contractName)-len(filepath.Ext(contractName))]
+// generate generates the server of one contract artifact or IR file into an output directory or archive, and returns
+// the contract's IR as generated; with --report, what it filters out and the files it generates are recorded in the
+// contract's report
+func generate(cmd *cobra.Command, artifactPath, outputDir string, filter *ir.Projection, logger *logging.Logger, entry *contractReport) (*ir.ContractIR, error) {
+        destination, err := output.Path(outputFormat, outputDir)
+        if err != nil {
+                return nil, usageError(err)
+        }
+        modTime, err := output.ModTime()
+        if err != nil {
+                return nil, err
         }
+        phase := time.Now()
 
-        // Create metadata
-        metadata := ir.ContractMetadata{
+        // Parse the artifact, or read and validate an IR file
+        contractIR, err := loadContract(artifactPath, ir.ContractMetadata{
                 Name:    contractName,
                 Chain:   chainType,
                 Address: contractAddr,
+        })
+        if err != nil {
+                return nil, err
         }
 
-        // Parse the artifact
-        var contractIR *ir.ContractIR
-        switch chainType {
-        case "ethereum", "evm":
-                p := parser.NewEVMABIParser()
-                contractIR, err = p.Parse(file, metadata)
+        for _, value := range deployments {
+                chainID, deployment, err := ir.ParseDeployment(value)
                 if err != nil {
-                        return fmt.Errorf("failed to parse EVM ABI: %w", err)
+                        return nil, err
                 }
-        case "solana":
-                return fmt.Errorf("solana support not implemented yet")
-        default:
-                return fmt.Errorf("unsupported chain type: %s", chainType)
+                if contractIR.Metadata.Deployments == nil {
+                        contractIR.Metadata.Deployments = map[string]ir.Deployment{}
+                }
+                contractIR.Metadata.Deployments[chainID] = deployment
+        }
+        if network != "" {
+                preset, err := ir.LookupNetwork(network)
+                if err != nil {
+                        return nil, usageError(err)
+                }
+                if ir.IsAPIChain(contractIR.Metadata.Chain) {
+                        return nil, usageError(fmt.Errorf("--network applies to contracts, not %s APIs", contractIR.Metadata.Chain))
+                }
+                contractIR.Metadata.SetNetwork(preset)
+                logger.Infof("Network: %s (chain %d)", preset.Name, preset.ChainID)
+        }
+        // Deployments recorded by hardhat-deploy or forge script fill in what --deployment and --address left out
+        if !ir.IsAPIChain(contractIR.Metadata.Chain) {
+                if hardhat.IsDeploymentFile(artifactPath) {
+                        err = applyHardhatDeployments(contractIR, artifactPath, logger)
+                } else if !noBroadcast && !standards.IsReference(artifactPath) {
+                        err = applyBroadcasts(contractIR, artifactPath, logger)
+                }
+                if err != nil {
+                        return nil, err
+                }
+        }
+        if rpcURL != "" {
+                recorded := contractIR.Metadata.Network()
+                recorded.RPCURL = rpcURL
+                contractIR.Metadata.SetNetwork(recorded)
+        }
+        // Callers of serve-mcp and serve-http must not make the server send requests to endpoints they pick
+        if lookupContract && !remoteCommand && !ir.IsAPIChain(contractIR.Metadata.Chain) {
+                applyLookup(contractIR, artifactPath, logger)
+        }
+        phase = logger.Phase("parse", phase)
+
+        // Unnamed parameters are named before anything refers to them, so annotations and overlays can key them by name
+        if named := ir.NameParameters(contractIR); named > 0 {
+                logger.Infof("Parameters: %d unnamed parameters named", named)
         }
 
-        // Debug output
-        fmt.Println("Parsed contract IR:")
-        fmt.Printf("Functions: %d\n", len(contractIR.Functions))
-        for i, f := range contractIR.Functions {
-                fmt.Printf("  %d. %s (StateMutability: %s)\n", i+1, f.Name, f.StateMutability)
+        // Overloads are named by the chosen policy before annotations and overlays key functions by name
+        if cmd.Flags().Changed("overload-naming") {
+                overloaded := ir.MarkOverloads(contractIR)
+                if err := ir.NameOverloads(contractIR, ir.OverloadNaming(overloadNaming)); err != nil {
+                        return nil, err
+                }
+                if overloaded > 0 {
+                        logger.Infof("Overloads: %d overloaded functions named by %s", overloaded, overloadNaming)
+                }
         }
-        
+
+        // Descriptions are rewritten by an LLM before hand-written annotations and overlays are applied
+        if llm.Provider != "" && !noLLM {
+                options, err := llm.Normalize()
+                if err != nil {
+                        return nil, err
+                }
+                if err := offline.Check("LLM enrichment with "+options.Provider, options.Endpoint); err != nil {
+                        return nil, usageError(fmt.Errorf("%w (pass --no-llm, or a local model with --llm-provider local)", err))
+                }
+                logger.Infof("Enriching descriptions with %s (%s)...", options.Provider, options.Model)
+                result, err := enrich.Enrich(cmd.Context(), contractIR, options)
+                if err != nil {
+                        logger.Warnf("description enrichment stopped, keeping the remaining parsed descriptions: %v", err)
+                }
+                if result.Described > 0 {
+                        logger.Infof("Descriptions: %d written by the LLM (%d cached)", result.Described, result.Cached)
+                }
+                phase = logger.Phase("enrich", phase)
+        }
+
+        // Hand-written annotations take precedence over LLM descriptions
+        if err := applyAnnotations(contractIR, artifactPath, annotations, logger); err != nil {
+                return nil, err
+        }
+
+        // Customizations are merged onto the IR before anything is derived from it
+        for _, path := range overlays {
+                overlay, err := ir.LoadOverlay(path)
+                if err != nil {
+                        return nil, err
+                }
+                unmatched, err := overlay.Apply(contractIR)
+                if err != nil {
+                        return nil, fmt.Errorf("failed to apply overlay %s: %w", path, err)
+                }
+                for _, key := range unmatched {
+                        logger.Warnf("overlay %s: %s matches nothing in the contract", path, key)
+                }
+        }
+
+        // The config's filter projects the IR onto the functions, events and data the server exposes
+        if filter != nil {
+                functions := len(contractIR.Functions)
+                unfiltered := contractIR
+                if contractIR, err = ir.Project(contractIR, *filter); err != nil {
+                        return nil, err
+                }
+                entry.filter(unfiltered, contractIR)
+                logger.Infof("Filter: %d of %d functions kept", len(contractIR.Functions), functions)
+        }
+
+        // Descriptions are localized once every source of descriptions and translations has been applied
+        if err := localize(contractIR, locale, logger); err != nil {
+                return nil, err
+        }
+
+        logger.Infof("Parsed: %d functions, %d events, %d errors (list them with generate-mcp inspect)", len(contractIR.Functions), len(contractIR.Events), len(contractIR.Errors))
+        if logger.Enabled(logging.LevelDebug) {
+                for i, f := range contractIR.Functions {
+                        logger.Debugf("  %d. %s (%s)", i+1, f.Name, f.StateMutability)
+                }
+        }
+        phase = logger.Phase("customize", phase)
+
+        // Token amounts are converted with the contract's decimals(), and durations, timestamps and basis points documented
+        if detectAmounts {
+                if detected := ir.DetectTokenAmounts(contractIR); detected > 0 {
+                        logger.Infof("Token amounts: %d parameters converted with decimals()", detected)
+                }
+                if detected := ir.DetectUnits(contractIR); detected > 0 {
+                        logger.Infof("Units: %d durations, timestamps and basis points detected", detected)
+                }
+        }
+
+        // Amounts in wei, gwei or tokens of known decimals are converted whether their units were detected or annotated
+        if resolved := ir.ResolveUnits(contractIR); resolved > 0 {
+                logger.Infof("Units: %d amounts converted with the decimals of their units", resolved)
+        }
+
+        // Privileged functions are documented and checked against the signer before sending
+        if detectAccess {
+                if detected := ir.DetectAccessControl(contractIR); detected > 0 {
+                        logger.Infof("Access control: %d functions restricted to the owner or role holders", detected)
+                }
+        }
+
+        // Example values document tool arguments and seed test fixtures; they are guessed once units are known
+        if detectExamples {
+                if detected := ir.DetectExamples(contractIR); detected > 0 {
+                        logger.Infof("Examples: %d parameters given example values", detected)
+                }
+        }
+
+        // Tools are grouped by category, guessed for functions whose category is not set by the IR or an overlay
+        if categorized := ir.Categorize(contractIR); categorized > 0 {
+                logger.Infof("Categories: %d write functions categorized", categorized)
+        }
+
+        // Structs are declared once, however many functions and events use them
+        if dedupeTuples {
+                if lifted := ir.DeduplicateTuples(contractIR); lifted > 0 {
+                        logger.Infof("Custom types: %d tuple shapes declared as named types", lifted)
+                }
+        }
+        phase = logger.Phase("analyze", phase)
+
+        // Tool names are checked once no overlay, filter or analysis renames functions any more
+        if err := checkCollisions(cmd, contractIR, artifactPath, logger); err != nil {
+                return nil, err
+        }
+
+        // The IR is validated as it will be rendered; warnings fail generation in strict mode
+        report := contractIR.Report()
+        for _, warning := range report.Warnings {
+                logger.Warnf("%s", warning)
+        }
+        if report.Failed(strict) {
+                findings := append(report.Errors, report.Warnings...)
+                message := fmt.Sprintf("invalid IR: %d errors, %d warnings", len(report.Errors), len(report.Warnings))
+                for _, finding := range findings {
+                        message += "\n  " + finding.String()
+                }
+                return nil, validationError(errors.New(message), findings)
+        }
+        phase = logger.Phase("validate", phase)
+
         // Generate the MCP server
+        generator, ok := template.LookupGenerator(lang)
+        if !ok {
+                return nil, usageError(fmt.Errorf("unsupported language: %s (supported: %s; see generate-mcp list-generators)", lang, languageList()))
+        }
+        if verify && generator.Verify == nil {
+                return nil, usageError(fmt.Errorf("--verify cannot check %s servers, as their generator declares no verification commands", generator.Language))
+        }
         var files map[string][]byte
-        switch lang {
-        case "ts", "typescript":
-                r := template.NewTypeScriptTemplateRenderer()
+        var pack *template.TemplatePack
+        switch generator.Language {
+        case "ts":
+                vars, err := template.ParseVars(templateVars)
+                if err != nil {
+                        return nil, usageError(err)
+                }
+                r := template.NewTypeScriptTemplateRenderer().WithRuntime(runtime).WithDocker(docker).WithCI(ci).WithWrites(enableWrites).WithTransport(transport).WithSubscriptions(subscriptions).WithCache(cache).WithAccessLists(accessLists).WithSigners(signers).WithMode(mode).WithPackage(pkg).WithVars(vars)
+                if templatePack != "" {
+                        if pack, err = template.ResolveTemplatePack(templatePack); err != nil {
+                                return nil, renderError(err)
+                        }
+                        r.WithTemplatePack(pack)
+                }
+                if templatesDir != "" {
+                        info, err := os.Stat(templatesDir)
+                        if err != nil || !info.IsDir() {
+                                return nil, ioError(fmt.Errorf("template override directory not found: %s", templatesDir))
+                        }
+                        r.WithTemplateDir(templatesDir)
+                }
                 files, err = r.Render(contractIR)
                 if err != nil {
-                        return fmt.Errorf("failed to render TypeScript MCP server: %w", err)
+                        return nil, renderError(fmt.Errorf("failed to render TypeScript MCP server: %w", err))
                 }
-        case "python", "py":
-                return fmt.Errorf("python support not implemented yet")
         default:
-                return fmt.Errorf("unsupported language: %s", lang)
+                // Generators of plugins receive the values of the flags they support
+                files, err = generator.Render(contractIR, generatorOptions(cmd, generator))
+                if err != nil {
+                        return nil, renderError(fmt.Errorf("failed to render %s MCP server with plugin %s: %w", generator.Language, generator.Plugin, err))
+                }
         }
 
-        // Create the output directory
-        if err := os.MkdirAll(outputDir, 0755); err != nil {
-                return fmt.Errorf("failed to create output directory: %w", err)
+        // The OpenAPI and OpenRPC documents are generated from the same IR as the server
+        if openAPI {
+                content, err := json.MarshalIndent(openapi.Generate(contractIR, openapi.Options{EnableWrites: enableWrites}), "", "  ")
+                if err != nil {
+                        return nil, renderError(fmt.Errorf("failed to generate OpenAPI document: %w", err))
+                }
+                if _, exists := files["openapi.json"]; exists {
+                        return nil, renderError(fmt.Errorf("openapi.json is both rendered from the templates and written by --openapi"))
+                }
+                files["openapi.json"] = content
+        }
+        if openRPC {
+                content, err := json.MarshalIndent(openrpc.Generate(contractIR, openrpc.Options{EnableWrites: enableWrites}), "", "  ")
+                if err != nil {
+                        return nil, renderError(fmt.Errorf("failed to generate OpenRPC document: %w", err))
+                }
+                if _, exists := files["openrpc.json"]; exists {
+                        return nil, renderError(fmt.Errorf("openrpc.json is both rendered from the templates and written by --openrpc"))
+                }
+                files["openrpc.json"] = content
         }
 
-        // Create src directory
-        if err := os.MkdirAll(filepath.Join(outputDir, "src"), 0755); err != nil {
-                return fmt.Errorf("failed to create src directory: %w", err)
+        // Code written inside the protected regions of the output directory's files is carried into the new files
+        if outputFormat == output.FormatDir {
+                dropped, err := output.PreserveRegions(files, destination)
+                if err != nil {
+                        return nil, ioError(err)
+                }
+                for _, region := range dropped {
+                        logger.Warnf("protected region %s is no longer generated; regenerating drops its content", region)
+                }
         }
 
-        // Write the files
-        for path, content := range files {
-                fullPath := filepath.Join(outputDir, path)
-                
-                // Create parent directories if they don't exist
-                if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-                        return fmt.Errorf("failed to create directory for %s: %w", path, err)
+        // The lock file records how the project was generated and the hash of every generated file
+        if _, exists := files[output.LockFile]; exists {
+                return nil, renderError(fmt.Errorf("%s is both rendered from the templates and written as the lock file", output.LockFile))
+        }
+        lock, err := generationLock(cmd, contractIR, artifactPath, outputDir, pack, files)
+        if err != nil {
+                return nil, ioError(err)
+        }
+        if files[output.LockFile], err = lock.Encode(); err != nil {
+                return nil, renderError(err)
+        }
+        if collisions := output.Collisions(files); len(collisions) > 0 {
+                var groups []string
+                for _, group := range collisions {
+                        groups = append(groups, strings.Join(group, ", "))
                 }
-                
-                if err := os.WriteFile(fullPath, content, 0644); err != nil {
-                        return fmt.Errorf("failed to write file %s: %w", path, err)
+                return nil, renderError(fmt.Errorf("%d groups of generated files would overwrite each other (on case-insensitive file systems, or as file and directory):\n  %s", len(groups), strings.Join(groups, "\n  ")))
+        }
+        entry.files(files)
+        phase = logger.Phase("render", phase)
+
+        // A diff against the output directory replaces writing it
+        if diffOutput {
+                return contractIR, diffDir(cmd, files, destination, logger)
+        }
+
+        // Files of the output directory edited since the previous generation, or added by users, are never clobbered
+        // silently: generation fails listing them, unless --force overwrites them or --merge merges into them
+        if outputFormat == output.FormatDir {
+                if err := resolveConflicts(files, destination, logger); err != nil {
+                        return nil, err
+                }
+        }
+
+        // Write the project as a directory, an archive or a stream
+        if err := output.Write(files, outputFormat, outputDir, cmd.OutOrStdout(), modTime); err != nil {
+                return nil, ioError(err)
+        }
+        phase = logger.Phase("write", phase)
+
+        // The server is built in a copy of the project, so that broken generated code fails generation
+        if verify {
+                if err := verifyProject(cmd.Context(), generator, generatorOptions(cmd, generator), files, logger); err != nil {
+                        return nil, err
                 }
+                logger.Phase("verify", phase)
+        }
+
+        if outputFormat != output.FormatStdout {
+                logger.Infof("MCP server generated successfully in %s", destination)
         }
+        return contractIR, nil
+}
 
-        fmt.Printf("MCP server generated successfully in %s\n", outputDir)
+// generatorOptions returns the values of the flags a generator supports, keyed by flag name
+func generatorOptions(cmd *cobra.Command, generator template.Generator) map[string]string {
+        options := map[string]string{}
+        for _, name := range generator.Flags {
+                if flag := cmd.Flags().Lookup(name); flag != nil {
+                        options[name] = flag.Value.String()
+                }
+        }
+        return options
+}
+
+// resolveConflicts finds the files of the output directory that writing the generated files would clobber, and fails
+// listing them, or with --force warns that they are overwritten, or with --merge merges the generated files into them
+func resolveConflicts(files map[string][]byte, dir string, logger *logging.Logger) error {
+        previous, err := output.ReadLock(dir)
+        if err != nil {
+                // Without a readable lock file, no existing file is known to be generated
+                logger.Warnf("%v", err)
+        }
+        conflicts, err := output.Conflicts(files, dir, previous)
+        if err != nil {
+                return ioError(err)
+        }
+        if len(conflicts) == 0 {
+                return nil
+        }
+
+        switch {
+        case overwrite:
+                for _, conflict := range conflicts {
+                        logger.Warnf("overwriting %s (%s)", conflict.Path, conflictReason(conflict))
+                }
+        case mergeOutput:
+                merged, kept, err := output.Merge(files, dir, conflicts)
+                if err != nil {
+                        return ioError(err)
+                }
+                for _, path := range merged {
+                        logger.Warnf("%s has conflict markers where it differs from the generated file; resolve them before building", path)
+                }
+                for _, path := range kept {
+                        logger.Warnf("%s is binary and cannot be merged, so it is kept; --force overwrites it", path)
+                }
+        default:
+                lines := make([]string, 0, len(conflicts))
+                for _, conflict := range conflicts {
+                        lines = append(lines, fmt.Sprintf("%s (%s)", conflict.Path, conflictReason(conflict)))
+                }
+                return conflictError(fmt.Errorf("generating would overwrite %d files in %s:\n  %s\npass --force to overwrite them, or --merge to merge the generated files into them; code inside protected regions (mcpgen:begin/mcpgen:end) is always kept",
+                        len(conflicts), dir, strings.Join(lines, "\n  ")), conflicts)
+        }
         return nil
+}
+
+// conflictReason explains why a file of the output directory conflicts with the generated one
+func conflictReason(conflict output.Conflict) string {
+        if conflict.Reason == output.ConflictModified {
+                return "modified since it was generated"
+        }
+        return "not generated by generate-mcp"
+}
+
+// applyConfig sets the flags not given on the command line from the project config: the --config file, or else
+// mcpgen.yaml in the working directory if there is one, with the --profile profile (or the config's profile option)
+// selected. It returns the config's IR filter, if any.
+func applyConfig(cmd *cobra.Command) (*ir.Projection, error) {
+        if configPath == "" {
+                if configPath = config.Find("."); configPath == "" {
+                        if profile != "" {
+                                return nil, usageError(fmt.Errorf("--profile %s selects a profile of the project config, but there is none (pass --config, or add mcpgen.yaml)", profile))
+                        }
+                        return nil, nil
+                }
+        }
+        cfg, err := config.Load(configPath)
+        if err != nil {
+                return nil, err
+        }
+        if profile == "" {
+                profile = cfg.DefaultProfile()
+        }
+        if profile != "" {
+                if cfg, err = cfg.Profile(profile); err != nil {
+                        return nil, usageError(err)
+                }
+        }
+        paths := []string{"artifact", "output", "templates", "annotations", "overlay", "llm-cache-dir"}
+        if err := cfg.Apply(cmd.Flags(), paths, []string{"template-pack"}); err != nil {
+                return nil, err
+        }
+        return cfg.Filter, nil
+}
+
+// parseArtifact parses a contract artifact with the parser registered for its chain
+func parseArtifact(r io.Reader, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+        registration, ok := parser.Lookup(metadata.Chain)
+        if !ok {
+                return nil, fmt.Errorf("unsupported chain type: %s (supported: %s; see generate-mcp list-chains)", metadata.Chain, chainList())
+        }
+        contractIR, err := registration.New().Parse(r, metadata)
+        if err != nil {
+                return nil, fmt.Errorf("failed to parse %s: %w", registration.Artifact, err)
+        }
+        return contractIR, nil
 }
\ No newline at end of file
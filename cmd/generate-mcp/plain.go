@@ -0,0 +1,63 @@
+package main
+
+import (
+        "io"
+        "os"
+        "strings"
+
+        "github.com/spf13/cobra"
+)
+
+// ANSI colors of diff lines
+const (
+        colorRed   = "\x1b[31m"
+        colorGreen = "\x1b[32m"
+        colorCyan  = "\x1b[36m"
+        colorReset = "\x1b[0m"
+)
+
+// resolvePlain sets plain output from --plain if given, or else turns it on when the command's stdout is not a
+// terminal, as in CI systems and pipes
+func resolvePlain(cmd *cobra.Command) {
+        if !cmd.Flags().Changed("plain") {
+                plainOutput = !isTerminal(cmd.OutOrStdout())
+        }
+}
+
+// isTerminal reports whether out is a terminal
+func isTerminal(out io.Writer) bool {
+        file, ok := out.(*os.File)
+        if !ok {
+                return false
+        }
+        info, err := file.Stat()
+        return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorEnabled reports whether output to out is colored: on terminals, outside plain output and unless NO_COLOR is set
+// (see no-color.org)
+func colorEnabled(out io.Writer) bool {
+        return !plainOutput && os.Getenv("NO_COLOR") == "" && isTerminal(out)
+}
+
+// colorDiff colors the removed lines of a unified diff red, its added lines green and its hunk headers cyan
+func colorDiff(diff string) string {
+        lines := strings.SplitAfter(diff, "\n")
+        for i, line := range lines {
+                color := ""
+                switch {
+                case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+                case strings.HasPrefix(line, "@@"):
+                        color = colorCyan
+                case strings.HasPrefix(line, "+"):
+                        color = colorGreen
+                case strings.HasPrefix(line, "-"):
+                        color = colorRed
+                }
+                if color != "" {
+                        text := strings.TrimSuffix(line, "\n")
+                        lines[i] = color + text + colorReset + line[len(text):]
+                }
+        }
+        return strings.Join(lines, "")
+}
\ No newline at end of file
@@ -0,0 +1,203 @@
+package main
+
+import (
+        "encoding/json"
+        "os"
+        "sort"
+        "sync"
+        "time"
+
+        "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/output"
+)
+
+// generationReport is the machine-readable summary of a generation written with --report, for platforms embedding
+// the generator and for auditing what the generated servers let agents do
+// A nil report collects nothing, so that generation reports without checking whether --report is given.
+type generationReport struct {
+        mu sync.Mutex
+
+        // Generator that generated the servers
+        Generator output.LockGenerator `json:"generator"`
+
+        // When generation started, and how long it took
+        StartedAt  time.Time `json:"startedAt"`
+        DurationMs float64   `json:"durationMs"`
+
+        // Whether every contract's server was generated
+        Succeeded bool `json:"succeeded"`
+
+        // Servers generated, one per contract, in artifact order
+        Contracts []*contractReport `json:"contracts"`
+}
+
+// contractReport summarizes the generation of one contract's server
+type contractReport struct {
+        // Artifact the server was generated from, and where it was written
+        Artifact string `json:"artifact"`
+        Output   string `json:"output"`
+
+        // Contract as generated; empty if it failed to load
+        Contract string `json:"contract,omitempty"`
+        Chain    string `json:"chain,omitempty"`
+        Address  string `json:"address,omitempty"`
+
+        // Whether the server was generated, or else the cause of the failure (as in --error-format json) and its message
+        Succeeded bool   `json:"succeeded"`
+        Cause     string `json:"cause,omitempty"`
+        Error     string `json:"error,omitempty"`
+
+        // Whether write tools were generated
+        WritesEnabled bool `json:"writesEnabled"`
+
+        // Tools of the server, as the TypeScript generator names them, and the functions and events without one, and why
+        Tools   []ir.Tool          `json:"tools"`
+        Skipped []ir.SkippedMember `json:"skipped"`
+
+        // Warnings logged while generating the server
+        Warnings []string `json:"warnings"`
+
+        // Generated files, sorted by path
+        Files []reportFile `json:"files"`
+
+        // How long each phase took, and the whole generation
+        Timings    []logging.Timing `json:"timings"`
+        DurationMs float64          `json:"durationMs"`
+
+        started  time.Time
+        record   logging.Record
+        filtered []ir.SkippedMember
+}
+
+// reportFile is a generated file of a report
+type reportFile struct {
+        Path string `json:"path"`
+        Size int    `json:"size"`
+        Hash string `json:"hash"`
+}
+
+// newGenerationReport returns the report of a generation, or nil without --report
+func newGenerationReport() *generationReport {
+        if reportPath == "" {
+                return nil
+        }
+        return &generationReport{
+                Generator: output.LockGenerator{Name: "generate-mcp", Version: serverVersion()},
+                StartedAt: time.Now().UTC(),
+                Contracts: []*contractReport{},
+        }
+}
+
+// contract adds the report of a contract's server generated into an output directory or archive, in artifact order
+// whatever order contracts are generated in
+func (r *generationReport) contract(index int, artifact, outputDir string) *contractReport {
+        if r == nil {
+                return nil
+        }
+        destination, err := output.Path(outputFormat, outputDir)
+        if err != nil {
+                destination = outputDir
+        }
+        entry := &contractReport{Artifact: artifact, Output: destination, started: time.Now()}
+        r.mu.Lock()
+        defer r.mu.Unlock()
+        for len(r.Contracts) <= index {
+                r.Contracts = append(r.Contracts, nil)
+        }
+        r.Contracts[index] = entry
+        return entry
+}
+
+// write writes the report of a generation that failed with err, or succeeded if nil, to --report
+func (r *generationReport) write(err error) error {
+        if r == nil {
+                return nil
+        }
+        r.DurationMs = milliseconds(time.Since(r.StartedAt))
+        r.Succeeded = err == nil
+        for _, entry := range r.Contracts {
+                r.Succeeded = r.Succeeded && entry.Succeeded
+        }
+        content, err := json.MarshalIndent(r, "", "  ")
+        if err != nil {
+                return err
+        }
+        if err := os.WriteFile(reportPath, append(content, '\n'), 0644); err != nil {
+                return ioError(err)
+        }
+        return nil
+}
+
+// logger returns a logger writing to logger that records the warnings and timings of the contract's generation
+func (c *contractReport) logger(logger *logging.Logger) *logging.Logger {
+        if c == nil {
+                return logger
+        }
+        return logger.Recording(&c.record)
+}
+
+// filter records the functions and events a filter removed from a contract
+func (c *contractReport) filter(before, after *ir.ContractIR) {
+        if c == nil {
+                return
+        }
+        kept := map[string]bool{}
+        for _, f := range after.Functions {
+                kept["function "+f.Name] = true
+        }
+        for _, e := range after.Events {
+                kept["event "+e.Name] = true
+        }
+        for _, f := range before.Functions {
+                if !kept["function "+f.Name] {
+                        c.filtered = append(c.filtered, ir.SkippedMember{Function: f.Name, Signature: f.Signature, Reason: "excluded by the project config's filter"})
+                }
+        }
+        for _, e := range before.Events {
+                if !kept["event "+e.Name] {
+                        c.filtered = append(c.filtered, ir.SkippedMember{Event: e.Name, Signature: e.Signature, Reason: "excluded by the project config's filter"})
+                }
+        }
+}
+
+// files records the generated files
+func (c *contractReport) files(files map[string][]byte) {
+        if c == nil {
+                return
+        }
+        c.Files = make([]reportFile, 0, len(files))
+        for path, content := range files {
+                c.Files = append(c.Files, reportFile{Path: path, Size: len(content), Hash: output.Hash(content)})
+        }
+        sort.Slice(c.Files, func(i, j int) bool { return c.Files[i].Path < c.Files[j].Path })
+}
+
+// finish records the outcome of the contract's generation: its IR as generated, or the error it failed with
+func (c *contractReport) finish(contractIR *ir.ContractIR, err error) {
+        if c == nil {
+                return
+        }
+        c.DurationMs = milliseconds(time.Since(c.started))
+        c.Warnings = c.record.Warnings()
+        c.Timings = c.record.Timings()
+        c.WritesEnabled = enableWrites
+        c.Tools, c.Skipped = []ir.Tool{}, append([]ir.SkippedMember{}, c.filtered...)
+        if c.Files == nil {
+                c.Files = []reportFile{}
+        }
+        if err != nil {
+                failure := classify(err)
+                c.Cause, c.Error = failure.cause, err.Error()
+                return
+        }
+        c.Succeeded = true
+        c.Contract, c.Chain, c.Address = contractIR.Metadata.Name, contractIR.Metadata.Chain, contractIR.Metadata.Address
+        tools, skipped := ir.Tools(contractIR, enableWrites)
+        c.Tools, c.Skipped = tools, append(c.Skipped, skipped...)
+}
+
+// milliseconds returns a duration in milliseconds, to the microsecond
+func milliseconds(d time.Duration) float64 {
+        return float64(d.Microseconds()) / 1000
+}
\ No newline at end of file
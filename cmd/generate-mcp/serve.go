@@ -0,0 +1,312 @@
+package main
+
+import (
+        "bytes"
+        "context"
+        "encoding/json"
+        "errors"
+        "fmt"
+        "os"
+        "path/filepath"
+        "runtime/debug"
+        "strings"
+        "sync"
+
+        "github.com/openhands/mcp-generator/internal/mcpserver"
+        "github.com/spf13/cobra"
+)
+
+// newServeMCPCommand returns the command serving the generator's own commands as MCP tools over stdio
+func newServeMCPCommand() *cobra.Command {
+        return &cobra.Command{
+                Use:   "serve-mcp",
+                Short: "Serve the generator itself as an MCP server over stdio",
+                Long: `Serve the generator's own capabilities as MCP tools over stdio, so that coding agents can parse, inspect and
+validate artifacts and generate servers as part of their workflow:
+
+  parse_artifact    the IR of an artifact or IR file, as generate-mcp parse
+  inspect_artifact  the functions, events, errors and types of an artifact, as generate-mcp inspect --json
+  validate_ir       the validation report of an IR file or artifact, as generate-mcp validate --format json
+  generate_server   an MCP server generated from an artifact or IR file into an output directory or archive
+  list_chains       the chains artifacts can be parsed for, as generate-mcp list-chains --json
+  list_generators   the languages servers can be generated in, as generate-mcp list-generators --json
+
+Tools take the artifact as a path, relative to the working directory of the server, or inline as content. Each call
+runs on its own command, so the flags of one call do not carry over to the next; the project config (mcpgen.yaml)
+of the working directory applies to generate_server as on the command line. Failures are tool results marked
+isError, with the cause, exit code, message and details of --error-format json.
+
+Register it with an MCP client, e.g.: {"command": "generate-mcp", "args": ["serve-mcp"]}`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        commandLogger(cmd).Infof("Serving generate-mcp tools over stdio")
+                        server := mcpserver.New("generate-mcp", serverVersion(), serveTools())
+                        if err := server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout()); err != nil {
+                                return ioError(fmt.Errorf("MCP transport failed: %w", err))
+                        }
+                        return nil
+                },
+        }
+}
+
+// serverVersion returns the module version generate-mcp was built from, or "dev" for local builds
+func serverVersion() string {
+        if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+                return info.Main.Version
+        }
+        return "dev"
+}
+
+// artifactArguments are the arguments of tools taking an artifact, given as a path or inline
+type artifactArguments struct {
+        Artifact string `json:"artifact"`
+        Content  string `json:"content"`
+        Name     string `json:"name"`
+        Chain    string `json:"chain"`
+}
+
+// artifactProperties returns the JSON Schema properties of artifactArguments, merged with a tool's own
+func artifactProperties(properties map[string]interface{}) map[string]interface{} {
+        schema := map[string]interface{}{
+                "artifact": stringProperty("Path to the contract artifact (ABI/IDL), API description or IR file (JSON or YAML); give either artifact or content"),
+                "content":  stringProperty("Content of the artifact or IR, JSON or YAML, instead of a path"),
+                "name":     stringProperty("Contract name (default: the name in IR files, or the file name of artifacts; Contract for content)"),
+                "chain":    map[string]interface{}{"type": "string", "description": "Blockchain type of artifacts (" + chainList() + ")", "default": "ethereum"},
+        }
+        for name, property := range properties {
+                schema[name] = property
+        }
+        return map[string]interface{}{"type": "object", "properties": schema}
+}
+
+func stringProperty(description string) map[string]interface{} {
+        return map[string]interface{}{"type": "string", "description": description}
+}
+
+func booleanProperty(description string) map[string]interface{} {
+        return map[string]interface{}{"type": "boolean", "description": description}
+}
+
+// serveTools returns the tools of serve-mcp
+func serveTools() []mcpserver.Tool {
+        noArguments := map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+        return []mcpserver.Tool{
+                {
+                        Name:        "parse_artifact",
+                        Description: "Parse a contract artifact (ABI/IDL), API description or IR file and return its IR, with annotations applied",
+                        InputSchema: artifactProperties(map[string]interface{}{
+                                "format": map[string]interface{}{"type": "string", "enum": []string{"json", "yaml"}, "description": "IR format (default: json)"},
+                        }),
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                var args struct {
+                                        artifactArguments
+                                        Format string `json:"format"`
+                                }
+                                return runArtifactTool(ctx, arguments, &args, &args.artifactArguments, func(path string) []string {
+                                        return appendFlag(artifactFlags([]string{"parse", path}, args.artifactArguments), "format", args.Format)
+                                })
+                        },
+                },
+                {
+                        Name:        "inspect_artifact",
+                        Description: "Summarize the functions (grouped by state mutability, with signatures and selectors), events, errors and types of a contract artifact or IR file",
+                        InputSchema: artifactProperties(nil),
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                var args artifactArguments
+                                return runArtifactTool(ctx, arguments, &args, &args, func(path string) []string {
+                                        return appendFlag([]string{"inspect", path, "--json"}, "chain", args.Chain)
+                                })
+                        },
+                },
+                {
+                        Name:        "validate_ir",
+                        Description: "Validate an IR file or artifact, returning its errors and warnings (e.g. functions without descriptions) with their codes",
+                        InputSchema: artifactProperties(map[string]interface{}{
+                                "strict": booleanProperty("Fail on warnings as well as errors"),
+                        }),
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                var args struct {
+                                        artifactArguments
+                                        Strict bool `json:"strict"`
+                                }
+                                return runArtifactTool(ctx, arguments, &args, &args.artifactArguments, func(path string) []string {
+                                        command := appendFlag([]string{"validate", path, "--format", "json"}, "chain", args.Chain)
+                                        if args.Strict {
+                                                command = append(command, "--strict")
+                                        }
+                                        return command
+                                })
+                        },
+                },
+                {
+                        Name:        "generate_server",
+                        Description: "Generate an MCP server from a contract artifact or IR file into an output directory or archive, and return the generation log",
+                        InputSchema: artifactProperties(map[string]interface{}{
+                                "output":       stringProperty("Output directory of the generated server, relative to the working directory of the server (default: ./mcp-server)"),
+                                "outputFormat": map[string]interface{}{"type": "string", "enum": []string{"dir", "zip", "tar"}, "description": "dir, zip (<output>.zip) or tar (<output>.tar.gz) (default: dir)"},
+                                "lang":         stringProperty("Output language (" + languageList() + "; default: ts)"),
+                                "address":      stringProperty("Contract address"),
+                                "transport":    map[string]interface{}{"type": "string", "enum": []string{"stdio", "http"}, "description": "MCP transport of the generated server (default: stdio)"},
+                                "runtime":      map[string]interface{}{"type": "string", "enum": []string{"node", "deno", "bun"}, "description": "JavaScript runtime for TypeScript output (default: node)"},
+                                "enableWrites": booleanProperty("Expose payable and nonpayable functions as tools that build and optionally send transactions"),
+                                "strict":       booleanProperty("Fail on IR validation warnings as well as errors"),
+                        }),
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                var args struct {
+                                        artifactArguments
+                                        Output       string `json:"output"`
+                                        OutputFormat string `json:"outputFormat"`
+                                        Lang         string `json:"lang"`
+                                        Address      string `json:"address"`
+                                        Transport    string `json:"transport"`
+                                        Runtime      string `json:"runtime"`
+                                        EnableWrites bool   `json:"enableWrites"`
+                                        Strict       bool   `json:"strict"`
+                                }
+                                return runArtifactTool(ctx, arguments, &args, &args.artifactArguments, func(path string) []string {
+                                        command := artifactFlags([]string{"--artifact", path}, args.artifactArguments)
+                                        for _, flag := range [][2]string{
+                                                {"output", args.Output}, {"output-format", args.OutputFormat}, {"lang", args.Lang},
+                                                {"address", args.Address}, {"transport", args.Transport}, {"runtime", args.Runtime},
+                                        } {
+                                                command = appendFlag(command, flag[0], flag[1])
+                                        }
+                                        if args.EnableWrites {
+                                                command = append(command, "--enable-writes")
+                                        }
+                                        if args.Strict {
+                                                command = append(command, "--strict")
+                                        }
+                                        return command
+                                })
+                        },
+                },
+                {
+                        Name:        "list_chains",
+                        Description: "List the chains artifacts can be parsed for, with the artifacts they parse and their chain-specific flags",
+                        InputSchema: noArguments,
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                return runTool(ctx, "list-chains", "--json")
+                        },
+                },
+                {
+                        Name:        "list_generators",
+                        Description: "List the languages servers can be generated in, with their flags and template packs",
+                        InputSchema: noArguments,
+                        Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+                                return runTool(ctx, "list-generators", "--json")
+                        },
+                },
+        }
+}
+
+// runArtifactTool decodes the arguments of a tool taking an artifact into args, whose artifactArguments are
+// artifact, writes inline content to a temporary file, and runs the command built for the artifact's path
+func runArtifactTool(ctx context.Context, arguments json.RawMessage, args interface{}, artifact *artifactArguments, command func(path string) []string) (string, error) {
+        if err := json.Unmarshal(arguments, args); err != nil {
+                return "", fmt.Errorf("invalid arguments: %w", err)
+        }
+        switch {
+        case artifact.Artifact != "" && artifact.Content != "":
+                return "", errors.New("give the artifact either as artifact or as content, not both")
+        case artifact.Artifact == stdinPath:
+                return "", errors.New("stdin carries the MCP transport; give the artifact as a path or as content")
+        case artifact.Artifact != "":
+                return runTool(ctx, command(artifact.Artifact)...)
+        case artifact.Content == "":
+                return "", errors.New("artifact or content is required")
+        }
+
+        dir, err := os.MkdirTemp("", "generate-mcp-")
+        if err != nil {
+                return "", fmt.Errorf("failed to store the artifact content: %w", err)
+        }
+        defer os.RemoveAll(dir)
+        path, err := writeArtifactContent(dir, artifact.Name, []byte(artifact.Content))
+        if err != nil {
+                return "", err
+        }
+        return runTool(ctx, command(path)...)
+}
+
+// artifactFlags appends the --name and --chain flags of artifact arguments to a command
+func artifactFlags(command []string, artifact artifactArguments) []string {
+        return appendFlag(appendFlag(command, "name", artifact.Name), "chain", artifact.Chain)
+}
+
+// appendFlag appends a flag with its value to a command, unless the value is empty
+func appendFlag(command []string, name, value string) []string {
+        if value == "" {
+                return command
+        }
+        return append(command, "--"+name, value)
+}
+
+// writeArtifactContent writes the content of an artifact or IR given inline to a directory, named after the contract
+// (Contract without a name) with the extension of its format, JSON or YAML, and returns its path
+func writeArtifactContent(dir, name string, content []byte) (string, error) {
+        if name == "" {
+                name = defaultStdinName
+        }
+        ext := ".yaml"
+        if trimmed := bytes.TrimSpace(content); bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+                ext = ".json"
+        }
+        path := filepath.Join(dir, filepath.Base(name)+ext)
+        if err := os.WriteFile(path, content, 0o644); err != nil {
+                return "", fmt.Errorf("failed to store the artifact content: %w", err)
+        }
+        return path, nil
+}
+
+// commandMu serializes the commands run in-process by serve-mcp and serve-http, as their flags are package variables
+var commandMu sync.Mutex
+
+// remoteCommand is set while a command runs for a caller of serve-mcp or serve-http, turning contract lookups off
+var remoteCommand bool
+
+// runCommand runs a generate-mcp command on a fresh root command and returns what it wrote to stdout and stderr
+func runCommand(ctx context.Context, args ...string) (string, string, error) {
+        return executeCommand(ctx, false, args...)
+}
+
+// runRemoteCommand runs a generate-mcp command for a caller of serve-mcp or serve-http, which may not make the server
+// look contracts up over RPC, Sourcify or explorers
+func runRemoteCommand(ctx context.Context, args ...string) (string, string, error) {
+        return executeCommand(ctx, true, args...)
+}
+
+// executeCommand runs a command, one at a time since flags are package variables
+func executeCommand(ctx context.Context, remote bool, args ...string) (string, string, error) {
+        commandMu.Lock()
+        defer commandMu.Unlock()
+        remoteCommand = remote
+        defer func() { remoteCommand = false }()
+
+        rootCmd := newRootCommand()
+        var stdout, stderr bytes.Buffer
+        rootCmd.SetArgs(args)
+        rootCmd.SetIn(bytes.NewReader(nil))
+        rootCmd.SetOut(&stdout)
+        rootCmd.SetErr(&stderr)
+        rootCmd.SilenceErrors = true
+        rootCmd.SilenceUsage = true
+        err := rootCmd.ExecuteContext(ctx)
+        return stdout.String(), stderr.String(), err
+}
+
+// runTool runs a generate-mcp command and returns its output, followed by its logs
+// Failures are returned as the JSON of --error-format json.
+func runTool(ctx context.Context, args ...string) (string, error) {
+        stdout, stderr, err := runRemoteCommand(ctx, args...)
+        text := stdout
+        if stderr != "" {
+                text = strings.TrimRight(text+"\n"+stderr, "\n")
+        }
+        if err != nil {
+                var failure bytes.Buffer
+                writeError(&failure, err, errorFormatJSON)
+                return strings.TrimSpace(text), errors.New(strings.TrimSpace(failure.String()))
+        }
+        return strings.TrimSpace(text), nil
+}
\ No newline at end of file
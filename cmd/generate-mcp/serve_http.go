@@ -0,0 +1,255 @@
+package main
+
+import (
+        "bytes"
+        "context"
+        "errors"
+        "fmt"
+        "io"
+        "net/http"
+        "os"
+        "os/signal"
+        "path/filepath"
+        "sort"
+        "strings"
+        "syscall"
+        "time"
+
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/spf13/cobra"
+)
+
+// parseQueryFlags are the flags of generate-mcp parse that POST /parse takes as query parameters
+var parseQueryFlags = []string{"chain", "name", "format", "canonical"}
+
+// generateQueryFlags are the generation flags POST /generate takes as query parameters; flags naming files or
+// directories of the server (templates, overlays, annotations, output) are not exposed, nor those picking the RPC
+// and explorer endpoints the server would send requests to (network, rpc-url)
+var generateQueryFlags = []string{
+        "chain", "name", "address", "deployment", "lang", "mode", "runtime", "transport", "enable-writes",
+        "enable-subscriptions", "enable-cache", "enable-access-lists", "signers", "tests", "docker", "ci", "strict", "openapi", "openrpc", "locale",
+        "detect-amounts", "detect-access", "detect-examples", "overload-naming", "dedupe-tuples",
+        "package-scope", "package-version", "license", "author", "repository", "set", "on-collision",
+}
+
+// newServeHTTPCommand returns the command serving parsing and generation as an HTTP API
+func newServeHTTPCommand() *cobra.Command {
+        var listen string
+        var maxBodySize int64
+        serveCmd := &cobra.Command{
+                Use:   "serve-http",
+                Short: "Serve parsing and generation as an HTTP API",
+                Long: `Serve the generator as a long-running HTTP API, e.g. to back a web playground or a platform service without
+running generate-mcp per request. Requests carry the artifact or IR (JSON or YAML) as body, and options as query
+parameters named after the flags of the command line:
+
+  POST /parse        the IR of the artifact, as generate-mcp parse (chain, name, format, canonical)
+  POST /generate     the generated server as a zip archive (chain, name, address, lang, transport, enable-writes, ...)
+  GET  /chains       the chains artifacts can be parsed for, as generate-mcp list-chains --json
+  GET  /generators   the languages servers can be generated in, as generate-mcp list-generators --json
+  GET  /healthz      ok while the server runs
+
+  curl --data-binary @IERC20.json 'localhost:8080/generate?name=Token&enable-writes=true' -o token-mcp-server.zip
+
+Failures answer the JSON of --error-format json, with status 400 for invalid options, 422 for artifacts that cannot
+be parsed or fail validation, and 500 otherwise. Requests are handled one at a time; the project config
+(mcpgen.yaml) of the working directory applies to generation as on the command line.`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        logger := commandLogger(cmd)
+                        server := &http.Server{
+                                Addr:              listen,
+                                Handler:           newHTTPHandler(logger, maxBodySize),
+                                ReadHeaderTimeout: 10 * time.Second,
+                        }
+
+                        ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+                        defer stop()
+                        failed := make(chan error, 1)
+                        go func() { failed <- server.ListenAndServe() }()
+                        logger.Infof("Serving the generate-mcp HTTP API on http://%s", listen)
+
+                        select {
+                        case err := <-failed:
+                                return ioError(fmt.Errorf("HTTP server failed: %w", err))
+                        case <-ctx.Done():
+                        }
+                        logger.Infof("Shutting down")
+                        shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+                        defer cancel()
+                        return server.Shutdown(shutdown)
+                },
+        }
+        serveCmd.Flags().StringVar(&listen, "listen", "127.0.0.1:8080", "Address to listen on, e.g. :8080 for all interfaces")
+        serveCmd.Flags().Int64Var(&maxBodySize, "max-body-size", 10<<20, "Largest accepted request body, in bytes")
+        return serveCmd
+}
+
+// newHTTPHandler returns the handler of the HTTP API, logging each request
+func newHTTPHandler(logger *logging.Logger, maxBodySize int64) http.Handler {
+        mux := http.NewServeMux()
+        mux.HandleFunc("/parse", func(w http.ResponseWriter, r *http.Request) {
+                withArtifact(w, r, maxBodySize, parseQueryFlags, func(dir, path string, flags []string) {
+                        stdout, _, err := runRemoteCommand(r.Context(), append([]string{"parse", path}, flags...)...)
+                        if err != nil {
+                                writeHTTPError(w, err)
+                                return
+                        }
+                        contentType := "application/json"
+                        if r.URL.Query().Get("format") == "yaml" {
+                                contentType = "application/yaml"
+                        }
+                        w.Header().Set("Content-Type", contentType)
+                        io.WriteString(w, stdout)
+                })
+        })
+        mux.HandleFunc("/generate", func(w http.ResponseWriter, r *http.Request) {
+                withArtifact(w, r, maxBodySize, generateQueryFlags, func(dir, path string, flags []string) {
+                        output := filepath.Join(dir, "mcp-server")
+                        args := append([]string{"--artifact", path, "--output", output, "--output-format", "zip", "--quiet"}, flags...)
+                        if _, _, err := runRemoteCommand(r.Context(), args...); err != nil {
+                                writeHTTPError(w, err)
+                                return
+                        }
+                        archive, err := os.ReadFile(output + ".zip")
+                        if err != nil {
+                                writeHTTPError(w, ioError(err))
+                                return
+                        }
+                        name := "mcp-server.zip"
+                        if contract := r.URL.Query().Get("name"); contract != "" {
+                                name = filepath.Base(contract) + "-mcp-server.zip"
+                        }
+                        w.Header().Set("Content-Type", "application/zip")
+                        w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+                        w.Write(archive)
+                })
+        })
+        for route, command := range map[string]string{"/chains": "list-chains", "/generators": "list-generators"} {
+                command := command
+                mux.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+                        if !allowMethod(w, r, http.MethodGet) {
+                                return
+                        }
+                        stdout, _, err := runRemoteCommand(r.Context(), command, "--json")
+                        if err != nil {
+                                writeHTTPError(w, err)
+                                return
+                        }
+                        w.Header().Set("Content-Type", "application/json")
+                        io.WriteString(w, stdout)
+                })
+        }
+        mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+                io.WriteString(w, "ok\n")
+        })
+
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                start := time.Now()
+                recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+                mux.ServeHTTP(recorder, r)
+                logger.Infof("%s %s %d (%s)", r.Method, r.URL.Path, recorder.status, time.Since(start).Round(time.Millisecond))
+        })
+}
+
+// withArtifact reads the artifact of a POST request into a temporary directory and calls handle with the directory,
+// the artifact's path and the command-line flags of the request's query parameters, which must be among allowed
+func withArtifact(w http.ResponseWriter, r *http.Request, maxBodySize int64, allowed []string, handle func(dir, path string, flags []string)) {
+        if !allowMethod(w, r, http.MethodPost) {
+                return
+        }
+        flags, err := queryFlags(r, allowed)
+        if err != nil {
+                writeHTTPError(w, usageError(err))
+                return
+        }
+        body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+        if err != nil {
+                writeHTTPError(w, usageError(fmt.Errorf("failed to read the artifact: %w", err)))
+                return
+        }
+        if len(bytes.TrimSpace(body)) == 0 {
+                writeHTTPError(w, usageError(errors.New("the request body must be the artifact or IR, JSON or YAML")))
+                return
+        }
+
+        dir, err := os.MkdirTemp("", "generate-mcp-")
+        if err != nil {
+                writeHTTPError(w, ioError(err))
+                return
+        }
+        defer os.RemoveAll(dir)
+        path, err := writeArtifactContent(dir, r.URL.Query().Get("name"), body)
+        if err != nil {
+                writeHTTPError(w, ioError(err))
+                return
+        }
+        handle(dir, path, flags)
+}
+
+// queryFlags returns the query parameters of a request as flags, e.g. --enable-writes=true for enable-writes=true,
+// rejecting parameters that are not among allowed
+func queryFlags(r *http.Request, allowed []string) ([]string, error) {
+        query := r.URL.Query()
+        names := make([]string, 0, len(query))
+        for name := range query {
+                names = append(names, name)
+        }
+        sort.Strings(names)
+
+        var flags []string
+        for _, name := range names {
+                known := false
+                for _, flag := range allowed {
+                        known = known || flag == name
+                }
+                if !known {
+                        return nil, fmt.Errorf("unsupported query parameter: %s (expected one of %s)", name, strings.Join(allowed, ", "))
+                }
+                for _, value := range query[name] {
+                        flags = append(flags, "--"+name+"="+value)
+                }
+        }
+        return flags, nil
+}
+
+// allowMethod answers 405 to requests of other methods than the one of their route
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+        if r.Method == method {
+                return true
+        }
+        w.Header().Set("Allow", method)
+        writeHTTPStatus(w, http.StatusMethodNotAllowed, usageError(fmt.Errorf("%s %s is not supported (use %s)", r.Method, r.URL.Path, method)))
+        return false
+}
+
+// writeHTTPError answers a failure with the status of its cause: 400 for usage, 422 for parse and validation
+// failures, 500 otherwise
+func writeHTTPError(w http.ResponseWriter, err error) {
+        status := http.StatusInternalServerError
+        switch classify(err).cause {
+        case "usage":
+                status = http.StatusBadRequest
+        case "parse", "validation":
+                status = http.StatusUnprocessableEntity
+        }
+        writeHTTPStatus(w, status, err)
+}
+
+// writeHTTPStatus answers a failure as the JSON of --error-format json
+func writeHTTPStatus(w http.ResponseWriter, status int, err error) {
+        w.Header().Set("Content-Type", "application/json")
+        w.WriteHeader(status)
+        writeError(w, err, errorFormatJSON)
+}
+
+// statusRecorder records the status of a response for the request log
+type statusRecorder struct {
+        http.ResponseWriter
+        status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+        r.status = status
+        r.ResponseWriter.WriteHeader(status)
+}
\ No newline at end of file
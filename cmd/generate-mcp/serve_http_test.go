@@ -0,0 +1,163 @@
+package main
+
+import (
+        "encoding/json"
+        "errors"
+        "io"
+        "net/http"
+        "net/http/httptest"
+        "path/filepath"
+        "strings"
+        "testing"
+
+        "github.com/openhands/mcp-generator/internal/logging"
+)
+
+// testABI is the ABI of a contract with a view and a write function
+const testABI = `[
+        {"type": "function", "name": "balanceOf", "stateMutability": "view", "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+        {"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]}
+]`
+
+// serveHTTP answers a request with the handler of the HTTP API
+func serveHTTP(t *testing.T, maxBodySize int64, method, target, body string) *httptest.ResponseRecorder {
+        t.Helper()
+        logger, err := logging.New(io.Discard, logging.LevelInfo, logging.FormatText)
+        if err != nil {
+                t.Fatal(err)
+        }
+        recorder := httptest.NewRecorder()
+        newHTTPHandler(logger, maxBodySize).ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+        return recorder
+}
+
+// httpFailure decodes the JSON failure of a response
+func httpFailure(t *testing.T, recorder *httptest.ResponseRecorder) (cause, message string) {
+        t.Helper()
+        var failure struct {
+                Error struct {
+                        Cause   string `json:"cause"`
+                        Message string `json:"message"`
+                } `json:"error"`
+        }
+        if err := json.Unmarshal(recorder.Body.Bytes(), &failure); err != nil {
+                t.Fatalf("failure is not JSON: %v\n%s", err, recorder.Body.String())
+        }
+        return failure.Error.Cause, failure.Error.Message
+}
+
+func TestHTTPHandlerRejectsRequests(t *testing.T) {
+        tests := []struct {
+                name    string
+                method  string
+                target  string
+                body    string
+                status  int
+                message string
+        }{
+                {"RPC URL", http.MethodPost, "/generate?rpc-url=http://169.254.169.254", testABI, http.StatusBadRequest, "unsupported query parameter: rpc-url"},
+                {"network", http.MethodPost, "/generate?network=mainnet", testABI, http.StatusBadRequest, "unsupported query parameter: network"},
+                {"output directory", http.MethodPost, "/generate?output=/tmp", testABI, http.StatusBadRequest, "unsupported query parameter: output"},
+                {"parse flag", http.MethodPost, "/parse?enable-writes=true", testABI, http.StatusBadRequest, "unsupported query parameter: enable-writes"},
+                {"empty body", http.MethodPost, "/parse", " \n", http.StatusBadRequest, "the request body must be the artifact"},
+                {"body too large", http.MethodPost, "/parse", testABI + strings.Repeat(" ", 1024), http.StatusBadRequest, "failed to read the artifact"},
+                {"method", http.MethodGet, "/generate", "", http.StatusMethodNotAllowed, "GET /generate is not supported (use POST)"},
+                {"invalid artifact", http.MethodPost, "/parse", `{"abi": "not an ABI"}`, http.StatusUnprocessableEntity, ""},
+        }
+        for _, test := range tests {
+                t.Run(test.name, func(t *testing.T) {
+                        recorder := serveHTTP(t, int64(len(testABI)+512), test.method, test.target, test.body)
+                        if recorder.Code != test.status {
+                                t.Fatalf("Expected status %d but got %d: %s", test.status, recorder.Code, recorder.Body.String())
+                        }
+                        if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+                                t.Errorf("Expected a JSON failure but got %s", contentType)
+                        }
+                        if _, message := httpFailure(t, recorder); !strings.Contains(message, test.message) {
+                                t.Errorf("Expected %q in the failure but got %q", test.message, message)
+                        }
+                })
+        }
+}
+
+func TestHTTPHandlerParse(t *testing.T) {
+        recorder := serveHTTP(t, 1<<20, http.MethodPost, "/parse?name=../../Token", testABI)
+        if recorder.Code != http.StatusOK {
+                t.Fatalf("Expected status 200 but got %d: %s", recorder.Code, recorder.Body.String())
+        }
+        var contract struct {
+                Functions []struct {
+                        Name string `json:"name"`
+                } `json:"functions"`
+        }
+        if err := json.Unmarshal(recorder.Body.Bytes(), &contract); err != nil {
+                t.Fatalf("IR is not JSON: %v", err)
+        }
+        if len(contract.Functions) != 2 {
+                t.Errorf("Expected 2 functions but got %+v", contract.Functions)
+        }
+}
+
+func TestHTTPHandlerGenerate(t *testing.T) {
+        recorder := serveHTTP(t, 1<<20, http.MethodPost, "/generate?name=../../Token&enable-writes=true", testABI)
+        if recorder.Code != http.StatusOK {
+                t.Fatalf("Expected status 200 but got %d: %s", recorder.Code, recorder.Body.String())
+        }
+        if contentType := recorder.Header().Get("Content-Type"); contentType != "application/zip" {
+                t.Errorf("Expected a zip archive but got %s", contentType)
+        }
+        // The archive is named after the contract, without the directories of its name
+        if disposition := recorder.Header().Get("Content-Disposition"); disposition != `attachment; filename="Token-mcp-server.zip"` {
+                t.Errorf("Unexpected Content-Disposition %q", disposition)
+        }
+        if !strings.HasPrefix(recorder.Body.String(), "PK") {
+                t.Errorf("Body is not a zip archive")
+        }
+}
+
+func TestWriteArtifactContent(t *testing.T) {
+        dir := t.TempDir()
+        tests := []struct {
+                name     string
+                content  string
+                expected string
+        }{
+                {"", testABI, "Contract.json"},
+                {"Token", "metadata:\n  name: Token\n", "Token.yaml"},
+                {"../../etc/Token", testABI, "Token.json"},
+                {"/tmp/Token", testABI, "Token.json"},
+        }
+        for _, test := range tests {
+                path, err := writeArtifactContent(dir, test.name, []byte(test.content))
+                if err != nil {
+                        t.Fatal(err)
+                }
+                if path != filepath.Join(dir, test.expected) {
+                        t.Errorf("Expected %q stored as %s but got %s", test.name, test.expected, path)
+                }
+        }
+}
+
+func TestWriteHTTPError(t *testing.T) {
+        tests := []struct {
+                err    error
+                status int
+                cause  string
+        }{
+                {usageError(errors.New("bad flag")), http.StatusBadRequest, "usage"},
+                {parseError(errors.New("bad artifact")), http.StatusUnprocessableEntity, "parse"},
+                {validationError(errors.New("invalid IR"), nil), http.StatusUnprocessableEntity, "validation"},
+                {ioError(errors.New("disk full")), http.StatusInternalServerError, "io"},
+                {errors.New("unexpected"), http.StatusInternalServerError, "error"},
+        }
+        for _, test := range tests {
+                recorder := httptest.NewRecorder()
+                writeHTTPError(recorder, test.err)
+                if recorder.Code != test.status {
+                        t.Errorf("Expected status %d for %v but got %d", test.status, test.err, recorder.Code)
+                }
+                if cause, _ := httpFailure(t, recorder); cause != test.cause {
+                        t.Errorf("Expected cause %s for %v but got %s", test.cause, test.err, cause)
+                }
+        }
+}
\ No newline at end of file
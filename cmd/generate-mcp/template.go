@@ -0,0 +1,58 @@
+package main
+
+import (
+        "fmt"
+
+        "github.com/openhands/mcp-generator/internal/template"
+        "github.com/spf13/cobra"
+)
+
+// newTemplateCommand returns the template command, which scaffolds customized templates
+func newTemplateCommand() *cobra.Command {
+        templateCmd := &cobra.Command{
+                Use:   "template",
+                Short: "Scaffold and document customized templates",
+        }
+
+        var force bool
+        initCmd := &cobra.Command{
+                Use:   "init [dir]",
+                Short: "Copy the built-in templates into a directory for customization",
+                Long: `Copy the built-in TypeScript templates into a directory (default: ./templates), ready to be edited and used with
+--templates. Templates left unchanged can be deleted, as missing templates fall back to the built-in ones.
+
+The directory also gets ` + template.TemplatesManifestFile + `, recording the generator version and the hash of every template as
+copied, so customized templates can be told from stock ones after upgrading, and ` + template.DataDocsFile + `, the reference of
+the data templates are rendered with. Existing files are not overwritten unless --force is given.`,
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        dir := "./templates"
+                        if len(args) > 0 {
+                                dir = args[0]
+                        }
+                        manifest, err := template.InitTemplates(dir, serverVersion(), force)
+                        if err != nil {
+                                return ioError(fmt.Errorf("%w; pass --force to overwrite them", err))
+                        }
+                        out := cmd.OutOrStdout()
+                        fmt.Fprintf(out, "Copied %d templates to %s, with %s and %s\n", len(manifest.Files), dir, template.TemplatesManifestFile, template.DataDocsFile)
+                        fmt.Fprintf(out, "Edit them and generate with --templates %s\n", dir)
+                        return nil
+                },
+        }
+        initCmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files in the directory")
+        templateCmd.AddCommand(initCmd)
+
+        templateCmd.AddCommand(&cobra.Command{
+                Use:   "docs",
+                Short: "Print the reference of the template data fields",
+                Long: `Print the Markdown reference of the data templates are rendered with, generated from the IR structs, as written to
+` + template.DataDocsFile + ` by template init.`,
+                Args: cobra.NoArgs,
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        _, err := cmd.OutOrStdout().Write(template.RenderDataDocs())
+                        return err
+                },
+        })
+        return templateCmd
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+package main
+
+import (
+        "fmt"
+        "io"
+        "os"
+        "path/filepath"
+        "sort"
+
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/spf13/cobra"
+)
+
+// newUpgradeCommand returns the command regenerating a project with the generator's current templates
+func newUpgradeCommand() *cobra.Command {
+        var dryRun, showDiff, force, merge bool
+        upgradeCmd := &cobra.Command{
+                Use:   "upgrade [dir]",
+                Short: "Regenerate a project with the current templates, from the inputs and options in its lock file",
+                Long: `Regenerate a generated project directory (default: ./mcp-server) with this generator's templates, or the
+latest version of its registered template pack, from the artifact, project config, overlays and options recorded in
+its lock file (mcpgen.lock.json), and summarize the files the upgrade changes.
+
+Code inside protected regions (between mcpgen:begin <name> and mcpgen:end <name> comments) is kept, as on every
+regeneration; other edits to generated files stop the upgrade, listing the files, unless --force overwrites them or
+--merge merges the new files into them with conflict markers. Input paths are resolved from the working directory, as
+when the project was generated. --dry-run only prints the summary, and --diff the unified diff of the changes.`,
+                Args: cobra.MaximumNArgs(1),
+                RunE: func(cmd *cobra.Command, args []string) error {
+                        dir := "./mcp-server"
+                        if len(args) > 0 {
+                                dir = args[0]
+                        }
+                        lock, err := output.ReadLock(dir)
+                        if err != nil {
+                                return ioError(err)
+                        }
+                        if lock == nil {
+                                return usageError(fmt.Errorf("%s has no %s, so how it was generated is unknown", dir, output.LockFile))
+                        }
+                        // Generations run on fresh commands, which reset the logging flags
+                        logger := commandLogger(cmd)
+                        out := cmd.OutOrStdout()
+                        if lock.Generator.Version != serverVersion() {
+                                fmt.Fprintf(out, "Upgrading %s from %s %s to %s\n", dir, lock.Generator.Name, lock.Generator.Version, serverVersion())
+                        }
+                        for _, input := range lock.Inputs {
+                                if input.Path == stdinPath {
+                                        return usageError(fmt.Errorf("%s was generated from stdin, which cannot be read again; regenerate it with --artifact", dir))
+                                }
+                                if hash, err := inputHash(input.Kind, input.Path); err != nil {
+                                        return ioError(fmt.Errorf("failed to read %s %s recorded in %s: %w", input.Kind, input.Path, output.LockFile, err))
+                                } else if hash != input.Hash {
+                                        fmt.Fprintf(out, "Note: %s %s changed since the project was generated; the summary includes its changes\n", input.Kind, input.Path)
+                                }
+                        }
+
+                        // The generation is run again as recorded, first rendering in memory to find the changes
+                        generateArgs := lockedArgs(lock, dir)
+                        stdout, stderr, err := runCommand(cmd.Context(), append(generateArgs, "--diff", "--quiet")...)
+                        var changes []output.FileChange
+                        if failure := classify(err); err != nil && failure.exitCode != exitChanges {
+                                io.WriteString(cmd.ErrOrStderr(), stderr)
+                                return err
+                        } else if err != nil {
+                                changes, _ = failure.details.([]output.FileChange)
+                        }
+                        if dryRun {
+                                io.WriteString(cmd.ErrOrStderr(), stderr)
+                        }
+                        if len(changes) == 0 {
+                                fmt.Fprintf(out, "%s is up to date\n", dir)
+                                return nil
+                        }
+                        if showDiff {
+                                io.WriteString(out, stdout)
+                        }
+
+                        counts := map[string]int{}
+                        for _, change := range changes {
+                                counts[change.Kind]++
+                                fmt.Fprintf(out, "  %-8s  %s\n", change.Kind, change.Path)
+                        }
+                        summary := fmt.Sprintf("%d files (%d modified, %d added, %d removed)", len(changes),
+                                counts[output.ChangeModified], counts[output.ChangeAdded], counts[output.ChangeRemoved])
+                        if dryRun {
+                                fmt.Fprintf(out, "Upgrading %s would change %s\n", dir, summary)
+                                return nil
+                        }
+
+                        // Warnings, e.g. of edits outside protected regions being overwritten, are logged by the generation
+                        if force {
+                                generateArgs = append(generateArgs, "--force")
+                        }
+                        if merge {
+                                generateArgs = append(generateArgs, "--merge")
+                        }
+                        stdout, stderr, err = runCommand(cmd.Context(), append(generateArgs, "--quiet")...)
+                        // A failure is reported once, by this command, rather than also with the generation's output
+                        io.WriteString(cmd.ErrOrStderr(), stdout)
+                        if err != nil {
+                                return err
+                        }
+                        io.WriteString(cmd.ErrOrStderr(), stderr)
+        
+                        // Files no longer generated are removed, unless they were edited since
+                        for _, change := range changes {
+                                if change.Kind != output.ChangeRemoved {
+                                        continue
+                                }
+                                path := filepath.Join(dir, filepath.FromSlash(change.Path))
+                                if hash, err := output.HashFile(path); err != nil || hash != lock.Files[change.Path] {
+                                        logger.Warnf("%s is no longer generated but was modified since, so it is kept as a user file", change.Path)
+                                        continue
+                                }
+                                if err := os.Remove(path); err != nil {
+                                        return ioError(fmt.Errorf("failed to remove %s: %w", change.Path, err))
+                                }
+                        }
+                fmt.Fprintf(out, "Upgraded %s: changed %s\n", dir, summary)
+                        return nil
+                },
+        }
+        upgradeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the summary of the changes without writing them")
+        upgradeCmd.Flags().BoolVar(&showDiff, "diff", false, "Also print the unified diff of the changes")
+        upgradeCmd.Flags().BoolVar(&force, "force", false, "Overwrite generated files edited outside their protected regions")
+        upgradeCmd.Flags().BoolVar(&merge, "merge", false, "Merge the new files into generated files edited outside their protected regions, with conflict markers")
+        return upgradeCmd
+}
+
+// lockedArgs returns the generate-mcp arguments regenerating a project from the options of its lock file, into dir
+func lockedArgs(lock *output.Lock, dir string) []string {
+        names := make([]string, 0, len(lock.Options))
+        for name := range lock.Options {
+                names = append(names, name)
+        }
+        sort.Strings(names)
+
+        args := []string{"--output", dir}
+        for _, name := range names {
+                // Names given at prompts are not recorded, so colliding names fail as by default
+                if name == "output" || name == "diff" || name == "output-format" || name == "on-collision" && lock.Options[name] == collisionsPrompt {
+                        continue
+                }
+                switch value := lock.Options[name].(type) {
+                case []interface{}:
+                        for _, item := range value {
+                                args = append(args, fmt.Sprintf("--%s=%v", name, item))
+                        }
+                default:
+                        args = append(args, fmt.Sprintf("--%s=%v", name, value))
+                }
+        }
+        return args
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package main
+
+import (
+        "bytes"
+        "context"
+        "errors"
+        "fmt"
+        "os"
+        "os/exec"
+        "strings"
+        "time"
+
+        "github.com/openhands/mcp-generator/internal/logging"
+        "github.com/openhands/mcp-generator/internal/output"
+        "github.com/openhands/mcp-generator/internal/template"
+)
+
+// verifyOutputLines bounds the output of a failed verification command quoted in its error
+const verifyOutputLines = 40
+
+// verifyProject checks that a generated project builds by running its generator's verification commands, e.g. npm
+// install then tsc --noEmit, in a temporary copy, so that the output directory is left as generated
+func verifyProject(ctx context.Context, generator template.Generator, options map[string]string, files map[string][]byte, logger *logging.Logger) error {
+        dir, err := os.MkdirTemp("", "mcpgen-verify-")
+        if err != nil {
+                return ioError(err)
+        }
+        defer os.RemoveAll(dir)
+        if err := output.WriteDir(files, dir, time.Time{}); err != nil {
+                return ioError(err)
+        }
+
+        for _, command := range generator.Verify(options) {
+                line := strings.Join(command, " ")
+                logger.Infof("Verifying: %s", line)
+                var out bytes.Buffer
+                run := exec.CommandContext(ctx, command[0], command[1:]...)
+                run.Dir = dir
+                run.Stdout = &out
+                run.Stderr = &out
+                if err := run.Run(); err != nil {
+                        if errors.Is(err, exec.ErrNotFound) {
+                                return verifyError(fmt.Errorf("--verify runs %s, which is not installed: %w", command[0], err))
+                        }
+                        return verifyError(fmt.Errorf("generated server failed verification: %s: %w%s", line, err, quoteOutput(out.String())))
+                }
+        }
+        logger.Infof("Verified: the generated server builds")
+        return nil
+}
+
+// quoteOutput returns the last lines of a command's output, indented to follow an error message
+func quoteOutput(out string) string {
+        lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+        if len(lines) == 1 && lines[0] == "" {
+                return ""
+        }
+        if len(lines) > verifyOutputLines {
+                lines = append([]string{fmt.Sprintf("... (%d lines before)", len(lines)-verifyOutputLines)}, lines[len(lines)-verifyOutputLines:]...)
+        }
+        return "\n  " + strings.Join(lines, "\n  ")
+}
\ No newline at end of file
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/explorer"
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/spf13/pflag"
+)
+
+// DefaultFiles are the project config files generate-mcp reads from the working directory, in lookup order
+var DefaultFiles = []string{"mcpgen.yaml", "mcpgen.yml"}
+
+// filterKey is the config key of the IR projection applied before generation
+const filterKey = "filter"
+
+// profilesKey is the config key of the named profiles, e.g. dev, staging and prod
+const profilesKey = "profiles"
+
+// profileKey is the option selecting a profile, also given on the command line with --profile
+const profileKey = "profile"
+
+// Config holds the generation settings committed with a project, so that generate-mcp without flags reproduces a
+// generation
+// Options are keyed by the names of generate-mcp's flags (e.g. artifact, chain, enable-writes, overlay) and override
+// their defaults; flags given on the command line override the config.
+type Config struct {
+	// Path of the config file
+	Path string
+
+	// Flag values by flag name: strings, numbers, booleans, or lists for repeatable flags
+	Options map[string]interface{}
+
+	// Projection applied to the IR before generation, e.g. to expose only read-only functions
+	Filter *ir.Projection
+
+	// Named profiles, whose options and filter replace those of the config when selected (e.g. the address,
+	// network and filter of an environment)
+	Profiles map[string]*Config
+}
+
+// Find returns the project config file in a directory, or "" if there is none
+func Find(dir string) string {
+	for _, name := range DefaultFiles {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Load reads a config from a YAML or, by its .json extension, JSON file
+// Unknown filter keys are rejected; unknown options are rejected when the config is applied to flags.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		if content, err = ir.YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+
+	config, err := parse(path, content, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// parse parses the options and filter of a config or, without profiles allowed, of one of its profiles
+func parse(path string, content []byte, profiles bool) (*Config, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("expected a mapping of options: %w", err)
+	}
+	config := &Config{Path: path, Options: map[string]interface{}{}}
+	for key, raw := range entries {
+		if key == profilesKey && profiles {
+			var named map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &named); err != nil {
+				return nil, fmt.Errorf("%s: expected a mapping of profiles by name: %w", profilesKey, err)
+			}
+			config.Profiles = make(map[string]*Config, len(named))
+			for name, content := range named {
+				profile, err := parse(path, content, false)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", profilesKey, name, err)
+				}
+				config.Profiles[name] = profile
+			}
+			continue
+		}
+		if key == profilesKey || (key == profileKey && !profiles) {
+			return nil, fmt.Errorf("profiles cannot select or define profiles")
+		}
+		if key == filterKey {
+			decoder := json.NewDecoder(bytes.NewReader(raw))
+			decoder.DisallowUnknownFields()
+			config.Filter = &ir.Projection{}
+			if err := decoder.Decode(config.Filter); err != nil {
+				return nil, fmt.Errorf("%s: %w", filterKey, err)
+			}
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		config.Options[key] = value
+	}
+	return config, nil
+}
+
+// ProfileNames returns the names of the config's profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultProfile returns the profile the config selects with its profile option, or "" if it selects none
+func (c *Config) DefaultProfile() string {
+	name, _ := c.Options[profileKey].(string)
+	return name
+}
+
+// Profile returns the config with a profile selected: the profile's options override the config's, and its filter
+// replaces the config's filter
+func (c *Config) Profile(name string) (*Config, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return nil, fmt.Errorf("config %s: unknown profile %q (the config has no profiles)", c.Path, name)
+		}
+		return nil, fmt.Errorf("config %s: unknown profile %q (expected one of %s)", c.Path, name, strings.Join(c.ProfileNames(), ", "))
+	}
+	selected := &Config{Path: c.Path, Options: make(map[string]interface{}, len(c.Options)+len(profile.Options)), Filter: c.Filter, Profiles: c.Profiles}
+	for key, value := range c.Options {
+		selected.Options[key] = value
+	}
+	for key, value := range profile.Options {
+		selected.Options[key] = value
+	}
+	if profile.Filter != nil {
+		selected.Filter = profile.Filter
+	}
+	return selected, nil
+}
+
+// Apply sets the flags the config has options for, except those given on the command line
+// Relative paths of the named path flags are resolved against the config file's directory; so are those of the
+// named optional path flags, but only if the resolved path exists (e.g. a template pack given by path or by name).
+func (c *Config) Apply(flags *pflag.FlagSet, paths, optionalPaths []string) error {
+	isPath := map[string]bool{}
+	for _, name := range paths {
+		isPath[name] = true
+	}
+	isOptionalPath := map[string]bool{}
+	for _, name := range optionalPaths {
+		isOptionalPath[name] = true
+	}
+
+	names := make([]string, 0, len(c.Options))
+	for name := range c.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("config %s: unknown option %q (options are the flags of generate-mcp, e.g. artifact or enable-writes)", c.Path, name)
+		}
+		if flag.Changed {
+			continue
+		}
+
+		values, err := optionValues(c.Options[name], strings.HasSuffix(flag.Value.Type(), "Slice") || strings.HasSuffix(flag.Value.Type(), "Array"))
+		if err != nil {
+			return fmt.Errorf("config %s: %s: %w", c.Path, name, err)
+		}
+		for _, value := range values {
+			if isPath[name] || isOptionalPath[name] {
+				value = c.resolve(value, isOptionalPath[name])
+			}
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("config %s: %s: %w", c.Path, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns a relative path relative to the config file's directory; optional paths are kept as they are if
+// nothing exists there, and so are - for stdin and explorer references
+func (c *Config) resolve(path string, optional bool) string {
+	if path == "" || path == "-" || filepath.IsAbs(path) || explorer.IsReference(path) {
+		return path
+	}
+	resolved := filepath.Join(filepath.Dir(c.Path), path)
+	if optional {
+		if _, err := os.Stat(resolved); err != nil {
+			return path
+		}
+	}
+	return resolved
+}
+
+// optionValues converts an option to the flag values it sets: one value, or one per item of lists for repeatable flags
+func optionValues(option interface{}, repeatable bool) ([]string, error) {
+	if items, ok := option.([]interface{}); ok {
+		if !repeatable {
+			return nil, fmt.Errorf("only repeatable options take a list")
+		}
+		values := make([]string, len(items))
+		for i, item := range items {
+			value, err := optionValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	value, err := optionValue(option)
+	if err != nil {
+		return nil, err
+	}
+	return []string{value}, nil
+}
+
+// optionValue converts a scalar option to a flag value
+func optionValue(option interface{}) (string, error) {
+	switch value := option.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("expected a string, number, boolean or list but got %T", option)
+}
\ No newline at end of file
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	artifact, chain, output, pack string
+	profile                       string
+	writes                        bool
+	overlays                      []string
+}
+
+func testFlags(o *options) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("generate-mcp", pflag.ContinueOnError)
+	flags.StringVarP(&o.artifact, "artifact", "a", "", "")
+	flags.StringVarP(&o.chain, "chain", "c", "ethereum", "")
+	flags.StringVarP(&o.output, "output", "o", "./mcp-server", "")
+	flags.StringVar(&o.pack, "template-pack", "", "")
+	flags.StringVar(&o.profile, "profile", "", "")
+	flags.BoolVar(&o.writes, "enable-writes", false, "")
+	flags.StringArrayVar(&o.overlays, "overlay", nil, "")
+	return flags
+}
+
+func writeConfig(t *testing.T, content string) string {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mcpgen.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAndApply(t *testing.T) {
+	path := writeConfig(t, `artifact: abi/Token.json
+output: /srv/token-mcp
+chain: ethereum
+enable-writes: true
+template-pack: minimal
+overlay:
+  - overlays/names.yaml
+  - overlays/docs.yaml
+filter:
+  readOnly: true
+  exclude: ["owner()"]
+`)
+	if found := Find(filepath.Dir(path)); found != path {
+		t.Errorf("Expected to find %s but got %q", path, found)
+	}
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config.Filter, &ir.Projection{ReadOnly: true, Exclude: []string{"owner()"}}) {
+		t.Errorf("Unexpected filter %+v", config.Filter)
+	}
+
+	var o options
+	flags := testFlags(&o)
+	if err := flags.Parse([]string{"--chain", "evm"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Apply(flags, []string{"artifact", "output", "overlay"}, []string{"template-pack"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Dir(path)
+	if o.artifact != filepath.Join(dir, "abi", "Token.json") || o.output != "/srv/token-mcp" {
+		t.Errorf("Expected paths resolved against the config directory but got %s and %s", o.artifact, o.output)
+	}
+	if o.chain != "evm" {
+		t.Errorf("Expected the command line to override the config but got chain %s", o.chain)
+	}
+	if !o.writes || o.pack != "minimal" {
+		t.Errorf("Expected writes enabled and the minimal pack by name but got %v and %s", o.writes, o.pack)
+	}
+	expected := []string{filepath.Join(dir, "overlays", "names.yaml"), filepath.Join(dir, "overlays", "docs.yaml")}
+	if !reflect.DeepEqual(o.overlays, expected) {
+		t.Errorf("Expected overlays %v but got %v", expected, o.overlays)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Unknown option", "enable-write: true\n", `unknown option "enable-write"`},
+		{"List", "chain: [ethereum, solana]\n", "chain: only repeatable options take a list"},
+		{"Invalid value", "enable-writes: sometimes\n", "enable-writes"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := Load(writeConfig(t, test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var o options
+			err = config.Apply(testFlags(&o), nil, nil)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected an error containing %q but got %v", test.expected, err)
+			}
+		})
+	}
+
+	if _, err := Load(writeConfig(t, "filter:\n  writable: true\n")); err == nil || !strings.Contains(err.Error(), "filter") {
+		t.Errorf("Expected unknown filter keys to be rejected but got %v", err)
+	}
+}
+
+func TestExplorerReference(t *testing.T) {
+	config, err := Load(writeConfig(t, "artifact: explorer:base/0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o options
+	if err := config.Apply(testFlags(&o), []string{"artifact"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if o.artifact != "explorer:base/0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913" {
+		t.Errorf("Expected the explorer reference kept as given but got %s", o.artifact)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	path := writeConfig(t, `artifact: abi/Token.json
+chain: ethereum
+profile: dev
+filter:
+  readOnly: true
+profiles:
+  dev:
+    output: out/dev
+  prod:
+    output: out/prod
+    enable-writes: true
+    filter:
+      exclude: ["owner()"]
+`)
+	config, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := config.ProfileNames(); !reflect.DeepEqual(names, []string{"dev", "prod"}) || config.DefaultProfile() != "dev" {
+		t.Errorf("Unexpected profiles %v (default %q)", names, config.DefaultProfile())
+	}
+
+	prod, err := config.Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(prod.Filter, &ir.Projection{Exclude: []string{"owner()"}}) {
+		t.Errorf("Expected the profile's filter to replace the config's but got %+v", prod.Filter)
+	}
+	var o options
+	if err := prod.Apply(testFlags(&o), []string{"artifact", "output"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Dir(path)
+	if o.artifact != filepath.Join(dir, "abi", "Token.json") || o.output != filepath.Join(dir, "out", "prod") || !o.writes {
+		t.Errorf("Expected the config's options overridden by the profile's but got %+v", o)
+	}
+	if dev, _ := config.Profile("dev"); !reflect.DeepEqual(dev.Filter, config.Filter) {
+		t.Errorf("Expected a profile without a filter to keep the config's but got %+v", dev.Filter)
+	}
+
+	if _, err := config.Profile("staging"); err == nil || !strings.Contains(err.Error(), "expected one of dev, prod") {
+		t.Errorf("Expected an unknown profile to fail but got %v", err)
+	}
+	if _, err := Load(writeConfig(t, "profiles:\n  dev:\n    profiles: {}\n")); err == nil {
+		t.Error("Expected nested profiles to be rejected")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,274 @@
+package enrich
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// LLM providers descriptions can be enriched with
+const (
+	// ProviderOpenAI uses the OpenAI chat completions API (OPENAI_API_KEY)
+	ProviderOpenAI = "openai"
+
+	// ProviderAnthropic uses the Anthropic messages API (ANTHROPIC_API_KEY)
+	ProviderAnthropic = "anthropic"
+
+	// ProviderLocal uses an OpenAI-compatible server such as Ollama or llama.cpp (LLM_API_KEY, optional)
+	ProviderLocal = "local"
+)
+
+// defaults holds the endpoint, model and API key variable of each provider
+var defaults = map[string]struct {
+	endpoint string
+	model    string
+	keyEnv   string
+}{
+	ProviderOpenAI:    {"https://api.openai.com/v1", "gpt-4o-mini", "OPENAI_API_KEY"},
+	ProviderAnthropic: {"https://api.anthropic.com/v1", "claude-3-5-haiku-latest", "ANTHROPIC_API_KEY"},
+	ProviderLocal:     {"http://localhost:11434/v1", "llama3.1", "LLM_API_KEY"},
+}
+
+// systemPrompt frames every request
+const systemPrompt = `You write descriptions of smart contract functions and events for AI agents that choose which MCP tool to call.
+Reply with a JSON object only: {"description": "...", "parameters": {"<parameter name>": "..."}}.
+Descriptions are one or two plain sentences on what the item does and when to use it; do not restate types or invent behavior the signature and documentation do not support.`
+
+// Options configures the LLM used for enrichment
+type Options struct {
+	// Provider: openai, anthropic or local
+	Provider string
+
+	// Model name (default: a small, inexpensive model of the provider)
+	Model string
+
+	// Base URL of the API (default: the provider's; http://localhost:11434/v1 for local)
+	Endpoint string
+
+	// API key (default: read from the provider's environment variable)
+	APIKey string
+
+	// Directory responses are cached in, so regenerating only queries new or changed items; empty disables caching
+	CacheDir string
+
+	// HTTP client (default: one with a 60 second timeout)
+	Client *http.Client
+}
+
+// Result counts the descriptions written by an enrichment pass
+type Result struct {
+	// Descriptions written, including cached ones
+	Described int
+
+	// Descriptions read from the cache
+	Cached int
+}
+
+// DefaultCacheDir returns the per-user directory LLM responses are cached in
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-generator", "llm")
+}
+
+// Normalize validates the options and fills in defaults, e.g. the API key from the environment
+func (o Options) Normalize() (Options, error) {
+	provider, ok := defaults[o.Provider]
+	if !ok {
+		return o, fmt.Errorf("unsupported LLM provider: %s (expected %s, %s or %s)", o.Provider, ProviderOpenAI, ProviderAnthropic, ProviderLocal)
+	}
+	if o.Model == "" {
+		o.Model = provider.model
+	}
+	if o.Endpoint == "" {
+		o.Endpoint = provider.endpoint
+	}
+	o.Endpoint = strings.TrimSuffix(o.Endpoint, "/")
+	if o.APIKey == "" {
+		o.APIKey = os.Getenv(provider.keyEnv)
+	}
+	if o.APIKey == "" && o.Provider != ProviderLocal {
+		return o, fmt.Errorf("the %s LLM provider needs an API key: set %s", o.Provider, provider.keyEnv)
+	}
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: 60 * time.Second}
+	}
+	return o, nil
+}
+
+// Enrich replaces the descriptions of a contract's functions, their inputs and its events with ones written by an LLM
+// from their signatures, current descriptions and any NatSpec documentation recorded in their chain data
+// It stops at the first failed request, keeping the descriptions written so far, so a failure can be reported and ignored.
+func Enrich(ctx context.Context, c *ir.ContractIR, options Options) (Result, error) {
+	result := Result{}
+	options, err := options.Normalize()
+	if err != nil {
+		return result, err
+	}
+
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		if f.IsFallback || f.IsReceive {
+			continue
+		}
+		kind := "function"
+		if f.IsConstructor {
+			kind = "constructor"
+		}
+		description, cached, err := options.describe(ctx, prompt(c, kind, functionSignature(f), f.Description, f.EVM().NatSpec))
+		if err != nil {
+			return result, fmt.Errorf("failed to describe %s: %w", f.Name, err)
+		}
+		if description.Description != "" {
+			f.Description = description.Description
+		}
+		for j := range f.Inputs {
+			if text := description.Parameters[f.Inputs[j].Name]; text != "" && f.Inputs[j].Name != "" {
+				f.Inputs[j].Description = text
+			}
+		}
+		result.count(cached)
+	}
+
+	for i := range c.Events {
+		e := &c.Events[i]
+		description, cached, err := options.describe(ctx, prompt(c, "event", eventSignature(e), e.Description, e.EVM().NatSpec))
+		if err != nil {
+			return result, fmt.Errorf("failed to describe %s: %w", e.Name, err)
+		}
+		if description.Description != "" {
+			e.Description = description.Description
+		}
+		result.count(cached)
+	}
+
+	return result, nil
+}
+
+func (r *Result) count(cached bool) {
+	r.Described++
+	if cached {
+		r.Cached++
+	}
+}
+
+// description is the reply requested from the LLM
+type description struct {
+	Description string            `json:"description"`
+	Parameters  map[string]string `json:"parameters"`
+}
+
+// prompt describes a function or event to the LLM
+func prompt(c *ir.ContractIR, kind, signature, current string, natspec interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Contract: %s (%s)\n", c.Metadata.Name, c.Metadata.Chain)
+	if c.Metadata.Description != "" {
+		fmt.Fprintf(&b, "Contract description: %s\n", c.Metadata.Description)
+	}
+	fmt.Fprintf(&b, "%s: %s\n", strings.ToUpper(kind[:1])+kind[1:], signature)
+	if current != "" {
+		fmt.Fprintf(&b, "Current description: %s\n", current)
+	}
+	if natspec != nil {
+		if content, err := json.Marshal(natspec); err == nil {
+			fmt.Fprintf(&b, "NatSpec: %s\n", content)
+		}
+	}
+	return b.String()
+}
+
+// functionSignature formats a function with its parameter names, mutability and return values
+func functionSignature(f *ir.Function) string {
+	signature := f.ABIName() + "(" + parameters(f.Inputs) + ")"
+	if f.StateMutability != "" {
+		signature += " " + string(f.StateMutability)
+	}
+	if len(f.Outputs) > 0 {
+		signature += " returns (" + parameters(f.Outputs) + ")"
+	}
+	return signature
+}
+
+// eventSignature formats an event with its parameter names and indexed parameters
+func eventSignature(e *ir.Event) string {
+	fields := make([]string, len(e.Parameters))
+	for i, p := range e.Parameters {
+		field := p.Type.ABIType()
+		if p.Indexed {
+			field += " indexed"
+		}
+		fields[i] = strings.TrimSpace(field + " " + p.Name)
+	}
+	return e.Name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+func parameters(parameters []ir.Parameter) string {
+	fields := make([]string, len(parameters))
+	for i, p := range parameters {
+		fields[i] = strings.TrimSpace(p.Type.ABIType() + " " + p.Name)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// describe returns the LLM's description for a prompt, from the cache if it was requested before
+func (o Options) describe(ctx context.Context, prompt string) (description, bool, error) {
+	var reply description
+	path := o.cachePath(prompt)
+	if path != "" {
+		if content, err := os.ReadFile(path); err == nil && json.Unmarshal(content, &reply) == nil {
+			return reply, true, nil
+		}
+	}
+
+	text, err := o.complete(ctx, prompt)
+	if err != nil {
+		return reply, false, err
+	}
+	if reply, err = parseDescription(text); err != nil {
+		return reply, false, err
+	}
+
+	if path != "" {
+		// Caching is best effort; a read-only cache directory only costs repeated requests
+		if content, err := json.Marshal(reply); err == nil && os.MkdirAll(filepath.Dir(path), 0755) == nil {
+			os.WriteFile(path, content, 0644)
+		}
+	}
+	return reply, false, nil
+}
+
+// cachePath returns the cache file of a prompt's reply, keyed by provider, model and prompt, or "" if caching is disabled
+func (o Options) cachePath(prompt string) string {
+	if o.CacheDir == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(o.Provider + "\x00" + o.Model + "\x00" + systemPrompt + "\x00" + prompt))
+	return filepath.Join(o.CacheDir, hex.EncodeToString(hash[:])+".json")
+}
+
+// parseDescription reads the JSON object in an LLM reply, tolerating surrounding text such as Markdown fences
+func parseDescription(text string) (description, error) {
+	var reply description
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return reply, errors.New("the LLM reply is not a JSON object")
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &reply); err != nil {
+		return reply, fmt.Errorf("the LLM reply is not a valid description: %w", err)
+	}
+	reply.Description = strings.TrimSpace(reply.Description)
+	return reply, nil
+}
\ No newline at end of file
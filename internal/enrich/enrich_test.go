@@ -0,0 +1,163 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// testContract has a function and an event to describe
+func testContract() *ir.ContractIR {
+	return &ir.ContractIR{
+		Metadata: ir.ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []ir.Function{
+			{
+				Name:            "transfer",
+				Description:     "transfer - Parameters: to (address), amount (uint256)",
+				StateMutability: ir.Nonpayable,
+				Inputs:          []ir.Parameter{{Name: "to", Type: ir.ParameterType{BaseType: "address"}}, {Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}}},
+				Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "bool"}}},
+				ChainData:       map[string]interface{}{"natspec": map[string]interface{}{"notice": "Moves tokens"}},
+			},
+			{Name: "receive", StateMutability: ir.Payable, IsReceive: true},
+		},
+		Events: []ir.Event{{Name: "Transfer", Parameters: []ir.EventParameter{{Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true}}}},
+	}
+}
+
+func TestEnrichOpenAICompatible(t *testing.T) {
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var request struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Model != "llama3.1" || len(request.Messages) != 2 || request.Messages[0].Role != "system" {
+			t.Errorf("Unexpected request %+v", request)
+		}
+		prompt := request.Messages[1].Content
+		prompts = append(prompts, prompt)
+
+		reply := `{"description": "Emitted when tokens move."}`
+		if strings.Contains(prompt, "Function:") {
+			reply = "```json\n{\"description\": \"Sends tokens to an account.\", \"parameters\": {\"to\": \"Recipient\"}}\n```"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"choices": []interface{}{map[string]interface{}{"message": map[string]string{"content": reply}}}})
+	}))
+	defer server.Close()
+
+	options := Options{Provider: ProviderLocal, Endpoint: server.URL + "/v1/", CacheDir: t.TempDir()}
+	contract := testContract()
+	result, err := Enrich(context.Background(), contract, options)
+	if err != nil {
+		t.Fatalf("Failed to enrich: %v", err)
+	}
+	if result.Described != 2 || result.Cached != 0 || len(prompts) != 2 {
+		t.Errorf("Expected 2 requested descriptions but got %+v after %d requests", result, len(prompts))
+	}
+
+	f := contract.Functions[0]
+	if f.Description != "Sends tokens to an account." || f.Inputs[0].Description != "Recipient" || f.Inputs[1].Description != "" {
+		t.Errorf("Unexpected function descriptions %+v", f)
+	}
+	if contract.Events[0].Description != "Emitted when tokens move." {
+		t.Errorf("Unexpected event description %q", contract.Events[0].Description)
+	}
+	for _, expected := range []string{"Contract: Token (ethereum)", "Function: transfer(address to, uint256 amount) nonpayable returns (bool)", `NatSpec: {"notice":"Moves tokens"}`, "Current description: transfer - Parameters"} {
+		if !strings.Contains(prompts[0], expected) {
+			t.Errorf("Expected %q in the prompt\n%s", expected, prompts[0])
+		}
+	}
+	if !strings.Contains(prompts[1], "Event: Transfer(address indexed from)") {
+		t.Errorf("Unexpected event prompt\n%s", prompts[1])
+	}
+
+	// Regenerating from the same IR reads the cache instead of the LLM
+	result, err = Enrich(context.Background(), testContract(), options)
+	if err != nil || result.Cached != 2 || len(prompts) != 2 {
+		t.Errorf("Expected 2 cached descriptions but got %+v after %d requests (%v)", result, len(prompts), err)
+	}
+}
+
+func TestEnrichAnthropic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" || r.Header.Get("x-api-key") != "secret" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("Unexpected request to %s with headers %v", r.URL.Path, r.Header)
+		}
+		var request struct {
+			System string `json:"system"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.System != systemPrompt {
+			t.Errorf("Expected the system prompt but got %q", request.System)
+		}
+		w.Write([]byte(`{"content": [{"type": "text", "text": "{\"description\": \"Described.\"}"}]}`))
+	}))
+	defer server.Close()
+
+	contract := testContract()
+	if _, err := Enrich(context.Background(), contract, Options{Provider: ProviderAnthropic, Endpoint: server.URL, APIKey: "secret"}); err != nil {
+		t.Fatalf("Failed to enrich: %v", err)
+	}
+	if contract.Functions[0].Description != "Described." {
+		t.Errorf("Unexpected description %q", contract.Functions[0].Description)
+	}
+}
+
+func TestEnrichFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	contract := testContract()
+	description := contract.Functions[0].Description
+	_, err := Enrich(context.Background(), contract, Options{Provider: ProviderOpenAI, Endpoint: server.URL, APIKey: "secret"})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("Expected the status in the error but got %v", err)
+	}
+	if contract.Functions[0].Description != description {
+		t.Errorf("Expected the parsed description to be kept")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "from-env")
+	options, err := Options{Provider: ProviderAnthropic}.Normalize()
+	if err != nil || options.APIKey != "from-env" || options.Model == "" || options.Endpoint != "https://api.anthropic.com/v1" {
+		t.Errorf("Unexpected options %+v (%v)", options, err)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "")
+	if _, err := (Options{Provider: ProviderOpenAI}).Normalize(); err == nil || !strings.Contains(err.Error(), "OPENAI_API_KEY") {
+		t.Errorf("Expected a missing key error but got %v", err)
+	}
+	if _, err := (Options{Provider: ProviderLocal}).Normalize(); err != nil {
+		t.Errorf("Expected local providers to work without a key but got %v", err)
+	}
+	if _, err := (Options{Provider: "gemini"}).Normalize(); err == nil {
+		t.Errorf("Expected an error for an unsupported provider")
+	}
+}
+
+func TestParseDescription(t *testing.T) {
+	if _, err := parseDescription("I cannot help with that."); err == nil {
+		t.Errorf("Expected an error for a reply without JSON")
+	}
+	reply, err := parseDescription(`Sure: {"description": "  Reads a balance. ", "parameters": {}}`)
+	if err != nil || reply.Description != "Reads a balance." {
+		t.Errorf("Unexpected reply %+v (%v)", reply, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,125 @@
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// anthropicVersion is the Anthropic API version requests are made against
+const anthropicVersion = "2023-06-01"
+
+// maxTokens bounds the length of a reply
+const maxTokens = 512
+
+// complete sends a prompt to the provider and returns the text of its reply
+func (o Options) complete(ctx context.Context, prompt string) (string, error) {
+	if o.Provider == ProviderAnthropic {
+		return o.completeAnthropic(ctx, prompt)
+	}
+	return o.completeOpenAI(ctx, prompt)
+}
+
+// completeOpenAI uses the chat completions API, which local servers such as Ollama implement too
+func (o Options) completeOpenAI(ctx context.Context, prompt string) (string, error) {
+	request := map[string]interface{}{
+		"model": o.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": systemPrompt},
+			{"role": "user", "content": prompt},
+		},
+		"temperature": 0,
+		"max_tokens":  maxTokens,
+	}
+	headers := map[string]string{}
+	if o.APIKey != "" {
+		headers["Authorization"] = "Bearer " + o.APIKey
+	}
+
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := o.post(ctx, o.Endpoint+"/chat/completions", headers, request, &response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", errors.New("the LLM returned no choices")
+	}
+	return response.Choices[0].Message.Content, nil
+}
+
+// completeAnthropic uses the messages API
+func (o Options) completeAnthropic(ctx context.Context, prompt string) (string, error) {
+	request := map[string]interface{}{
+		"model":       o.Model,
+		"system":      systemPrompt,
+		"messages":    []map[string]string{{"role": "user", "content": prompt}},
+		"temperature": 0,
+		"max_tokens":  maxTokens,
+	}
+	headers := map[string]string{
+		"x-api-key":         o.APIKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := o.post(ctx, o.Endpoint+"/messages", headers, request, &response); err != nil {
+		return "", err
+	}
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			return block.Text, nil
+		}
+	}
+	return "", errors.New("the LLM returned no text")
+}
+
+// post sends a JSON request and decodes the JSON response
+func (o Options) post(ctx context.Context, url string, headers map[string]string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(content) > 200 {
+			content = content[:200]
+		}
+		return fmt.Errorf("%s returned %s: %s", url, resp.Status, bytes.TrimSpace(content))
+	}
+	if err := json.Unmarshal(content, response); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", url, err)
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+package explorer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cachePath returns the cache file of a reference, keyed by chain ID and address, or "" if caching is disabled
+func cachePath(dir string, ref Reference) string {
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, fmt.Sprint(ref.Network.ChainID), strings.ToLower(ref.Address)+".json")
+}
+
+// readCache returns the cached contract of a reference, or nil if it is not cached
+func readCache(dir string, ref Reference) (*Contract, error) {
+	path := cachePath(dir, ref)
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached %s: %w", ref, err)
+	}
+	contract := &Contract{}
+	// A corrupt entry is fetched again and overwritten
+	if json.Unmarshal(content, contract) != nil || len(contract.ABI) == 0 {
+		return nil, nil
+	}
+	return contract, nil
+}
+
+// writeCache caches the contract of a reference
+func writeCache(dir string, ref Reference, contract *Contract) error {
+	path := cachePath(dir, ref)
+	if path == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(contract, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to cache %s: %w", ref, err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to cache %s: %w", ref, err)
+	}
+	return nil
+}
+
+// ClearCache removes the cached contracts of a cache directory and returns how many there were
+func ClearCache(dir string) (int, error) {
+	if dir == "" {
+		return 0, nil
+	}
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && filepath.Ext(path) == ".json" {
+			count++
+		}
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, os.RemoveAll(dir)
+}
\ No newline at end of file
@@ -0,0 +1,127 @@
+package explorer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// KeychainService is the keychain service explorer API keys are stored under, with the account explorer-<chainId>
+// for a chain's key or explorer for a key of every chain
+const KeychainService = "mcp-generator"
+
+// GenericKeyEnv is the environment variable of an API key used for chains without their own
+const GenericKeyEnv = "EXPLORER_API_KEY"
+
+// keyEnvs are the environment variables of the API keys of each chain's explorer, as named by their tooling;
+// testnets use the key of their mainnet's explorer
+var keyEnvs = map[int]string{
+	1:        "ETHERSCAN_API_KEY",
+	10:       "OPTIMISTIC_ETHERSCAN_API_KEY",
+	56:       "BSCSCAN_API_KEY",
+	100:      "GNOSISSCAN_API_KEY",
+	137:      "POLYGONSCAN_API_KEY",
+	8453:     "BASESCAN_API_KEY",
+	17000:    "ETHERSCAN_API_KEY",
+	42161:    "ARBISCAN_API_KEY",
+	43114:    "SNOWTRACE_API_KEY",
+	59144:    "LINEASCAN_API_KEY",
+	80002:    "POLYGONSCAN_API_KEY",
+	84532:    "BASESCAN_API_KEY",
+	421614:   "ARBISCAN_API_KEY",
+	534352:   "SCROLLSCAN_API_KEY",
+	11155111: "ETHERSCAN_API_KEY",
+	11155420: "OPTIMISTIC_ETHERSCAN_API_KEY",
+}
+
+// Credentials hold the explorer API keys given on the command line or in the project config, by chain ID; the key
+// of chain 0 is used for every chain without its own
+type Credentials struct {
+	Keys map[int]string
+}
+
+// ParseKey parses an API key given as <network or chain ID>=<key>, or as <key> for every chain
+func ParseKey(value string) (int, string, error) {
+	chain, key, ok := strings.Cut(value, "=")
+	if !ok {
+		return 0, value, nil
+	}
+	if key == "" {
+		return 0, "", fmt.Errorf("explorer API key %s has no key", value)
+	}
+	if chainID, err := strconv.Atoi(chain); err == nil && chainID > 0 {
+		return chainID, key, nil
+	}
+	network, err := ir.LookupNetwork(chain)
+	if err != nil {
+		return 0, "", fmt.Errorf("explorer API key for %s: %w", chain, err)
+	}
+	return network.ChainID, key, nil
+}
+
+// KeyEnv returns the environment variable of the API key of a chain's explorer
+func KeyEnv(chainID int) string {
+	if env, ok := keyEnvs[chainID]; ok {
+		return env
+	}
+	return fmt.Sprintf("EXPLORER_API_KEY_%d", chainID)
+}
+
+// Key returns the API key of a chain's explorer and where it was found: the chain's key given on the command line or
+// in the project config, its environment variable, the keychain, and then a key given for every chain, in the
+// environment or the keychain. It returns "" for both without a key.
+func (c Credentials) Key(chainID int) (key, source string) {
+	if key := c.Keys[chainID]; key != "" {
+		return key, "--explorer-key"
+	}
+	if key := os.Getenv(KeyEnv(chainID)); key != "" {
+		return key, KeyEnv(chainID)
+	}
+	if key := keychainLookup(fmt.Sprintf("explorer-%d", chainID)); key != "" {
+		return key, "keychain"
+	}
+	if key := c.Keys[0]; key != "" {
+		return key, "--explorer-key"
+	}
+	if key := os.Getenv(GenericKeyEnv); key != "" {
+		return key, GenericKeyEnv
+	}
+	if key := keychainLookup("explorer"); key != "" {
+		return key, "keychain"
+	}
+	return "", ""
+}
+
+// keyHelp returns how to give the API key of a chain's explorer
+func keyHelp(chainID int) string {
+	return fmt.Sprintf("set %s, pass --explorer-key %d=<key>, or store it in the keychain as service %s, account explorer-%d",
+		KeyEnv(chainID), chainID, KeychainService, chainID)
+}
+
+// keychainLookup returns a key stored in the system keychain, or "" if there is none or no keychain tool
+// It is a variable so that tests do not read the keychain.
+var keychainLookup = func(account string) string {
+	var command *exec.Cmd
+	switch goruntime.GOOS {
+	case "darwin":
+		command = exec.Command("security", "find-generic-password", "-s", KeychainService, "-a", account, "-w")
+	case "linux":
+		command = exec.Command("secret-tool", "lookup", "service", KeychainService, "account", account)
+	default:
+		return ""
+	}
+	if _, err := exec.LookPath(command.Path); err != nil {
+		return ""
+	}
+	out, err := command.Output()
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(out))
+}
\ No newline at end of file
@@ -0,0 +1,319 @@
+// Package explorer fetches the verified ABIs of deployed contracts from Sourcify and Etherscan-compatible block
+// explorers, caching them on disk
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/offline"
+)
+
+// Scheme prefixes the artifact references of contracts fetched from explorers, e.g.
+// explorer:base/0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913
+const Scheme = "explorer:"
+
+// DefaultSourcifyURL is the base URL of the Sourcify API
+const DefaultSourcifyURL = "https://sourcify.dev/server"
+
+// DefaultTTL is how long fetched contracts are cached
+const DefaultTTL = 24 * time.Hour
+
+// DefaultRetries is how often rate-limited requests are retried
+const DefaultRetries = 3
+
+// addressPattern matches EVM addresses
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Reference names a deployed contract: its network preset and address
+type Reference struct {
+	Network ir.Network
+	Address string
+}
+
+// String returns the reference as given with --artifact
+func (r Reference) String() string {
+	return Scheme + r.Network.Name + "/" + r.Address
+}
+
+// IsReference reports whether an artifact path is an explorer reference
+func IsReference(path string) bool {
+	return strings.HasPrefix(path, Scheme)
+}
+
+// ParseReference parses an explorer reference, explorer:<network>/<address>
+func ParseReference(path string) (Reference, error) {
+	network, address, ok := strings.Cut(strings.TrimPrefix(path, Scheme), "/")
+	if !IsReference(path) || !ok {
+		return Reference{}, fmt.Errorf("invalid explorer reference %s (expected %s<network>/<address>)", path, Scheme)
+	}
+	preset, err := ir.LookupNetwork(network)
+	if err != nil {
+		return Reference{}, err
+	}
+	if !addressPattern.MatchString(address) {
+		return Reference{}, fmt.Errorf("invalid explorer reference %s: %s is not an address", path, address)
+	}
+	return Reference{Network: preset, Address: address}, nil
+}
+
+// Contract is a verified contract fetched from an explorer
+type Contract struct {
+	// ABI of the contract, a JSON array
+	ABI json.RawMessage `json:"abi"`
+
+	// Name of the contract as verified, if known
+	Name string `json:"name,omitempty"`
+
+	// Where the contract was fetched from: sourcify, or the host of the explorer's API
+	Source string `json:"source"`
+
+	// When the contract was fetched
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// Options configure fetching
+type Options struct {
+	// Directory fetched contracts are cached in; empty disables caching
+	CacheDir string
+
+	// How long cached contracts are used before being fetched again (default: DefaultTTL)
+	TTL time.Duration
+
+	// Fetch contracts even if cached, refreshing the cache
+	NoCache bool
+
+	// Base URL of the Sourcify API (default: DefaultSourcifyURL)
+	SourcifyURL string
+
+	// API keys of the explorers
+	Credentials Credentials
+
+	// How often rate-limited requests are retried (default: DefaultRetries), first after Backoff (default: one
+	// second), doubling the delay each time
+	Retries int
+	Backoff time.Duration
+
+	// HTTP client (default: one with a 30 second timeout)
+	Client *http.Client
+}
+
+// Fetch returns the verified contract of a reference: from the cache if it was fetched within the TTL, or else from
+// Sourcify and then the network's explorer. It reports whether the contract was cached.
+// Offline, cached contracts are used whatever their age, and others fail to be fetched.
+func Fetch(ctx context.Context, ref Reference, options Options) (*Contract, bool, error) {
+	if options.TTL <= 0 {
+		options.TTL = DefaultTTL
+	}
+	if options.SourcifyURL == "" {
+		options.SourcifyURL = DefaultSourcifyURL
+	}
+	if options.Retries == 0 {
+		options.Retries = DefaultRetries
+	}
+	if options.Backoff <= 0 {
+		options.Backoff = time.Second
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	cached, err := readCache(options.CacheDir, ref)
+	if err != nil {
+		return nil, false, err
+	}
+	if cached != nil && (offline.Enabled() || !options.NoCache && time.Since(cached.FetchedAt) < options.TTL) {
+		return cached, true, nil
+	}
+	if err := offline.Check("fetching "+ref.String(), options.SourcifyURL); err != nil {
+		return nil, false, fmt.Errorf("%w, and %s is not cached", err, ref)
+	}
+
+	// Sourcify needs no API key, so explorers are only asked for contracts it has not verified
+	contract, err := fetchSourcify(ctx, ref, options)
+	if errors.Is(err, ErrNotVerified) && ref.Network.ExplorerAPI != "" {
+		contract, err = fetchExplorer(ctx, ref, options)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	contract.FetchedAt = time.Now().UTC()
+	if err := writeCache(options.CacheDir, ref, contract); err != nil {
+		return nil, false, err
+	}
+	return contract, false, nil
+}
+
+// ErrNotVerified is the error of contracts a source has not verified, wrapped by Fetch when no source has
+var ErrNotVerified = errors.New("contract is not verified")
+
+// fetchSourcify fetches a contract from Sourcify's v2 API
+func fetchSourcify(ctx context.Context, ref Reference, options Options) (*Contract, error) {
+	endpoint := fmt.Sprintf("%s/v2/contract/%d/%s?fields=abi,compilation", strings.TrimSuffix(options.SourcifyURL, "/"), ref.Network.ChainID, ref.Address)
+	var response struct {
+		ABI         json.RawMessage `json:"abi"`
+		Compilation struct {
+			Name string `json:"name"`
+		} `json:"compilation"`
+	}
+	status, err := options.get(ctx, endpoint, &response, nil)
+	if status == http.StatusNotFound {
+		return nil, ErrNotVerified
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sourcify: %w", err)
+	}
+	if len(response.ABI) == 0 || string(response.ABI) == "null" {
+		return nil, ErrNotVerified
+	}
+	return &Contract{ABI: response.ABI, Name: response.Compilation.Name, Source: "sourcify"}, nil
+}
+
+// fetchExplorer fetches a contract from the Etherscan-compatible API of a network's explorer, with the chain's API key
+func fetchExplorer(ctx context.Context, ref Reference, options Options) (*Contract, error) {
+	query := url.Values{"module": {"contract"}, "action": {"getsourcecode"}, "address": {ref.Address}}
+	key, _ := options.Credentials.Key(ref.Network.ChainID)
+	if key != "" {
+		query.Set("apikey", key)
+	}
+	endpoint, err := url.Parse(ref.Network.ExplorerAPI)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawQuery = query.Encode()
+
+	var response explorerResponse
+	if _, err := options.get(ctx, endpoint.String(), &response, response.rateLimited); err != nil {
+		return nil, explorerError(endpoint.Host, ref, key, err)
+	}
+	var results []struct {
+		ABI          string `json:"ABI"`
+		ContractName string `json:"ContractName"`
+	}
+	if response.Status != "1" || json.Unmarshal(response.Result, &results) != nil || len(results) == 0 {
+		return nil, explorerError(endpoint.Host, ref, key, errors.New(response.reason()))
+	}
+	if !json.Valid([]byte(results[0].ABI)) || !strings.HasPrefix(strings.TrimSpace(results[0].ABI), "[") {
+		return nil, fmt.Errorf("%s: %w", endpoint.Host, ErrNotVerified)
+	}
+	return &Contract{ABI: json.RawMessage(results[0].ABI), Name: results[0].ContractName, Source: endpoint.Host}, nil
+}
+
+// explorerResponse is the envelope of Etherscan-compatible API responses
+type explorerResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// reason returns why a request failed: its result, e.g. "Missing/Invalid API Key", or else its message
+func (r *explorerResponse) reason() string {
+	var reason string
+	if json.Unmarshal(r.Result, &reason) != nil || reason == "" {
+		return r.Message
+	}
+	return reason
+}
+
+// rateLimited reports whether a response was refused for the rate limit, which explorers answer with HTTP 200
+func (r *explorerResponse) rateLimited() bool {
+	return r.Status == "0" && strings.Contains(strings.ToLower(r.reason()), "rate limit")
+}
+
+// errRateLimited is the error of requests still rate-limited after their retries
+var errRateLimited = errors.New("rate limited")
+
+// explorerError explains a failure of an explorer: missing or invalid API keys and rate limits come with how to give
+// a key
+func explorerError(host string, ref Reference, key string, err error) error {
+	reason := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(reason, "api key") && key == "":
+		return fmt.Errorf("%s needs an API key: %s", host, keyHelp(ref.Network.ChainID))
+	case strings.Contains(reason, "api key"):
+		return fmt.Errorf("%s rejected the API key of chain %d (%v); check the key or replace it: %s", host, ref.Network.ChainID, err, keyHelp(ref.Network.ChainID))
+	case errors.Is(err, errRateLimited) && key == "":
+		return fmt.Errorf("%s: %w; requests without an API key have the lowest rate limit, so add one: %s", host, err, keyHelp(ref.Network.ChainID))
+	}
+	return fmt.Errorf("%s: %w", host, err)
+}
+
+// get sends a GET request and decodes its JSON response, returning the response's status
+// Responses with HTTP 429, or that rateLimited reports, are retried with exponential backoff, honoring Retry-After.
+func (o Options) get(ctx context.Context, endpoint string, response interface{}, rateLimited func() bool) (int, error) {
+	delay := o.Backoff
+	for attempt := 0; ; attempt++ {
+		status, retryAfter, err := o.getOnce(ctx, endpoint, response)
+		limited := status == http.StatusTooManyRequests || err == nil && rateLimited != nil && rateLimited()
+		if !limited {
+			return status, err
+		}
+		if attempt >= o.Retries {
+			return status, fmt.Errorf("%w after %d retries", errRateLimited, o.Retries)
+		}
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// getOnce sends a GET request and decodes its JSON response, returning its status and Retry-After delay
+// Errors never contain the request's query, which holds the API key.
+func (o Options) getOnce(ctx context.Context, endpoint string, response interface{}) (int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+		}
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, retryAfter, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, retryAfter, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return resp.StatusCode, retryAfter, fmt.Errorf("invalid response: %w", err)
+	}
+	return resp.StatusCode, retryAfter, nil
+}
+
+// DefaultCacheDir returns the per-user directory fetched contracts are cached in
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-generator", "explorer")
+}
\ No newline at end of file
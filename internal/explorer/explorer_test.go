@@ -0,0 +1,181 @@
+package explorer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const usdc = "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+
+// Tests never read the system keychain
+func init() {
+	keychainLookup = func(string) string { return "" }
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("explorer:Base/" + usdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Network.ChainID != 8453 || ref.Address != usdc || ref.String() != "explorer:base/"+usdc {
+		t.Errorf("Unexpected reference %+v", ref)
+	}
+	for _, invalid := range []string{"explorer:base", "explorer:nowhere/" + usdc, "explorer:base/0x1234", "abi.json"} {
+		if _, err := ParseReference(invalid); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch {
+		case r.URL.Path == "/sourcify/v2/contract/8453/"+usdc:
+			w.Write([]byte(`{"abi": [{"type": "function", "name": "decimals"}], "compilation": {"name": "FiatTokenProxy"}}`))
+		case strings.HasPrefix(r.URL.Path, "/sourcify/"):
+			http.NotFound(w, r)
+		case r.URL.Query().Get("address") == usdc:
+			w.Write([]byte(`{"status": "1", "message": "OK", "result": [{"ABI": "[{\"type\":\"function\",\"name\":\"name\"}]", "ContractName": "Token"}]}`))
+		default:
+			w.Write([]byte(`{"status": "0", "message": "NOTOK", "result": "Contract source code not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ref, _ := ParseReference("explorer:base/" + usdc)
+	options := Options{CacheDir: t.TempDir(), SourcifyURL: server.URL + "/sourcify"}
+	contract, cached, err := Fetch(context.Background(), ref, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached || contract.Name != "FiatTokenProxy" || contract.Source != "sourcify" || !strings.Contains(string(contract.ABI), "decimals") {
+		t.Errorf("Unexpected contract %+v (cached %v)", contract, cached)
+	}
+
+	// Within the TTL the contract is read from the cache, unless NoCache is set
+	if contract, cached, err = Fetch(context.Background(), ref, options); err != nil || !cached || contract.Name != "FiatTokenProxy" {
+		t.Errorf("Expected the cached contract but got %+v (cached %v, %v)", contract, cached, err)
+	}
+	options.NoCache = true
+	if _, cached, _ = Fetch(context.Background(), ref, options); cached || requests["/sourcify/v2/contract/8453/"+usdc] != 2 {
+		t.Errorf("Expected --no-cache to fetch again but got %d requests", requests["/sourcify/v2/contract/8453/"+usdc])
+	}
+	options.NoCache = false
+	options.TTL = time.Nanosecond
+	if _, cached, _ = Fetch(context.Background(), ref, options); cached {
+		t.Error("Expected an expired contract to be fetched again")
+	}
+
+	// Contracts Sourcify has not verified are fetched from the network's explorer
+	ref.Network.ChainID = 1
+	ref.Network.ExplorerAPI = server.URL + "/api"
+	contract, _, err = Fetch(context.Background(), ref, Options{SourcifyURL: server.URL + "/sourcify"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.Name != "Token" || !strings.HasPrefix(contract.Source, "127.0.0.1:") || string(contract.ABI) != `[{"type":"function","name":"name"}]` {
+		t.Errorf("Unexpected contract %+v", contract)
+	}
+	ref.Address = "0x" + strings.Repeat("0", 40)
+	if _, _, err = Fetch(context.Background(), ref, Options{SourcifyURL: server.URL + "/sourcify"}); err == nil || !strings.HasSuffix(err.Error(), ": Contract source code not found") {
+		t.Errorf("Expected the explorer's reason but got %v", err)
+	}
+
+	count, err := ClearCache(options.CacheDir)
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 cached contract cleared but got %d (%v)", count, err)
+	}
+	if count, err = ClearCache(options.CacheDir); err != nil || count != 0 {
+		t.Errorf("Expected an empty cache but got %d (%v)", count, err)
+	}
+}
+
+func TestFetchExplorerKeys(t *testing.T) {
+	keychainLookup = func(account string) string {
+		if account == "explorer-8453" {
+			return "keychain-key"
+		}
+		return ""
+	}
+	defer func() { keychainLookup = func(string) string { return "" } }()
+	t.Setenv(GenericKeyEnv, "")
+	t.Setenv("ETHERSCAN_API_KEY", "")
+
+	limited := 2
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/sourcify/") {
+			http.NotFound(w, r)
+			return
+		}
+		key := r.URL.Query().Get("apikey")
+		keys = append(keys, key)
+		switch {
+		case key == "":
+			w.Write([]byte(`{"status": "0", "message": "NOTOK", "result": "Missing/Invalid API Key"}`))
+		case limited > 0:
+			limited--
+			w.Write([]byte(`{"status": "0", "message": "NOTOK", "result": "Max rate limit reached, please use API Key for higher rate limit"}`))
+		default:
+			w.Write([]byte(`{"status": "1", "message": "OK", "result": [{"ABI": "[]", "ContractName": "Token"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	ref, _ := ParseReference("explorer:mainnet/" + usdc)
+	ref.Network.ExplorerAPI = server.URL + "/api"
+	options := Options{SourcifyURL: server.URL + "/sourcify", Backoff: time.Millisecond}
+	_, _, err := Fetch(context.Background(), ref, options)
+	if err == nil || !strings.Contains(err.Error(), "needs an API key: set ETHERSCAN_API_KEY, pass --explorer-key 1=<key>") {
+		t.Errorf("Expected how to give a key but got %v", err)
+	}
+
+	// Rate-limited requests are retried; keys come from the flags before the environment and the keychain
+	t.Setenv("ETHERSCAN_API_KEY", "env-key")
+	if _, _, err = Fetch(context.Background(), ref, options); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"", "env-key", "env-key", "env-key"}; strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected keys %v but got %v", expected, keys)
+	}
+	options.Credentials = Credentials{Keys: map[int]string{1: "flag-key"}}
+	if key, source := options.Credentials.Key(1); key != "flag-key" || source != "--explorer-key" {
+		t.Errorf("Expected the flag's key but got %s from %s", key, source)
+	}
+	if key, source := options.Credentials.Key(8453); key != "keychain-key" || source != "keychain" {
+		t.Errorf("Expected the keychain's key but got %s from %s", key, source)
+	}
+
+	limited = 10
+	options.Retries = 2
+	_, _, err = Fetch(context.Background(), ref, options)
+	if err == nil || !strings.Contains(err.Error(), "rate limited after 2 retries") || strings.Contains(err.Error(), "flag-key") {
+		t.Errorf("Expected the rate limit reported without the key but got %v", err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := map[string][2]interface{}{
+		"abc":         {0, "abc"},
+		"base=abc":    {8453, "abc"},
+		"10=abc":      {10, "abc"},
+		"Arbitrum=ab": {42161, "ab"},
+	}
+	for value, expected := range tests {
+		chainID, key, err := ParseKey(value)
+		if err != nil || chainID != expected[0] || key != expected[1] {
+			t.Errorf("Expected %v for %s but got %d, %s (%v)", expected, value, chainID, key, err)
+		}
+	}
+	for _, invalid := range []string{"base=", "nowhere=abc"} {
+		if _, _, err := ParseKey(invalid); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,169 @@
+// Package foundry reads the deployments recorded by Foundry's forge script in the broadcast directory of a project
+package foundry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigFile is the file marking the root directory of a Foundry project
+const ConfigFile = "foundry.toml"
+
+// BroadcastDirName is the directory of a Foundry project that forge script records its runs in
+const BroadcastDirName = "broadcast"
+
+// latestRun is the file of the latest run of a script on a chain, broadcast/<script>/<chain ID>/run-latest.json
+const latestRun = "run-latest.json"
+
+// Deployment is a contract deployment recorded in a broadcast
+type Deployment struct {
+	// Chain ID the contract was deployed on
+	ChainID uint64
+
+	// Address of the deployed contract
+	Address string
+
+	// Block the deployment was mined in, or 0 if the broadcast has no receipt for it
+	Block uint64
+
+	// Script that deployed the contract (e.g., "Deploy.s.sol")
+	Script string
+
+	// When the script ran, as recorded by forge
+	Timestamp int64
+}
+
+// ProjectRoot returns the root directory of the Foundry project containing a directory: the nearest directory with a
+// foundry.toml, or "" if there is none
+func ProjectRoot(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ConfigFile)); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// run is the part of a broadcast run file needed to find deployments
+type run struct {
+	Transactions []struct {
+		Hash            string `json:"hash"`
+		TransactionType string `json:"transactionType"`
+		ContractName    string `json:"contractName"`
+		ContractAddress string `json:"contractAddress"`
+	} `json:"transactions"`
+	Receipts []struct {
+		TransactionHash string          `json:"transactionHash"`
+		BlockNumber     json.RawMessage `json:"blockNumber"`
+		Status          json.RawMessage `json:"status"`
+	} `json:"receipts"`
+	Chain     uint64 `json:"chain"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Deployments returns the latest deployment of a contract on each chain, by chain ID, found in the latest runs of
+// the scripts of a broadcast directory
+// Simulated runs (dry-run directories) and deployments whose receipt reports a failure are skipped. When several
+// scripts deployed the contract on a chain, the latest run wins, and within a run the last deployment.
+func Deployments(dir, contract string) (map[uint64]Deployment, error) {
+	deployments := map[uint64]Deployment{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == "dry-run" {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() || entry.Name() != latestRun {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var broadcast run
+		if err := json.Unmarshal(content, &broadcast); err != nil {
+			return fmt.Errorf("invalid broadcast %s: %w", path, err)
+		}
+		// Older broadcasts have no chain field, only the chain ID directory
+		chainDir := filepath.Dir(path)
+		if broadcast.Chain == 0 {
+			broadcast.Chain, _ = strconv.ParseUint(filepath.Base(chainDir), 10, 64)
+		}
+		if broadcast.Chain == 0 {
+			return nil
+		}
+		script := filepath.Base(filepath.Dir(chainDir))
+
+		for _, tx := range broadcast.Transactions {
+			if tx.ContractName != contract || tx.ContractAddress == "" || !strings.HasPrefix(tx.TransactionType, "CREATE") {
+				continue
+			}
+			deployment := Deployment{ChainID: broadcast.Chain, Address: tx.ContractAddress, Script: script, Timestamp: broadcast.Timestamp}
+			failed := false
+			for _, receipt := range broadcast.Receipts {
+				if !strings.EqualFold(receipt.TransactionHash, tx.Hash) {
+					continue
+				}
+				deployment.Block = quantity(receipt.BlockNumber)
+				failed = len(receipt.Status) > 0 && string(receipt.Status) != "null" && quantity(receipt.Status) == 0
+			}
+			if failed {
+				continue
+			}
+			if previous, ok := deployments[broadcast.Chain]; !ok || previous.Timestamp <= deployment.Timestamp {
+				deployments[broadcast.Chain] = deployment
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return deployments, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+// quantity parses a number of a broadcast, which forge writes as a hex string or, in older versions, as a JSON number;
+// invalid numbers are 0
+func quantity(raw json.RawMessage) uint64 {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		base := 10
+		if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+			text, base = text[2:], 16
+		}
+		value, _ := strconv.ParseUint(text, base, 64)
+		return value
+	}
+	var number uint64
+	json.Unmarshal(raw, &number)
+	return number
+}
+
+// ChainIDs returns the chain IDs of deployments, in ascending order
+func ChainIDs(deployments map[uint64]Deployment) []uint64 {
+	ids := make([]uint64, 0, len(deployments))
+	for id := range deployments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
\ No newline at end of file
@@ -0,0 +1,88 @@
+package foundry
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/testutil"
+)
+
+const (
+	counterSepolia = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	counterBase    = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+	counterOld     = "0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0"
+)
+
+func TestDeployments(t *testing.T) {
+	project := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(project, ConfigFile), "[profile.default]\n")
+	broadcast := filepath.Join(project, BroadcastDirName)
+
+	// The deploy script ran on Sepolia and Base; on Sepolia its counter replaced one deployed by an older script
+	testutil.WriteFile(t, filepath.Join(broadcast, "Deploy.s.sol", "11155111", "run-latest.json"), `{
+		"transactions": [
+			{"hash": "0xaa", "transactionType": "CREATE", "contractName": "Token", "contractAddress": "0x0000000000000000000000000000000000000001"},
+			{"hash": "0xbb", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "`+counterSepolia+`"},
+			{"hash": "0xcc", "transactionType": "CALL", "contractName": "Counter", "contractAddress": "`+counterSepolia+`", "function": "increment()"}
+		],
+		"receipts": [{"transactionHash": "0xBB", "blockNumber": "0x10", "status": "0x1"}],
+		"chain": 11155111,
+		"timestamp": 1700000200
+	}`)
+	testutil.WriteFile(t, filepath.Join(broadcast, "Legacy.s.sol", "11155111", "run-latest.json"), `{
+		"transactions": [{"hash": "0x01", "transactionType": "CREATE2", "contractName": "Counter", "contractAddress": "`+counterOld+`"}],
+		"receipts": [{"transactionHash": "0x01", "blockNumber": 5, "status": 1}],
+		"timestamp": 1700000100
+	}`)
+	testutil.WriteFile(t, filepath.Join(broadcast, "Deploy.s.sol", "8453", "run-latest.json"), `{
+		"transactions": [
+			{"hash": "0x02", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "0x0000000000000000000000000000000000000002"},
+			{"hash": "0x03", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "`+counterBase+`"}
+		],
+		"receipts": [{"transactionHash": "0x02", "blockNumber": "0x20", "status": "0x1"}, {"transactionHash": "0x03", "blockNumber": "0x21", "status": "0x1"}],
+		"chain": 8453,
+		"timestamp": 1700000300
+	}`)
+	// Simulations, failed deployments and other runs than the latest are not deployments
+	testutil.WriteFile(t, filepath.Join(broadcast, "Deploy.s.sol", "1", "dry-run", "run-latest.json"), `{
+		"transactions": [{"hash": "0x04", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "0x0000000000000000000000000000000000000004"}],
+		"chain": 1
+	}`)
+	testutil.WriteFile(t, filepath.Join(broadcast, "Deploy.s.sol", "10", "run-latest.json"), `{
+		"transactions": [{"hash": "0x05", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "0x0000000000000000000000000000000000000005"}],
+		"receipts": [{"transactionHash": "0x05", "blockNumber": "0x30", "status": "0x0"}],
+		"chain": 10
+	}`)
+	testutil.WriteFile(t, filepath.Join(broadcast, "Deploy.s.sol", "10", "run-1700000000.json"), `{
+		"transactions": [{"hash": "0x06", "transactionType": "CREATE", "contractName": "Counter", "contractAddress": "0x0000000000000000000000000000000000000006"}],
+		"chain": 10
+	}`)
+
+	if root := ProjectRoot(filepath.Join(project, "out", "Counter.sol")); root != project {
+		t.Errorf("Expected the project root %s but got %s", project, root)
+	}
+	deployments, err := Deployments(broadcast, "Counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[uint64]Deployment{
+		11155111: {ChainID: 11155111, Address: counterSepolia, Block: 16, Script: "Deploy.s.sol", Timestamp: 1700000200},
+		8453:     {ChainID: 8453, Address: counterBase, Block: 33, Script: "Deploy.s.sol", Timestamp: 1700000300},
+	}
+	if len(deployments) != len(expected) {
+		t.Fatalf("Expected %d deployments but got %+v", len(expected), deployments)
+	}
+	for chainID, deployment := range expected {
+		if deployments[chainID] != deployment {
+			t.Errorf("Expected %+v on chain %d but got %+v", deployment, chainID, deployments[chainID])
+		}
+	}
+	if ids := ChainIDs(deployments); len(ids) != 2 || ids[0] != 8453 || ids[1] != 11155111 {
+		t.Errorf("Unexpected chain IDs %v", ids)
+	}
+
+	// Projects that never broadcast have no deployments
+	if deployments, err := Deployments(filepath.Join(t.TempDir(), BroadcastDirName), "Counter"); err != nil || len(deployments) != 0 {
+		t.Errorf("Expected no deployments but got %+v (%v)", deployments, err)
+	}
+}
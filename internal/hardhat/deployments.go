@@ -0,0 +1,157 @@
+// Package hardhat reads the deployments hardhat-deploy records in the deployments directory of a Hardhat project:
+// deployments/<network>/<contract>.json files with the ABI, address and receipt of each deployed contract, next to
+// the network's .chainId file
+package hardhat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ChainIDFile is the file of a network's deployments directory holding the network's chain ID
+const ChainIDFile = ".chainId"
+
+// Deployment is a contract deployment recorded by hardhat-deploy
+type Deployment struct {
+	// Network the contract was deployed to, as named in the Hardhat config (e.g., "sepolia")
+	Network string
+
+	// Chain ID of the network
+	ChainID uint64
+
+	// Address of the deployed contract
+	Address string
+
+	// Block the deployment was mined in, or 0 if its receipt was not recorded
+	Block uint64
+
+	// Hash of the deploying transaction, if recorded
+	TransactionHash string
+}
+
+// IsNetworkDir reports whether a directory holds the deployments of a network, as it has a .chainId file
+func IsNetworkDir(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ChainIDFile))
+	return err == nil && !info.IsDir()
+}
+
+// IsDeploymentFile reports whether a file is the deployment of a contract in the deployments directory of a network
+func IsDeploymentFile(path string) bool {
+	return isContractFile(filepath.Base(path)) && IsNetworkDir(filepath.Dir(path))
+}
+
+// isContractFile reports whether a file name of a network's deployments directory is that of a contract: JSON files
+// other than hidden ones such as .migrations.json
+func isContractFile(name string) bool {
+	return filepath.Ext(name) == ".json" && !strings.HasPrefix(name, ".")
+}
+
+// Contracts returns the deployment files of the contracts of a network's deployments directory, sorted
+func Contracts(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isContractFile(entry.Name()) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// ReadDeployment reads where the contract of a deployment file was deployed
+func ReadDeployment(path string) (*Deployment, error) {
+	dir := filepath.Dir(path)
+	chainID, err := readChainID(dir)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Address         string `json:"address"`
+		TransactionHash string `json:"transactionHash"`
+		Receipt         *struct {
+			BlockNumber json.RawMessage `json:"blockNumber"`
+		} `json:"receipt"`
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid deployment %s: %w", path, err)
+	}
+	if file.Address == "" {
+		return nil, fmt.Errorf("invalid deployment %s: no address", path)
+	}
+	deployment := &Deployment{Network: filepath.Base(dir), ChainID: chainID, Address: file.Address, TransactionHash: file.TransactionHash}
+	if file.Receipt != nil {
+		deployment.Block = blockNumber(file.Receipt.BlockNumber)
+	}
+	return deployment, nil
+}
+
+// blockNumber parses the block number of a receipt, a JSON number or, in receipts of some providers, a hex string;
+// invalid numbers are 0
+func blockNumber(raw json.RawMessage) uint64 {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		number, _ := strconv.ParseUint(strings.TrimPrefix(text, "0x"), 16, 64)
+		return number
+	}
+	var number uint64
+	json.Unmarshal(raw, &number)
+	return number
+}
+
+// readChainID reads the chain ID of a network's deployments directory
+func readChainID(dir string) (uint64, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ChainIDFile))
+	if err != nil {
+		return 0, err
+	}
+	chainID, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil || chainID == 0 {
+		return 0, fmt.Errorf("invalid chain ID in %s", filepath.Join(dir, ChainIDFile))
+	}
+	return chainID, nil
+}
+
+// Deployments returns the deployments of a contract to the networks of a deployments directory, by chain ID, read
+// from deployments/<network>/<contract>.json
+// Networks sharing a chain ID, such as hardhat and localhost, are read in name order, the first one winning.
+func Deployments(root, contract string) (map[uint64]Deployment, error) {
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[uint64]Deployment{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	deployments := map[uint64]Deployment{}
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		if !entry.IsDir() || !IsNetworkDir(dir) {
+			continue
+		}
+		path := filepath.Join(dir, contract+".json")
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		deployment, err := ReadDeployment(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := deployments[deployment.ChainID]; !ok {
+			deployments[deployment.ChainID] = *deployment
+		}
+	}
+	return deployments, nil
+}
\ No newline at end of file
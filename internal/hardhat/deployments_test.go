@@ -0,0 +1,60 @@
+package hardhat
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/testutil"
+)
+
+const (
+	counterSepolia = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	counterBase    = "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"
+)
+
+func TestDeployments(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "deployments")
+	testutil.WriteFile(t, filepath.Join(root, "sepolia", ChainIDFile), "11155111")
+	testutil.WriteFile(t, filepath.Join(root, "sepolia", "Counter.json"), `{"address": "`+counterSepolia+`", "abi": [], "transactionHash": "0xaa", "receipt": {"blockNumber": 4567}}`)
+	testutil.WriteFile(t, filepath.Join(root, "sepolia", "Token.json"), `{"address": "0x0000000000000000000000000000000000000001", "abi": []}`)
+	testutil.WriteFile(t, filepath.Join(root, "sepolia", ".migrations.json"), `{}`)
+	testutil.WriteFile(t, filepath.Join(root, "sepolia", "solcInputs", "abc.json"), `{}`)
+	testutil.WriteFile(t, filepath.Join(root, "base", ChainIDFile), "8453\n")
+	testutil.WriteFile(t, filepath.Join(root, "base", "Counter.json"), `{"address": "`+counterBase+`", "abi": [], "receipt": {"blockNumber": "0x10"}}`)
+	// Directories without a chain ID are not networks
+	testutil.WriteFile(t, filepath.Join(root, "notes", "Counter.json"), `{"address": "0x0000000000000000000000000000000000000002"}`)
+
+	contracts, err := Contracts(filepath.Join(root, "sepolia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contracts) != 2 || filepath.Base(contracts[0]) != "Counter.json" || filepath.Base(contracts[1]) != "Token.json" {
+		t.Errorf("Unexpected contracts %v", contracts)
+	}
+	if !IsDeploymentFile(contracts[0]) || IsDeploymentFile(filepath.Join(root, "notes", "Counter.json")) {
+		t.Error("Expected only files of network directories to be deployments")
+	}
+
+	deployments, err := Deployments(root, "Counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[uint64]Deployment{
+		11155111: {Network: "sepolia", ChainID: 11155111, Address: counterSepolia, Block: 4567, TransactionHash: "0xaa"},
+		8453:     {Network: "base", ChainID: 8453, Address: counterBase, Block: 16},
+	}
+	if len(deployments) != len(expected) {
+		t.Fatalf("Expected %d deployments but got %+v", len(expected), deployments)
+	}
+	for chainID, deployment := range expected {
+		if deployments[chainID] != deployment {
+			t.Errorf("Expected %+v on chain %d but got %+v", deployment, chainID, deployments[chainID])
+		}
+	}
+
+	testutil.WriteFile(t, filepath.Join(root, "broken", ChainIDFile), "mainnet")
+	testutil.WriteFile(t, filepath.Join(root, "broken", "Counter.json"), `{"address": "0x0000000000000000000000000000000000000003"}`)
+	if _, err := Deployments(root, "Counter"); err == nil {
+		t.Error("Expected an invalid chain ID to fail")
+	}
+}
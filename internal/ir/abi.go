@@ -0,0 +1,49 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ABIName returns the name a function is called by on chain, which differs from its tool name
+// for overloaded functions and functions renamed by an overlay
+func (f Function) ABIName() string {
+	if f.Overload != nil && f.Overload.Name != "" {
+		return f.Overload.Name
+	}
+	if originalName := f.EVM().OriginalName; originalName != "" {
+		return originalName
+	}
+	return f.Name
+}
+
+// ABIType returns the canonical ABI type of a parameter type (e.g., "uint256[]"), expanding tuples
+func (t ParameterType) ABIType() string {
+	typeName := t.BaseType
+	if len(t.Components) > 0 {
+		components := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			components[i] = component.Type.ABIType()
+		}
+		typeName = "(" + strings.Join(components, ",") + ")"
+	}
+
+	if t.IsArray {
+		if t.ArraySize > 0 {
+			return fmt.Sprintf("%s[%d]", typeName, t.ArraySize)
+		}
+		return typeName + "[]"
+	}
+	return typeName
+}
+
+// Selector returns the EVM selector of a function or error signature (e.g., "0xa9059cbb" for
+// "transfer(address,uint256)"), the first 4 bytes of its Keccak-256 hash
+func Selector(signature string) string {
+	return keccakHex(signature)[:10]
+}
+
+// Topic returns the EVM topic of an event signature, the Keccak-256 hash its logs are filtered by
+func Topic(signature string) string {
+	return keccakHex(signature)
+}
\ No newline at end of file
@@ -0,0 +1,162 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultAdminRole is the AccessControl role administering all others, including itself
+const DefaultAdminRole = "DEFAULT_ADMIN_ROLE"
+
+// ownableFunctions are the Ownable functions only the owner may call
+var ownableFunctions = map[string]bool{
+	"transferOwnership": true,
+	"renounceOwnership": true,
+}
+
+// roleAdminFunctions are the AccessControl functions only a role's admin may call
+var roleAdminFunctions = map[string]bool{
+	"grantRole":  true,
+	"revokeRole": true,
+}
+
+// String describes who may call the function, e.g. "the owner or holders of MINTER_ROLE"
+func (a AccessRestriction) String() string {
+	var callers []string
+	if a.OnlyOwner {
+		callers = append(callers, "the owner")
+	}
+	if len(a.Roles) > 0 {
+		callers = append(callers, "holders of "+strings.Join(a.Roles, " or "))
+	}
+	if a.Allowlist != "" {
+		callers = append(callers, "accounts on the "+a.Allowlist)
+	}
+	if len(callers) == 0 {
+		return ""
+	}
+	return strings.Join(callers, " or ")
+}
+
+// IsEmpty reports whether the restriction restricts nothing
+func (a *AccessRestriction) IsEmpty() bool {
+	return a == nil || (!a.OnlyOwner && len(a.Roles) == 0 && a.Allowlist == "")
+}
+
+// HasOwner reports whether the contract has an Ownable style owner() view function
+func (c *ContractIR) HasOwner() bool {
+	return c.hasGetter("owner", "address")
+}
+
+// HasRoles reports whether the contract has an AccessControl style hasRole(bytes32,address) view function
+func (c *ContractIR) HasRoles() bool {
+	for _, function := range c.Functions {
+		if function.ABIName() == "hasRole" && len(function.Inputs) == 2 && function.Inputs[0].Type.BaseType == "bytes32" &&
+			function.Inputs[1].Type.BaseType == "address" && isReadOnlyFunction(function) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles returns the AccessControl roles the contract declares getters of, e.g. MINTER_ROLE()
+func (c *ContractIR) Roles() []string {
+	var roles []string
+	for _, function := range c.Functions {
+		name := function.ABIName()
+		if strings.HasSuffix(name, "_ROLE") && name != DefaultAdminRole && c.hasGetter(name, "bytes32") {
+			roles = append(roles, name)
+		}
+	}
+	return roles
+}
+
+// DetectAccessControl restricts the write functions of Ownable and AccessControl contracts that are known or named
+// to need privileges: transferOwnership and renounceOwnership to the owner, grantRole and revokeRole to
+// DEFAULT_ADMIN_ROLE, and functions named after a declared role to its holders, e.g. mint to MINTER_ROLE and
+// pause and unpause to PAUSER_ROLE. Functions that are already restricted are left unchanged.
+// It returns the number of functions restricted.
+func DetectAccessControl(c *ContractIR) int {
+	hasOwner, hasRoles := c.HasOwner(), c.HasRoles()
+	if !hasOwner && !hasRoles {
+		return 0
+	}
+
+	// Role verbs are the role names without their suffix and agent noun, e.g. "mint" for MINTER_ROLE
+	var roles, verbs []string
+	if hasRoles {
+		for _, role := range c.Roles() {
+			verb := strings.ToLower(strings.TrimSuffix(role, "_ROLE"))
+			verb = strings.TrimSuffix(strings.TrimSuffix(verb, "er"), "r")
+			if len(verb) >= 3 {
+				roles = append(roles, role)
+				verbs = append(verbs, verb)
+			}
+		}
+	}
+
+	detected := 0
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		if f.Access != nil || isReadOnlyFunction(*f) || f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		name := f.ABIName()
+		access := &AccessRestriction{}
+		switch {
+		case hasOwner && ownableFunctions[name]:
+			access.OnlyOwner = true
+		case hasRoles && roleAdminFunctions[name]:
+			access.Roles = []string{DefaultAdminRole}
+		default:
+			for j, verb := range verbs {
+				if strings.Contains(strings.ToLower(name), verb) {
+					access.Roles = append(access.Roles, roles[j])
+				}
+			}
+		}
+		if access.IsEmpty() {
+			continue
+		}
+		f.Access = access
+		detected++
+	}
+	return detected
+}
+
+// hasGetter reports whether the contract has a view function of the given name taking no arguments and
+// returning a single value of the given type
+func (c *ContractIR) hasGetter(name, baseType string) bool {
+	for _, function := range c.Functions {
+		if function.ABIName() == name && len(function.Inputs) == 0 && len(function.Outputs) == 1 &&
+			function.Outputs[0].Type.BaseType == baseType && !function.Outputs[0].Type.IsArray && isReadOnlyFunction(function) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReadOnlyFunction reports whether a function only reads contract state
+func isReadOnlyFunction(f Function) bool {
+	return f.StateMutability == View || f.StateMutability == Pure
+}
+
+// accessErrors reports empty role and allowlist names
+func accessErrors(a *AccessRestriction) []ValidationError {
+	if a == nil {
+		return nil
+	}
+	var errors []ValidationError
+	for i, role := range a.Roles {
+		if strings.TrimSpace(role) == "" {
+			errors = append(errors, ValidationError{Code: CodeInvalidAccess, Field: fmt.Sprintf("Access.Roles[%d]", i), Message: "role name is required"})
+		}
+	}
+	if a.Allowlist != "" && strings.TrimSpace(a.Allowlist) == "" {
+		errors = append(errors, ValidationError{Code: CodeInvalidAccess, Field: "Access.Allowlist", Message: "allowlist name must not be blank"})
+	}
+	if a.IsEmpty() {
+		errors = append(errors, ValidationError{Code: CodeInvalidAccess, Field: "Access", Message: "access restriction restricts nothing"})
+	}
+	return errors
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectAccessControl(t *testing.T) {
+	address := Parameter{Name: "account", Type: ParameterType{BaseType: "address"}}
+	role := Parameter{Name: "role", Type: ParameterType{BaseType: "bytes32"}}
+	getter := func(name, baseType string) Function {
+		return Function{Name: name, StateMutability: View, Outputs: []Parameter{{Type: ParameterType{BaseType: baseType}}}}
+	}
+	contract := &ContractIR{
+		Functions: []Function{
+			getter("owner", "address"),
+			getter("MINTER_ROLE", "bytes32"),
+			getter("PAUSER_ROLE", "bytes32"),
+			getter(DefaultAdminRole, "bytes32"),
+			{Name: "hasRole", StateMutability: View, Inputs: []Parameter{role, address}, Outputs: []Parameter{{Type: ParameterType{BaseType: "bool"}}}},
+			{Name: "transferOwnership", StateMutability: Nonpayable, Inputs: []Parameter{address}},
+			{Name: "grantRole", StateMutability: Nonpayable, Inputs: []Parameter{role, address}},
+			{Name: "mint", StateMutability: Nonpayable, Inputs: []Parameter{address}},
+			{Name: "unpause", StateMutability: Nonpayable},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{address}},
+			{Name: "setFee", StateMutability: Nonpayable, Access: &AccessRestriction{Allowlist: "operators"}},
+		},
+	}
+
+	if detected := DetectAccessControl(contract); detected != 4 {
+		t.Errorf("Expected 4 restricted functions but got %d", detected)
+	}
+	expected := map[string]*AccessRestriction{
+		"transferOwnership": {OnlyOwner: true},
+		"grantRole":         {Roles: []string{DefaultAdminRole}},
+		"mint":              {Roles: []string{"MINTER_ROLE"}},
+		"unpause":           {Roles: []string{"PAUSER_ROLE"}},
+		"transfer":          nil,
+		"setFee":            {Allowlist: "operators"},
+		"owner":             nil,
+	}
+	for _, f := range contract.Functions {
+		if want, ok := expected[f.Name]; ok && !reflect.DeepEqual(f.Access, want) {
+			t.Errorf("Expected %s to be restricted to %+v but got %+v", f.Name, want, f.Access)
+		}
+	}
+
+	if detected := DetectAccessControl(&ContractIR{Functions: []Function{{Name: "transferOwnership", StateMutability: Nonpayable}}}); detected != 0 {
+		t.Errorf("Expected no restrictions without owner() or hasRole but got %d", detected)
+	}
+}
+
+func TestAccessRestrictionString(t *testing.T) {
+	access := AccessRestriction{OnlyOwner: true, Roles: []string{"MINTER_ROLE", "ADMIN_ROLE"}, Allowlist: "whitelist"}
+	if expected := "the owner or holders of MINTER_ROLE or ADMIN_ROLE or accounts on the whitelist"; access.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, access.String())
+	}
+	var unrestricted *AccessRestriction
+	if !unrestricted.IsEmpty() || !(&AccessRestriction{}).IsEmpty() {
+		t.Error("Expected missing and empty restrictions to be empty")
+	}
+}
\ No newline at end of file
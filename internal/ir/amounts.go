@@ -0,0 +1,102 @@
+package ir
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DecimalsKey is the ParameterType chain data key marking an unsigned integer as a token amount
+// Its value is the number of decimals of the amount, or DecimalsFromContract
+const DecimalsKey = "decimals"
+
+// DecimalsFromContract marks amounts whose decimals are read from the contract's decimals() function
+const DecimalsFromContract = "contract"
+
+// MaxDecimals is the largest number of decimals a uint256 amount can use
+const MaxDecimals = 77
+
+// amountNames matches the names of parameters holding token amounts
+var amountNames = regexp.MustCompile(`(?i)(amount|value|balance|supply|allowance)`)
+
+// amountFunctions are the ERC-20 functions whose outputs are token amounts
+var amountFunctions = map[string]bool{
+	"totalSupply": true,
+	"balanceOf":   true,
+	"allowance":   true,
+}
+
+// AmountDecimals returns the decimals of a token amount type
+// fromContract reports that they are read from the contract's decimals() function,
+// and ok is false if the type is not annotated as an amount
+func (t ParameterType) AmountDecimals() (decimals int, fromContract bool, ok bool) {
+	if !isScalarUint(t) {
+		return 0, false, false
+	}
+
+	switch value := t.ChainData[DecimalsKey].(type) {
+	case string:
+		return 0, true, value == DecimalsFromContract
+	case int:
+		return value, false, value >= 0 && value <= MaxDecimals
+	case float64:
+		// Annotations decoded from JSON
+		decimals := int(value)
+		return decimals, false, float64(decimals) == value && decimals >= 0 && decimals <= MaxDecimals
+	}
+	return 0, false, false
+}
+
+// HasDecimalsFunction reports whether the contract has an ERC-20 style decimals() view function
+func (c *ContractIR) HasDecimalsFunction() bool {
+	for _, function := range c.Functions {
+		if function.ABIName() == "decimals" && len(function.Inputs) == 0 && len(function.Outputs) == 1 &&
+			isScalarUint(function.Outputs[0].Type) &&
+			(function.StateMutability == View || function.StateMutability == Pure) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectTokenAmounts annotates the token amount parameters of contracts with a decimals() function
+// so their decimals are read from the contract: unsigned integer inputs and outputs named like
+// amounts (amount, value, balance, ...) and the outputs of totalSupply, balanceOf and allowance.
+// Parameters that are already annotated or have a unit are left unchanged. Detected amounts get the token unit.
+// It returns the number of parameters annotated.
+func DetectTokenAmounts(c *ContractIR) int {
+	if !c.HasDecimalsFunction() {
+		return 0
+	}
+
+	detected := 0
+	annotate := func(p *Parameter, isAmount bool) {
+		if _, annotated := p.Type.ChainData[DecimalsKey]; annotated || p.Unit != "" || !isAmount || !isScalarUint(p.Type) {
+			return
+		}
+		if p.Type.ChainData == nil {
+			p.Type.ChainData = map[string]interface{}{}
+		}
+		p.Type.ChainData[DecimalsKey] = DecimalsFromContract
+		p.Unit = UnitToken
+		detected++
+	}
+
+	for i := range c.Functions {
+		function := &c.Functions[i]
+		if function.IsConstructor {
+			continue
+		}
+		for j := range function.Inputs {
+			annotate(&function.Inputs[j], amountNames.MatchString(function.Inputs[j].Name))
+		}
+		for j := range function.Outputs {
+			annotate(&function.Outputs[j], amountFunctions[function.ABIName()] || amountNames.MatchString(function.Outputs[j].Name))
+		}
+	}
+	return detected
+}
+
+// isScalarUint reports whether t is a single unsigned integer
+func isScalarUint(t ParameterType) bool {
+	return strings.HasPrefix(t.BaseType, "uint") && !t.IsArray && !t.IsMap && len(t.Components) == 0
+}
\ No newline at end of file
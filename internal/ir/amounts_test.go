@@ -0,0 +1,75 @@
+package ir
+
+import (
+	"testing"
+)
+
+func TestAmountDecimals(t *testing.T) {
+	tests := []struct {
+		name         string
+		paramType    ParameterType
+		decimals     int
+		fromContract bool
+		ok           bool
+	}{
+		{"Fixed decimals", ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 6}}, 6, false, true},
+		{"Decimals from JSON", ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 18.0}}, 18, false, true},
+		{"Decimals from contract", ParameterType{BaseType: "uint128", ChainData: map[string]interface{}{DecimalsKey: DecimalsFromContract}}, 0, true, true},
+		{"Not annotated", ParameterType{BaseType: "uint256"}, 0, false, false},
+		{"Too many decimals", ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 78}}, 78, false, false},
+		{"Fractional decimals", ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 1.5}}, 1, false, false},
+		{"Signed integer", ParameterType{BaseType: "int256", ChainData: map[string]interface{}{DecimalsKey: 18}}, 0, false, false},
+		{"Array", ParameterType{BaseType: "uint256", IsArray: true, ChainData: map[string]interface{}{DecimalsKey: 18}}, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decimals, fromContract, ok := tt.paramType.AmountDecimals()
+			if decimals != tt.decimals || fromContract != tt.fromContract || ok != tt.ok {
+				t.Errorf("Expected (%d, %v, %v) but got (%d, %v, %v)", tt.decimals, tt.fromContract, tt.ok, decimals, fromContract, ok)
+			}
+		})
+	}
+}
+
+func TestDetectTokenAmounts(t *testing.T) {
+	uint256 := ParameterType{BaseType: "uint256"}
+	contract := &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "decimals", StateMutability: View, Outputs: []Parameter{{Type: ParameterType{BaseType: "uint8"}}}},
+			{Name: "balanceOf", StateMutability: View, Inputs: []Parameter{{Name: "account", Type: ParameterType{BaseType: "address"}}}, Outputs: []Parameter{{Type: uint256}}},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, {Name: "amount", Type: uint256}}},
+			{Name: "nonces", StateMutability: View, Inputs: []Parameter{{Name: "owner", Type: ParameterType{BaseType: "address"}}}, Outputs: []Parameter{{Type: uint256}}},
+			{Name: "mint", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "value", Type: ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 6}}}}},
+		},
+	}
+
+	if detected := DetectTokenAmounts(contract); detected != 2 {
+		t.Errorf("Expected 2 token amounts but detected %d", detected)
+	}
+	if _, fromContract, ok := contract.Functions[1].Outputs[0].Type.AmountDecimals(); !ok || !fromContract {
+		t.Errorf("Expected the balanceOf output to be an amount")
+	}
+	if _, fromContract, ok := contract.Functions[2].Inputs[1].Type.AmountDecimals(); !ok || !fromContract {
+		t.Errorf("Expected the transfer amount to be an amount")
+	}
+	if _, _, ok := contract.Functions[3].Outputs[0].Type.AmountDecimals(); ok {
+		t.Errorf("Expected the nonces output not to be an amount")
+	}
+	if decimals, fromContract, _ := contract.Functions[4].Inputs[0].Type.AmountDecimals(); decimals != 6 || fromContract {
+		t.Errorf("Expected the annotated mint value to keep its decimals")
+	}
+	if errors := contract.Validate(); len(errors) != 0 {
+		t.Errorf("Expected no validation errors but got %v", errors)
+	}
+
+	// Without decimals() nothing is detected, and reading decimals from the contract is invalid
+	contract.Functions = contract.Functions[1:]
+	if detected := DetectTokenAmounts(&ContractIR{Functions: []Function{{Name: "transfer", Inputs: []Parameter{{Name: "amount", Type: uint256}}}}}); detected != 0 {
+		t.Errorf("Expected no token amounts without decimals() but detected %d", detected)
+	}
+	if errors := contract.Validate(); len(errors) != 2 {
+		t.Errorf("Expected 2 validation errors but got %v", errors)
+	}
+}
\ No newline at end of file
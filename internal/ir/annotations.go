@@ -0,0 +1,285 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Annotations document a contract in a file maintained next to its artifact, e.g. Token.annotations.yaml for
+// Token.json, rather than in the generated IR. They are applied when the artifact is parsed.
+// Functions are keyed by their IR name or canonical signature, as in overlays, and events by name.
+type Annotations struct {
+	// Function annotations by name or signature
+	Functions map[string]Annotation `json:"functions,omitempty"`
+
+	// Event annotations by name
+	Events map[string]Annotation `json:"events,omitempty"`
+}
+
+// Annotation documents a function or event
+type Annotation struct {
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Translated descriptions by language tag (e.g., "ja")
+	Descriptions Translations `json:"descriptions,omitempty"`
+
+	// Labels grouping the function or event
+	Tags []string `json:"tags,omitempty"`
+
+	// Example arguments by input name (functions only)
+	Examples []map[string]interface{} `json:"examples,omitempty"`
+
+	// How much harm a mistaken call can do: low, medium or high (functions only)
+	Danger DangerLevel `json:"danger,omitempty"`
+
+	// Whether the function or event is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Who may call the function (functions only)
+	Access *AccessRestriction `json:"access,omitempty"`
+
+	// Units of unsigned integer inputs and outputs by parameter name, e.g. "token:18" or "seconds" (functions only)
+	Units map[string]string `json:"units,omitempty"`
+
+	// Example values of inputs by parameter name, e.g. amount: ["1.5"] (functions only)
+	ParameterExamples map[string][]interface{} `json:"parameterExamples,omitempty"`
+
+	// Defaults of inputs by parameter name, e.g. deadline: "+1200" (functions only)
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+
+	// Bounds and documentation of the native currency sent to a payable function (functions only)
+	Value *ValueConstraint `json:"value,omitempty"`
+}
+
+// annotationExtensions are the extensions of sidecar annotation files, in lookup order
+var annotationExtensions = []string{".annotations.yaml", ".annotations.yml", ".annotations.json"}
+
+// IsAnnotationsFile reports whether a file is a sidecar annotations file rather than an artifact, e.g. Token.annotations.json
+func IsAnnotationsFile(path string) bool {
+	for _, extension := range annotationExtensions {
+		if strings.HasSuffix(path, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// SidecarAnnotationsPath returns the annotations file next to an artifact, e.g. Token.annotations.yaml for Token.json,
+// or "" if there is none
+func SidecarAnnotationsPath(artifact string) string {
+	base := strings.TrimSuffix(artifact, filepath.Ext(artifact))
+	for _, extension := range annotationExtensions {
+		if info, err := os.Stat(base + extension); err == nil && !info.IsDir() {
+			return base + extension
+		}
+	}
+	return ""
+}
+
+// LoadAnnotations reads annotations from a JSON or, by its .yaml or .yml extension, YAML file
+// Unknown keys are rejected so that typos do not go unnoticed
+func LoadAnnotations(path string) (*Annotations, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotations: %w", err)
+	}
+	if IsYAMLFile(path) {
+		if content, err = YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("failed to parse annotations %s: %w", path, err)
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	annotations := &Annotations{}
+	if err := decoder.Decode(annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations %s: %w", path, err)
+	}
+	return annotations, nil
+}
+
+// Apply sets the descriptions, tags, examples and danger levels of a contract's functions and events
+// Keys matching nothing in the contract are returned rather than failing, so annotations keep working across ABI
+// changes. Unknown danger levels, and examples naming arguments the function does not take, are errors.
+func (a *Annotations) Apply(c *ContractIR) (unmatched []string, err error) {
+	matched := map[string]bool{}
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		for _, key := range functionKeys(*f) {
+			annotation, ok := a.Functions[key]
+			if !ok {
+				continue
+			}
+			matched["functions."+key] = true
+			if err := annotation.validate(*f); err != nil {
+				return nil, fmt.Errorf("functions.%s: %w", key, err)
+			}
+			if annotation.Description != "" {
+				f.Description = annotation.Description
+			}
+			f.Descriptions = f.Descriptions.Merge(annotation.Descriptions)
+			if len(annotation.Tags) > 0 {
+				f.Tags = annotation.Tags
+			}
+			if len(annotation.Examples) > 0 {
+				f.Examples = annotation.Examples
+			}
+			if annotation.Danger != "" {
+				f.Danger = annotation.Danger
+			}
+			if annotation.Access != nil {
+				f.Access = annotation.Access
+			}
+			if annotation.Value != nil {
+				f.Value = annotation.Value
+			}
+			f.Deprecated = f.Deprecated || annotation.Deprecated
+			for j := range f.Inputs {
+				if unit, ok := annotation.Units[f.Inputs[j].Name]; ok {
+					f.Inputs[j].Unit = unit
+				}
+				if examples, ok := annotation.ParameterExamples[f.Inputs[j].Name]; ok {
+					f.Inputs[j].Examples = examples
+				}
+				if value, ok := annotation.Defaults[f.Inputs[j].Name]; ok {
+					f.Inputs[j].Default = value
+					// Relative defaults make timestamps of inputs whose unit is not detected yet
+					if isRelativeDefault(value) && f.Inputs[j].Unit == "" {
+						f.Inputs[j].Unit = UnitTimestamp
+					}
+				}
+			}
+			for j := range f.Outputs {
+				if unit, ok := annotation.Units[f.Outputs[j].Name]; ok {
+					f.Outputs[j].Unit = unit
+				}
+			}
+			break
+		}
+	}
+
+	for i := range c.Events {
+		e := &c.Events[i]
+		annotation, ok := a.Events[e.Name]
+		if !ok {
+			continue
+		}
+		matched["events."+e.Name] = true
+		if len(annotation.Examples) > 0 || annotation.Danger != "" || len(annotation.Units) > 0 || annotation.Access != nil || len(annotation.ParameterExamples) > 0 || annotation.Value != nil || len(annotation.Defaults) > 0 {
+			return nil, fmt.Errorf("events.%s: only functions have examples, danger levels, units, access restrictions, values and defaults", e.Name)
+		}
+		if annotation.Description != "" {
+			e.Description = annotation.Description
+		}
+		e.Descriptions = e.Descriptions.Merge(annotation.Descriptions)
+		if len(annotation.Tags) > 0 {
+			e.Tags = annotation.Tags
+		}
+		e.Deprecated = e.Deprecated || annotation.Deprecated
+	}
+
+	unmatched = []string{}
+	for key := range a.Functions {
+		if !matched["functions."+key] {
+			unmatched = append(unmatched, "functions."+key)
+		}
+	}
+	for key := range a.Events {
+		if !matched["events."+key] {
+			unmatched = append(unmatched, "events."+key)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched, nil
+}
+
+// validate checks an annotation against the function it documents
+func (a Annotation) validate(f Function) error {
+	switch a.Danger {
+	case "", DangerLow, DangerMedium, DangerHigh:
+	default:
+		return fmt.Errorf("invalid danger level %q (expected %s, %s or %s)", a.Danger, DangerLow, DangerMedium, DangerHigh)
+	}
+	if errs := accessErrors(a.Access); len(errs) > 0 {
+		return fmt.Errorf("access: %s", errs[0].Message)
+	}
+	if errs := valueErrors(&Function{Name: f.Name, StateMutability: f.StateMutability, Value: a.Value}); len(errs) > 0 {
+		return fmt.Errorf("value: %s", errs[0].Message)
+	}
+
+	inputs := make(map[string]bool, len(f.Inputs))
+	for _, input := range f.Inputs {
+		inputs[input.Name] = true
+	}
+	parameters := make(map[string]Parameter, len(f.Inputs)+len(f.Outputs))
+	for _, parameter := range append(append([]Parameter{}, f.Outputs...), f.Inputs...) {
+		parameters[parameter.Name] = parameter
+	}
+	names := make([]string, 0, len(a.Units))
+	for name := range a.Units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parameter, ok := parameters[name]
+		if !ok {
+			return fmt.Errorf("units: %s has no parameter named %s", f.Name, name)
+		}
+		parameter.Unit = a.Units[name]
+		if errs := unitErrors(&parameter); len(errs) > 0 {
+			return fmt.Errorf("units.%s: %s", name, errs[0].Message)
+		}
+	}
+	names = names[:0]
+	for name := range a.ParameterExamples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !inputs[name] {
+			return fmt.Errorf("parameterExamples: %s takes no argument named %s", f.Name, name)
+		}
+	}
+	names = names[:0]
+	for name := range a.Defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parameter, ok := parameters[name]
+		if !ok || !inputs[name] {
+			return fmt.Errorf("defaults: %s takes no argument named %s", f.Name, name)
+		}
+		if unit, ok := a.Units[name]; ok {
+			parameter.Unit = unit
+		}
+		parameter.Default = a.Defaults[name]
+		if isRelativeDefault(parameter.Default) && parameter.Unit == "" {
+			parameter.Unit = UnitTimestamp
+			if errs := unitErrors(&parameter); len(errs) > 0 {
+				return fmt.Errorf("defaults.%s: %s", name, errs[0].Message)
+			}
+		}
+		if errs := defaultErrors(&parameter); len(errs) > 0 {
+			return fmt.Errorf("defaults.%s: %s", name, errs[0].Message)
+		}
+	}
+	for i, example := range a.Examples {
+		names := make([]string, 0, len(example))
+		for name := range example {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !inputs[name] {
+				return fmt.Errorf("examples[%d]: %s takes no argument named %s", i, f.Name, name)
+			}
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package ir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnnotationsApply(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Token.annotations.yaml")
+	content := `functions:
+  mint(address,uint256):
+    description: Creates tokens
+    descriptions:
+      ja: トークンを発行する
+    tags: [supply]
+    danger: medium
+    units:
+      amount: token:6
+    parameterExamples:
+      amount: ["1.5"]
+    defaults:
+      amount: "1"
+    examples:
+      - to: "0x0000000000000000000000000000000000000002"
+        amount: "1000"
+  burn:
+    tags: [supply]
+events:
+  Transfer:
+    description: Tokens moved
+    tags: [transfers]
+  Paused:
+    tags: [admin]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	annotations, err := LoadAnnotations(path)
+	if err != nil {
+		t.Fatalf("Failed to load annotations: %v", err)
+	}
+
+	c := overlayContract()
+	unmatched, err := annotations.Apply(c)
+	if err != nil {
+		t.Fatalf("Failed to apply annotations: %v", err)
+	}
+	if expected := []string{"events.Paused", "functions.burn"}; !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("Expected unmatched %v but got %v", expected, unmatched)
+	}
+
+	mint := c.Functions[1]
+	if mint.Description != "Creates tokens" || mint.Danger != DangerMedium || !reflect.DeepEqual(mint.Tags, []string{"supply"}) {
+		t.Errorf("Unexpected annotated function %+v", mint)
+	}
+	if !reflect.DeepEqual(mint.Descriptions, Translations{"ja": "トークンを発行する"}) {
+		t.Errorf("Unexpected translated descriptions %v", mint.Descriptions)
+	}
+	if len(mint.Examples) != 1 || mint.Examples[0]["amount"] != "1000" {
+		t.Errorf("Unexpected examples %v", mint.Examples)
+	}
+	if mint.Inputs[1].Unit != "token:6" || mint.Inputs[0].Unit != "" {
+		t.Errorf("Unexpected units %+v", mint.Inputs)
+	}
+	if !reflect.DeepEqual(mint.Inputs[1].Examples, []interface{}{"1.5"}) || mint.Inputs[0].Examples != nil {
+		t.Errorf("Unexpected parameter examples %+v", mint.Inputs)
+	}
+	if mint.Inputs[1].Default != "1" || mint.Inputs[0].Default != nil {
+		t.Errorf("Unexpected defaults %+v", mint.Inputs)
+	}
+	if c.Functions[2].Tags != nil || c.Functions[2].Danger != "" {
+		t.Errorf("Expected the mint overload to be left alone but got %+v", c.Functions[2])
+	}
+	if c.Events[0].Description != "Tokens moved" || !reflect.DeepEqual(c.Events[0].Tags, []string{"transfers"}) {
+		t.Errorf("Unexpected annotated event %+v", c.Events[0])
+	}
+}
+
+func TestAnnotationsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations Annotations
+		expected    string
+	}{
+		{"Danger", Annotations{Functions: map[string]Annotation{"mint": {Danger: "extreme"}}}, `functions.mint: invalid danger level "extreme"`},
+		{"Example", Annotations{Functions: map[string]Annotation{"mint": {Examples: []map[string]interface{}{{"recipient": "0x"}}}}}, "examples[0]: mint takes no argument named recipient"},
+		{"Unit", Annotations{Functions: map[string]Annotation{"mint": {Units: map[string]string{"amount": "ether"}}}}, `units.amount: unknown unit "ether"`},
+		{"Parameter example", Annotations{Functions: map[string]Annotation{"mint": {ParameterExamples: map[string][]interface{}{"recipient": {"0x"}}}}}, "parameterExamples: mint takes no argument named recipient"},
+		{"Unit parameter", Annotations{Functions: map[string]Annotation{"mint": {Units: map[string]string{"to": UnitSeconds}}}}, "units.to: units apply to single unsigned integers"},
+		{"Access", Annotations{Functions: map[string]Annotation{"mint": {Access: &AccessRestriction{Roles: []string{" "}}}}}, "functions.mint: access: role name is required"},
+		{"Default", Annotations{Functions: map[string]Annotation{"mint": {Defaults: map[string]interface{}{"to": true}}}}, "defaults.to: default of an address"},
+		{"Default parameter", Annotations{Functions: map[string]Annotation{"mint": {Defaults: map[string]interface{}{"deadline": "+60"}}}}, "defaults: mint takes no argument named deadline"},
+		{"Value", Annotations{Functions: map[string]Annotation{"mint": {Value: &ValueConstraint{Min: "0.01"}}}}, "functions.mint: value: only payable functions receive a value"},
+		{"Event", Annotations{Events: map[string]Annotation{"Transfer": {Danger: DangerLow}}}, "events.Transfer: only functions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.annotations.Apply(overlayContract())
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q but got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSidecarAnnotationsPath(t *testing.T) {
+	dir := t.TempDir()
+	artifact := filepath.Join(dir, "Token.json")
+	if path := SidecarAnnotationsPath(artifact); path != "" {
+		t.Errorf("Expected no sidecar but got %s", path)
+	}
+	sidecar := filepath.Join(dir, "Token.annotations.yml")
+	if err := os.WriteFile(sidecar, []byte("functions: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := SidecarAnnotationsPath(artifact); path != sidecar {
+		t.Errorf("Expected %s but got %s", sidecar, path)
+	}
+}
+
+func TestLoadAnnotationsUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Token.annotations.json")
+	if err := os.WriteFile(path, []byte(`{"functions": {"transfer": {"danger_level": "high"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnnotations(path); err == nil || !strings.Contains(err.Error(), "danger_level") {
+		t.Errorf("Expected an unknown field error but got %v", err)
+	}
+}
\ No newline at end of file
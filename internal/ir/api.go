@@ -0,0 +1,129 @@
+package ir
+
+// Chains of IRs imported from API descriptions rather than contract artifacts
+const (
+	// ChainOpenAPI IRs describe the operations of an HTTP API, imported from an OpenAPI document
+	ChainOpenAPI = "openapi"
+
+	// ChainOpenRPC IRs describe the methods of a JSON-RPC API, imported from an OpenRPC document
+	ChainOpenRPC = "openrpc"
+)
+
+// Chain data keys of IRs imported from API descriptions
+const (
+	// BaseURLKey holds the URL of the API server in the metadata's chain data
+	BaseURLKey = "baseUrl"
+
+	// HTTPMethodKey holds the HTTP method of an operation in a function's chain data
+	HTTPMethodKey = "httpMethod"
+
+	// HTTPPathKey holds the path template of an operation (e.g., "/pets/{petId}") in a function's chain data
+	HTTPPathKey = "httpPath"
+
+	// RPCMethodKey holds the JSON-RPC method name of a function in its chain data
+	RPCMethodKey = "rpcMethod"
+
+	// ParamsByPositionKey marks JSON-RPC methods taking their parameters as an array rather than an object
+	ParamsByPositionKey = "paramsByPosition"
+
+	// ParameterInKey holds where an operation's input is sent in its type's chain data: in the path, query or
+	// header, as a field of the JSON request body (body), or as the whole body (requestBody)
+	ParameterInKey = "in"
+
+	// OptionalKey marks inputs and object fields that may be left out, in their type's chain data
+	OptionalKey = "optional"
+)
+
+// Base types of API IRs, named after the JSON Schema types
+const (
+	APIString  = "string"
+	APIBoolean = "boolean"
+	APIInteger = "integer"
+	APINumber  = "number"
+	APIObject  = "object"
+
+	// APIAny is a value of any JSON type
+	APIAny = "any"
+)
+
+// IsAPIChain reports whether an IR describes an off-chain API rather than a contract
+func IsAPIChain(chain string) bool {
+	return chain == ChainOpenAPI || chain == ChainOpenRPC
+}
+
+// APIServer is the chain data of the metadata of an IR imported from an API description
+type APIServer struct {
+	// URL of the API server, if the description lists one
+	BaseURL string
+}
+
+// APIOperation is the chain data of a function imported from an API description
+type APIOperation struct {
+	// HTTP method and path template of OpenAPI operations
+	HTTPMethod string
+	HTTPPath   string
+
+	// JSON-RPC method name of OpenRPC methods
+	RPCMethod string
+
+	// Whether an OpenRPC method takes its parameters as an array rather than an object
+	ParamsByPosition bool
+}
+
+// APIParameter is the chain data of the type of an input or object field imported from an API description
+type APIParameter struct {
+	// Where an OpenAPI input is sent: path, query, header, body or requestBody
+	In string
+
+	// Whether the input or field may be left out
+	Optional bool
+}
+
+// API returns the API chain data of a contract's metadata
+func (m ContractMetadata) API() APIServer {
+	return APIServer{BaseURL: chainString(m.ChainData, BaseURLKey)}
+}
+
+// ChainData returns the chain data map holding the server data, or nil if nothing is set
+func (d APIServer) ChainData() map[string]interface{} {
+	if d.BaseURL == "" {
+		return nil
+	}
+	return map[string]interface{}{BaseURLKey: d.BaseURL}
+}
+
+// API returns the API chain data of a function
+func (f Function) API() APIOperation {
+	return APIOperation{
+		HTTPMethod:       chainString(f.ChainData, HTTPMethodKey),
+		HTTPPath:         chainString(f.ChainData, HTTPPathKey),
+		RPCMethod:        chainString(f.ChainData, RPCMethodKey),
+		ParamsByPosition: chainBool(f.ChainData, ParamsByPositionKey),
+	}
+}
+
+// ChainData returns the chain data map holding the operation data, without unset entries
+func (d APIOperation) ChainData() map[string]interface{} {
+	data := map[string]interface{}{}
+	setChainString(data, HTTPMethodKey, d.HTTPMethod)
+	setChainString(data, HTTPPathKey, d.HTTPPath)
+	setChainString(data, RPCMethodKey, d.RPCMethod)
+	setChainBool(data, ParamsByPositionKey, d.ParamsByPosition)
+	return data
+}
+
+// API returns the API chain data of a parameter type
+func (t ParameterType) API() APIParameter {
+	return APIParameter{In: chainString(t.ChainData, ParameterInKey), Optional: chainBool(t.ChainData, OptionalKey)}
+}
+
+// IsAPIOperation reports whether a function is an operation or method of an off-chain API
+func (f Function) IsAPIOperation() bool {
+	d := f.API()
+	return d.HTTPMethod != "" || d.RPCMethod != ""
+}
+
+// IsOptional reports whether an input or object field may be left out
+func (t ParameterType) IsOptional() bool {
+	return t.API().Optional
+}
\ No newline at end of file
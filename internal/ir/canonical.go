@@ -0,0 +1,50 @@
+package ir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// HashPrefix is the algorithm prefix of content hashes
+const HashPrefix = "sha256:"
+
+// Canonicalize sorts a contract's functions, events, errors and custom types by name, and functions
+// sharing a name by signature, so that IRs describing the same contract list their members in the same order.
+// Parameters keep their order, which is part of the ABI.
+func Canonicalize(c *ContractIR) {
+	sort.SliceStable(c.Functions, func(i, j int) bool {
+		if c.Functions[i].Name != c.Functions[j].Name {
+			return c.Functions[i].Name < c.Functions[j].Name
+		}
+		return c.Functions[i].Signature < c.Functions[j].Signature
+	})
+	sort.SliceStable(c.Events, func(i, j int) bool {
+		if c.Events[i].Name != c.Events[j].Name {
+			return c.Events[i].Name < c.Events[j].Name
+		}
+		return c.Events[i].Signature < c.Events[j].Signature
+	})
+	sort.SliceStable(c.Errors, func(i, j int) bool { return c.Errors[i].Name < c.Errors[j].Name })
+	sort.SliceStable(c.Types, func(i, j int) bool { return c.Types[i].Name < c.Types[j].Name })
+}
+
+// ContentHash returns a stable hash of a contract's content, e.g. "sha256:3f2a...", for cache keys and change
+// detection. It is the SHA-256 of the canonical JSON encoding of the contract, so member order does not change it,
+// and the contract itself is left unchanged.
+func ContentHash(c *ContractIR) (string, error) {
+	// A JSON round trip copies the contract and normalizes chain data numbers
+	canonical, err := copyContract(c)
+	if err != nil {
+		return "", err
+	}
+	Canonicalize(canonical)
+	content, err := json.Marshal(canonical)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode IR: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return HashPrefix + hex.EncodeToString(sum[:]), nil
+}
\ No newline at end of file
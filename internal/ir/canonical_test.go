@@ -0,0 +1,66 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	c := &ContractIR{
+		Functions: []Function{
+			{Name: "transfer", Signature: "transfer(address,uint256)"},
+			{Name: "mint", Signature: "mint(uint256)"},
+			{Name: "mint", Signature: "mint(address,uint256)"},
+		},
+		Events: []Event{{Name: "Transfer"}, {Name: "Approval"}},
+		Errors: []ContractError{{Name: "Paused"}, {Name: "Insufficient"}},
+	}
+	Canonicalize(c)
+
+	var order []string
+	for _, f := range c.Functions {
+		order = append(order, f.Signature)
+	}
+	if got := strings.Join(order, " "); got != "mint(address,uint256) mint(uint256) transfer(address,uint256)" {
+		t.Errorf("Unexpected function order %s", got)
+	}
+	if c.Events[0].Name != "Approval" || c.Errors[0].Name != "Insufficient" {
+		t.Errorf("Unexpected event and error order %+v %+v", c.Events, c.Errors)
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	contract := func() *ContractIR {
+		return &ContractIR{
+			Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+			Functions: []Function{
+				{Name: "balanceOf", StateMutability: View, Outputs: []Parameter{{Name: "balance", Type: ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 18}}}}},
+				{Name: "transfer", StateMutability: Nonpayable},
+			},
+		}
+	}
+
+	original := contract()
+	hash, err := ContentHash(original)
+	if err != nil {
+		t.Fatalf("Failed to hash the IR: %v", err)
+	}
+	if !strings.HasPrefix(hash, HashPrefix) || len(hash) != len(HashPrefix)+64 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if original.Functions[0].Name != "balanceOf" || original.Functions[1].Name != "transfer" {
+		t.Errorf("Hashing reordered the contract")
+	}
+
+	reordered := contract()
+	reordered.Functions[0], reordered.Functions[1] = reordered.Functions[1], reordered.Functions[0]
+	if other, _ := ContentHash(reordered); other != hash {
+		t.Errorf("Expected member order not to change the hash but got %s and %s", hash, other)
+	}
+
+	changed := contract()
+	changed.Functions[1].Description = "Moves tokens"
+	if other, _ := ContentHash(changed); other == hash {
+		t.Errorf("Expected a changed description to change the hash")
+	}
+}
\ No newline at end of file
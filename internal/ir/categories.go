@@ -0,0 +1,140 @@
+package ir
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Categories of functions, which generated servers group their tools by
+const (
+	// CategoryRead holds functions that only read state
+	CategoryRead = "read"
+
+	// CategoryTransfers holds functions moving or approving tokens
+	CategoryTransfers = "transfers"
+
+	// CategoryLiquidity holds functions depositing, withdrawing, staking or swapping funds
+	CategoryLiquidity = "liquidity"
+
+	// CategoryGovernance holds functions voting, proposing or delegating
+	CategoryGovernance = "governance"
+
+	// CategoryAdmin holds privileged functions: ownership, roles, pausing, upgrades and settings
+	CategoryAdmin = "admin"
+
+	// CategoryWrite holds the remaining functions that modify state
+	CategoryWrite = "write"
+)
+
+// categoryPattern matches category names
+var categoryPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// categoryOrder is the order of known categories; other categories follow in alphabetical order
+var categoryOrder = []string{CategoryRead, CategoryTransfers, CategoryLiquidity, CategoryGovernance, CategoryAdmin, CategoryWrite}
+
+// categoryNames match the names of write functions in each category, checked in order
+var categoryNames = []struct {
+	category string
+	names    *regexp.Regexp
+}{
+	{CategoryAdmin, regexp.MustCompile(`(?i)^(transferOwnership|renounceOwnership|acceptOwnership|grantRole|revokeRole|renounceRole|pause|unpause|upgrade|set[A-Z_]|initialize)`)},
+	{CategoryTransfers, regexp.MustCompile(`(?i)^(safe)?(transfer|approve|increaseAllowance|decreaseAllowance|permit|setApprovalForAll|mint|burn)`)},
+	{CategoryLiquidity, regexp.MustCompile(`(?i)(liquidity|deposit|withdraw|stake|redeem|swap|borrow|repay|claim|harvest)`)},
+	{CategoryGovernance, regexp.MustCompile(`(?i)^(castVote|vote|propose|queue|execute|cancel|delegate)`)},
+}
+
+// CategoryOrDefault returns a function's category, or the category it is put in by default:
+// read for view and pure functions, write for the others
+func (f Function) CategoryOrDefault() string {
+	switch {
+	case f.Category != "":
+		return f.Category
+	case f.StateMutability == View || f.StateMutability == Pure:
+		return CategoryRead
+	}
+	return CategoryWrite
+}
+
+// Categorize sets the categories of the functions that have none: read for view and pure functions, admin for
+// access-restricted functions and functions named like ownership, role, pause, upgrade and setter functions,
+// and transfers, liquidity or governance for functions named like those. It returns the number of functions
+// given a category other than the default.
+func Categorize(c *ContractIR) int {
+	categorized := 0
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		if f.Category != "" {
+			continue
+		}
+		f.Category = f.CategoryOrDefault()
+		if f.Category == CategoryRead {
+			continue
+		}
+		if !f.Access.IsEmpty() {
+			f.Category = CategoryAdmin
+		} else {
+			for _, candidate := range categoryNames {
+				if candidate.names.MatchString(f.ABIName()) {
+					f.Category = candidate.category
+					break
+				}
+			}
+		}
+		if f.Category != CategoryWrite {
+			categorized++
+		}
+	}
+	return categorized
+}
+
+// FunctionGroup is the functions of one category
+type FunctionGroup struct {
+	// Category of the functions
+	Category string
+
+	// Functions in the category, in contract order
+	Functions []Function
+}
+
+// GroupByCategory groups functions by category: known categories first in a fixed order (read, transfers,
+// liquidity, governance, admin, write), then other categories in alphabetical order
+func GroupByCategory(functions []Function) []FunctionGroup {
+	index := map[string]int{}
+	var groups []FunctionGroup
+	for _, f := range functions {
+		category := f.CategoryOrDefault()
+		i, ok := index[category]
+		if !ok {
+			i = len(groups)
+			index[category] = i
+			groups = append(groups, FunctionGroup{Category: category})
+		}
+		groups[i].Functions = append(groups[i].Functions, f)
+	}
+
+	rank := func(category string) int {
+		for i, known := range categoryOrder {
+			if category == known {
+				return i
+			}
+		}
+		return len(categoryOrder)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		ri, rj := rank(groups[i].Category), rank(groups[j].Category)
+		if ri != rj {
+			return ri < rj
+		}
+		return groups[i].Category < groups[j].Category
+	})
+	return groups
+}
+
+// categoryErrors reports category names that are not lowercase words
+func categoryErrors(category string) []ValidationError {
+	if category == "" || categoryPattern.MatchString(category) {
+		return nil
+	}
+	return []ValidationError{{Code: CodeInvalidValue, Field: "Category", Message: fmt.Sprintf("invalid category %q (expected lowercase letters, digits and dashes)", category)}}
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	contract := &ContractIR{
+		Functions: []Function{
+			{Name: "balanceOf", StateMutability: View},
+			{Name: "safeTransferFrom", StateMutability: Nonpayable},
+			{Name: "addLiquidity", StateMutability: Payable},
+			{Name: "castVote", StateMutability: Nonpayable},
+			{Name: "setFee", StateMutability: Nonpayable},
+			{Name: "rescue", StateMutability: Nonpayable, Access: &AccessRestriction{OnlyOwner: true}},
+			{Name: "poke", StateMutability: Nonpayable},
+			{Name: "sync", StateMutability: Nonpayable, Category: "maintenance"},
+		},
+	}
+
+	if categorized := Categorize(contract); categorized != 5 {
+		t.Errorf("Expected 5 categorized functions but got %d", categorized)
+	}
+	expected := []string{CategoryRead, CategoryTransfers, CategoryLiquidity, CategoryGovernance, CategoryAdmin, CategoryAdmin, CategoryWrite, "maintenance"}
+	for i, f := range contract.Functions {
+		if f.Category != expected[i] {
+			t.Errorf("Expected %s to be in %s but got %s", f.Name, expected[i], f.Category)
+		}
+	}
+}
+
+func TestGroupByCategory(t *testing.T) {
+	functions := []Function{
+		{Name: "poke", StateMutability: Nonpayable},
+		{Name: "sync", StateMutability: Nonpayable, Category: "maintenance"},
+		{Name: "setFee", StateMutability: Nonpayable, Category: CategoryAdmin},
+		{Name: "balanceOf", StateMutability: View},
+		{Name: "archive", StateMutability: Nonpayable, Category: "cleanup"},
+		{Name: "totalSupply", StateMutability: View},
+	}
+
+	var got [][]string
+	for _, group := range GroupByCategory(functions) {
+		names := []string{group.Category}
+		for _, f := range group.Functions {
+			names = append(names, f.Name)
+		}
+		got = append(got, names)
+	}
+	expected := [][]string{
+		{CategoryRead, "balanceOf", "totalSupply"},
+		{CategoryAdmin, "setFee"},
+		{CategoryWrite, "poke"},
+		{"cleanup", "archive"},
+		{"maintenance", "sync"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected groups %v but got %v", expected, got)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+package ir
+
+// Chain data is free-form, so IR files may be hand-written or decoded from JSON, where every number is a float64.
+// The typed accessors (Function.EVM, Function.API, ...) read entries of the wrong type as unset rather than failing,
+// and their ChainData methods write only the entries that are set, so round trips keep chain data unchanged.
+
+// EnumValuesKey holds the values a parameter type is restricted to, in its chain data
+const EnumValuesKey = "enumValues"
+
+// EnumValues returns the values a parameter type is restricted to, if any
+func (t ParameterType) EnumValues() []interface{} {
+	switch values := t.ChainData[EnumValuesKey].(type) {
+	case []interface{}:
+		return values
+	case []string:
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = value
+		}
+		return result
+	}
+	return nil
+}
+
+// chainString returns a string entry of chain data, or "" if it is unset or not a string
+func chainString(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+// chainBool returns a boolean entry of chain data, or false if it is unset or not a boolean
+func chainBool(data map[string]interface{}, key string) bool {
+	value, _ := data[key].(bool)
+	return value
+}
+
+// chainInt returns an integer entry of chain data, as parsed or decoded from JSON, or 0 if it is unset or not a number
+func chainInt(data map[string]interface{}, key string) int {
+	switch value := data[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case uint64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
+// setChainString sets a string entry of chain data, unless it is empty
+func setChainString(data map[string]interface{}, key, value string) {
+	if value != "" {
+		data[key] = value
+	}
+}
+
+// setChainBool sets a boolean entry of chain data, unless it is false
+func setChainBool(data map[string]interface{}, key string, value bool) {
+	if value {
+		data[key] = value
+	}
+}
\ No newline at end of file
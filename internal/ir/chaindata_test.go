@@ -0,0 +1,68 @@
+package ir
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEVMChainData(t *testing.T) {
+	f := Function{Name: "transfer_1", ChainData: EVMFunction{OriginalName: "transfer", OriginalSignature: "transfer(address,uint256,bytes)"}.ChainData()}
+	if expected := map[string]interface{}{OriginalNameKey: "transfer", OriginalSignatureKey: "transfer(address,uint256,bytes)"}; !reflect.DeepEqual(f.ChainData, expected) {
+		t.Errorf("Expected chain data %v but got %v", expected, f.ChainData)
+	}
+	if data := f.EVM(); data.OriginalName != "transfer" || data.Constant || data.Payable {
+		t.Errorf("Unexpected function data %+v", data)
+	}
+	if f.ABIName() != "transfer" {
+		t.Errorf("Expected ABI name transfer but got %s", f.ABIName())
+	}
+
+	// Numbers of IR files are decoded as float64
+	var e Event
+	if err := json.Unmarshal([]byte(`{"name": "Transfer", "chainData": {"anonymous": true, "indexedCount": 2}}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (EVMEvent{Anonymous: true, IndexedCount: 2}); !reflect.DeepEqual(e.EVM(), expected) {
+		t.Errorf("Expected event data %+v but got %+v", expected, e.EVM())
+	}
+
+	// Entries of the wrong type read as unset
+	if data := (Event{ChainData: map[string]interface{}{AnonymousKey: "yes"}}).EVM(); data.Anonymous {
+		t.Error("Expected a non-boolean anonymous entry to read as false")
+	}
+
+	if data := (EVMType{}).ChainData(); data != nil {
+		t.Errorf("Expected no chain data for an empty type but got %v", data)
+	}
+	array := ParameterType{BaseType: "uint256", IsArray: true, ArraySize: 3, ChainData: EVMType{IsArray: true, IsFixedArray: true, ArraySize: 3}.ChainData()}
+	if data := array.EVM(); !data.IsFixedArray || data.ArraySize != 3 || data.IsDynamicArray {
+		t.Errorf("Unexpected type data %+v", data)
+	}
+}
+
+func TestAPIChainData(t *testing.T) {
+	f := Function{ChainData: APIOperation{HTTPMethod: "GET", HTTPPath: "/pets"}.ChainData()}
+	if expected := (APIOperation{HTTPMethod: "GET", HTTPPath: "/pets"}); f.API() != expected {
+		t.Errorf("Expected operation data %+v but got %+v", expected, f.API())
+	}
+	if !f.IsAPIOperation() {
+		t.Error("Expected an API operation")
+	}
+	if (Function{ChainData: EVMFunction{Payable: true}.ChainData()}).IsAPIOperation() {
+		t.Error("Expected an EVM function not to be an API operation")
+	}
+
+	m := ContractMetadata{ChainData: APIServer{BaseURL: "https://api.example.com"}.ChainData()}
+	if m.API().BaseURL != "https://api.example.com" {
+		t.Errorf("Unexpected server data %+v", m.API())
+	}
+
+	input := ParameterType{BaseType: APIString, ChainData: map[string]interface{}{ParameterInKey: "query", OptionalKey: true, EnumValuesKey: []string{"asc", "desc"}}}
+	if expected := (APIParameter{In: "query", Optional: true}); input.API() != expected || !input.IsOptional() {
+		t.Errorf("Expected parameter data %+v but got %+v", expected, input.API())
+	}
+	if expected := []interface{}{"asc", "desc"}; !reflect.DeepEqual(input.EnumValues(), expected) {
+		t.Errorf("Expected enum values %v but got %v", expected, input.EnumValues())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,76 @@
+package ir
+
+import "strings"
+
+// Collisions are the naming conflicts of the tools of a contract's server
+type Collisions struct {
+	// Tool names that map to the same identifier in generated code
+	Tools []NameCollision `json:"tools"`
+
+	// Overloads whose name by the overload naming policy was taken by another function, named with a numeric
+	// suffix instead
+	Suffixed []SuffixedOverload `json:"suffixed"`
+}
+
+// SuffixedOverload is an overload named with a numeric suffix because its name was taken
+type SuffixedOverload struct {
+	// Canonical signature of the overload, e.g. "transfer(address,uint256)"
+	Signature string `json:"signature"`
+
+	// Name the naming policy gave the overload, taken by another function
+	Taken string `json:"taken"`
+
+	// Name the overload got instead, e.g. "transfer_1_1"
+	Name string `json:"name"`
+}
+
+// Empty reports whether a contract's tools have no naming conflicts
+func (c *Collisions) Empty() bool {
+	return len(c.Tools) == 0 && len(c.Suffixed) == 0
+}
+
+// FindCollisions returns the naming conflicts of the tools of a contract's visible functions and events, as the
+// TypeScript generator names them with or without write tools
+// Overloads keep being reported as suffixed until they are renamed, e.g. by an overlay.
+func FindCollisions(c *ContractIR, writes bool) *Collisions {
+	collisions := &Collisions{Tools: nameCollisions(tools(c, writes)), Suffixed: []SuffixedOverload{}}
+	for _, f := range c.Functions {
+		if f.Hidden || f.Overload == nil || f.Overload.suffixed == "" || f.Name != f.Overload.suffixed {
+			continue
+		}
+		collisions.Suffixed = append(collisions.Suffixed, SuffixedOverload{Signature: f.Overload.Signature, Taken: f.Overload.taken, Name: f.Name})
+	}
+	return collisions
+}
+
+// nameCollisions groups tools whose names differ only in case, which get the same enum key, and duplicates
+func nameCollisions(tools []Tool) []NameCollision {
+	groups := map[string]*NameCollision{}
+	var keys []string
+	for _, t := range tools {
+		key := strings.ToUpper(t.Name)
+		group, ok := groups[key]
+		if !ok {
+			group = &NameCollision{Key: key}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.Names = append(group.Names, t.Name)
+		if t.Function != "" {
+			group.Functions = append(group.Functions, t.Function)
+		}
+	}
+	collisions := []NameCollision{}
+	for _, key := range keys {
+		if len(groups[key].Names) > 1 {
+			collisions = append(collisions, *groups[key])
+		}
+	}
+	return collisions
+}
+
+// tools returns the tools of a contract's server, as named by Tools
+func tools(c *ContractIR, writes bool) []Tool {
+	tools, _ := Tools(c, writes)
+	return tools
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindCollisionsSuffixed(t *testing.T) {
+	c := overloadedContract()
+	MarkOverloads(c)
+	if err := NameOverloads(c, OverloadTypes); err != nil {
+		t.Fatal(err)
+	}
+
+	collisions := FindCollisions(c, true)
+	expected := []SuffixedOverload{{Signature: "transfer(address,uint256)", Taken: "transferAddressUint256", Name: "transferAddressUint256_1"}}
+	if !reflect.DeepEqual(collisions.Suffixed, expected) || len(collisions.Tools) != 0 {
+		t.Errorf("Expected the suffixed overload but got %+v", collisions)
+	}
+
+	// Renaming the overload, as overlays do, resolves it
+	c.Functions[1].Name = "transferTo"
+	if collisions := FindCollisions(c, true); !collisions.Empty() {
+		t.Errorf("Expected no collisions after renaming but got %+v", collisions)
+	}
+
+	// Naming again by a policy giving free names forgets the fallback
+	c = overloadedContract()
+	MarkOverloads(c)
+	NameOverloads(c, OverloadTypes)
+	NameOverloads(c, OverloadSuffix)
+	if collisions := FindCollisions(c, true); !collisions.Empty() {
+		t.Errorf("Expected no collisions with suffix naming but got %+v", collisions)
+	}
+}
+
+func TestFindCollisionsTools(t *testing.T) {
+	c := &ContractIR{
+		Metadata: ContractMetadata{Name: "Pool", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "deposit", StateMutability: Nonpayable},
+			{Name: "simulateDeposit", StateMutability: View},
+			{Name: "Owner", StateMutability: View},
+			{Name: "owner", StateMutability: View},
+		},
+	}
+	collisions := FindCollisions(c, true)
+	expected := []NameCollision{
+		{Key: "SIMULATEDEPOSIT", Names: []string{"simulateDeposit", "simulateDeposit"}, Functions: []string{"deposit", "simulateDeposit"}},
+		{Key: "OWNER", Names: []string{"Owner", "owner"}, Functions: []string{"Owner", "owner"}},
+	}
+	if !reflect.DeepEqual(collisions.Tools, expected) {
+		t.Errorf("Expected collisions %+v but got %+v", expected, collisions.Tools)
+	}
+
+	// Without writes there is no simulate tool, and hidden functions have no tools
+	c.Functions[3].Hidden = true
+	if collisions := FindCollisions(c, false); !collisions.Empty() {
+		t.Errorf("Expected no collisions but got %+v", collisions)
+	}
+}
\ No newline at end of file
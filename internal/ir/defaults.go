@@ -0,0 +1,156 @@
+package ir
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// relativeDefaultPattern matches relative timestamp defaults: a number of seconds after the time of the call, e.g. "+1200"
+var relativeDefaultPattern = regexp.MustCompile(`^\+[0-9]+$`)
+
+var (
+	integerDefaultPattern = regexp.MustCompile(`^(-?[0-9]+|0x[0-9a-fA-F]+)$`)
+	addressDefaultPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	bytesDefaultPattern   = regexp.MustCompile(`^0x([0-9a-fA-F]{2})*$`)
+)
+
+// isRelativeDefault reports whether a default is relative to the time of the call, e.g. "+1200"
+func isRelativeDefault(value interface{}) bool {
+	text, ok := value.(string)
+	return ok && relativeDefaultPattern.MatchString(text)
+}
+
+// IsOptional reports whether a tool may be called without the parameter: it has a default, or is an optional API input
+func (p Parameter) IsOptional() bool {
+	return p.Default != nil || p.Type.IsOptional()
+}
+
+// RelativeDefault returns the number of seconds after the time of the call a timestamp parameter defaults to, e.g.
+// 1200 for a deadline defaulting to "+1200", and whether its default is relative
+func (p Parameter) RelativeDefault() (int64, bool) {
+	if p.Unit != UnitTimestamp || !isRelativeDefault(p.Default) {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(p.Default.(string)[1:], 10, 64)
+	return seconds, err == nil
+}
+
+// DefaultDescription describes the default of a parameter, e.g. `"latest"` or "1200 seconds from now", or returns ""
+// if it has none
+func (p Parameter) DefaultDescription() string {
+	if p.Default == nil {
+		return ""
+	}
+	if seconds, ok := p.RelativeDefault(); ok {
+		return fmt.Sprintf("%d seconds from now", seconds)
+	}
+	if text, ok := p.Default.(string); ok {
+		return strconv.Quote(text)
+	}
+	return fmt.Sprint(p.Default)
+}
+
+// defaultErrors reports defaults that the parameter's tools would reject: values of another type, relative defaults
+// of parameters that are not timestamps, and values outside the parameter's enum
+func defaultErrors(p *Parameter) []ValidationError {
+	if p.Default == nil {
+		return nil
+	}
+	invalid := func(message string, args ...interface{}) []ValidationError {
+		return []ValidationError{{Code: CodeInvalidDefault, Field: "Default", Message: fmt.Sprintf(message, args...)}}
+	}
+
+	if text, ok := p.Default.(string); ok && strings.HasPrefix(text, "+") {
+		if _, relative := p.RelativeDefault(); !relative {
+			return invalid("relative default %q requires a %s parameter and a number of seconds, e.g. +1200", text, UnitTimestamp)
+		}
+		return nil
+	}
+	if p.Type.IsArray {
+		if _, ok := p.Default.([]interface{}); !ok {
+			return invalid("default of an array must be an array")
+		}
+		return nil
+	}
+	if len(p.Type.Components) > 0 {
+		if _, ok := p.Default.(map[string]interface{}); !ok {
+			return invalid("default of a tuple must be an object")
+		}
+		return nil
+	}
+	if values := p.Type.EnumValues(); len(values) > 0 {
+		for _, value := range values {
+			if fmt.Sprint(value) == fmt.Sprint(p.Default) {
+				return nil
+			}
+		}
+		return invalid("default %v is not one of the allowed values", p.Default)
+	}
+
+	switch base := p.Type.BaseType; {
+	case base == "bool" || base == APIBoolean:
+		if _, ok := p.Default.(bool); !ok {
+			return invalid("default of a %s must be true or false", base)
+		}
+	case base == "string":
+		if _, ok := p.Default.(string); !ok {
+			return invalid("default of a string must be a string")
+		}
+	case base == "address":
+		if text, ok := p.Default.(string); !ok || !addressDefaultPattern.MatchString(text) {
+			return invalid("default of an address must be a 0x-prefixed 20-byte hex address")
+		}
+	case strings.HasPrefix(base, "bytes"):
+		if text, ok := p.Default.(string); !ok || !bytesDefaultPattern.MatchString(text) {
+			return invalid("default of %s must be 0x-prefixed hex", base)
+		}
+	case strings.HasPrefix(base, "uint") || strings.HasPrefix(base, "int") || base == APIInteger:
+		if _, _, amount := p.Type.AmountDecimals(); amount || isDecimalUnit(p.Unit) {
+			if !isAmountDefault(p.Default) {
+				return invalid("default of an amount must be a decimal number, e.g. \"1.5\"")
+			}
+		} else if !isIntegerDefault(p.Default) {
+			return invalid("default of %s must be an integer", base)
+		}
+	case base == APINumber:
+		if _, ok := p.Default.(float64); !ok {
+			return invalid("default of a number must be a number")
+		}
+	}
+	return nil
+}
+
+// isDecimalUnit reports whether values of a unit are given in whole units, e.g. ether for wei
+func isDecimalUnit(unit string) bool {
+	_, _, ok := UnitDecimals(unit)
+	return ok
+}
+
+// isIntegerDefault reports whether a default is an integer, given as a JSON number or a decimal or hex string
+func isIntegerDefault(value interface{}) bool {
+	switch value := value.(type) {
+	case float64:
+		return value == math.Trunc(value)
+	case int, int64:
+		return true
+	case string:
+		return integerDefaultPattern.MatchString(value)
+	}
+	return false
+}
+
+// isAmountDefault reports whether a default is a non-negative decimal number, given as a JSON number or a string
+func isAmountDefault(value interface{}) bool {
+	switch value := value.(type) {
+	case float64:
+		return value >= 0
+	case int:
+		return value >= 0
+	case string:
+		return nativeAmountPattern.MatchString(value)
+	}
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,67 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRelativeDefault(t *testing.T) {
+	deadline := Parameter{Name: "deadline", Type: ParameterType{BaseType: "uint256"}, Unit: UnitTimestamp, Default: "+1200"}
+	if seconds, ok := deadline.RelativeDefault(); !ok || seconds != 1200 {
+		t.Errorf("Expected a default 1200 seconds after the call but got %d (%v)", seconds, ok)
+	}
+	if description := deadline.DefaultDescription(); description != "1200 seconds from now" {
+		t.Errorf("Unexpected description %q", description)
+	}
+
+	deadline.Unit = UnitSeconds
+	if _, ok := deadline.RelativeDefault(); ok {
+		t.Error("Expected only timestamps to have relative defaults")
+	}
+
+	block := Parameter{Name: "blockTag", Type: ParameterType{BaseType: "string"}, Default: "latest"}
+	if !block.IsOptional() || block.DefaultDescription() != `"latest"` {
+		t.Errorf("Expected an optional parameter defaulting to \"latest\" but got %q", block.DefaultDescription())
+	}
+	if (Parameter{Type: ParameterType{BaseType: "uint256"}}).IsOptional() {
+		t.Error("Expected parameters without default to be required")
+	}
+}
+
+func TestDefaultValidation(t *testing.T) {
+	uint256 := ParameterType{BaseType: "uint256"}
+	valid := []Parameter{
+		{Type: uint256, Default: float64(100)},
+		{Type: uint256, Default: "0x64"},
+		{Type: uint256, Unit: UnitTimestamp, Default: "+1200"},
+		{Type: uint256, Unit: "token:6", Default: "1.5"},
+		{Type: ParameterType{BaseType: "address"}, Default: "0x0000000000000000000000000000000000000001"},
+		{Type: ParameterType{BaseType: "bool"}, Default: false},
+		{Type: ParameterType{BaseType: "bytes32", IsArray: true}, Default: []interface{}{}},
+		{Type: ParameterType{BaseType: APIString, ChainData: map[string]interface{}{EnumValuesKey: []interface{}{"asc", "desc"}}}, Default: "asc"},
+	}
+	for _, p := range valid {
+		if errs := defaultErrors(&p); len(errs) > 0 {
+			t.Errorf("Unexpected errors for default %v of %s: %v", p.Default, p.Type.BaseType, errs)
+		}
+	}
+
+	invalid := []struct {
+		parameter Parameter
+		expected  string
+	}{
+		{Parameter{Type: uint256, Default: "+1200"}, "relative default"},
+		{Parameter{Type: uint256, Default: 1.5}, "must be an integer"},
+		{Parameter{Type: uint256, Unit: UnitWei, Default: "-1"}, "must be a decimal number"},
+		{Parameter{Type: ParameterType{BaseType: "address"}, Default: "alice"}, "20-byte hex address"},
+		{Parameter{Type: ParameterType{BaseType: "bool"}, Default: "true"}, "true or false"},
+		{Parameter{Type: ParameterType{BaseType: "address", IsArray: true}, Default: "0x"}, "must be an array"},
+		{Parameter{Type: ParameterType{BaseType: APIString, ChainData: map[string]interface{}{EnumValuesKey: []interface{}{"asc", "desc"}}}, Default: "up"}, "not one of the allowed values"},
+	}
+	for _, test := range invalid {
+		errs := defaultErrors(&test.parameter)
+		if len(errs) != 1 || errs[0].Code != CodeInvalidDefault || !strings.Contains(errs[0].Message, test.expected) {
+			t.Errorf("Expected an error containing %q for default %v but got %v", test.expected, test.parameter.Default, errs)
+		}
+	}
+}
\ No newline at end of file
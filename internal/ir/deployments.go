@@ -0,0 +1,63 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChainIDs returns the chain IDs the contract has deployments on, in ascending order
+func (m *ContractMetadata) ChainIDs() []uint64 {
+	ids := make([]uint64, 0, len(m.Deployments))
+	for key := range m.Deployments {
+		if id, err := strconv.ParseUint(key, 10, 64); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// DeploymentOn returns the deployment of the contract on a chain
+func (m *ContractMetadata) DeploymentOn(chainID uint64) (Deployment, bool) {
+	deployment, ok := m.Deployments[strconv.FormatUint(chainID, 10)]
+	return deployment, ok
+}
+
+// ParseDeployment parses a deployment given as "<chainId>=<address>[@<block>]", e.g. "10=0xabc...@1234567"
+func ParseDeployment(value string) (string, Deployment, error) {
+	chainID, rest, ok := strings.Cut(value, "=")
+	if !ok {
+		return "", Deployment{}, fmt.Errorf("invalid deployment %q (expected <chainId>=<address>[@<block>])", value)
+	}
+	if id, err := strconv.ParseUint(chainID, 10, 64); err != nil || id == 0 {
+		return "", Deployment{}, fmt.Errorf("invalid deployment %q: chain ID must be a positive integer", value)
+	}
+	address, block, hasBlock := strings.Cut(rest, "@")
+	deployment := Deployment{Address: address}
+	if hasBlock {
+		number, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			return "", Deployment{}, fmt.Errorf("invalid deployment %q: block must be a non-negative integer", value)
+		}
+		deployment.Block = number
+	}
+	if errs := deploymentErrors(chainID, deployment); len(errs) > 0 {
+		return "", Deployment{}, fmt.Errorf("invalid deployment %q: %s", value, errs[0].Message)
+	}
+	return chainID, deployment, nil
+}
+
+// deploymentErrors reports deployments keyed by anything but a positive decimal chain ID, or without an address
+func deploymentErrors(chainID string, d Deployment) []ValidationError {
+	var errors []ValidationError
+	field := "Deployments." + chainID
+	if id, err := strconv.ParseUint(chainID, 10, 64); err != nil || id == 0 || strconv.FormatUint(id, 10) != chainID {
+		errors = append(errors, ValidationError{Code: CodeInvalidValue, Field: field, Message: fmt.Sprintf("invalid chain ID %q (expected a positive decimal integer)", chainID)})
+	}
+	if strings.TrimSpace(d.Address) == "" {
+		errors = append(errors, ValidationError{Code: CodeRequired, Field: field + ".Address", Message: "deployment address is required"})
+	}
+	return errors
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package ir
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeployments(t *testing.T) {
+	m := &ContractMetadata{
+		Name:  "Token",
+		Chain: "ethereum",
+		Deployments: map[string]Deployment{
+			"8453": {Address: "0x0000000000000000000000000000000000000003"},
+			"1":    {Address: "0x0000000000000000000000000000000000000001", Block: 100},
+			"10":   {Address: "0x0000000000000000000000000000000000000002"},
+		},
+	}
+	if ids := m.ChainIDs(); !reflect.DeepEqual(ids, []uint64{1, 10, 8453}) {
+		t.Errorf("Expected chain IDs in numeric order but got %v", ids)
+	}
+	if deployment, ok := m.DeploymentOn(1); !ok || deployment.Block != 100 {
+		t.Errorf("Unexpected deployment on chain 1: %+v", deployment)
+	}
+	if _, ok := m.DeploymentOn(5); ok {
+		t.Error("Expected no deployment on chain 5")
+	}
+	if errs := m.Validate(); len(errs) != 0 {
+		t.Errorf("Expected valid deployments but got %v", errs)
+	}
+
+	m.Deployments["mainnet"] = Deployment{}
+	errs := m.Validate()
+	if len(errs) != 2 || errs[0].Field != "Deployments.mainnet" || errs[1].Field != "Deployments.mainnet.Address" {
+		t.Errorf("Expected an invalid chain ID and a missing address but got %v", errs)
+	}
+}
+
+func TestParseDeployment(t *testing.T) {
+	chainID, deployment, err := ParseDeployment("10=0x0000000000000000000000000000000000000002@1234567")
+	if err != nil {
+		t.Fatalf("Failed to parse deployment: %v", err)
+	}
+	if chainID != "10" || deployment != (Deployment{Address: "0x0000000000000000000000000000000000000002", Block: 1234567}) {
+		t.Errorf("Unexpected deployment %s %+v", chainID, deployment)
+	}
+
+	for value, expected := range map[string]string{
+		"0x01":         "expected <chainId>=<address>",
+		"0=0x01":       "chain ID must be a positive integer",
+		"1=0x01@first": "block must be a non-negative integer",
+		"1=":           "deployment address is required",
+	} {
+		if _, _, err := ParseDeployment(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q to fail with %q but got %v", value, expected, err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,262 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of changes between two IRs
+const (
+	// Added marks something present only in the new IR
+	Added = "added"
+
+	// Removed marks something present only in the old IR
+	Removed = "removed"
+
+	// Changed marks a value that differs between the IRs
+	Changed = "changed"
+)
+
+// Change is a single structural difference between two IRs
+type Change struct {
+	// Kind of change: added, removed or changed
+	Kind string `json:"kind"`
+
+	// Path of what changed, e.g. "functions.transfer.inputs[1].type"
+	Path string `json:"path"`
+
+	// Value in the old IR (removed and changed only)
+	Old string `json:"old,omitempty"`
+
+	// Value in the new IR (added and changed only)
+	New string `json:"new,omitempty"`
+}
+
+// String formats the change as a line of a human-readable diff
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, quoteEmpty(c.Old), quoteEmpty(c.New))
+	}
+}
+
+// Diff structurally compares two IRs and returns their differences, in the order of the old IR followed by additions
+// Functions, events and errors are matched by name; their parameters by position, so renamed parameters show as name changes
+func Diff(old, new *ContractIR) []Change {
+	d := &differ{changes: []Change{}}
+
+	d.value("metadata.name", old.Metadata.Name, new.Metadata.Name)
+	d.value("metadata.description", old.Metadata.Description, new.Metadata.Description)
+	d.translations("metadata.descriptions", old.Metadata.Descriptions, new.Metadata.Descriptions)
+	d.value("metadata.address", old.Metadata.Address, new.Metadata.Address)
+	d.value("metadata.chain", old.Metadata.Chain, new.Metadata.Chain)
+
+	newFunctions := make(map[string]Function, len(new.Functions))
+	for _, f := range new.Functions {
+		newFunctions[f.Name] = f
+	}
+	oldFunctions := make(map[string]bool, len(old.Functions))
+	for _, f := range old.Functions {
+		oldFunctions[f.Name] = true
+		path := "functions." + f.Name
+		updated, ok := newFunctions[f.Name]
+		if !ok {
+			d.removed(path, functionSummary(f))
+			continue
+		}
+		d.value(path+".description", f.Description, updated.Description)
+		d.translations(path+".descriptions", f.Descriptions, updated.Descriptions)
+		d.value(path+".stateMutability", string(f.StateMutability), string(updated.StateMutability))
+		d.value(path+".abiName", f.ABIName(), updated.ABIName())
+		d.value(path+".deprecated", fmt.Sprint(f.Deprecated), fmt.Sprint(updated.Deprecated))
+		d.value(path+".hidden", fmt.Sprint(f.Hidden), fmt.Sprint(updated.Hidden))
+		d.parameters(path+".inputs", f.Inputs, updated.Inputs)
+		d.parameters(path+".outputs", f.Outputs, updated.Outputs)
+	}
+	for _, f := range new.Functions {
+		if !oldFunctions[f.Name] {
+			d.added("functions."+f.Name, functionSummary(f))
+		}
+	}
+
+	newEvents := make(map[string]Event, len(new.Events))
+	for _, e := range new.Events {
+		newEvents[e.Name] = e
+	}
+	oldEvents := make(map[string]bool, len(old.Events))
+	for _, e := range old.Events {
+		oldEvents[e.Name] = true
+		path := "events." + e.Name
+		updated, ok := newEvents[e.Name]
+		if !ok {
+			d.removed(path, eventSummary(e))
+			continue
+		}
+		d.value(path+".description", e.Description, updated.Description)
+		d.translations(path+".descriptions", e.Descriptions, updated.Descriptions)
+		d.value(path+".deprecated", fmt.Sprint(e.Deprecated), fmt.Sprint(updated.Deprecated))
+		d.value(path+".hidden", fmt.Sprint(e.Hidden), fmt.Sprint(updated.Hidden))
+		d.eventParameters(path+".parameters", e.Parameters, updated.Parameters)
+	}
+	for _, e := range new.Events {
+		if !oldEvents[e.Name] {
+			d.added("events."+e.Name, eventSummary(e))
+		}
+	}
+
+	newErrors := make(map[string]ContractError, len(new.Errors))
+	for _, e := range new.Errors {
+		newErrors[e.Name] = e
+	}
+	oldErrors := make(map[string]bool, len(old.Errors))
+	for _, e := range old.Errors {
+		oldErrors[e.Name] = true
+		path := "errors." + e.Name
+		updated, ok := newErrors[e.Name]
+		if !ok {
+			d.removed(path, e.Name+"("+parameterList(e.Parameters)+")")
+			continue
+		}
+		d.value(path+".description", e.Description, updated.Description)
+		d.translations(path+".descriptions", e.Descriptions, updated.Descriptions)
+		d.parameters(path+".parameters", e.Parameters, updated.Parameters)
+	}
+	for _, e := range new.Errors {
+		if !oldErrors[e.Name] {
+			d.added("errors."+e.Name, e.Name+"("+parameterList(e.Parameters)+")")
+		}
+	}
+
+	return d.changes
+}
+
+// differ collects the changes found while comparing two IRs
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) added(path, value string) {
+	d.changes = append(d.changes, Change{Kind: Added, Path: path, New: value})
+}
+
+func (d *differ) removed(path, value string) {
+	d.changes = append(d.changes, Change{Kind: Removed, Path: path, Old: value})
+}
+
+// value records a change if a value differs
+func (d *differ) value(path, old, new string) {
+	if old != new {
+		d.changes = append(d.changes, Change{Kind: Changed, Path: path, Old: old, New: new})
+	}
+}
+
+// translations records added, removed and changed translations by language tag
+func (d *differ) translations(path string, old, new Translations) {
+	locales := make([]string, 0, len(old)+len(new))
+	for locale := range old {
+		locales = append(locales, locale)
+	}
+	for locale := range new {
+		if _, ok := old[locale]; !ok {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		oldText, inOld := old[locale]
+		newText, inNew := new[locale]
+		switch {
+		case !inNew:
+			d.removed(path+"."+locale, oldText)
+		case !inOld:
+			d.added(path+"."+locale, newText)
+		default:
+			d.value(path+"."+locale, oldText, newText)
+		}
+	}
+}
+
+// parameters compares function inputs, outputs or error parameters by position
+func (d *differ) parameters(path string, old, new []Parameter) {
+	for i := 0; i < len(old) || i < len(new); i++ {
+		parameterPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(new):
+			d.removed(parameterPath, parameterSummary(old[i].Name, old[i].Type))
+		case i >= len(old):
+			d.added(parameterPath, parameterSummary(new[i].Name, new[i].Type))
+		default:
+			d.value(parameterPath+".name", old[i].Name, new[i].Name)
+			d.value(parameterPath+".type", old[i].Type.ABIType(), new[i].Type.ABIType())
+			d.value(parameterPath+".description", old[i].Description, new[i].Description)
+			d.translations(parameterPath+".descriptions", old[i].Descriptions, new[i].Descriptions)
+		}
+	}
+}
+
+// eventParameters compares event parameters by position
+func (d *differ) eventParameters(path string, old, new []EventParameter) {
+	for i := 0; i < len(old) || i < len(new); i++ {
+		parameterPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(new):
+			d.removed(parameterPath, parameterSummary(old[i].Name, old[i].Type))
+		case i >= len(old):
+			d.added(parameterPath, parameterSummary(new[i].Name, new[i].Type))
+		default:
+			d.value(parameterPath+".name", old[i].Name, new[i].Name)
+			d.value(parameterPath+".type", old[i].Type.ABIType(), new[i].Type.ABIType())
+			d.value(parameterPath+".indexed", fmt.Sprint(old[i].Indexed), fmt.Sprint(new[i].Indexed))
+		}
+	}
+}
+
+// functionSummary describes an added or removed function, e.g. "transfer(address to, uint256 amount) nonpayable"
+func functionSummary(f Function) string {
+	summary := f.ABIName() + "(" + parameterList(f.Inputs) + ")"
+	if f.StateMutability != "" {
+		summary += " " + string(f.StateMutability)
+	}
+	if len(f.Outputs) > 0 {
+		summary += " returns (" + parameterList(f.Outputs) + ")"
+	}
+	return summary
+}
+
+// eventSummary describes an added or removed event, e.g. "Transfer(address indexed from, ...)"
+func eventSummary(e Event) string {
+	parameters := make([]string, len(e.Parameters))
+	for i, p := range e.Parameters {
+		typeName := p.Type.ABIType()
+		if p.Indexed {
+			typeName += " indexed"
+		}
+		parameters[i] = strings.TrimSpace(typeName + " " + p.Name)
+	}
+	return e.Name + "(" + strings.Join(parameters, ", ") + ")"
+}
+
+func parameterList(parameters []Parameter) string {
+	summaries := make([]string, len(parameters))
+	for i, p := range parameters {
+		summaries[i] = parameterSummary(p.Name, p.Type)
+	}
+	return strings.Join(summaries, ", ")
+}
+
+func parameterSummary(name string, t ParameterType) string {
+	return strings.TrimSpace(t.ABIType() + " " + name)
+}
+
+// quoteEmpty shows empty values, e.g. a removed description, as ""
+func quoteEmpty(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := overlayContract()
+	new := overlayContract()
+
+	if changes := Diff(old, new); len(changes) != 0 {
+		t.Fatalf("Expected no changes between identical IRs but got %v", changes)
+	}
+
+	new.Metadata.Address = "0x0000000000000000000000000000000000000001"
+	new.Functions[0].Description = "Balance of an account"
+	new.Functions[0].Descriptions = Translations{"ja": "アカウントの残高"}
+	new.Functions[0].Inputs[0].Type = ParameterType{BaseType: "bytes32"}
+	new.Functions[1].StateMutability = Payable
+	new.Functions[1].Inputs = new.Functions[1].Inputs[:1]
+	new.Functions[2] = Function{Name: "burn", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "amount", Type: ParameterType{BaseType: "uint256"}}}}
+	new.Events = []Event{{Name: "Transfer", Parameters: []EventParameter{{Name: "from", Type: ParameterType{BaseType: "address"}, Indexed: true}}}}
+
+	expected := []Change{
+		{Kind: Changed, Path: "metadata.address", New: "0x0000000000000000000000000000000000000001"},
+		{Kind: Changed, Path: "functions.balanceOf.description", New: "Balance of an account"},
+		{Kind: Added, Path: "functions.balanceOf.descriptions.ja", New: "アカウントの残高"},
+		{Kind: Changed, Path: "functions.balanceOf.inputs[0].type", Old: "address", New: "bytes32"},
+		{Kind: Changed, Path: "functions.mint.stateMutability", Old: "nonpayable", New: "payable"},
+		{Kind: Removed, Path: "functions.mint.inputs[1]", Old: "uint256 amount"},
+		{Kind: Removed, Path: "functions.mint_1", Old: "mint(uint256 amount) nonpayable"},
+		{Kind: Added, Path: "functions.burn", New: "burn(uint256 amount) nonpayable"},
+		{Kind: Added, Path: "events.Transfer.parameters[0]", New: "address from"},
+		{Kind: Removed, Path: "events.Approval", Old: "Approval()"},
+	}
+	if changes := Diff(old, new); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes\n%v\nbut got\n%v", expected, changes)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	tests := []struct {
+		change   Change
+		expected string
+	}{
+		{Change{Kind: Added, Path: "functions.burn", New: "burn(uint256 amount) nonpayable"}, "+ functions.burn: burn(uint256 amount) nonpayable"},
+		{Change{Kind: Removed, Path: "events.Approval", Old: "Approval()"}, "- events.Approval: Approval()"},
+		{Change{Kind: Changed, Path: "functions.mint.inputs[0].type", Old: "address", New: "bytes32"}, "~ functions.mint.inputs[0].type: address -> bytes32"},
+		{Change{Kind: Changed, Path: "functions.mint.description", Old: "Mints tokens"}, `~ functions.mint.description: Mints tokens -> ""`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.expected {
+			t.Errorf("Expected %q but got %q", tt.expected, got)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,165 @@
+package ir
+
+// Chain data keys of IRs parsed from EVM ABIs
+const (
+	// ConstantKey marks functions declared constant in legacy ABIs
+	ConstantKey = "constant"
+
+	// PayableKey marks functions declared payable in legacy ABIs
+	PayableKey = "payable"
+
+	// OriginalNameKey holds the ABI name of a function renamed by an overlay, or of an overload in IRs predating
+	// Function.Overload
+	OriginalNameKey = "originalName"
+
+	// OriginalSignatureKey holds the ABI signature of an overload in IRs predating Function.Overload
+	OriginalSignatureKey = "originalSignature"
+
+	// NatSpecKey holds the NatSpec documentation of a function or event, as recorded from compiler output
+	NatSpecKey = "natspec"
+
+	// AnonymousKey marks anonymous events, whose logs have no topic to filter by
+	AnonymousKey = "anonymous"
+
+	// IndexedCountKey holds the number of indexed parameters of an event
+	IndexedCountKey = "indexedCount"
+
+	// InternalTypeKey holds the Solidity type of a tuple (e.g., "struct Pool.Key")
+	InternalTypeKey = "internalType"
+
+	// IsTupleKey marks tuple types
+	IsTupleKey = "isTuple"
+
+	// TypeDescriptionKey holds a readable outline of a tuple's fields (e.g., "{to: address, amount: uint256}")
+	TypeDescriptionKey = "typeDescription"
+
+	// IsArrayKey, IsFixedArrayKey and IsDynamicArrayKey mark array types, and ArraySizeKey holds the length of
+	// fixed-size ones
+	IsArrayKey        = "isArray"
+	IsFixedArrayKey   = "isFixedArray"
+	IsDynamicArrayKey = "isDynamicArray"
+	ArraySizeKey      = "arraySize"
+)
+
+// EVMFunction is the chain data of a function parsed from an EVM ABI
+type EVMFunction struct {
+	// Whether the function is declared constant in a legacy ABI
+	Constant bool
+
+	// Whether the function is declared payable in a legacy ABI
+	Payable bool
+
+	// ABI name of the function, if an overlay renamed its tool
+	OriginalName string
+
+	// ABI signature of an overload of a legacy IR
+	OriginalSignature string
+
+	// NatSpec documentation, if recorded
+	NatSpec interface{}
+}
+
+// EVMEvent is the chain data of an event parsed from an EVM ABI
+type EVMEvent struct {
+	// Whether the event is anonymous
+	Anonymous bool
+
+	// Number of indexed parameters
+	IndexedCount int
+
+	// NatSpec documentation, if recorded
+	NatSpec interface{}
+}
+
+// EVMType is the chain data of a parameter type parsed from an EVM ABI
+type EVMType struct {
+	// Solidity type of a tuple (e.g., "struct Pool.Key")
+	InternalType string
+
+	// Whether the type is a tuple, and a readable outline of its fields
+	IsTuple         bool
+	TypeDescription string
+
+	// Whether the type is an array, of fixed or dynamic length
+	IsArray        bool
+	IsFixedArray   bool
+	IsDynamicArray bool
+
+	// Length of fixed-size arrays
+	ArraySize int
+}
+
+// EVM returns the EVM chain data of a function
+func (f Function) EVM() EVMFunction {
+	return EVMFunction{
+		Constant:          chainBool(f.ChainData, ConstantKey),
+		Payable:           chainBool(f.ChainData, PayableKey),
+		OriginalName:      chainString(f.ChainData, OriginalNameKey),
+		OriginalSignature: chainString(f.ChainData, OriginalSignatureKey),
+		NatSpec:           f.ChainData[NatSpecKey],
+	}
+}
+
+// ChainData returns the chain data map holding the function data, without unset entries
+func (d EVMFunction) ChainData() map[string]interface{} {
+	data := map[string]interface{}{}
+	setChainBool(data, ConstantKey, d.Constant)
+	setChainBool(data, PayableKey, d.Payable)
+	setChainString(data, OriginalNameKey, d.OriginalName)
+	setChainString(data, OriginalSignatureKey, d.OriginalSignature)
+	if d.NatSpec != nil {
+		data[NatSpecKey] = d.NatSpec
+	}
+	return data
+}
+
+// EVM returns the EVM chain data of an event
+func (e Event) EVM() EVMEvent {
+	return EVMEvent{
+		Anonymous:    chainBool(e.ChainData, AnonymousKey),
+		IndexedCount: chainInt(e.ChainData, IndexedCountKey),
+		NatSpec:      e.ChainData[NatSpecKey],
+	}
+}
+
+// ChainData returns the chain data map holding the event data, without unset entries
+// The indexed parameter count is always set, as the parser records it.
+func (d EVMEvent) ChainData() map[string]interface{} {
+	data := map[string]interface{}{IndexedCountKey: d.IndexedCount}
+	setChainBool(data, AnonymousKey, d.Anonymous)
+	if d.NatSpec != nil {
+		data[NatSpecKey] = d.NatSpec
+	}
+	return data
+}
+
+// EVM returns the EVM chain data of a parameter type
+func (t ParameterType) EVM() EVMType {
+	return EVMType{
+		InternalType:    chainString(t.ChainData, InternalTypeKey),
+		IsTuple:         chainBool(t.ChainData, IsTupleKey),
+		TypeDescription: chainString(t.ChainData, TypeDescriptionKey),
+		IsArray:         chainBool(t.ChainData, IsArrayKey),
+		IsFixedArray:    chainBool(t.ChainData, IsFixedArrayKey),
+		IsDynamicArray:  chainBool(t.ChainData, IsDynamicArrayKey),
+		ArraySize:       chainInt(t.ChainData, ArraySizeKey),
+	}
+}
+
+// ChainData returns the chain data map holding the type data, or nil if nothing is set
+func (d EVMType) ChainData() map[string]interface{} {
+	data := map[string]interface{}{}
+	setChainString(data, InternalTypeKey, d.InternalType)
+	setChainBool(data, IsTupleKey, d.IsTuple)
+	setChainString(data, TypeDescriptionKey, d.TypeDescription)
+	setChainBool(data, IsArrayKey, d.IsArray)
+	setChainBool(data, IsFixedArrayKey, d.IsFixedArray)
+	setChainBool(data, IsDynamicArrayKey, d.IsDynamicArray)
+	if d.ArraySize > 0 {
+		data[ArraySizeKey] = d.ArraySize
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
\ No newline at end of file
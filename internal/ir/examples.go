@@ -0,0 +1,79 @@
+package ir
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Example values of parameters, as tools take them
+const (
+	// ZeroAddress is the example value of addresses
+	ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+	// ExampleBlockNumber is the example value of block numbers, a recent Ethereum mainnet block
+	ExampleBlockNumber = "20000000"
+
+	// ExampleRawAmount is the example value of amounts given in base units, 1e18 (one token of 18 decimals)
+	ExampleRawAmount = "1000000000000000000"
+)
+
+// blockNames match the names of parameters holding block numbers, and rawAmountNames those holding amounts
+var (
+	blockNames     = regexp.MustCompile(`(?i)(^block|block$|blockNumber$)`)
+	rawAmountNames = regexp.MustCompile(`(?i)(amount|value|wad|shares|assets)$`)
+)
+
+// DetectExamples gives function inputs without examples one guessed from their type, unit and name: the zero
+// address for addresses, one token for token amounts, 1e18 for other amounts, a recent block for block numbers, and
+// typical durations, timestamps and basis points. Inputs nothing is guessed for are left without examples.
+// It returns the number of inputs given an example.
+func DetectExamples(c *ContractIR) int {
+	detected := 0
+	for i := range c.Functions {
+		for j := range c.Functions[i].Inputs {
+			p := &c.Functions[i].Inputs[j]
+			if len(p.Examples) > 0 {
+				continue
+			}
+			if example, ok := guessExample(*p); ok {
+				p.Examples = []interface{}{example}
+				detected++
+			}
+		}
+	}
+	return detected
+}
+
+// guessExample returns an example value for a single address, bool or integer parameter
+func guessExample(p Parameter) (interface{}, bool) {
+	t := p.Type
+	if t.IsArray || t.IsMap || len(t.Components) > 0 {
+		return nil, false
+	}
+	switch {
+	case t.BaseType == "address":
+		return ZeroAddress, true
+	case t.BaseType == "bool":
+		return true, true
+	case !strings.HasPrefix(t.BaseType, "uint"):
+		return nil, false
+	}
+
+	if _, _, ok := t.AmountDecimals(); ok {
+		// Token amounts are given in whole tokens
+		return "1", true
+	}
+	switch {
+	case p.Unit == UnitTimestamp:
+		return "1700000000", true
+	case p.Unit == UnitSeconds:
+		return "3600", true
+	case p.Unit == UnitBasisPoints:
+		return "100", true
+	case blockNames.MatchString(p.Name):
+		return ExampleBlockNumber, true
+	case rawAmountNames.MatchString(p.Name):
+		return ExampleRawAmount, true
+	}
+	return nil, false
+}
\ No newline at end of file
@@ -0,0 +1,48 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectExamples(t *testing.T) {
+	uint256 := ParameterType{BaseType: "uint256"}
+	c := &ContractIR{
+		Functions: []Function{
+			{
+				Name: "swap",
+				Inputs: []Parameter{
+					{Name: "to", Type: ParameterType{BaseType: "address"}},
+					{Name: "amountIn", Type: ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{DecimalsKey: 6}}},
+					{Name: "minValue", Type: uint256},
+					{Name: "fromBlock", Type: uint256},
+					{Name: "deadline", Type: uint256, Unit: UnitTimestamp},
+					{Name: "feeBps", Type: uint256, Unit: UnitBasisPoints},
+					{Name: "path", Type: ParameterType{BaseType: "address", IsArray: true}},
+					{Name: "nonce", Type: uint256},
+					{Name: "recipient", Type: ParameterType{BaseType: "address"}, Examples: []interface{}{"0x0000000000000000000000000000000000000002"}},
+				},
+			},
+		},
+	}
+
+	if detected := DetectExamples(c); detected != 6 {
+		t.Errorf("Expected 6 examples but got %d", detected)
+	}
+	expected := map[string][]interface{}{
+		"to":        {ZeroAddress},
+		"amountIn":  {"1"},
+		"minValue":  {ExampleRawAmount},
+		"fromBlock": {ExampleBlockNumber},
+		"deadline":  {"1700000000"},
+		"feeBps":    {"100"},
+		"path":      nil,
+		"nonce":     nil,
+		"recipient": {"0x0000000000000000000000000000000000000002"},
+	}
+	for _, input := range c.Functions[0].Inputs {
+		if !reflect.DeepEqual(input.Examples, expected[input.Name]) {
+			t.Errorf("Expected examples %v for %s but got %v", expected[input.Name], input.Name, input.Examples)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,179 @@
+package ir
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Inspection is a readable summary of a contract's interface, for exploring an artifact before generating a server
+type Inspection struct {
+	// Contract name and chain
+	Name  string `json:"name"`
+	Chain string `json:"chain"`
+
+	// Functions grouped by state mutability, in the order view, pure, nonpayable, payable
+	Functions []MutabilityGroup `json:"functions"`
+
+	// Events, custom errors and tuple types, in contract order
+	Events []MemberSummary `json:"events"`
+	Errors []MemberSummary `json:"errors"`
+	Types  []TypeSummary   `json:"types"`
+}
+
+// MutabilityGroup lists the functions of one state mutability
+type MutabilityGroup struct {
+	// State mutability of the functions
+	Mutability StateMutability `json:"mutability"`
+
+	// Functions, in contract order
+	Functions []MemberSummary `json:"functions"`
+}
+
+// MemberSummary summarizes a function, event or error
+type MemberSummary struct {
+	// Name, as tools are named
+	Name string `json:"name"`
+
+	// Name in the contract's ABI, which differs from Name for renamed overloads (e.g., "add" for add_1)
+	ABIName string `json:"abiName"`
+
+	// Canonical signature (e.g., "transfer(address,uint256)")
+	Signature string `json:"signature"`
+
+	// 4-byte selector of functions and errors, or the topic of events (EVM only)
+	Selector string `json:"selector,omitempty"`
+
+	// Inputs and outputs (e.g., "address to"); event parameters are inputs, marked "indexed" where they are
+	Inputs  []string `json:"inputs"`
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Special functions, anonymous events, and members that are deprecated or hidden from tools
+	Flags []string `json:"flags,omitempty"`
+}
+
+// TypeSummary summarizes a tuple type
+type TypeSummary struct {
+	// Type name
+	Name string `json:"name"`
+
+	// Fields (e.g., "uint256 amount")
+	Fields []string `json:"fields"`
+}
+
+// inspectionMutabilities is the order function groups are listed in, from harmless to sending value
+var inspectionMutabilities = []StateMutability{View, Pure, Nonpayable, Payable}
+
+// Inspect summarizes a contract
+// Selectors of EVM functions and errors and topics of EVM events are computed from their signatures when the IR
+// records none.
+func Inspect(c *ContractIR) *Inspection {
+	evm := c.Metadata.Chain == "ethereum" || c.Metadata.Chain == "evm"
+	inspection := &Inspection{
+		Name:      c.Metadata.Name,
+		Chain:     c.Metadata.Chain,
+		Functions: []MutabilityGroup{},
+		Events:    []MemberSummary{},
+		Errors:    []MemberSummary{},
+		Types:     []TypeSummary{},
+	}
+
+	groups := map[StateMutability][]MemberSummary{}
+	for _, f := range c.Functions {
+		signature := canonicalSignature(f)
+		summary := MemberSummary{Name: f.Name, ABIName: f.ABIName(), Signature: signature, Selector: f.Selector, Inputs: parameterSummaries(f.Inputs), Outputs: parameterSummaries(f.Outputs)}
+		if summary.Selector == "" && evm && !f.IsConstructor && !f.IsFallback && !f.IsReceive {
+			summary.Selector = Selector(signature)
+		}
+		for _, flag := range []struct {
+			set  bool
+			name string
+		}{{f.IsConstructor, "constructor"}, {f.IsFallback, "fallback"}, {f.IsReceive, "receive"}, {f.Deprecated, "deprecated"}, {f.Hidden, "hidden"}} {
+			if flag.set {
+				summary.Flags = append(summary.Flags, flag.name)
+			}
+		}
+		groups[f.StateMutability] = append(groups[f.StateMutability], summary)
+	}
+	for _, mutability := range inspectionMutabilities {
+		if len(groups[mutability]) > 0 {
+			inspection.Functions = append(inspection.Functions, MutabilityGroup{Mutability: mutability, Functions: groups[mutability]})
+		}
+	}
+
+	for _, e := range c.Events {
+		types := make([]string, len(e.Parameters))
+		summary := MemberSummary{Name: e.Name, ABIName: e.Name, Inputs: make([]string, len(e.Parameters))}
+		for i, p := range e.Parameters {
+			types[i] = p.Type.ABIType()
+			if p.Indexed {
+				summary.Inputs[i] = typedName(p.Type, strings.TrimSpace("indexed "+p.Name))
+			} else {
+				summary.Inputs[i] = typedName(p.Type, p.Name)
+			}
+		}
+		summary.Signature = e.Name + "(" + strings.Join(types, ",") + ")"
+		anonymous := e.EVM().Anonymous
+		if evm && !anonymous {
+			summary.Selector = Topic(summary.Signature)
+		}
+		for _, flag := range []struct {
+			set  bool
+			name string
+		}{{anonymous, "anonymous"}, {e.Deprecated, "deprecated"}, {e.Hidden, "hidden"}} {
+			if flag.set {
+				summary.Flags = append(summary.Flags, flag.name)
+			}
+		}
+		inspection.Events = append(inspection.Events, summary)
+	}
+
+	for _, e := range c.Errors {
+		summary := MemberSummary{Name: e.Name, ABIName: e.Name, Signature: canonicalSignature(Function{Name: e.Name, Inputs: e.Parameters}), Inputs: parameterSummaries(e.Parameters)}
+		if evm {
+			summary.Selector = Selector(summary.Signature)
+		}
+		inspection.Errors = append(inspection.Errors, summary)
+	}
+
+	for _, t := range c.Types {
+		inspection.Types = append(inspection.Types, TypeSummary{Name: t.Name, Fields: parameterSummaries(t.Fields)})
+	}
+	return inspection
+}
+
+// parameterSummaries summarizes parameters as their types and names, e.g. "address to"
+func parameterSummaries(parameters []Parameter) []string {
+	summaries := make([]string, len(parameters))
+	for i, p := range parameters {
+		summaries[i] = typedName(p.Type, p.Name)
+	}
+	return summaries
+}
+
+// typedName joins a type and a name, e.g. "address to", naming tuples by their type name if they have one
+// The type alone is returned for unnamed parameters.
+func typedName(t ParameterType, name string) string {
+	typeName := t.ABIType()
+	if t.TypeName != "" {
+		typeName = t.TypeName
+		if t.IsArray && t.ArraySize > 0 {
+			typeName += fmt.Sprintf("[%d]", t.ArraySize)
+		} else if t.IsArray {
+			typeName += "[]"
+		}
+	}
+	if name == "" {
+		return typeName
+	}
+	return typeName + " " + name
+}
+
+// keccakHex returns the 0x-prefixed Keccak-256 hash of a signature, whose first 4 bytes are its selector
+func keccakHex(signature string) string {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(signature))
+	return "0x" + hex.EncodeToString(hash.Sum(nil))
+}
\ No newline at end of file
@@ -0,0 +1,90 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	order := ParameterType{BaseType: "tuple", TypeName: "Order", IsArray: true, Components: []Parameter{
+		{Name: "maker", Type: ParameterType{BaseType: "address"}},
+		{Name: "amount", Type: ParameterType{BaseType: "uint256"}},
+	}}
+	contract := &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "constructor", IsConstructor: true, StateMutability: Nonpayable},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, {Name: "amount", Type: ParameterType{BaseType: "uint256"}}}, Outputs: []Parameter{{Type: ParameterType{BaseType: "bool"}}}},
+			{Name: "transfer_1", Overload: &Overload{Name: "transfer", Index: 1}, StateMutability: Nonpayable, Inputs: []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, {Name: "data", Type: ParameterType{BaseType: "bytes"}}}},
+			{Name: "balanceOf", StateMutability: View, Inputs: []Parameter{{Name: "owner", Type: ParameterType{BaseType: "address"}}}, Outputs: []Parameter{{Type: ParameterType{BaseType: "uint256"}}}},
+			{Name: "fill", StateMutability: Payable, Inputs: []Parameter{{Name: "orders", Type: order}}, Hidden: true},
+		},
+		Events: []Event{
+			{Name: "Transfer", Parameters: []EventParameter{
+				{Name: "from", Type: ParameterType{BaseType: "address"}, Indexed: true},
+				{Name: "to", Type: ParameterType{BaseType: "address"}, Indexed: true},
+				{Name: "value", Type: ParameterType{BaseType: "uint256"}},
+			}},
+			{Name: "Ping", ChainData: map[string]interface{}{"anonymous": true}},
+		},
+		Errors: []ContractError{{Name: "InsufficientBalance", Parameters: []Parameter{{Name: "available", Type: ParameterType{BaseType: "uint256"}}, {Name: "required", Type: ParameterType{BaseType: "uint256"}}}}},
+		Types:  []CustomType{{Name: "Order", Fields: order.Components}},
+	}
+
+	inspection := Inspect(contract)
+	var mutabilities []StateMutability
+	for _, group := range inspection.Functions {
+		mutabilities = append(mutabilities, group.Mutability)
+	}
+	if expected := []StateMutability{View, Nonpayable, Payable}; !reflect.DeepEqual(mutabilities, expected) {
+		t.Fatalf("Expected groups %v but got %v", expected, mutabilities)
+	}
+
+	balanceOf := inspection.Functions[0].Functions[0]
+	if expected := (MemberSummary{Name: "balanceOf", ABIName: "balanceOf", Signature: "balanceOf(address)", Selector: "0x70a08231", Inputs: []string{"address owner"}, Outputs: []string{"uint256"}}); !reflect.DeepEqual(balanceOf, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, balanceOf)
+	}
+	constructor, transfer := inspection.Functions[1].Functions[0], inspection.Functions[1].Functions[1]
+	if constructor.Selector != "" || !reflect.DeepEqual(constructor.Flags, []string{"constructor"}) {
+		t.Errorf("Expected a constructor without selector but got %+v", constructor)
+	}
+	if transfer.Selector != "0xa9059cbb" {
+		t.Errorf("Expected the selector of transfer but got %s", transfer.Selector)
+	}
+	// Renamed overloads keep their tool name, and are signed by their name in the ABI
+	if overload := inspection.Functions[1].Functions[2]; overload.Name != "transfer_1" || overload.ABIName != "transfer" || overload.Signature != "transfer(address,bytes)" {
+		t.Errorf("Unexpected overload summary: %+v", overload)
+	}
+	fill := inspection.Functions[2].Functions[0]
+	if fill.Signature != "fill((address,uint256)[])" || !reflect.DeepEqual(fill.Inputs, []string{"Order[] orders"}) || !reflect.DeepEqual(fill.Flags, []string{"hidden"}) {
+		t.Errorf("Unexpected tuple function summary: %+v", fill)
+	}
+
+	transferEvent := inspection.Events[0]
+	if transferEvent.Selector != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Expected the topic of Transfer but got %s", transferEvent.Selector)
+	}
+	if expected := []string{"address indexed from", "address indexed to", "uint256 value"}; !reflect.DeepEqual(transferEvent.Inputs, expected) {
+		t.Errorf("Expected event parameters %v but got %v", expected, transferEvent.Inputs)
+	}
+	if ping := inspection.Events[1]; ping.Selector != "" || !reflect.DeepEqual(ping.Flags, []string{"anonymous"}) {
+		t.Errorf("Expected an anonymous event without topic but got %+v", ping)
+	}
+
+	if err := inspection.Errors[0]; err.Signature != "InsufficientBalance(uint256,uint256)" || err.Selector != "0xcf479181" {
+		t.Errorf("Unexpected error summary: %+v", err)
+	}
+	if expected := []TypeSummary{{Name: "Order", Fields: []string{"address maker", "uint256 amount"}}}; !reflect.DeepEqual(inspection.Types, expected) {
+		t.Errorf("Expected types %v but got %v", expected, inspection.Types)
+	}
+}
+
+func TestInspectNonEVM(t *testing.T) {
+	contract := &ContractIR{
+		Metadata:  ContractMetadata{Name: "Pets", Chain: ChainOpenAPI},
+		Functions: []Function{{Name: "listPets", StateMutability: View}},
+	}
+	if selector := Inspect(contract).Functions[0].Functions[0].Selector; selector != "" {
+		t.Errorf("Expected no selector outside EVM chains but got %s", selector)
+	}
+}
\ No newline at end of file
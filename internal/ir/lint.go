@@ -0,0 +1,268 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity is how a lint finding is reported
+type Severity string
+
+const (
+	// SeverityError findings fail the lint
+	SeverityError Severity = "error"
+
+	// SeverityWarning findings are reported without failing the lint
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo findings are informational
+	SeverityInfo Severity = "info"
+
+	// SeverityOff disables a rule
+	SeverityOff Severity = "off"
+)
+
+// Lint rules
+const (
+	// RuleMissingDescription reports functions and events without a description
+	RuleMissingDescription = "missing-description"
+
+	// RuleUnnamedParameter reports inputs, outputs and event parameters without a name
+	RuleUnnamedParameter = "unnamed-parameter"
+
+	// RuleToolNameLength reports tool names longer than MCP clients accept
+	RuleToolNameLength = "tool-name-length"
+
+	// RuleUnsupportedType reports parameter types the target generator cannot express
+	RuleUnsupportedType = "unsupported-type"
+
+	// RuleProhibitedWrite reports functions that modify state, for servers meant to be read-only
+	RuleProhibitedWrite = "prohibited-write"
+)
+
+// DefaultMaxToolNameLength is the longest tool name most MCP clients accept
+const DefaultMaxToolNameLength = 64
+
+// DefaultLintTarget is the generator types are checked against by default
+const DefaultLintTarget = "typescript"
+
+// defaultSeverities are the severities of rules not configured otherwise
+var defaultSeverities = map[string]Severity{
+	RuleMissingDescription: SeverityWarning,
+	RuleUnnamedParameter:   SeverityWarning,
+	RuleToolNameLength:     SeverityError,
+	RuleUnsupportedType:    SeverityError,
+	RuleProhibitedWrite:    SeverityOff,
+}
+
+// supportedBaseTypes match the base types each lint target can express
+var supportedBaseTypes = map[string]*regexp.Regexp{
+	"typescript": regexp.MustCompile(`^(address|bool|string|bytes([0-9]+)?|u?int([0-9]+)?|tuple)$`),
+}
+
+// apiBaseTypes match the base types of IRs imported from API descriptions, which every target expresses as JSON
+var apiBaseTypes = regexp.MustCompile(`^(string|boolean|integer|number|object|any)$`)
+
+// LintConfig selects the lint rules and their severities
+type LintConfig struct {
+	// Severities by rule name; rules not listed keep their default severity
+	Rules map[string]Severity `json:"rules,omitempty"`
+
+	// Longest accepted tool name (default: 64)
+	MaxToolNameLength int `json:"maxToolNameLength,omitempty"`
+
+	// Generator types are checked against (default: typescript)
+	Target string `json:"target,omitempty"`
+}
+
+// LintFinding is a problem found by a lint rule
+type LintFinding struct {
+	// Rule that found the problem
+	Rule string `json:"rule"`
+
+	// Severity of the rule
+	Severity Severity `json:"severity"`
+
+	// Path of the offending member, e.g. "functions.transfer.inputs[1]"
+	Path string `json:"path"`
+
+	// What is wrong
+	Message string `json:"message"`
+}
+
+// String formats the finding as a line of a human-readable report
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", f.Severity, f.Path, f.Message, f.Rule)
+}
+
+// LintRules returns the names of the lint rules, in alphabetical order
+func LintRules() []string {
+	rules := make([]string, 0, len(defaultSeverities))
+	for rule := range defaultSeverities {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// LoadLintConfig reads a lint configuration from a JSON or, by its .yaml or .yml extension, YAML file
+// Unknown keys, rules and severities are rejected so that typos do not go unnoticed
+func LoadLintConfig(path string) (*LintConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint configuration: %w", err)
+	}
+	if IsYAMLFile(path) {
+		if content, err = YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("failed to parse lint configuration %s: %w", path, err)
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	config := &LintConfig{}
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to parse lint configuration %s: %w", path, err)
+	}
+	if err := config.Check(); err != nil {
+		return nil, fmt.Errorf("invalid lint configuration %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Check reports unknown rules, severities and targets
+func (l *LintConfig) Check() error {
+	for _, rule := range sortedKeys(l.Rules) {
+		if _, ok := defaultSeverities[rule]; !ok {
+			return fmt.Errorf("unknown lint rule %q (expected one of %s)", rule, strings.Join(LintRules(), ", "))
+		}
+		switch l.Rules[rule] {
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			return fmt.Errorf("invalid severity %q of %s (expected error, warning, info or off)", l.Rules[rule], rule)
+		}
+	}
+	if l.MaxToolNameLength < 0 {
+		return fmt.Errorf("maxToolNameLength must not be negative")
+	}
+	if _, ok := supportedBaseTypes[l.target()]; !ok {
+		return fmt.Errorf("unsupported lint target %q (expected typescript)", l.Target)
+	}
+	return nil
+}
+
+// Severity returns the severity of a rule
+func (l *LintConfig) Severity(rule string) Severity {
+	if severity, ok := l.Rules[rule]; ok {
+		return severity
+	}
+	return defaultSeverities[rule]
+}
+
+// target returns the generator types are checked against
+func (l *LintConfig) target() string {
+	if l.Target == "" {
+		return DefaultLintTarget
+	}
+	return l.Target
+}
+
+// Lint checks a contract against the configured rules and returns the findings in contract order
+// Hidden functions and events are skipped, since no tools are generated for them.
+func Lint(c *ContractIR, config LintConfig) []LintFinding {
+	maxLength := config.MaxToolNameLength
+	if maxLength == 0 {
+		maxLength = DefaultMaxToolNameLength
+	}
+	supported := supportedBaseTypes[config.target()]
+	if IsAPIChain(c.Metadata.Chain) {
+		supported = apiBaseTypes
+	}
+
+	findings := []LintFinding{}
+	report := func(rule, path, format string, args ...interface{}) {
+		if severity := config.Severity(rule); severity != SeverityOff {
+			findings = append(findings, LintFinding{Rule: rule, Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+	checkToolName := func(path, name string) {
+		if len(name) > maxLength {
+			report(RuleToolNameLength, path, "tool name %s is %d characters long (at most %d)", name, len(name), maxLength)
+		}
+	}
+	var checkType func(path string, t ParameterType)
+	checkType = func(path string, t ParameterType) {
+		if t.IsMap {
+			report(RuleUnsupportedType, path, "maps are not supported by the %s generator", config.target())
+			return
+		}
+		if len(t.Components) > 0 {
+			for i, component := range t.Components {
+				checkType(fmt.Sprintf("%s.components[%d]", path, i), component.Type)
+			}
+			return
+		}
+		if t.EnumValues() == nil && !supported.MatchString(t.BaseType) {
+			report(RuleUnsupportedType, path, "type %s is not supported by the %s generator", t.BaseType, config.target())
+		}
+	}
+	checkParameters := func(path string, parameters []Parameter) {
+		for i, p := range parameters {
+			parameterPath := fmt.Sprintf("%s[%d]", path, i)
+			if strings.TrimSpace(p.Name) == "" {
+				report(RuleUnnamedParameter, parameterPath, "parameter has no name")
+			}
+			checkType(parameterPath, p.Type)
+		}
+	}
+
+	for _, f := range c.Functions {
+		if f.Hidden || f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		path := "functions." + f.Name
+		if strings.TrimSpace(f.Description) == "" {
+			report(RuleMissingDescription, path, "function has no description")
+		}
+		checkToolName(path, f.Name)
+		if f.StateMutability == Payable || f.StateMutability == Nonpayable {
+			report(RuleProhibitedWrite, path, "%s function %s modifies state", f.StateMutability, f.Name)
+			checkToolName(path, "simulate"+strings.ToUpper(f.Name[:1])+f.Name[1:])
+		}
+		checkParameters(path+".inputs", f.Inputs)
+		checkParameters(path+".outputs", f.Outputs)
+	}
+
+	for _, e := range c.Events {
+		if e.Hidden {
+			continue
+		}
+		path := "events." + e.Name
+		if strings.TrimSpace(e.Description) == "" {
+			report(RuleMissingDescription, path, "event has no description")
+		}
+		checkToolName(path, "get"+e.Name+"Logs")
+		for i, p := range e.Parameters {
+			parameterPath := fmt.Sprintf("%s.parameters[%d]", path, i)
+			if strings.TrimSpace(p.Name) == "" {
+				report(RuleUnnamedParameter, parameterPath, "parameter has no name")
+			}
+			checkType(parameterPath, p.Type)
+		}
+	}
+	return findings
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys(m map[string]Severity) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
\ No newline at end of file
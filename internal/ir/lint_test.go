@@ -0,0 +1,82 @@
+package ir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func lintContract() *ContractIR {
+	return &ContractIR{
+		Metadata: ContractMetadata{Name: "Vault", Chain: "ethereum"},
+		Functions: []Function{
+			{
+				Name:            "balanceOf",
+				Description:     "Balance of an account",
+				StateMutability: View,
+				Inputs:          []Parameter{{Name: "account", Type: ParameterType{BaseType: "address"}}},
+				Outputs:         []Parameter{{Type: ParameterType{BaseType: "uint256"}}},
+			},
+			{
+				Name:            "deposit",
+				StateMutability: Payable,
+				Inputs:          []Parameter{{Name: "rate", Type: ParameterType{BaseType: "ufixed128x18"}}},
+			},
+			{Name: "debug", StateMutability: Nonpayable, Hidden: true},
+		},
+		Events: []Event{{Name: strings.Repeat("Long", 16), Description: "A long event"}},
+	}
+}
+
+func TestLint(t *testing.T) {
+	findings := Lint(lintContract(), LintConfig{Rules: map[string]Severity{RuleProhibitedWrite: SeverityError, RuleMissingDescription: SeverityInfo}})
+
+	var got []string
+	for _, finding := range findings {
+		got = append(got, finding.String())
+	}
+	expected := []string{
+		"warning: functions.balanceOf.outputs[0]: parameter has no name [unnamed-parameter]",
+		"info: functions.deposit: function has no description [missing-description]",
+		"error: functions.deposit: payable function deposit modifies state [prohibited-write]",
+		"error: functions.deposit.inputs[0]: type ufixed128x18 is not supported by the typescript generator [unsupported-type]",
+		"error: events." + strings.Repeat("Long", 16) + ": tool name get" + strings.Repeat("Long", 16) + "Logs is 71 characters long (at most 64) [tool-name-length]",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected findings\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	if findings := Lint(lintContract(), LintConfig{MaxToolNameLength: 100, Rules: map[string]Severity{RuleUnnamedParameter: SeverityOff, RuleUnsupportedType: SeverityOff, RuleMissingDescription: SeverityOff}}); len(findings) != 0 {
+		t.Errorf("Expected no findings but got %v", findings)
+	}
+}
+
+func TestLoadLintConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lint.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n  prohibited-write: error\nmaxToolNameLength: 48\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadLintConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load lint configuration: %v", err)
+	}
+	if config.Severity(RuleProhibitedWrite) != SeverityError || config.Severity(RuleUnnamedParameter) != SeverityWarning || config.MaxToolNameLength != 48 {
+		t.Errorf("Unexpected configuration %+v", config)
+	}
+
+	for content, expected := range map[string]string{
+		"rules:\n  no-writes: error\n":        `unknown lint rule "no-writes"`,
+		"rules:\n  prohibited-write: fatal\n": `invalid severity "fatal"`,
+		"target: python\n":                    `unsupported lint target "python"`,
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadLintConfig(path); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error containing %q but got %v", expected, err)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package ir
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// localePattern matches BCP 47 language tags as used for translations, e.g. "ja", "pt-BR" or "zh-Hant"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// Translations holds translated texts by language tag, e.g. {"ja": "トークンを送る"}
+// The untranslated field they accompany (e.g., Description) is the default for locales without a translation.
+type Translations map[string]string
+
+// IsLocale reports whether a string is a language tag translations can be keyed by
+func IsLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// Lookup returns the translation for a locale, falling back from regional variants to the language: "pt-BR" is
+// answered by "pt-BR", then "pt"
+// Language tags are matched case-insensitively.
+func (t Translations) Lookup(locale string) (string, bool) {
+	for tag := locale; tag != ""; {
+		for key, text := range t {
+			if strings.EqualFold(key, tag) && text != "" {
+				return text, true
+			}
+		}
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return "", false
+}
+
+// Merge returns the translations with others added, replacing those of the same language tag
+// Neither map is modified.
+func (t Translations) Merge(others Translations) Translations {
+	if len(others) == 0 {
+		return t
+	}
+	merged := make(Translations, len(t)+len(others))
+	for locale, text := range t {
+		merged[locale] = text
+	}
+	for locale, text := range others {
+		merged[locale] = text
+	}
+	return merged
+}
+
+// Locales returns the language tags a contract has translations for, sorted
+func Locales(c *ContractIR) []string {
+	found := map[string]bool{}
+	forEachDescription(c, func(_ *string, translations Translations) {
+		for locale := range translations {
+			found[locale] = true
+		}
+	})
+	locales := make([]string, 0, len(found))
+	for locale := range found {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Localize replaces the descriptions of a contract, its functions, events, errors, types and their
+// parameters by their translations to a locale, and returns the number of descriptions translated
+// Descriptions without a translation keep their default text, so generation never fails for missing translations.
+func Localize(c *ContractIR, locale string) (int, error) {
+	if !IsLocale(locale) {
+		return 0, fmt.Errorf("invalid locale %q (expected a language tag such as ja or pt-BR)", locale)
+	}
+	translated := 0
+	forEachDescription(c, func(description *string, translations Translations) {
+		if text, ok := translations.Lookup(locale); ok {
+			*description = text
+			translated++
+		}
+	})
+	return translated, nil
+}
+
+// forEachDescription calls fn with every description of a contract and its translations
+func forEachDescription(c *ContractIR, fn func(description *string, translations Translations)) {
+	fn(&c.Metadata.Description, c.Metadata.Descriptions)
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		fn(&f.Description, f.Descriptions)
+		forEachParameterDescription(f.Inputs, fn)
+		forEachParameterDescription(f.Outputs, fn)
+	}
+	for i := range c.Events {
+		fn(&c.Events[i].Description, c.Events[i].Descriptions)
+	}
+	for i := range c.Errors {
+		fn(&c.Errors[i].Description, c.Errors[i].Descriptions)
+		forEachParameterDescription(c.Errors[i].Parameters, fn)
+	}
+	for i := range c.Types {
+		fn(&c.Types[i].Description, c.Types[i].Descriptions)
+		forEachParameterDescription(c.Types[i].Fields, fn)
+	}
+}
+
+// forEachParameterDescription calls fn with the descriptions of parameters and the fields of their tuples
+func forEachParameterDescription(parameters []Parameter, fn func(description *string, translations Translations)) {
+	for i := range parameters {
+		fn(&parameters[i].Description, parameters[i].Descriptions)
+		forEachParameterDescription(parameters[i].Type.Components, fn)
+	}
+}
+
+// translationErrors reports invalid language tags and empty translations
+func translationErrors(t Translations) []ValidationError {
+	locales := make([]string, 0, len(t))
+	for locale := range t {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	var errors []ValidationError
+	for _, locale := range locales {
+		field := fmt.Sprintf("Descriptions[%s]", locale)
+		if !IsLocale(locale) {
+			errors = append(errors, ValidationError{Code: CodeInvalidTranslation, Field: field, Message: fmt.Sprintf("invalid language tag %q", locale)})
+		} else if strings.TrimSpace(t[locale]) == "" {
+			errors = append(errors, ValidationError{Code: CodeInvalidTranslation, Field: field, Message: "translation must not be empty"})
+		}
+	}
+	return errors
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func localizedContract() *ContractIR {
+	return &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum", Description: "A token", Descriptions: Translations{"ja": "トークン"}},
+		Functions: []Function{
+			{
+				Name:            "transfer",
+				Description:     "Sends tokens",
+				Descriptions:    Translations{"ja": "トークンを送る", "pt-BR": "Envia tokens"},
+				StateMutability: Nonpayable,
+				Inputs: []Parameter{
+					{Name: "to", Type: ParameterType{BaseType: "address"}, Description: "Recipient", Descriptions: Translations{"ja": "受取人"}},
+					{Name: "amount", Type: ParameterType{BaseType: "uint256"}, Description: "Amount"},
+				},
+			},
+		},
+		Events: []Event{{Name: "Transfer", Description: "Tokens moved", Descriptions: Translations{"pt": "Tokens movidos"}}},
+	}
+}
+
+func TestTranslationsLookup(t *testing.T) {
+	translations := Translations{"ja": "日本語", "pt": "Português", "zh-Hant": "繁體中文"}
+	tests := []struct {
+		locale   string
+		expected string
+		found    bool
+	}{
+		{"ja", "日本語", true},
+		{"ja-JP", "日本語", true},
+		{"pt-BR", "Português", true},
+		{"zh-hant-TW", "繁體中文", true},
+		{"zh", "", false},
+		{"fr", "", false},
+	}
+	for _, test := range tests {
+		text, found := translations.Lookup(test.locale)
+		if text != test.expected || found != test.found {
+			t.Errorf("Expected %q (%v) for %s but got %q (%v)", test.expected, test.found, test.locale, text, found)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	c := localizedContract()
+	if locales := Locales(c); !reflect.DeepEqual(locales, []string{"ja", "pt", "pt-BR"}) {
+		t.Errorf("Unexpected locales %v", locales)
+	}
+
+	translated, err := Localize(c, "ja")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated != 3 {
+		t.Errorf("Expected 3 translated descriptions but got %d", translated)
+	}
+	f := c.Functions[0]
+	if c.Metadata.Description != "トークン" || f.Description != "トークンを送る" || f.Inputs[0].Description != "受取人" {
+		t.Errorf("Expected Japanese descriptions but got %q, %q and %q", c.Metadata.Description, f.Description, f.Inputs[0].Description)
+	}
+	if f.Inputs[1].Description != "Amount" || c.Events[0].Description != "Tokens moved" {
+		t.Errorf("Expected untranslated descriptions to keep their default text")
+	}
+
+	c = localizedContract()
+	if translated, _ := Localize(c, "pt-BR"); translated != 2 || c.Events[0].Description != "Tokens movidos" {
+		t.Errorf("Expected regional locales to fall back to their language, got %d translations", translated)
+	}
+
+	if _, err := Localize(localizedContract(), "Japanese"); err == nil {
+		t.Error("Expected an invalid locale to be rejected")
+	}
+}
+
+func TestTranslationValidation(t *testing.T) {
+	f := Function{Name: "transfer", StateMutability: Nonpayable, Descriptions: Translations{"ja": " ", "Japanese": "トークンを送る"}}
+	var codes []string
+	for _, err := range f.Validate() {
+		codes = append(codes, err.Code+" "+err.Field)
+	}
+	expected := []string{CodeInvalidTranslation + " Descriptions[Japanese]", CodeInvalidTranslation + " Descriptions[ja]"}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("Expected %v but got %v", expected, codes)
+	}
+}
+
+func TestTranslationsMerge(t *testing.T) {
+	base := Translations{"ja": "古い", "de": "Alt"}
+	merged := base.Merge(Translations{"ja": "新しい"})
+	if !reflect.DeepEqual(merged, Translations{"ja": "新しい", "de": "Alt"}) {
+		t.Errorf("Unexpected merged translations %v", merged)
+	}
+	if base["ja"] != "古い" {
+		t.Error("Expected merging to leave the translations unchanged")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,170 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergePolicy decides which definition wins when two IRs define the same member differently
+type MergePolicy string
+
+const (
+	// MergeError fails the merge on conflicting definitions
+	MergeError MergePolicy = "error"
+
+	// PreferBase keeps the base IR's definition
+	PreferBase MergePolicy = "prefer-base"
+
+	// PreferExtension replaces the base IR's definition with the extension's
+	PreferExtension MergePolicy = "prefer-extension"
+)
+
+// MergeOptions configures Merge
+type MergeOptions struct {
+	// Policy for conflicting definitions (default: error)
+	OnConflict MergePolicy
+}
+
+// MergePolicies returns the supported conflict policies
+func MergePolicies() []MergePolicy {
+	return []MergePolicy{MergeError, PreferBase, PreferExtension}
+}
+
+// MergeConflictError lists the members two IRs define differently
+type MergeConflictError struct {
+	// Paths of the conflicting members, e.g. "functions.transfer(address,uint256)"
+	Conflicts []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("conflicting definitions: %s", strings.Join(e.Conflicts, ", "))
+}
+
+// Merge combines two IRs of the same chain into a new one, e.g. the interface a proxy or diamond exposes with the
+// implementation behind it. Members of the extension missing from the base are appended in order; members both define
+// identically are kept once. Functions and events are matched by signature, so overloads are kept side by side, and
+// errors and types by name. The base IR's metadata is kept, with empty fields filled in from the extension.
+// Neither IR is modified.
+func Merge(base, extension *ContractIR, opts MergeOptions) (*ContractIR, error) {
+	policy := opts.OnConflict
+	if policy == "" {
+		policy = MergeError
+	}
+	if policy != MergeError && policy != PreferBase && policy != PreferExtension {
+		return nil, fmt.Errorf("unsupported merge policy: %s (expected error, prefer-base or prefer-extension)", policy)
+	}
+	if base.Metadata.Chain != "" && extension.Metadata.Chain != "" && base.Metadata.Chain != extension.Metadata.Chain {
+		return nil, fmt.Errorf("cannot merge a %s IR with a %s IR", base.Metadata.Chain, extension.Metadata.Chain)
+	}
+
+	merged, err := copyContract(base)
+	if err != nil {
+		return nil, err
+	}
+	added, err := copyContract(extension)
+	if err != nil {
+		return nil, err
+	}
+	m := &merger{policy: policy}
+
+	mergeMetadata(&merged.Metadata, added.Metadata)
+	merged.Functions = mergeMembers(m, "functions", merged.Functions, added.Functions, functionKey)
+	merged.Events = mergeMembers(m, "events", merged.Events, added.Events, eventKey)
+	merged.Errors = mergeMembers(m, "errors", merged.Errors, added.Errors, func(e ContractError) string { return e.Name })
+	merged.Types = mergeMembers(m, "types", merged.Types, added.Types, func(t CustomType) string { return t.Name })
+
+	if len(m.conflicts) > 0 {
+		return nil, &MergeConflictError{Conflicts: m.conflicts}
+	}
+	return merged, nil
+}
+
+// merger collects the conflicts of a merge
+type merger struct {
+	policy    MergePolicy
+	conflicts []string
+}
+
+// mergeMembers merges the members of one kind, matched by key
+func mergeMembers[T any](m *merger, kind string, base, extension []T, key func(T) string) []T {
+	index := make(map[string]int, len(base))
+	for i, member := range base {
+		index[key(member)] = i
+	}
+	for _, member := range extension {
+		k := key(member)
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(base)
+			base = append(base, member)
+			continue
+		}
+		if reflect.DeepEqual(base[i], member) {
+			continue
+		}
+		switch m.policy {
+		case PreferExtension:
+			base[i] = member
+		case MergeError:
+			m.conflicts = append(m.conflicts, kind+"."+k)
+		}
+	}
+	return base
+}
+
+// mergeMetadata fills in empty metadata fields from another IR
+func mergeMetadata(metadata *ContractMetadata, other ContractMetadata) {
+	if metadata.Name == "" {
+		metadata.Name = other.Name
+	}
+	if metadata.Description == "" {
+		metadata.Description = other.Description
+	}
+	if metadata.Address == "" {
+		metadata.Address = other.Address
+	}
+	if metadata.Chain == "" {
+		metadata.Chain = other.Chain
+	}
+	if metadata.Source == nil {
+		metadata.Source = other.Source
+	}
+	for key, value := range other.ChainData {
+		if _, ok := metadata.ChainData[key]; ok {
+			continue
+		}
+		if metadata.ChainData == nil {
+			metadata.ChainData = map[string]interface{}{}
+		}
+		metadata.ChainData[key] = value
+	}
+}
+
+// functionKey identifies a function by signature, or by name if it has none
+func functionKey(f Function) string {
+	keys := functionKeys(f)
+	return keys[len(keys)-1]
+}
+
+// eventKey identifies an event by signature, or by name if it has none
+func eventKey(e Event) string {
+	if e.Signature != "" {
+		return e.Signature
+	}
+	return e.Name
+}
+
+// copyContract returns a deep copy of a contract
+func copyContract(c *ContractIR) (*ContractIR, error) {
+	content, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode IR: %w", err)
+	}
+	copied := &ContractIR{}
+	if err := json.Unmarshal(content, copied); err != nil {
+		return nil, fmt.Errorf("failed to decode IR: %w", err)
+	}
+	return copied, nil
+}
\ No newline at end of file
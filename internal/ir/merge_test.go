@@ -0,0 +1,85 @@
+package ir
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	proxy := &ContractIR{
+		Metadata: ContractMetadata{Name: "Proxy", Chain: "ethereum", Address: "0x1234"},
+		Functions: []Function{
+			{Name: "upgradeTo", Signature: "upgradeTo(address)", StateMutability: Nonpayable},
+			{Name: "implementation", Signature: "implementation()", StateMutability: View},
+		},
+		Events: []Event{{Name: "Upgraded", Signature: "Upgraded(address)"}},
+	}
+	implementation := &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum", Description: "An ERC-20 token"},
+		Functions: []Function{
+			{Name: "implementation", Signature: "implementation()", StateMutability: View},
+			{Name: "mint", Signature: "mint(uint256)", StateMutability: Nonpayable},
+			{Name: "mint", Signature: "mint(address,uint256)", StateMutability: Nonpayable},
+		},
+		Errors: []ContractError{{Name: "Paused"}},
+	}
+
+	merged, err := Merge(proxy, implementation, MergeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if merged.Metadata.Name != "Proxy" || merged.Metadata.Address != "0x1234" || merged.Metadata.Description != "An ERC-20 token" {
+		t.Errorf("Unexpected metadata %+v", merged.Metadata)
+	}
+	var signatures []string
+	for _, f := range merged.Functions {
+		signatures = append(signatures, f.Signature)
+	}
+	expected := []string{"upgradeTo(address)", "implementation()", "mint(uint256)", "mint(address,uint256)"}
+	if len(signatures) != len(expected) {
+		t.Fatalf("Expected functions %v, got %v", expected, signatures)
+	}
+	for i := range expected {
+		if signatures[i] != expected[i] {
+			t.Errorf("Expected functions %v, got %v", expected, signatures)
+			break
+		}
+	}
+	if len(merged.Events) != 1 || len(merged.Errors) != 1 {
+		t.Errorf("Expected 1 event and 1 error, got %+v %+v", merged.Events, merged.Errors)
+	}
+
+	merged.Functions[0].Description = "changed"
+	if proxy.Functions[0].Description != "" {
+		t.Error("Merge modified the base IR")
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	base := &ContractIR{Functions: []Function{{Name: "owner", Signature: "owner()", StateMutability: View, Description: "Proxy admin"}}}
+	extension := &ContractIR{Functions: []Function{{Name: "owner", Signature: "owner()", StateMutability: View, Description: "Token owner"}}}
+
+	_, err := Merge(base, extension, MergeOptions{OnConflict: MergeError})
+	var conflict *MergeConflictError
+	if !errors.As(err, &conflict) || len(conflict.Conflicts) != 1 || conflict.Conflicts[0] != "functions.owner()" {
+		t.Fatalf("Expected a conflict on functions.owner(), got %v", err)
+	}
+
+	tests := map[MergePolicy]string{PreferBase: "Proxy admin", PreferExtension: "Token owner"}
+	for policy, description := range tests {
+		merged, err := Merge(base, extension, MergeOptions{OnConflict: policy})
+		if err != nil {
+			t.Fatalf("Failed to merge with %s: %v", policy, err)
+		}
+		if len(merged.Functions) != 1 || merged.Functions[0].Description != description {
+			t.Errorf("Expected %q with %s, got %+v", description, policy, merged.Functions)
+		}
+	}
+
+	if _, err := Merge(base, extension, MergeOptions{OnConflict: "newest"}); err == nil {
+		t.Error("Expected an error for an unsupported policy")
+	}
+	if _, err := Merge(&ContractIR{Metadata: ContractMetadata{Chain: "ethereum"}}, &ContractIR{Metadata: ContractMetadata{Chain: "solana"}}, MergeOptions{}); err == nil {
+		t.Error("Expected an error merging IRs of different chains")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,125 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NameParameters names the unnamed parameters of a contract, so generated code never has empty identifiers:
+// function inputs, event and error parameters are named arg0, arg1, ..., tuple components field0, field1, ...,
+// and function outputs after what the function returns, e.g. balance for balanceOf, totalSupply for
+// totalSupply() and success for a boolean returned by a write function, falling back to result0, result1, ...
+// Names are chosen by position, so they are stable across runs. It returns the number of parameters named.
+func NameParameters(c *ContractIR) int {
+	named := 0
+	for i := range c.Functions {
+		named += NameFunctionParameters(&c.Functions[i])
+	}
+	for i := range c.Events {
+		for j := range c.Events[i].Parameters {
+			p := &c.Events[i].Parameters[j]
+			if strings.TrimSpace(p.Name) == "" {
+				p.Name = fmt.Sprintf("arg%d", j)
+				named++
+			}
+			named += nameComponents(p.Type.Components)
+		}
+	}
+	for i := range c.Errors {
+		named += nameParameters(c.Errors[i].Parameters, "arg", nil)
+	}
+	for i := range c.Types {
+		named += nameParameters(c.Types[i].Fields, "field", nil)
+	}
+	return named
+}
+
+// NameFunctionParameters names the unnamed inputs, outputs and tuple components of a function as NameParameters does,
+// e.g. for parsers describing the function by its parameters, and returns the number of parameters named
+func NameFunctionParameters(f *Function) int {
+	named := nameParameters(f.Inputs, "arg", nil)
+	named += nameParameters(f.Outputs, "result", func(index int) string {
+		if len(f.Outputs) == 1 {
+			return outputName(*f)
+		}
+		return ""
+	})
+	for j := range f.Inputs {
+		named += nameComponents(f.Inputs[j].Type.Components)
+	}
+	for j := range f.Outputs {
+		named += nameComponents(f.Outputs[j].Type.Components)
+	}
+	return named
+}
+
+// nameParameters names the unnamed parameters of a list by the suggested name, if any and not taken,
+// or else by prefix and position
+func nameParameters(parameters []Parameter, prefix string, suggest func(index int) string) int {
+	taken := map[string]bool{}
+	for _, p := range parameters {
+		taken[p.Name] = true
+	}
+	named := 0
+	for i := range parameters {
+		if strings.TrimSpace(parameters[i].Name) != "" {
+			continue
+		}
+		name := ""
+		if suggest != nil {
+			name = suggest(i)
+		}
+		if name == "" || taken[name] {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		for taken[name] {
+			// A named parameter already uses the positional name
+			name += "_"
+		}
+		parameters[i].Name = name
+		taken[name] = true
+		named++
+	}
+	return named
+}
+
+// nameComponents names unnamed tuple components field0, field1, ..., recursively
+func nameComponents(components []Parameter) int {
+	named := nameParameters(components, "field", nil)
+	for i := range components {
+		named += nameComponents(components[i].Type.Components)
+	}
+	return named
+}
+
+// outputName suggests the name of a function's single output, or returns ""
+func outputName(f Function) string {
+	name := f.ABIName()
+	switch {
+	case f.StateMutability != View && f.StateMutability != Pure:
+		if f.Outputs[0].Type.BaseType == "bool" && !f.Outputs[0].Type.IsArray {
+			return "success"
+		}
+		return ""
+	case strings.HasPrefix(name, "get") && len(name) > 3 && unicode.IsUpper(rune(name[3])):
+		return lowerFirst(name[3:])
+	case strings.HasSuffix(name, "Of") && len(name) > 2:
+		return strings.TrimSuffix(name, "Of")
+	case len(f.Inputs) == 0:
+		return name
+	}
+	return ""
+}
+
+// lowerFirst lowercases the first character of a name, and the whole leading acronym, e.g. URI to uri
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) || (i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
\ No newline at end of file
@@ -0,0 +1,65 @@
+package ir
+
+import (
+	"testing"
+)
+
+func TestNameParameters(t *testing.T) {
+	uint256 := ParameterType{BaseType: "uint256"}
+	unnamed := func(baseType string) Parameter { return Parameter{Type: ParameterType{BaseType: baseType}} }
+	contract := &ContractIR{
+		Functions: []Function{
+			{Name: "balanceOf", StateMutability: View, Inputs: []Parameter{unnamed("address")}, Outputs: []Parameter{unnamed("uint256")}},
+			{Name: "totalSupply", StateMutability: View, Outputs: []Parameter{unnamed("uint256")}},
+			{Name: "getTokenURI", StateMutability: View, Inputs: []Parameter{{Name: "id", Type: uint256}}, Outputs: []Parameter{unnamed("string")}},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, unnamed("uint256")}, Outputs: []Parameter{unnamed("bool")}},
+			{Name: "getReserves", StateMutability: View, Outputs: []Parameter{unnamed("uint112"), {Name: "result0", Type: uint256}, unnamed("uint32")}},
+			{Name: "quote", StateMutability: View, Inputs: []Parameter{{Name: "amount", Type: uint256}}, Outputs: []Parameter{{Type: ParameterType{BaseType: "tuple", Components: []Parameter{unnamed("uint256"), {Name: "fee", Type: uint256}}}}}},
+		},
+		Events: []Event{{Name: "Transfer", Parameters: []EventParameter{{Name: "from", Type: ParameterType{BaseType: "address"}}, {Type: uint256}}}},
+		Errors: []ContractError{{Name: "Insufficient", Parameters: []Parameter{unnamed("uint256")}}},
+	}
+
+	if named := NameParameters(contract); named != 12 {
+		t.Errorf("Expected 12 named parameters but got %d", named)
+	}
+	expected := map[string]string{
+		"balanceOf input":    contract.Functions[0].Inputs[0].Name,
+		"balance":            contract.Functions[0].Outputs[0].Name,
+		"totalSupply":        contract.Functions[1].Outputs[0].Name,
+		"tokenURI":           contract.Functions[2].Outputs[0].Name,
+		"arg1":               contract.Functions[3].Inputs[1].Name,
+		"success":            contract.Functions[3].Outputs[0].Name,
+		"result2":            contract.Functions[4].Outputs[2].Name,
+		"result":             contract.Functions[5].Outputs[0].Name,
+		"field0":             contract.Functions[5].Outputs[0].Type.Components[0].Name,
+		"Transfer parameter": contract.Events[0].Parameters[1].Name,
+		"error parameter":    contract.Errors[0].Parameters[0].Name,
+	}
+	names := map[string]string{
+		"balanceOf input":    "arg0",
+		"balance":            "balance",
+		"totalSupply":        "totalSupply",
+		"tokenURI":           "tokenURI",
+		"arg1":               "arg1",
+		"success":            "success",
+		"result2":            "result2",
+		"result":             "result0",
+		"field0":             "field0",
+		"Transfer parameter": "arg1",
+		"error parameter":    "arg0",
+	}
+	for key, got := range expected {
+		if got != names[key] {
+			t.Errorf("Expected %s to be named %s but got %s", key, names[key], got)
+		}
+	}
+	// The first output of getReserves cannot take result0, which is taken
+	if name := contract.Functions[4].Outputs[0].Name; name != "result0_" {
+		t.Errorf("Expected the first output of getReserves to be named result0_ but got %s", name)
+	}
+
+	if named := NameParameters(contract); named != 0 {
+		t.Errorf("Expected naming to be idempotent but %d parameters were renamed", named)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,152 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Chain data keys of the network a contract's server was generated for, in the metadata's chain data
+const (
+	// NetworkKey holds the name of the network preset (e.g., "base")
+	NetworkKey = "network"
+
+	// ChainIDKey holds the chain ID of the network
+	ChainIDKey = "chainId"
+
+	// RPCURLKey holds the public RPC URL generated servers default to
+	RPCURLKey = "rpcUrl"
+
+	// ExplorerURLKey holds the URL of the network's block explorer
+	ExplorerURLKey = "explorerUrl"
+
+	// ExplorerAPIKey holds the base URL of the block explorer's Etherscan-compatible API
+	ExplorerAPIKey = "explorerApi"
+)
+
+// Network is the chain data of the network a contract's server was generated for, given with --network
+type Network struct {
+	// Name of the preset, and other names it is selected by
+	Name    string
+	Aliases []string
+
+	// Chain ID of the network
+	ChainID int
+
+	// Public RPC URL generated servers use unless configured otherwise
+	RPCURL string
+
+	// Block explorer, and the base URL of its Etherscan-compatible API
+	ExplorerURL string
+	ExplorerAPI string
+}
+
+// networks are the network presets, mainnets first
+var networks = []Network{
+	{Name: "mainnet", Aliases: []string{"ethereum"}, ChainID: 1, RPCURL: "https://eth.llamarpc.com", ExplorerURL: "https://etherscan.io", ExplorerAPI: "https://api.etherscan.io/api"},
+	{Name: "optimism", Aliases: []string{"op"}, ChainID: 10, RPCURL: "https://optimism-rpc.publicnode.com", ExplorerURL: "https://optimistic.etherscan.io", ExplorerAPI: "https://api-optimistic.etherscan.io/api"},
+	{Name: "bsc", Aliases: []string{"bnb"}, ChainID: 56, RPCURL: "https://bsc-rpc.publicnode.com", ExplorerURL: "https://bscscan.com", ExplorerAPI: "https://api.bscscan.com/api"},
+	{Name: "gnosis", ChainID: 100, RPCURL: "https://gnosis-rpc.publicnode.com", ExplorerURL: "https://gnosisscan.io", ExplorerAPI: "https://api.gnosisscan.io/api"},
+	{Name: "polygon", ChainID: 137, RPCURL: "https://polygon-bor-rpc.publicnode.com", ExplorerURL: "https://polygonscan.com", ExplorerAPI: "https://api.polygonscan.com/api"},
+	{Name: "base", ChainID: 8453, RPCURL: "https://base-rpc.publicnode.com", ExplorerURL: "https://basescan.org", ExplorerAPI: "https://api.basescan.org/api"},
+	{Name: "arbitrum", Aliases: []string{"arbitrum-one"}, ChainID: 42161, RPCURL: "https://arbitrum-one-rpc.publicnode.com", ExplorerURL: "https://arbiscan.io", ExplorerAPI: "https://api.arbiscan.io/api"},
+	{Name: "avalanche", Aliases: []string{"avax"}, ChainID: 43114, RPCURL: "https://avalanche-c-chain-rpc.publicnode.com", ExplorerURL: "https://snowtrace.io", ExplorerAPI: "https://api.snowtrace.io/api"},
+	{Name: "linea", ChainID: 59144, RPCURL: "https://linea-rpc.publicnode.com", ExplorerURL: "https://lineascan.build", ExplorerAPI: "https://api.lineascan.build/api"},
+	{Name: "scroll", ChainID: 534352, RPCURL: "https://scroll-rpc.publicnode.com", ExplorerURL: "https://scrollscan.com", ExplorerAPI: "https://api.scrollscan.com/api"},
+	{Name: "holesky", ChainID: 17000, RPCURL: "https://ethereum-holesky-rpc.publicnode.com", ExplorerURL: "https://holesky.etherscan.io", ExplorerAPI: "https://api-holesky.etherscan.io/api"},
+	{Name: "polygon-amoy", ChainID: 80002, RPCURL: "https://polygon-amoy-bor-rpc.publicnode.com", ExplorerURL: "https://amoy.polygonscan.com", ExplorerAPI: "https://api-amoy.polygonscan.com/api"},
+	{Name: "base-sepolia", ChainID: 84532, RPCURL: "https://base-sepolia-rpc.publicnode.com", ExplorerURL: "https://sepolia.basescan.org", ExplorerAPI: "https://api-sepolia.basescan.org/api"},
+	{Name: "arbitrum-sepolia", ChainID: 421614, RPCURL: "https://arbitrum-sepolia-rpc.publicnode.com", ExplorerURL: "https://sepolia.arbiscan.io", ExplorerAPI: "https://api-sepolia.arbiscan.io/api"},
+	{Name: "sepolia", ChainID: 11155111, RPCURL: "https://ethereum-sepolia-rpc.publicnode.com", ExplorerURL: "https://sepolia.etherscan.io", ExplorerAPI: "https://api-sepolia.etherscan.io/api"},
+	{Name: "optimism-sepolia", ChainID: 11155420, RPCURL: "https://optimism-sepolia-rpc.publicnode.com", ExplorerURL: "https://sepolia-optimism.etherscan.io", ExplorerAPI: "https://api-sepolia-optimistic.etherscan.io/api"},
+}
+
+// Networks returns the network presets, mainnets first
+func Networks() []Network {
+	return append([]Network(nil), networks...)
+}
+
+// NetworkNames returns the names of the network presets, sorted
+func NetworkNames() []string {
+	names := make([]string, len(networks))
+	for i, n := range networks {
+		names[i] = n.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupNetwork returns the network preset of a name or alias, case-insensitively
+func LookupNetwork(name string) (Network, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, n := range networks {
+		if n.Name == name {
+			return n, nil
+		}
+		for _, alias := range n.Aliases {
+			if alias == name {
+				return n, nil
+			}
+		}
+	}
+	return Network{}, fmt.Errorf("unknown network: %s (expected one of %s)", name, strings.Join(NetworkNames(), ", "))
+}
+
+// NetworkByChainID returns the network preset of a chain ID, if there is one
+func NetworkByChainID(chainID int) (Network, bool) {
+	for _, n := range networks {
+		if n.ChainID == chainID {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+// Network returns the network chain data of a contract's metadata; its Name is empty without one
+func (m ContractMetadata) Network() Network {
+	return Network{
+		Name:        chainString(m.ChainData, NetworkKey),
+		ChainID:     chainInt(m.ChainData, ChainIDKey),
+		RPCURL:      chainString(m.ChainData, RPCURLKey),
+		ExplorerURL: chainString(m.ChainData, ExplorerURLKey),
+		ExplorerAPI: chainString(m.ChainData, ExplorerAPIKey),
+	}
+}
+
+// ChainData returns the chain data map holding the network data, without unset entries
+func (n Network) ChainData() map[string]interface{} {
+	data := map[string]interface{}{}
+	setChainString(data, NetworkKey, n.Name)
+	if n.ChainID > 0 {
+		data[ChainIDKey] = n.ChainID
+	}
+	setChainString(data, RPCURLKey, n.RPCURL)
+	setChainString(data, ExplorerURLKey, n.ExplorerURL)
+	setChainString(data, ExplorerAPIKey, n.ExplorerAPI)
+	return data
+}
+
+// SetNetwork records the network a contract's server is generated for in its metadata's chain data, replacing the
+// one recorded before, and records its address as the deployment on the network's chain unless it has one
+func (m *ContractMetadata) SetNetwork(n Network) {
+	if m.ChainData == nil {
+		m.ChainData = map[string]interface{}{}
+	}
+	for _, key := range []string{NetworkKey, ChainIDKey, RPCURLKey, ExplorerURLKey, ExplorerAPIKey} {
+		delete(m.ChainData, key)
+	}
+	for key, value := range n.ChainData() {
+		m.ChainData[key] = value
+	}
+
+	if m.Address == "" || n.ChainID <= 0 {
+		return
+	}
+	if _, ok := m.DeploymentOn(uint64(n.ChainID)); ok {
+		return
+	}
+	if m.Deployments == nil {
+		m.Deployments = map[string]Deployment{}
+	}
+	m.Deployments[fmt.Sprint(n.ChainID)] = Deployment{Address: m.Address}
+}
\ No newline at end of file
@@ -0,0 +1,65 @@
+package ir
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLookupNetwork(t *testing.T) {
+	for _, name := range []string{"base", "BASE", " arbitrum-one ", "ethereum"} {
+		if _, err := LookupNetwork(name); err != nil {
+			t.Errorf("Expected %q to be a network preset but got %v", name, err)
+		}
+	}
+	if n, _ := LookupNetwork("op"); n.Name != "optimism" || n.ChainID != 10 {
+		t.Errorf("Expected op to select optimism but got %+v", n)
+	}
+	if _, err := LookupNetwork("goerli"); err == nil {
+		t.Error("Expected an unknown network to fail")
+	}
+
+	seen := map[int]bool{}
+	for _, n := range Networks() {
+		if seen[n.ChainID] || n.ChainID <= 0 || n.RPCURL == "" || n.ExplorerURL == "" || n.ExplorerAPI == "" {
+			t.Errorf("Incomplete or duplicate network preset %+v", n)
+		}
+		seen[n.ChainID] = true
+	}
+}
+
+func TestSetNetwork(t *testing.T) {
+	base, _ := LookupNetwork("base")
+	m := ContractMetadata{Name: "Token", Address: "0x0000000000000000000000000000000000000001", ChainData: map[string]interface{}{"custom": "kept"}}
+	m.SetNetwork(base)
+	if m.ChainData["custom"] != "kept" || m.ChainData[NetworkKey] != "base" {
+		t.Errorf("Unexpected chain data %v", m.ChainData)
+	}
+	if deployment, ok := m.DeploymentOn(8453); !ok || deployment.Address != m.Address {
+		t.Errorf("Expected the address to be recorded as the deployment on Base but got %+v", m.Deployments)
+	}
+
+	// Numbers of IR files are decoded as float64
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ContractMetadata
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Network{Name: "base", ChainID: 8453, RPCURL: base.RPCURL, ExplorerURL: base.ExplorerURL, ExplorerAPI: base.ExplorerAPI}); !reflect.DeepEqual(decoded.Network(), expected) {
+		t.Errorf("Expected network %+v but got %+v", expected, decoded.Network())
+	}
+
+	// Another network replaces the recorded one, keeping existing deployments
+	sepolia, _ := LookupNetwork("sepolia")
+	m.Deployments["11155111"] = Deployment{Address: "0x0000000000000000000000000000000000000002"}
+	m.SetNetwork(sepolia)
+	if n := m.Network(); n.Name != "sepolia" || n.ChainID != 11155111 {
+		t.Errorf("Expected sepolia but got %+v", n)
+	}
+	if deployment, _ := m.DeploymentOn(11155111); deployment.Address != "0x0000000000000000000000000000000000000002" {
+		t.Errorf("Expected the existing deployment to be kept but got %+v", deployment)
+	}
+}
\ No newline at end of file
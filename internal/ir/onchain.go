@@ -0,0 +1,80 @@
+package ir
+
+// Chain data keys of what was looked up about a deployed contract, in the metadata's chain data
+const (
+	// TokenNameKey, TokenSymbolKey and TokenDecimalsKey hold what the token's name(), symbol() and decimals() return
+	TokenNameKey     = "tokenName"
+	TokenSymbolKey   = "tokenSymbol"
+	TokenDecimalsKey = "tokenDecimals"
+
+	// VerificationKey holds where the contract's source is verified: sourcify, the host of an explorer's API, or
+	// unverified
+	VerificationKey = "verification"
+
+	// ImplementationKey and ProxyAdminKey hold the implementation and admin of EIP-1967 proxies
+	ImplementationKey = "implementation"
+	ProxyAdminKey     = "proxyAdmin"
+)
+
+// Unverified is the verification of contracts that no source has verified
+const Unverified = "unverified"
+
+// OnChain is the chain data of what was looked up about a deployed contract; fields are empty if unknown
+type OnChain struct {
+	// Token name, symbol and decimals
+	TokenName     string
+	TokenSymbol   string
+	TokenDecimals *int
+
+	// Where the contract's source is verified, or Unverified
+	Verification string
+
+	// Implementation and admin of an EIP-1967 proxy
+	Implementation string
+	ProxyAdmin     string
+}
+
+// OnChain returns what was looked up about a contract, from its metadata's chain data
+func (m ContractMetadata) OnChain() OnChain {
+	o := OnChain{
+		TokenName:      chainString(m.ChainData, TokenNameKey),
+		TokenSymbol:    chainString(m.ChainData, TokenSymbolKey),
+		Verification:   chainString(m.ChainData, VerificationKey),
+		Implementation: chainString(m.ChainData, ImplementationKey),
+		ProxyAdmin:     chainString(m.ChainData, ProxyAdminKey),
+	}
+	// Decimals may be 0, so they are set if present rather than non-zero
+	if _, ok := m.ChainData[TokenDecimalsKey]; ok {
+		decimals := chainInt(m.ChainData, TokenDecimalsKey)
+		o.TokenDecimals = &decimals
+	}
+	return o
+}
+
+// ChainData returns the chain data map holding what was looked up, without unset entries
+func (o OnChain) ChainData() map[string]interface{} {
+	data := map[string]interface{}{}
+	setChainString(data, TokenNameKey, o.TokenName)
+	setChainString(data, TokenSymbolKey, o.TokenSymbol)
+	if o.TokenDecimals != nil {
+		data[TokenDecimalsKey] = *o.TokenDecimals
+	}
+	setChainString(data, VerificationKey, o.Verification)
+	setChainString(data, ImplementationKey, o.Implementation)
+	setChainString(data, ProxyAdminKey, o.ProxyAdmin)
+	return data
+}
+
+// SetOnChain records what was looked up about a contract in its metadata's chain data, replacing what was recorded
+// before
+func (m *ContractMetadata) SetOnChain(o OnChain) {
+	if m.ChainData == nil {
+		m.ChainData = map[string]interface{}{}
+	}
+	for _, key := range []string{TokenNameKey, TokenSymbolKey, TokenDecimalsKey, VerificationKey, ImplementationKey, ProxyAdminKey} {
+		delete(m.ChainData, key)
+	}
+	for key, value := range o.ChainData() {
+		m.ChainData[key] = value
+	}
+}
\ No newline at end of file
@@ -0,0 +1,32 @@
+package ir
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSetOnChain(t *testing.T) {
+	zero := 0
+	m := ContractMetadata{Name: "Token", ChainData: map[string]interface{}{"custom": "kept", TokenNameKey: "Old"}}
+	m.SetOnChain(OnChain{TokenSymbol: "GOV", TokenDecimals: &zero, Verification: Unverified})
+	if m.ChainData["custom"] != "kept" || m.ChainData[TokenNameKey] != nil || m.ChainData[ImplementationKey] != nil {
+		t.Errorf("Unexpected chain data %v", m.ChainData)
+	}
+
+	// Zero decimals survive a JSON round trip, where they decode as a float64
+	content, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ContractMetadata
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	o := decoded.OnChain()
+	if o.TokenSymbol != "GOV" || o.TokenDecimals == nil || *o.TokenDecimals != 0 || o.Verification != Unverified || o.TokenName != "" {
+		t.Errorf("Unexpected on-chain data %+v", o)
+	}
+	if (ContractMetadata{}).OnChain().TokenDecimals != nil {
+		t.Error("Expected no decimals without chain data")
+	}
+}
\ No newline at end of file
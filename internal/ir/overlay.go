@@ -0,0 +1,306 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// toolNamePattern matches names tools can be renamed to
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Overlay holds customizations merged onto a freshly parsed IR, so they survive regenerating it from the ABI
+// Functions are keyed by their IR name (e.g. "transfer", or "transfer_1" for an overload named by suffix) or their
+// canonical signature (e.g. "transfer(address,uint256)"), events by name, and function parameters by name or position
+// ("0", "1", ...)
+type Overlay struct {
+	// Contract metadata overrides
+	Contract *ContractOverlay `json:"contract,omitempty"`
+
+	// Function overrides by name or signature
+	Functions map[string]FunctionOverlay `json:"functions,omitempty"`
+
+	// Event overrides by name
+	Events map[string]EventOverlay `json:"events,omitempty"`
+}
+
+// ContractOverlay overrides contract metadata
+type ContractOverlay struct {
+	// Contract name
+	Name string `json:"name,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Translated descriptions by language tag, added to those of the IR
+	Descriptions Translations `json:"descriptions,omitempty"`
+
+	// Deployed address
+	Address string `json:"address,omitempty"`
+}
+
+// FunctionOverlay overrides a function and the tool generated for it
+type FunctionOverlay struct {
+	// Tool name; the contract is still called by its ABI name
+	Name string `json:"name,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Translated descriptions by language tag, added to those of the IR
+	Descriptions Translations `json:"descriptions,omitempty"`
+
+	// Whether the function is left out of the generated server
+	Hidden bool `json:"hidden,omitempty"`
+
+	// Whether the function's tool is marked as deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Category the function's tool is grouped by
+	Category string `json:"category,omitempty"`
+
+	// Labels grouping the function, replacing any it has
+	Tags []string `json:"tags,omitempty"`
+
+	// Input overrides by name or position
+	Inputs map[string]ParameterOverlay `json:"inputs,omitempty"`
+
+	// Output overrides by name or position
+	Outputs map[string]ParameterOverlay `json:"outputs,omitempty"`
+}
+
+// EventOverlay overrides an event and the log tool generated for it
+type EventOverlay struct {
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Translated descriptions by language tag, added to those of the IR
+	Descriptions Translations `json:"descriptions,omitempty"`
+
+	// Whether the event is left out of the generated server
+	Hidden bool `json:"hidden,omitempty"`
+
+	// Whether the event's log tool is marked as deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// ParameterOverlay overrides a parameter
+type ParameterOverlay struct {
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Translated descriptions by language tag, added to those of the IR
+	Descriptions Translations `json:"descriptions,omitempty"`
+}
+
+// LoadOverlay reads an overlay from a JSON or, by its .yaml or .yml extension, YAML file
+// Unknown keys are rejected so that typos do not go unnoticed
+func LoadOverlay(path string) (*Overlay, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+
+	overlay, err := ParseOverlay(content, IsYAMLFile(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse overlay %s: %w", path, err)
+	}
+	return overlay, nil
+}
+
+// ParseOverlay parses an overlay from JSON or YAML content, rejecting unknown keys
+func ParseOverlay(content []byte, yaml bool) (*Overlay, error) {
+	// YAML is decoded through JSON so the overlay has a single set of field names
+	if yaml {
+		var err error
+		if content, err = YAMLToJSON(content); err != nil {
+			return nil, err
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	overlay := &Overlay{}
+	if err := decoder.Decode(overlay); err != nil {
+		return nil, err
+	}
+	return overlay, nil
+}
+
+// Apply merges the overlay onto a contract
+// Keys matching nothing in the contract, e.g. after a function was removed from the ABI, are returned
+// rather than failing, so an overlay keeps working across ABI changes. Invalid renames are errors.
+func (o *Overlay) Apply(c *ContractIR) (unmatched []string, err error) {
+	if o.Contract != nil {
+		if o.Contract.Name != "" {
+			c.Metadata.Name = o.Contract.Name
+		}
+		if o.Contract.Description != "" {
+			c.Metadata.Description = o.Contract.Description
+		}
+		c.Metadata.Descriptions = c.Metadata.Descriptions.Merge(o.Contract.Descriptions)
+		if o.Contract.Address != "" {
+			c.Metadata.Address = o.Contract.Address
+		}
+	}
+
+	matched := map[string]bool{}
+	functions := make([]Function, 0, len(c.Functions))
+	for _, f := range c.Functions {
+		key, overlay, ok := o.function(f)
+		if !ok {
+			functions = append(functions, f)
+			continue
+		}
+		matched["functions."+key] = true
+		// Hidden functions stay in the IR, so their parameter overrides still apply
+		f.Hidden = f.Hidden || overlay.Hidden
+		f.Deprecated = f.Deprecated || overlay.Deprecated
+
+		if overlay.Name != "" && overlay.Name != f.Name {
+			if !toolNamePattern.MatchString(overlay.Name) {
+				return nil, fmt.Errorf("functions.%s: invalid tool name %q", key, overlay.Name)
+			}
+			if _, ok := f.ChainData[OriginalNameKey]; !ok && f.Overload == nil {
+				// Calls go through the ABI name, which the overload set already records for overloads
+				f.ChainData = copyChainData(f.ChainData)
+				f.ChainData[OriginalNameKey] = f.Name
+			}
+			f.Name = overlay.Name
+		}
+		if overlay.Description != "" {
+			f.Description = overlay.Description
+		}
+		f.Descriptions = f.Descriptions.Merge(overlay.Descriptions)
+		if overlay.Category != "" {
+			if errs := categoryErrors(overlay.Category); len(errs) > 0 {
+				return nil, fmt.Errorf("functions.%s: %s", key, errs[0].Message)
+			}
+			f.Category = overlay.Category
+		}
+		if len(overlay.Tags) > 0 {
+			f.Tags = overlay.Tags
+		}
+		f.Inputs = applyParameters(f.Inputs, overlay.Inputs, "functions."+key+".inputs.", matched)
+		f.Outputs = applyParameters(f.Outputs, overlay.Outputs, "functions."+key+".outputs.", matched)
+		functions = append(functions, f)
+	}
+
+	names := map[string]bool{}
+	for _, f := range functions {
+		if names[f.Name] {
+			return nil, fmt.Errorf("more than one function is named %s after applying the overlay", f.Name)
+		}
+		names[f.Name] = true
+	}
+	c.Functions = functions
+
+	events := make([]Event, 0, len(c.Events))
+	for _, e := range c.Events {
+		overlay, ok := o.Events[e.Name]
+		if !ok {
+			events = append(events, e)
+			continue
+		}
+		matched["events."+e.Name] = true
+		e.Hidden = e.Hidden || overlay.Hidden
+		e.Deprecated = e.Deprecated || overlay.Deprecated
+		if overlay.Description != "" {
+			e.Description = overlay.Description
+		}
+		e.Descriptions = e.Descriptions.Merge(overlay.Descriptions)
+		events = append(events, e)
+	}
+	c.Events = events
+
+	return o.unmatched(matched), nil
+}
+
+// function returns the overlay of a function, matched by IR name first and canonical signature second
+func (o *Overlay) function(f Function) (string, FunctionOverlay, bool) {
+	for _, key := range functionKeys(f) {
+		if overlay, ok := o.Functions[key]; ok {
+			return key, overlay, true
+		}
+	}
+	return "", FunctionOverlay{}, false
+}
+
+// functionKeys returns the keys a function can be customized by: its IR name and its canonical signature, if known
+func functionKeys(f Function) []string {
+	keys := []string{f.Name}
+	signature := f.Signature
+	if f.Overload != nil && f.Overload.Signature != "" {
+		signature = f.Overload.Signature
+	} else if original := f.EVM().OriginalSignature; original != "" {
+		signature = original
+	}
+	if signature != "" {
+		keys = append(keys, signature)
+	}
+	return keys
+}
+
+// applyParameters applies parameter overrides keyed by name or position, recording the keys used
+func applyParameters(parameters []Parameter, overlays map[string]ParameterOverlay, prefix string, matched map[string]bool) []Parameter {
+	if len(overlays) == 0 {
+		return parameters
+	}
+	result := make([]Parameter, len(parameters))
+	copy(result, parameters)
+	for i := range result {
+		for _, key := range []string{result[i].Name, strconv.Itoa(i)} {
+			overlay, ok := overlays[key]
+			if !ok || key == "" {
+				continue
+			}
+			matched[prefix+key] = true
+			if overlay.Description != "" {
+				result[i].Description = overlay.Description
+			}
+			result[i].Descriptions = result[i].Descriptions.Merge(overlay.Descriptions)
+			break
+		}
+	}
+	return result
+}
+
+// unmatched lists the overlay keys not recorded as matched, in order
+func (o *Overlay) unmatched(matched map[string]bool) []string {
+	unmatched := []string{}
+	check := func(key string) {
+		if !matched[key] {
+			unmatched = append(unmatched, key)
+		}
+	}
+	for key, f := range o.Functions {
+		if !matched["functions."+key] {
+			check("functions." + key)
+			continue
+		}
+		for name := range f.Inputs {
+			check("functions." + key + ".inputs." + name)
+		}
+		for name := range f.Outputs {
+			check("functions." + key + ".outputs." + name)
+		}
+	}
+	for key := range o.Events {
+		check("events." + key)
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+// copyChainData copies chain data so overrides do not leak into other parsed values sharing the map
+func copyChainData(data map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(data)+1)
+	for key, value := range data {
+		copied[key] = value
+	}
+	return copied
+}
\ No newline at end of file
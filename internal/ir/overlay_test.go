@@ -0,0 +1,153 @@
+package ir
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// overlayContract has an overloaded function, a plain one and two events
+func overlayContract() *ContractIR {
+	return &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []Function{
+			{
+				Name:            "balanceOf",
+				Signature:       "balanceOf(address)",
+				StateMutability: View,
+				Inputs:          []Parameter{{Name: "account", Type: ParameterType{BaseType: "address"}}},
+				Outputs:         []Parameter{{Type: ParameterType{BaseType: "uint256"}}},
+			},
+			{
+				Name:            "mint",
+				Signature:       "mint(address,uint256)",
+				StateMutability: Nonpayable,
+				Inputs:          []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, {Name: "amount", Type: ParameterType{BaseType: "uint256"}}},
+			},
+			{
+				Name:            "mint_1",
+				Signature:       "mint(uint256)",
+				StateMutability: Nonpayable,
+				Inputs:          []Parameter{{Name: "amount", Type: ParameterType{BaseType: "uint256"}}},
+				ChainData:       map[string]interface{}{"originalName": "mint", "originalSignature": "mint(uint256)"},
+			},
+		},
+		Events: []Event{{Name: "Transfer"}, {Name: "Approval"}},
+	}
+}
+
+func TestOverlayApply(t *testing.T) {
+	overlay := &Overlay{
+		Contract: &ContractOverlay{Description: "A token"},
+		Functions: map[string]FunctionOverlay{
+			"balanceOf": {
+				Name:        "getBalance",
+				Description: "Balance of an account",
+				Inputs:      map[string]ParameterOverlay{"account": {Description: "Holder"}},
+				Outputs:     map[string]ParameterOverlay{"0": {Description: "Balance"}},
+			},
+			"mint(uint256)": {Name: "mintToSelf", Category: CategoryAdmin, Tags: []string{"supply"}},
+			"burn":          {Hidden: true},
+		},
+		Events: map[string]EventOverlay{"Approval": {Hidden: true}, "Transfer": {Description: "Tokens moved"}},
+	}
+
+	contract := overlayContract()
+	unmatched, err := overlay.Apply(contract)
+	if err != nil {
+		t.Fatalf("Failed to apply the overlay: %v", err)
+	}
+	if expected := []string{"functions.burn"}; !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("Expected unmatched keys %v but got %v", expected, unmatched)
+	}
+	if contract.Metadata.Description != "A token" || contract.Metadata.Name != "Token" {
+		t.Errorf("Unexpected metadata %+v", contract.Metadata)
+	}
+
+	balance := contract.Functions[0]
+	if balance.Name != "getBalance" || balance.ABIName() != "balanceOf" || balance.Description != "Balance of an account" {
+		t.Errorf("Unexpected renamed function %+v", balance)
+	}
+	if balance.Inputs[0].Description != "Holder" || balance.Outputs[0].Description != "Balance" {
+		t.Errorf("Parameter descriptions were not applied: %+v %+v", balance.Inputs, balance.Outputs)
+	}
+
+	// Overloads keep the ABI name they were parsed with
+	if overload := contract.Functions[2]; overload.Name != "mintToSelf" || overload.ABIName() != "mint" || overload.Category != CategoryAdmin || len(overload.Tags) != 1 {
+		t.Errorf("Unexpected renamed overload %+v", overload)
+	}
+
+	if len(contract.Events) != 2 || contract.Events[0].Description != "Tokens moved" || contract.Events[0].Hidden || !contract.Events[1].Hidden {
+		t.Errorf("Unexpected events %+v", contract.Events)
+	}
+}
+
+func TestOverlayApplyHidden(t *testing.T) {
+	contract := overlayContract()
+	if _, err := (&Overlay{Functions: map[string]FunctionOverlay{"mint": {Hidden: true}, "balanceOf": {Deprecated: true}}}).Apply(contract); err != nil {
+		t.Fatalf("Failed to apply the overlay: %v", err)
+	}
+	if len(contract.Functions) != 3 || !contract.Functions[1].Hidden || contract.Functions[2].Hidden {
+		t.Errorf("Expected only the mint overload keyed by name to be hidden but got %+v", contract.Functions)
+	}
+	if !contract.Functions[0].Deprecated || contract.Functions[0].Hidden {
+		t.Errorf("Expected balanceOf to be deprecated but got %+v", contract.Functions[0])
+	}
+	if visible := contract.Visible(); len(visible.Functions) != 2 || visible.Functions[1].Name != "mint_1" || len(contract.Functions) != 3 {
+		t.Errorf("Expected the hidden function to be left out of the visible contract but got %+v", visible.Functions)
+	}
+}
+
+func TestOverlayApplyInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay *Overlay
+	}{
+		{"Invalid Tool Name", &Overlay{Functions: map[string]FunctionOverlay{"mint": {Name: "mint tokens"}}}},
+		{"Duplicate Tool Name", &Overlay{Functions: map[string]FunctionOverlay{"mint": {Name: "balanceOf"}}}},
+		{"Invalid Category", &Overlay{Functions: map[string]FunctionOverlay{"mint": {Category: "Token Admin"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.overlay.Apply(overlayContract()); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"overlay.json": `{"functions": {"balanceOf": {"name": "getBalance", "inputs": {"0": {"description": "Holder"}}}}}`,
+		"overlay.yaml": "functions:\n  balanceOf:\n    name: getBalance\n    inputs:\n      \"0\":\n        description: Holder\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write overlay: %v", err)
+			}
+			overlay, err := LoadOverlay(path)
+			if err != nil {
+				t.Fatalf("Failed to load overlay: %v", err)
+			}
+			f := overlay.Functions["balanceOf"]
+			if f.Name != "getBalance" || f.Inputs["0"].Description != "Holder" {
+				t.Errorf("Unexpected overlay %+v", overlay)
+			}
+		})
+	}
+
+	// Misspelled keys are rejected
+	path := filepath.Join(dir, "typo.yml")
+	if err := os.WriteFile(path, []byte("functions:\n  balanceOf:\n    hiden: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write overlay: %v", err)
+	}
+	if _, err := LoadOverlay(path); err == nil {
+		t.Errorf("Expected an error for an unknown key")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OverloadNaming is a policy for naming the tools of overloaded functions, which share an ABI name
+type OverloadNaming string
+
+const (
+	// OverloadSuffix keeps the ABI name for the first overload and numbers the others: transfer, transfer_1, ...
+	OverloadSuffix OverloadNaming = "suffix"
+
+	// OverloadTypes appends the input types to the ABI name: transferAddressUint256, transferAddressUint256Bytes
+	OverloadTypes OverloadNaming = "types"
+
+	// OverloadParams appends the input names to the ABI name: transferByToAmount, transferByToAmountData
+	OverloadParams OverloadNaming = "params"
+)
+
+// OverloadNamings returns the supported overload naming policies
+func OverloadNamings() []OverloadNaming {
+	return []OverloadNaming{OverloadSuffix, OverloadTypes, OverloadParams}
+}
+
+// MarkOverloads records the overload set of functions sharing an ABI name, with their position and canonical signature,
+// and returns the number of overloaded functions
+// Constructors, fallback and receive functions are never overloads. Functions of legacy IRs, where overloads were only
+// told apart by a renamed tool and their original name in chain data, are recognized by their ABI name.
+func MarkOverloads(c *ContractIR) int {
+	indexes := map[string][]int{}
+	var names []string
+	for i, f := range c.Functions {
+		if f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		name := f.ABIName()
+		if indexes[name] == nil {
+			names = append(names, name)
+		}
+		indexes[name] = append(indexes[name], i)
+	}
+
+	marked := 0
+	for _, name := range names {
+		if len(indexes[name]) < 2 {
+			continue
+		}
+		for position, i := range indexes[name] {
+			f := &c.Functions[i]
+			f.Overload = &Overload{Name: name, Index: position, Signature: canonicalSignature(*f)}
+			marked++
+		}
+	}
+	return marked
+}
+
+// NameOverloads names the tools of overloaded functions by a policy, keeping every function name unique
+// Names taken by other functions get a numeric suffix, reported by FindCollisions. Calls still go to the ABI name, by
+// signature.
+func NameOverloads(c *ContractIR, naming OverloadNaming) error {
+	var name func(f Function) string
+	switch naming {
+	case OverloadSuffix, "":
+		name = func(f Function) string {
+			if f.Overload.Index == 0 {
+				return f.Overload.Name
+			}
+			return fmt.Sprintf("%s_%d", f.Overload.Name, f.Overload.Index)
+		}
+	case OverloadTypes:
+		name = func(f Function) string {
+			suffix := ""
+			for _, input := range f.Inputs {
+				suffix += typeWord(input.Type)
+			}
+			return f.Overload.Name + suffix
+		}
+	case OverloadParams:
+		name = func(f Function) string {
+			if len(f.Inputs) == 0 {
+				return f.Overload.Name
+			}
+			suffix := "By"
+			for i, input := range f.Inputs {
+				suffix += identifierWord(parameterName(input.Name, "arg", i))
+			}
+			return f.Overload.Name + suffix
+		}
+	default:
+		return fmt.Errorf("unsupported overload naming: %s (expected suffix, types or params)", naming)
+	}
+
+	taken := map[string]bool{}
+	for _, f := range c.Functions {
+		if f.Overload == nil {
+			taken[f.Name] = true
+		}
+	}
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		if f.Overload == nil {
+			continue
+		}
+		candidate := name(*f)
+		unique := candidate
+		for n := 1; taken[unique]; n++ {
+			unique = fmt.Sprintf("%s_%d", candidate, n)
+		}
+		taken[unique] = true
+		f.Name = unique
+		f.Overload.taken, f.Overload.suffixed = "", ""
+		if unique != candidate {
+			f.Overload.taken, f.Overload.suffixed = candidate, unique
+		}
+	}
+	return nil
+}
+
+// canonicalSignature returns the signature a function is called by, e.g. "transfer(address,uint256)"
+func canonicalSignature(f Function) string {
+	types := make([]string, len(f.Inputs))
+	for i, input := range f.Inputs {
+		types[i] = input.Type.ABIType()
+	}
+	return f.ABIName() + "(" + strings.Join(types, ",") + ")"
+}
+
+// typeWord returns a type as a capitalized identifier word, e.g. Uint256Array for uint256[] or the struct name of tuples
+func typeWord(t ParameterType) string {
+	word := t.BaseType
+	if t.TypeName != "" {
+		word = t.TypeName
+	} else if len(t.Components) > 0 {
+		word = "tuple"
+	}
+	word = identifierWord(word)
+	if t.IsArray {
+		word += "Array"
+		if t.ArraySize > 0 {
+			word += fmt.Sprint(t.ArraySize)
+		}
+	}
+	return word
+}
+
+// identifierWord capitalizes a name and drops characters identifiers cannot contain
+func identifierWord(s string) string {
+	s = nonIdentifierPattern.ReplaceAllString(s, "")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
\ No newline at end of file
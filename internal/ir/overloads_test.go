@@ -0,0 +1,85 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func overloadedContract() *ContractIR {
+	uint256 := ParameterType{BaseType: "uint256"}
+	return &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "constructor", IsConstructor: true, StateMutability: Nonpayable},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{{Name: "to", Type: ParameterType{BaseType: "address"}}, {Name: "amount", Type: uint256}}},
+			// A legacy IR overload, renamed by the parser and recognized by its original name
+			{Name: "transfer_1", StateMutability: Nonpayable, ChainData: EVMFunction{OriginalName: "transfer"}.ChainData(), Inputs: []Parameter{
+				{Name: "to", Type: ParameterType{BaseType: "address"}},
+				{Name: "amounts", Type: ParameterType{BaseType: "uint256", IsArray: true}},
+			}},
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{
+				{Name: "to", Type: ParameterType{BaseType: "address"}},
+				{Name: "amounts", Type: ParameterType{BaseType: "uint256", IsArray: true, ArraySize: 2}},
+			}},
+			// Takes the name the types policy would give the first overload
+			{Name: "transferAddressUint256", StateMutability: Nonpayable},
+			{Name: "balanceOf", StateMutability: View, Inputs: []Parameter{{Name: "account", Type: ParameterType{BaseType: "address"}}}},
+		},
+	}
+}
+
+func TestMarkOverloads(t *testing.T) {
+	c := overloadedContract()
+	if marked := MarkOverloads(c); marked != 3 {
+		t.Errorf("Expected 3 overloads but got %d", marked)
+	}
+	expected := []*Overload{
+		nil,
+		{Name: "transfer", Index: 0, Signature: "transfer(address,uint256)"},
+		{Name: "transfer", Index: 1, Signature: "transfer(address,uint256[])"},
+		{Name: "transfer", Index: 2, Signature: "transfer(address,uint256[2])"},
+		nil,
+		nil,
+	}
+	for i, f := range c.Functions {
+		if !reflect.DeepEqual(f.Overload, expected[i]) {
+			t.Errorf("Expected overload %+v of function %d but got %+v", expected[i], i, f.Overload)
+		}
+	}
+}
+
+func TestNameOverloads(t *testing.T) {
+	tests := []struct {
+		naming   OverloadNaming
+		expected []string
+	}{
+		{OverloadSuffix, []string{"transfer", "transfer_1", "transfer_2"}},
+		{OverloadTypes, []string{"transferAddressUint256_1", "transferAddressUint256Array", "transferAddressUint256Array2"}},
+		{OverloadParams, []string{"transferByToAmount", "transferByToAmounts", "transferByToAmounts_1"}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.naming), func(t *testing.T) {
+			c := overloadedContract()
+			MarkOverloads(c)
+			if err := NameOverloads(c, test.naming); err != nil {
+				t.Fatal(err)
+			}
+			names := []string{c.Functions[1].Name, c.Functions[2].Name, c.Functions[3].Name}
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("Expected names %v but got %v", test.expected, names)
+			}
+			for _, f := range c.Functions[1:4] {
+				if f.ABIName() != "transfer" {
+					t.Errorf("Expected %s to be called as transfer but got %s", f.Name, f.ABIName())
+				}
+			}
+			if c.Functions[4].Name != "transferAddressUint256" || c.Functions[5].Name != "balanceOf" {
+				t.Errorf("Expected functions that are not overloads to keep their names but got %s and %s", c.Functions[4].Name, c.Functions[5].Name)
+			}
+		})
+	}
+
+	if err := NameOverloads(overloadedContract(), "random"); err == nil {
+		t.Error("Expected an unsupported naming to be rejected")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,244 @@
+package ir
+
+import (
+	"fmt"
+)
+
+// Projection selects the parts of a contract kept by Project; the zero value keeps everything
+// Functions are selected by their IR name (e.g. "transfer_1" for an overload) or canonical signature, as in overlays.
+type Projection struct {
+	// Keep only view and pure functions
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Drop functions annotated as more dangerous (low, medium or high); functions without a danger level are kept
+	MaxDanger DangerLevel `json:"maxDanger,omitempty"`
+
+	// Drop functions only the owner, role holders or allowlisted accounts may call
+	Unrestricted bool `json:"unrestricted,omitempty"`
+
+	// Keep only functions and events with one of these tags
+	Tags []string `json:"tags,omitempty"`
+
+	// Keep only these functions, by name or signature
+	Include []string `json:"include,omitempty"`
+
+	// Drop these functions, by name or signature
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Drop hidden functions and events instead of keeping them hidden
+	DropHidden bool `json:"dropHidden,omitempty"`
+
+	// Drop all events
+	NoEvents bool `json:"noEvents,omitempty"`
+
+	// Drop chain data other than the entries generated code depends on (ABI names of renamed functions, anonymous events and
+	// API routing), e.g. NatSpec and parser bookkeeping
+	StripChainData bool `json:"stripChainData,omitempty"`
+
+	// Drop the source code information of the metadata
+	StripSource bool `json:"stripSource,omitempty"`
+}
+
+// essentialChainDataKeys are the chain data entries StripChainData keeps
+var essentialChainDataKeys = map[string]bool{
+	OriginalNameKey:      true,
+	OriginalSignatureKey: true,
+	AnonymousKey:         true,
+	EnumValuesKey:        true,
+	BaseURLKey:           true,
+	HTTPMethodKey:        true,
+	HTTPPathKey:          true,
+	RPCMethodKey:         true,
+	ParamsByPositionKey:  true,
+	ParameterInKey:       true,
+	OptionalKey:          true,
+	NetworkKey:           true,
+	ChainIDKey:           true,
+	RPCURLKey:            true,
+	ExplorerURLKey:       true,
+	ExplorerAPIKey:       true,
+}
+
+// dangerRanks orders danger levels
+var dangerRanks = map[DangerLevel]int{DangerLow: 1, DangerMedium: 2, DangerHigh: 3}
+
+// Project returns a filtered copy of a contract, e.g. to share an IR without its internals or to generate a
+// restricted read-only server from the same source IR as a full one
+// Custom types no longer used by any member are dropped; the contract is not modified.
+func Project(c *ContractIR, p Projection) (*ContractIR, error) {
+	maxRank := 0
+	if p.MaxDanger != "" {
+		rank, ok := dangerRanks[p.MaxDanger]
+		if !ok {
+			return nil, fmt.Errorf("invalid danger level %q (expected %s, %s or %s)", p.MaxDanger, DangerLow, DangerMedium, DangerHigh)
+		}
+		maxRank = rank
+	}
+	projected, err := copyContract(c)
+	if err != nil {
+		return nil, err
+	}
+	tags := stringSet(p.Tags)
+	include := stringSet(p.Include)
+	exclude := stringSet(p.Exclude)
+	tagged := func(memberTags []string) bool {
+		if len(tags) == 0 {
+			return true
+		}
+		for _, tag := range memberTags {
+			if tags[tag] {
+				return true
+			}
+		}
+		return false
+	}
+	matches := func(set map[string]bool, f Function) bool {
+		for _, key := range functionKeys(f) {
+			if set[key] {
+				return true
+			}
+		}
+		return false
+	}
+
+	functions := []Function{}
+	for _, f := range projected.Functions {
+		switch {
+		case p.ReadOnly && !isReadOnlyFunction(f),
+			maxRank > 0 && dangerRanks[f.Danger] > maxRank,
+			p.Unrestricted && !f.Access.IsEmpty(),
+			!tagged(f.Tags),
+			len(include) > 0 && !matches(include, f),
+			matches(exclude, f),
+			p.DropHidden && f.Hidden:
+			continue
+		}
+		functions = append(functions, f)
+	}
+	projected.Functions = functions
+
+	events := []Event{}
+	if !p.NoEvents {
+		for _, e := range projected.Events {
+			if tagged(e.Tags) && !(p.DropHidden && e.Hidden) {
+				events = append(events, e)
+			}
+		}
+	}
+	projected.Events = events
+
+	pruneTypes(projected)
+
+	if p.StripSource {
+		projected.Metadata.Source = nil
+	}
+	if p.StripChainData {
+		stripChainData(projected)
+	}
+	return projected, nil
+}
+
+// pruneTypes drops the custom types no function, event, error or used type refers to
+func pruneTypes(c *ContractIR) {
+	if len(c.Types) == 0 {
+		return
+	}
+	byName := map[string]CustomType{}
+	for _, t := range c.Types {
+		byName[t.Name] = t
+	}
+	used := map[string]bool{}
+	var visitType func(t ParameterType)
+	var visit func(parameters []Parameter)
+	visitType = func(t ParameterType) {
+		for _, name := range []string{t.TypeName, t.BaseType} {
+			if custom, ok := byName[name]; ok && !used[name] {
+				used[name] = true
+				visit(custom.Fields)
+			}
+		}
+		visit(t.Components)
+	}
+	visit = func(parameters []Parameter) {
+		for _, p := range parameters {
+			visitType(p.Type)
+		}
+	}
+	for _, f := range c.Functions {
+		visit(f.Inputs)
+		visit(f.Outputs)
+	}
+	for _, e := range c.Events {
+		for _, p := range e.Parameters {
+			visitType(p.Type)
+		}
+	}
+	for _, e := range c.Errors {
+		visit(e.Parameters)
+	}
+
+	var types []CustomType
+	for _, t := range c.Types {
+		if used[t.Name] {
+			types = append(types, t)
+		}
+	}
+	c.Types = types
+}
+
+// stripChainData drops the non-essential chain data of a contract and all its members
+func stripChainData(c *ContractIR) {
+	c.Metadata.ChainData = essentialChainData(c.Metadata.ChainData)
+	var stripParameters func(parameters []Parameter)
+	stripType := func(t *ParameterType) {
+		t.ChainData = essentialChainData(t.ChainData)
+		stripParameters(t.Components)
+	}
+	stripParameters = func(parameters []Parameter) {
+		for i := range parameters {
+			stripType(&parameters[i].Type)
+		}
+	}
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		f.ChainData = essentialChainData(f.ChainData)
+		stripParameters(f.Inputs)
+		stripParameters(f.Outputs)
+	}
+	for i := range c.Events {
+		e := &c.Events[i]
+		e.ChainData = essentialChainData(e.ChainData)
+		for j := range e.Parameters {
+			stripType(&e.Parameters[j].Type)
+		}
+	}
+	for i := range c.Errors {
+		stripParameters(c.Errors[i].Parameters)
+	}
+	for i := range c.Types {
+		stripParameters(c.Types[i].Fields)
+	}
+}
+
+// essentialChainData returns the essential entries of chain data, or nil if there are none
+func essentialChainData(data map[string]interface{}) map[string]interface{} {
+	var essential map[string]interface{}
+	for key, value := range data {
+		if essentialChainDataKeys[key] {
+			if essential == nil {
+				essential = map[string]interface{}{}
+			}
+			essential[key] = value
+		}
+	}
+	return essential
+}
+
+// stringSet returns a set of strings
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func projectionContract() *ContractIR {
+	order := ParameterType{BaseType: "tuple", TypeName: "Order", Components: []Parameter{{Name: "amount", Type: ParameterType{BaseType: "uint256"}}}}
+	return &ContractIR{
+		Metadata: ContractMetadata{Name: "Exchange", Chain: "ethereum", Source: &SourceInfo{Language: "solidity"}},
+		Functions: []Function{
+			{Name: "balanceOf", StateMutability: View, Tags: []string{"balances"}, ChainData: map[string]interface{}{NatSpecKey: "Balance"}},
+			{Name: "transfer", Signature: "transfer(address,uint256)", StateMutability: Nonpayable, Danger: DangerMedium, Tags: []string{"balances"}},
+			{Name: "transfer_1", Signature: "transfer(address,uint256,bytes)", StateMutability: Nonpayable, ChainData: EVMFunction{Constant: true, OriginalName: "transfer"}.ChainData()},
+			{Name: "fill", StateMutability: Payable, Inputs: []Parameter{{Name: "order", Type: order}}},
+			{Name: "upgrade", StateMutability: Nonpayable, Danger: DangerHigh, Access: &AccessRestriction{OnlyOwner: true}},
+			{Name: "debug", StateMutability: View, Hidden: true},
+		},
+		Events: []Event{
+			{Name: "Transfer", Tags: []string{"balances"}, ChainData: EVMEvent{IndexedCount: 2}.ChainData()},
+			{Name: "Debug", Hidden: true},
+		},
+		Types: []CustomType{{Name: "Order", Fields: order.Components}},
+	}
+}
+
+func functionNames(c *ContractIR) []string {
+	names := []string{}
+	for _, f := range c.Functions {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection Projection
+		functions  []string
+		events     int
+		types      int
+	}{
+		{"everything", Projection{}, []string{"balanceOf", "transfer", "transfer_1", "fill", "upgrade", "debug"}, 2, 1},
+		{"read only", Projection{ReadOnly: true}, []string{"balanceOf", "debug"}, 2, 0},
+		{"max danger", Projection{MaxDanger: DangerLow}, []string{"balanceOf", "transfer_1", "fill", "debug"}, 2, 1},
+		{"unrestricted", Projection{Unrestricted: true}, []string{"balanceOf", "transfer", "transfer_1", "fill", "debug"}, 2, 1},
+		{"tags", Projection{Tags: []string{"balances"}}, []string{"balanceOf", "transfer"}, 1, 0},
+		{"include by signature", Projection{Include: []string{"transfer(address,uint256,bytes)", "balanceOf"}}, []string{"balanceOf", "transfer_1"}, 2, 0},
+		{"exclude", Projection{Exclude: []string{"upgrade", "transfer(address,uint256)"}}, []string{"balanceOf", "transfer_1", "fill", "debug"}, 2, 1},
+		{"drop hidden", Projection{DropHidden: true, NoEvents: true}, []string{"balanceOf", "transfer", "transfer_1", "fill", "upgrade"}, 0, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projected, err := Project(projectionContract(), test.projection)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if names := functionNames(projected); !reflect.DeepEqual(names, test.functions) {
+				t.Errorf("Expected functions %v but got %v", test.functions, names)
+			}
+			if len(projected.Events) != test.events || len(projected.Types) != test.types {
+				t.Errorf("Expected %d events and %d types but got %d and %d", test.events, test.types, len(projected.Events), len(projected.Types))
+			}
+		})
+	}
+}
+
+func TestProjectStripsChainData(t *testing.T) {
+	contract := projectionContract()
+	projected, err := Project(contract, Projection{StripChainData: true, StripSource: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projected.Metadata.Source != nil {
+		t.Error("Expected the source information to be stripped")
+	}
+	if projected.Functions[0].ChainData != nil || projected.Events[0].ChainData != nil {
+		t.Errorf("Expected non-essential chain data to be stripped but got %v and %v", projected.Functions[0].ChainData, projected.Events[0].ChainData)
+	}
+	// Overloads are still called by their ABI name
+	if expected := map[string]interface{}{OriginalNameKey: "transfer"}; !reflect.DeepEqual(projected.Functions[2].ChainData, expected) {
+		t.Errorf("Expected chain data %v but got %v", expected, projected.Functions[2].ChainData)
+	}
+
+	// The original is not modified
+	if contract.Metadata.Source == nil || contract.Functions[0].ChainData[NatSpecKey] != "Balance" {
+		t.Error("Expected the projected contract to be left unchanged")
+	}
+}
+
+func TestProjectRejectsInvalidDanger(t *testing.T) {
+	if _, err := Project(projectionContract(), Projection{MaxDanger: "extreme"}); err == nil {
+		t.Error("Expected an invalid danger level to be rejected")
+	}
+}
\ No newline at end of file
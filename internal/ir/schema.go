@@ -29,12 +29,18 @@ type ContractMetadata struct {
         // Description of the contract's purpose
         Description string `json:"description,omitempty"`
         
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
         // Address where the contract is deployed (if known)
         Address string `json:"address,omitempty"`
         
         // Chain identifier (e.g., "ethereum", "solana")
         Chain string `json:"chain"`
         
+        // Addresses of the contract across networks, keyed by decimal chain ID (e.g., "1" for Ethereum mainnet)
+        Deployments map[string]Deployment `json:"deployments,omitempty"`
+        
         // Chain-specific information
         ChainData map[string]interface{} `json:"chainData,omitempty"`
         
@@ -42,6 +48,15 @@ type ContractMetadata struct {
         Source *SourceInfo `json:"source,omitempty"`
 }
 
+// Deployment is where a contract is deployed on one network
+type Deployment struct {
+        // Address of the contract on the network
+        Address string `json:"address"`
+        
+        // Block the contract was deployed in, before which it has no events (optional)
+        Block uint64 `json:"block,omitempty"`
+}
+
 // SourceInfo contains information about the contract's source code
 type SourceInfo struct {
         // Programming language
@@ -62,6 +77,9 @@ type Function struct {
         // Human-readable description
         Description string `json:"description,omitempty"`
         
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
         // Function signature (e.g., "transfer(address,uint256)")
         Signature string `json:"signature,omitempty"`
         
@@ -89,10 +107,65 @@ type Function struct {
         // Whether this is a receive function (EVM specific)
         IsReceive bool `json:"isReceive,omitempty"`
         
+        // Labels grouping the function (e.g., "admin", "liquidity")
+        Tags []string `json:"tags,omitempty"`
+        
+        // Category generated tools are grouped by (e.g., "read", "admin", "liquidity")
+        Category string `json:"category,omitempty"`
+        
+        // How much harm a mistaken call can do
+        Danger DangerLevel `json:"danger,omitempty"`
+        
+        // Example arguments by input name, for documentation
+        Examples []map[string]interface{} `json:"examples,omitempty"`
+        
+        // Who may call the function, if it is restricted
+        Access *AccessRestriction `json:"access,omitempty"`
+        
+        // Whether the function is deprecated; its tool is still generated but marked as deprecated
+        Deprecated bool `json:"deprecated,omitempty"`
+        
+        // Whether the function is kept in the IR but left out of generated tools
+        Hidden bool `json:"hidden,omitempty"`
+        
+        // Overload set of the function, if other functions share its ABI name
+        Overload *Overload `json:"overload,omitempty"`
+        
+        // Bounds and documentation of the native currency sent to a payable function
+        Value *ValueConstraint `json:"value,omitempty"`
+        
         // Chain-specific function data
         ChainData map[string]interface{} `json:"chainData,omitempty"`
 }
 
+// AccessRestriction describes who may call a function
+type AccessRestriction struct {
+        // Only the contract owner may call the function (e.g., Ownable's onlyOwner)
+        OnlyOwner bool `json:"onlyOwner,omitempty"`
+        
+        // Callers need one of these roles (e.g., AccessControl's "MINTER_ROLE")
+        Roles []string `json:"roles,omitempty"`
+        
+        // Name of an allowlist callers must be on (e.g., "whitelist")
+        Allowlist string `json:"allowlist,omitempty"`
+}
+
+// Overload identifies one of several functions sharing an ABI name
+// Their tool names (Function.Name) are chosen by an OverloadNaming policy; calls are dispatched by signature.
+type Overload struct {
+        // ABI name shared by the overloads (e.g., "safeTransferFrom")
+        Name string `json:"name"`
+        
+        // Position of the function among the overloads, in ABI order
+        Index int `json:"index"`
+        
+        // Canonical signature the function is called by (e.g., "safeTransferFrom(address,address,uint256,bytes)")
+        Signature string `json:"signature"`
+
+        // Name the naming policy gave the function when another function had it, and the suffixed name given instead
+        taken, suffixed string
+}
+
 // Event represents an event that can be emitted by the contract
 type Event struct {
         // Event name
@@ -101,12 +174,27 @@ type Event struct {
         // Human-readable description
         Description string `json:"description,omitempty"`
         
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
         // Event signature
         Signature string `json:"signature,omitempty"`
         
+        // Topic of the event's logs (e.g., the Keccak-256 hash of the signature for EVM); empty for anonymous events
+        Topic string `json:"topic,omitempty"`
+        
         // Parameters included in the event
         Parameters []EventParameter `json:"parameters"`
         
+        // Labels grouping the event (e.g., "transfers")
+        Tags []string `json:"tags,omitempty"`
+        
+        // Whether the event is deprecated; its log tool is still generated but marked as deprecated
+        Deprecated bool `json:"deprecated,omitempty"`
+        
+        // Whether the event is kept in the IR but left out of generated tools
+        Hidden bool `json:"hidden,omitempty"`
+        
         // Chain-specific event data
         ChainData map[string]interface{} `json:"chainData,omitempty"`
 }
@@ -133,6 +221,19 @@ type Parameter struct {
         
         // Human-readable description
         Description string `json:"description,omitempty"`
+        
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
+        // Unit of an unsigned integer (e.g., "token", "token:18", "wei", "seconds", "bps")
+        Unit string `json:"unit,omitempty"`
+        
+        // Example values as tools take them (e.g., token amounts in whole tokens), for documentation and test fixtures
+        Examples []interface{} `json:"examples,omitempty"`
+        
+        // Value tools use when the argument is left out, as tools take it (e.g., "latest"); timestamps may default to a
+        // number of seconds after the call (e.g., "+1200" for a deadline 20 minutes out)
+        Default interface{} `json:"default,omitempty"`
 }
 
 // ParameterType represents the type of a parameter
@@ -155,6 +256,9 @@ type ParameterType struct {
         // If this is a custom struct type, the fields
         Components []Parameter `json:"components,omitempty"`
         
+        // If this is a custom struct type, the name of the CustomType declaring its fields
+        TypeName string `json:"typeName,omitempty"`
+        
         // Chain-specific type data
         ChainData map[string]interface{} `json:"chainData,omitempty"`
 }
@@ -167,6 +271,9 @@ type ContractError struct {
         // Human-readable description
         Description string `json:"description,omitempty"`
         
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
         // Error parameters
         Parameters []Parameter `json:"parameters,omitempty"`
 }
@@ -179,6 +286,9 @@ type CustomType struct {
         // Human-readable description
         Description string `json:"description,omitempty"`
         
+        // Translated descriptions by language tag (e.g., "ja"), used instead of Description for that locale
+        Descriptions Translations `json:"descriptions,omitempty"`
+        
         // Fields in the custom type
         Fields []Parameter `json:"fields"`
 }
@@ -215,4 +325,18 @@ const (
         
         // Private functions can only be called from inside the contract
         Private Visibility = "private"
+)
+
+// DangerLevel indicates how much harm a mistaken call to a function can do
+type DangerLevel string
+
+const (
+        // Low danger functions are safe to call without review
+        DangerLow DangerLevel = "low"
+        
+        // Medium danger functions move funds or change settings and should be reviewed
+        DangerMedium DangerLevel = "medium"
+        
+        // High danger functions can cause irreversible loss, e.g. transferring ownership or upgrading the contract
+        DangerHigh DangerLevel = "high"
 )
\ No newline at end of file
@@ -0,0 +1,172 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Stats summarizes the size and complexity of a contract, to judge before generating a server whether it has too many
+// tools or too deeply nested parameters for MCP clients
+type Stats struct {
+	// Number of functions, excluding constructors, fallback and receive functions
+	Functions int `json:"functions"`
+
+	// Number of those functions by state mutability
+	ByMutability map[StateMutability]int `json:"byMutability"`
+
+	// Number of hidden functions and events
+	Hidden int `json:"hidden"`
+
+	// Number of events, errors and custom types
+	Events int `json:"events"`
+	Errors int `json:"errors"`
+	Types  int `json:"types"`
+
+	// Member with the most function inputs, function outputs and event parameters
+	MaxInputs          Extreme `json:"maxInputs"`
+	MaxOutputs         Extreme `json:"maxOutputs"`
+	MaxEventParameters Extreme `json:"maxEventParameters"`
+
+	// Parameter with the most deeply nested tuples; 1 for a flat tuple, 0 if there are none
+	MaxTupleDepth Extreme `json:"maxTupleDepth"`
+
+	// Estimated number of generated tools
+	Tools ToolEstimate `json:"tools"`
+
+	// Groups of tool names generated code cannot tell apart
+	Collisions []NameCollision `json:"collisions"`
+}
+
+// Extreme is the largest value of a statistic and where it occurs
+type Extreme struct {
+	// Largest value
+	Value int `json:"value"`
+
+	// Path of the member or parameter, e.g. "functions.swap.inputs[0]"; empty if the value is 0
+	Path string `json:"path,omitempty"`
+}
+
+// ToolEstimate is the number of tools generated for a contract
+type ToolEstimate struct {
+	// Tools of view and pure functions
+	Reads int `json:"reads"`
+
+	// Tools of write functions, only generated with writes enabled
+	Writes int `json:"writes"`
+
+	// Simulation tools of write functions, only generated with writes enabled
+	Simulations int `json:"simulations"`
+
+	// Event log query tools
+	EventLogs int `json:"eventLogs"`
+
+	// Tools of a read-only server
+	ReadOnly int `json:"readOnly"`
+
+	// Tools of a server with writes enabled
+	WithWrites int `json:"withWrites"`
+}
+
+// NameCollision is a group of tool names that map to the same identifier in generated code
+type NameCollision struct {
+	// Identifier the names map to, e.g. the ToolName enum key of TypeScript servers
+	Key string `json:"key"`
+
+	// Colliding tool names, in contract order
+	Names []string `json:"names"`
+
+	// IR names of the functions whose tools collide; event tools have none
+	Functions []string `json:"functions,omitempty"`
+}
+
+// ComputeStats computes the statistics of a contract
+// Tools are estimated as the TypeScript generator names them: one per visible function, a simulate<Function> tool per
+// write function and a get<Event>Logs tool per non-anonymous event. Hidden members get no tools.
+func ComputeStats(c *ContractIR) *Stats {
+	stats := &Stats{
+		ByMutability: map[StateMutability]int{},
+		Events:       len(c.Events),
+		Errors:       len(c.Errors),
+		Types:        len(c.Types),
+		Collisions:   []NameCollision{},
+	}
+	max := func(extreme *Extreme, value int, path string) {
+		if value > extreme.Value {
+			*extreme = Extreme{Value: value, Path: path}
+		}
+	}
+	depths := func(path string, parameters []Parameter) {
+		for i, p := range parameters {
+			max(&stats.MaxTupleDepth, tupleDepth(p.Type), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+
+	for _, f := range c.Functions {
+		if f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		stats.Functions++
+		stats.ByMutability[f.StateMutability]++
+		path := "functions." + f.Name
+		max(&stats.MaxInputs, len(f.Inputs), path)
+		max(&stats.MaxOutputs, len(f.Outputs), path)
+		depths(path+".inputs", f.Inputs)
+		depths(path+".outputs", f.Outputs)
+
+		if f.Hidden {
+			stats.Hidden++
+			continue
+		}
+		switch f.StateMutability {
+		case View, Pure:
+			stats.Tools.Reads++
+		case Payable, Nonpayable:
+			stats.Tools.Writes++
+			stats.Tools.Simulations++
+		}
+	}
+
+	for _, e := range c.Events {
+		path := "events." + e.Name
+		max(&stats.MaxEventParameters, len(e.Parameters), path)
+		for i, p := range e.Parameters {
+			max(&stats.MaxTupleDepth, tupleDepth(p.Type), fmt.Sprintf("%s.parameters[%d]", path, i))
+		}
+		if e.Hidden {
+			stats.Hidden++
+			continue
+		}
+		if !e.EVM().Anonymous {
+			stats.Tools.EventLogs++
+		}
+	}
+	stats.Tools.ReadOnly = stats.Tools.Reads + stats.Tools.EventLogs
+	stats.Tools.WithWrites = stats.Tools.ReadOnly + stats.Tools.Writes + stats.Tools.Simulations
+
+	stats.Collisions = nameCollisions(tools(c, true))
+	return stats
+}
+
+// Mutabilities returns the state mutabilities counted in the statistics, in alphabetical order
+func (s *Stats) Mutabilities() []StateMutability {
+	mutabilities := make([]StateMutability, 0, len(s.ByMutability))
+	for mutability := range s.ByMutability {
+		mutabilities = append(mutabilities, mutability)
+	}
+	sort.Slice(mutabilities, func(i, j int) bool { return mutabilities[i] < mutabilities[j] })
+	return mutabilities
+}
+
+// tupleDepth returns how deeply tuples nest in a type: 0 for other types, 1 for tuples of other types
+func tupleDepth(t ParameterType) int {
+	if len(t.Components) == 0 {
+		return 0
+	}
+	depth := 0
+	for _, component := range t.Components {
+		if d := tupleDepth(component.Type); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
\ No newline at end of file
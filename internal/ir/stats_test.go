@@ -0,0 +1,67 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	point := ParameterType{BaseType: "tuple", Components: []Parameter{
+		{Name: "x", Type: ParameterType{BaseType: "uint256"}},
+		{Name: "y", Type: ParameterType{BaseType: "uint256"}},
+	}}
+	segment := ParameterType{BaseType: "tuple", IsArray: true, Components: []Parameter{
+		{Name: "from", Type: point},
+		{Name: "to", Type: point},
+	}}
+	contract := &ContractIR{
+		Metadata: ContractMetadata{Name: "Canvas", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "constructor", IsConstructor: true, StateMutability: Nonpayable},
+			{Name: "origin", StateMutability: Pure, Outputs: []Parameter{{Name: "point", Type: point}}},
+			{Name: "draw", StateMutability: Payable, Inputs: []Parameter{{Name: "color", Type: ParameterType{BaseType: "uint8"}}, {Name: "segments", Type: segment}}},
+			{Name: "simulateDraw", StateMutability: View},
+			{Name: "getDrawnLogs", StateMutability: View},
+			{Name: "reset", StateMutability: Nonpayable, Hidden: true},
+		},
+		Events: []Event{
+			{Name: "Drawn", Parameters: []EventParameter{{Name: "painter", Type: ParameterType{BaseType: "address"}, Indexed: true}}},
+			{Name: "Anonymous", ChainData: map[string]interface{}{"anonymous": true}},
+		},
+		Errors: []ContractError{{Name: "OutOfBounds"}},
+	}
+
+	stats := ComputeStats(contract)
+	if stats.Functions != 5 || stats.Hidden != 1 || stats.Events != 2 || stats.Errors != 1 {
+		t.Errorf("Unexpected counts: %+v", stats)
+	}
+	if expected := map[StateMutability]int{Pure: 1, View: 2, Payable: 1, Nonpayable: 1}; !reflect.DeepEqual(stats.ByMutability, expected) {
+		t.Errorf("Expected mutability counts %v but got %v", expected, stats.ByMutability)
+	}
+	if expected := []StateMutability{Nonpayable, Payable, Pure, View}; !reflect.DeepEqual(stats.Mutabilities(), expected) {
+		t.Errorf("Expected mutabilities %v but got %v", expected, stats.Mutabilities())
+	}
+	if expected := (Extreme{Value: 2, Path: "functions.draw"}); stats.MaxInputs != expected {
+		t.Errorf("Expected max inputs %v but got %v", expected, stats.MaxInputs)
+	}
+	if expected := (Extreme{Value: 2, Path: "functions.draw.inputs[1]"}); stats.MaxTupleDepth != expected {
+		t.Errorf("Expected max tuple depth %v but got %v", expected, stats.MaxTupleDepth)
+	}
+	if expected := (Extreme{Value: 1, Path: "events.Drawn"}); stats.MaxEventParameters != expected {
+		t.Errorf("Expected max event parameters %v but got %v", expected, stats.MaxEventParameters)
+	}
+
+	// Hidden functions and anonymous events get no tools
+	if expected := (ToolEstimate{Reads: 3, Writes: 1, Simulations: 1, EventLogs: 1, ReadOnly: 4, WithWrites: 6}); stats.Tools != expected {
+		t.Errorf("Expected tools %+v but got %+v", expected, stats.Tools)
+	}
+
+	// Generated simulation and log tools collide with functions of the same names
+	expected := []NameCollision{
+		{Key: "SIMULATEDRAW", Names: []string{"simulateDraw", "simulateDraw"}, Functions: []string{"draw", "simulateDraw"}},
+		{Key: "GETDRAWNLOGS", Names: []string{"getDrawnLogs", "getDrawnLogs"}, Functions: []string{"getDrawnLogs"}},
+	}
+	if !reflect.DeepEqual(stats.Collisions, expected) {
+		t.Errorf("Expected collisions %v but got %v", expected, stats.Collisions)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,94 @@
+package ir
+
+import "strings"
+
+// ToolKind is what a tool of a generated server does
+type ToolKind string
+
+const (
+	// ToolRead calls a view or pure function, or sends a read-only API operation
+	ToolRead ToolKind = "read"
+
+	// ToolWrite sends a transaction calling a write function, or sends an API operation with side effects
+	ToolWrite ToolKind = "write"
+
+	// ToolSimulate simulates a write function's transaction without sending it
+	ToolSimulate ToolKind = "simulate"
+
+	// ToolEventLogs queries the logs of an event
+	ToolEventLogs ToolKind = "eventLogs"
+)
+
+// Tool is a tool of a contract's generated server
+type Tool struct {
+	// Name of the tool
+	Name string `json:"name"`
+
+	// What the tool does
+	Kind ToolKind `json:"kind"`
+
+	// IR name of the function the tool calls, or of the event whose logs it queries
+	Function string `json:"function,omitempty"`
+	Event    string `json:"event,omitempty"`
+
+	// Signature of the function or event, if known
+	Signature string `json:"signature,omitempty"`
+}
+
+// SkippedMember is a function or event of a contract that gets no tool
+type SkippedMember struct {
+	// IR name of the function or event
+	Function string `json:"function,omitempty"`
+	Event    string `json:"event,omitempty"`
+
+	// Signature of the function or event, if known
+	Signature string `json:"signature,omitempty"`
+
+	// Why it gets no tool, e.g. "write function, and writes are disabled"
+	Reason string `json:"reason"`
+}
+
+// Tools returns the tools of a contract's server as the TypeScript generator names them, in contract order, and the
+// functions and events that get none: a tool per read function, per write function and its simulate<Function> tool
+// when writes are enabled (API operations have no simulation), and a get<Event>Logs tool per non-anonymous event
+func Tools(c *ContractIR, writes bool) ([]Tool, []SkippedMember) {
+	tools := []Tool{}
+	skipped := []SkippedMember{}
+	for _, f := range c.Functions {
+		skip := func(reason string) {
+			skipped = append(skipped, SkippedMember{Function: f.Name, Signature: f.Signature, Reason: reason})
+		}
+		switch {
+		case f.IsConstructor:
+			skip("constructor")
+		case f.IsFallback:
+			skip("fallback function")
+		case f.IsReceive:
+			skip("receive function")
+		case f.Hidden:
+			skip("hidden")
+		case f.Name == "":
+			skip("unnamed")
+		case f.StateMutability == View || f.StateMutability == Pure:
+			tools = append(tools, Tool{Name: f.Name, Kind: ToolRead, Function: f.Name, Signature: f.Signature})
+		case !writes:
+			skip("write function, and writes are disabled")
+		default:
+			tools = append(tools, Tool{Name: f.Name, Kind: ToolWrite, Function: f.Name, Signature: f.Signature})
+			if !f.IsAPIOperation() {
+				tools = append(tools, Tool{Name: "simulate" + strings.ToUpper(f.Name[:1]) + f.Name[1:], Kind: ToolSimulate, Function: f.Name, Signature: f.Signature})
+			}
+		}
+	}
+	for _, e := range c.Events {
+		switch {
+		case e.Hidden:
+			skipped = append(skipped, SkippedMember{Event: e.Name, Signature: e.Signature, Reason: "hidden"})
+		case e.EVM().Anonymous:
+			skipped = append(skipped, SkippedMember{Event: e.Name, Signature: e.Signature, Reason: "anonymous event, whose logs cannot be filtered by topic"})
+		default:
+			tools = append(tools, Tool{Name: "get" + e.Name + "Logs", Kind: ToolEventLogs, Event: e.Name, Signature: e.Signature})
+		}
+	}
+	return tools, skipped
+}
\ No newline at end of file
@@ -0,0 +1,46 @@
+package ir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTools(t *testing.T) {
+	c := &ContractIR{
+		Metadata: ContractMetadata{Name: "Vault", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "constructor", IsConstructor: true, StateMutability: Nonpayable},
+			{Name: "balanceOf", Signature: "balanceOf(address)", StateMutability: View},
+			{Name: "deposit", Signature: "deposit(uint256)", StateMutability: Nonpayable},
+			{Name: "sweep", StateMutability: Nonpayable, Hidden: true},
+		},
+		Events: []Event{
+			{Name: "Deposit", Signature: "Deposit(address,uint256)"},
+			{Name: "Debug", ChainData: map[string]interface{}{AnonymousKey: true}},
+		},
+	}
+
+	tools, skipped := Tools(c, true)
+	expected := []Tool{
+		{Name: "balanceOf", Kind: ToolRead, Function: "balanceOf", Signature: "balanceOf(address)"},
+		{Name: "deposit", Kind: ToolWrite, Function: "deposit", Signature: "deposit(uint256)"},
+		{Name: "simulateDeposit", Kind: ToolSimulate, Function: "deposit", Signature: "deposit(uint256)"},
+		{Name: "getDepositLogs", Kind: ToolEventLogs, Event: "Deposit", Signature: "Deposit(address,uint256)"},
+	}
+	if !reflect.DeepEqual(tools, expected) {
+		t.Errorf("Expected tools %+v but got %+v", expected, tools)
+	}
+	reasons := map[string]string{}
+	for _, s := range skipped {
+		reasons[s.Function+s.Event] = s.Reason
+	}
+	if len(skipped) != 3 || reasons["constructor"] != "constructor" || reasons["sweep"] != "hidden" || reasons["Debug"] == "" {
+		t.Errorf("Unexpected skipped members %+v", skipped)
+	}
+
+	// Without writes, write functions are skipped too
+	tools, skipped = Tools(c, false)
+	if len(tools) != 2 || len(skipped) != 4 || skipped[1].Function != "deposit" || skipped[1].Reason != "write function, and writes are disabled" {
+		t.Errorf("Unexpected read-only tools %+v, skipped %+v", tools, skipped)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+package ir
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// nonIdentifierPattern matches characters that cannot appear in a type name
+var nonIdentifierPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// DeduplicateTuples lifts every distinct tuple shape of a contract into a named CustomType in Types, and sets the
+// TypeName of each tuple with that shape, so templates declare one type per struct instead of repeating inline shapes
+// Tuples have the same shape if their component names and types match. Types are named after the Solidity struct
+// (the internalType recorded by the parser) if known, or after their first use (e.g. SwapKey for the key parameter
+// of swap) otherwise, and are listed before the types using them. Tuples keep their components, so encoding is unchanged.
+// It returns the number of types added; existing Types are reused, so normalizing twice adds none.
+func DeduplicateTuples(c *ContractIR) int {
+	d := &tupleDeduplicator{contract: c, shapes: map[string]string{}, names: map[string]bool{}}
+	for _, t := range c.Types {
+		d.names[t.Name] = true
+		d.shapes[tupleShape(t.Fields)] = t.Name
+	}
+	existing := len(c.Types)
+
+	for i := range c.Functions {
+		f := &c.Functions[i]
+		d.parameters(f.Name, f.Inputs, "arg")
+		d.parameters(f.Name, f.Outputs, "output")
+	}
+	for i := range c.Events {
+		e := &c.Events[i]
+		for j := range e.Parameters {
+			d.tuple(e.Name, parameterName(e.Parameters[j].Name, "arg", j), &e.Parameters[j].Type)
+		}
+	}
+	for i := range c.Errors {
+		d.parameters(c.Errors[i].Name, c.Errors[i].Parameters, "arg")
+	}
+
+	return len(c.Types) - existing
+}
+
+// tupleDeduplicator names tuple shapes as it walks a contract
+type tupleDeduplicator struct {
+	contract *ContractIR
+
+	// Type name by tuple shape
+	shapes map[string]string
+
+	// Type names in use
+	names map[string]bool
+}
+
+// parameters names the tuples of parameters, naming unnamed ones prefix0, prefix1, ...
+func (d *tupleDeduplicator) parameters(owner string, parameters []Parameter, prefix string) {
+	for i := range parameters {
+		d.tuple(owner, parameterName(parameters[i].Name, prefix, i), &parameters[i].Type)
+	}
+}
+
+// tuple sets the type name of a tuple type, declaring its nested tuples and then itself if its shape is new
+func (d *tupleDeduplicator) tuple(owner, name string, t *ParameterType) {
+	if len(t.Components) == 0 {
+		return
+	}
+	usage := exportedName(owner) + exportedName(name)
+
+	// Components are shared with other parsed values, so they are copied before their types are named
+	components := make([]Parameter, len(t.Components))
+	copy(components, t.Components)
+	d.parameters(usage, components, "field")
+	t.Components = components
+
+	shape := tupleShape(components)
+	if typeName, ok := d.shapes[shape]; ok {
+		t.TypeName = typeName
+		return
+	}
+
+	typeName := structName(t.EVM().InternalType)
+	if typeName == "" {
+		typeName = usage
+	}
+	unique := typeName
+	for i := 2; d.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", typeName, i)
+	}
+	d.names[unique] = true
+	d.shapes[shape] = unique
+	t.TypeName = unique
+
+	fields := make([]Parameter, len(components))
+	copy(fields, components)
+	d.contract.Types = append(d.contract.Types, CustomType{Name: unique, Fields: fields})
+}
+
+// tupleShape returns a key identifying tuples with the same component names and types
+func tupleShape(components []Parameter) string {
+	fields := make([]string, len(components))
+	for i, component := range components {
+		fields[i] = component.Name + " " + componentShape(component.Type)
+	}
+	return "(" + strings.Join(fields, ",") + ")"
+}
+
+func componentShape(t ParameterType) string {
+	if len(t.Components) == 0 {
+		return t.ABIType()
+	}
+	shape := tupleShape(t.Components)
+	if t.IsArray {
+		if t.ArraySize > 0 {
+			return fmt.Sprintf("%s[%d]", shape, t.ArraySize)
+		}
+		return shape + "[]"
+	}
+	return shape
+}
+
+// structName derives a type name from a Solidity internal type such as "struct Pool.Key[]", or returns ""
+func structName(s string) string {
+	if !strings.HasPrefix(s, "struct ") {
+		return ""
+	}
+	s = strings.TrimPrefix(s, "struct ")
+	if i := strings.Index(s, "["); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		s = s[i+1:]
+	}
+	return exportedName(nonIdentifierPattern.ReplaceAllString(s, ""))
+}
+
+// parameterName returns the name of a parameter, or prefix<index> if it is unnamed
+func parameterName(name, prefix string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("%s%d", prefix, index)
+	}
+	return name
+}
+
+// exportedName uppercases the first character of an identifier
+func exportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
\ No newline at end of file
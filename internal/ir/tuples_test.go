@@ -0,0 +1,108 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeduplicateTuples(t *testing.T) {
+	inner := ParameterType{
+		BaseType:   "tuple",
+		Components: []Parameter{{Name: "amount", Type: ParameterType{BaseType: "uint256"}}},
+	}
+	order := func(internalType string, isArray bool) ParameterType {
+		return ParameterType{
+			BaseType: "tuple",
+			IsArray:  isArray,
+			Components: []Parameter{
+				{Name: "maker", Type: ParameterType{BaseType: "address"}},
+				{Name: "fill", Type: inner},
+			},
+			ChainData: map[string]interface{}{"internalType": internalType},
+		}
+	}
+	contract := &ContractIR{
+		Functions: []Function{
+			{Name: "fill", Inputs: []Parameter{{Name: "order", Type: order("struct Exchange.Order", false)}}},
+			{Name: "orders", Outputs: []Parameter{{Type: order("struct Exchange.Order[]", true)}}},
+			{Name: "quote", Outputs: []Parameter{{Type: ParameterType{BaseType: "tuple", Components: []Parameter{{Name: "price", Type: ParameterType{BaseType: "uint256"}}}}}}},
+		},
+		Events: []Event{{Name: "Filled", Parameters: []EventParameter{{Name: "order", Type: order("", false)}}}},
+	}
+
+	if added := DeduplicateTuples(contract); added != 3 {
+		t.Fatalf("Expected 3 types but got %d: %+v", added, contract.Types)
+	}
+
+	// Nested types come first; structs are named after their Solidity type, others after their first use
+	expected := []string{"FillOrderFill", "Order", "QuoteOutput0"}
+	for i, name := range expected {
+		if contract.Types[i].Name != name {
+			t.Errorf("Expected type %d to be %s but got %s", i, name, contract.Types[i].Name)
+		}
+	}
+	if got := contract.Types[1].Fields[1].Type.TypeName; got != "FillOrderFill" {
+		t.Errorf("Expected the nested tuple to reference FillOrderFill but got %s", got)
+	}
+
+	for _, got := range []ParameterType{contract.Functions[0].Inputs[0].Type, contract.Functions[1].Outputs[0].Type, contract.Events[0].Parameters[0].Type} {
+		if got.TypeName != "Order" || len(got.Components) != 2 {
+			t.Errorf("Expected the tuple to reference Order and keep its components but got %+v", got)
+		}
+	}
+	if inner.TypeName != "" {
+		t.Errorf("Expected shared components to be left untouched")
+	}
+
+	for _, err := range contract.Validate() {
+		if strings.HasSuffix(err.Field, ".TypeName") {
+			t.Errorf("Expected every type name to be declared but got %v", err)
+		}
+	}
+
+	// Normalizing again reuses the types
+	if added := DeduplicateTuples(contract); added != 0 || len(contract.Types) != 3 {
+		t.Errorf("Expected no new types but got %d", added)
+	}
+}
+
+func TestDeduplicateTuplesNameCollision(t *testing.T) {
+	tuple := func(field string) ParameterType {
+		return ParameterType{
+			BaseType:   "tuple",
+			Components: []Parameter{{Name: field, Type: ParameterType{BaseType: "bool"}}},
+			ChainData:  map[string]interface{}{"internalType": "struct Key"},
+		}
+	}
+	contract := &ContractIR{
+		Functions: []Function{
+			{Name: "a", Inputs: []Parameter{{Name: "key", Type: tuple("x")}}},
+			{Name: "b", Inputs: []Parameter{{Name: "key", Type: tuple("y")}}},
+		},
+	}
+
+	DeduplicateTuples(contract)
+	if got := contract.Functions[1].Inputs[0].Type.TypeName; got != "Key2" {
+		t.Errorf("Expected a differently shaped struct of the same name to be Key2 but got %s", got)
+	}
+}
+
+func TestValidateUnknownTypeName(t *testing.T) {
+	contract := &ContractIR{
+		Metadata: ContractMetadata{Name: "Exchange", Chain: "ethereum"},
+		Functions: []Function{{
+			Name:            "fill",
+			StateMutability: Nonpayable,
+			Inputs: []Parameter{{Name: "order", Type: ParameterType{
+				BaseType:   "tuple",
+				TypeName:   "Order",
+				Components: []Parameter{{Name: "maker", Type: ParameterType{BaseType: "address"}}},
+			}}},
+		}},
+	}
+
+	errors := contract.Validate()
+	if len(errors) != 1 || errors[0].Field != "Functions[0].Inputs[0].Type.TypeName" {
+		t.Errorf("Expected an unknown type name error but got %v", errors)
+	}
+}
\ No newline at end of file
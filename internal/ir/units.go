@@ -0,0 +1,169 @@
+package ir
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Units of unsigned integer parameters
+// Decimal units (token, token:<decimals>, wei and gwei) are given to and returned by tools in whole units, e.g.
+// "1.5" tokens or ether, and converted to and from the integer the contract uses; the others are documented.
+const (
+	// UnitToken is a token amount converted with the contract's decimals(); "token:18" gives the decimals instead
+	UnitToken = "token"
+
+	// UnitWei is an amount of native currency in wei, given and returned in ether
+	UnitWei = "wei"
+
+	// UnitGwei is an amount of native currency in gwei, e.g. a gas price, given and returned in ether
+	UnitGwei = "gwei"
+
+	// UnitSeconds is a duration in seconds
+	UnitSeconds = "seconds"
+
+	// UnitTimestamp is a Unix time in seconds
+	UnitTimestamp = "timestamp"
+
+	// UnitBasisPoints is a ratio in basis points (1/100 of a percent)
+	UnitBasisPoints = "bps"
+)
+
+// durationNames and timestampNames match the names of parameters holding times, and basisPointNames those
+// holding basis points
+var (
+	durationNames   = regexp.MustCompile(`(?i)(duration|period|delay|interval|timeout|cooldown)$`)
+	timestampNames  = regexp.MustCompile(`(?i)(deadline|timestamp|expiry|expiration|validUntil|validAfter|startTime|endTime)$`)
+	basisPointNames = regexp.MustCompile(`(?i)(bps|basisPoints)$`)
+)
+
+// UnitDecimals returns the decimals of a decimal unit
+// fromContract reports that they are read from the contract's decimals() function, and ok is false for
+// units that are not decimal units
+func UnitDecimals(unit string) (decimals int, fromContract bool, ok bool) {
+	switch unit {
+	case UnitToken:
+		return 0, true, true
+	case UnitWei:
+		return 18, false, true
+	case UnitGwei:
+		// Gwei amounts are ether amounts with nine fewer decimals
+		return 9, false, true
+	}
+	if value := strings.TrimPrefix(unit, UnitToken+":"); value != unit {
+		decimals, err := strconv.Atoi(value)
+		return decimals, false, err == nil && decimals >= 0 && decimals <= MaxDecimals
+	}
+	return 0, false, false
+}
+
+// IsUnit reports whether a unit is known
+func IsUnit(unit string) bool {
+	if _, _, ok := UnitDecimals(unit); ok {
+		return true
+	}
+	switch unit {
+	case UnitSeconds, UnitTimestamp, UnitBasisPoints:
+		return true
+	}
+	return false
+}
+
+// UnitDescription describes the values of a unit that is not a decimal unit, e.g. "duration in seconds",
+// or returns "" for decimal and unknown units
+func UnitDescription(unit string) string {
+	switch unit {
+	case UnitSeconds:
+		return "duration in seconds"
+	case UnitTimestamp:
+		return "Unix timestamp in seconds"
+	case UnitBasisPoints:
+		return "basis points, 10000 = 100%"
+	}
+	return ""
+}
+
+// DetectUnits sets the units of unsigned integer parameters named like durations (e.g. lockPeriod), timestamps
+// (e.g. deadline) or basis points (e.g. feeBps) that have none. It returns the number of parameters given a unit.
+func DetectUnits(c *ContractIR) int {
+	detected := 0
+	walkParameters(c, func(p *Parameter) {
+		if p.Unit != "" || !isScalarUint(p.Type) {
+			return
+		}
+		switch {
+		case basisPointNames.MatchString(p.Name):
+			p.Unit = UnitBasisPoints
+		case timestampNames.MatchString(p.Name):
+			p.Unit = UnitTimestamp
+		case durationNames.MatchString(p.Name):
+			p.Unit = UnitSeconds
+		default:
+			return
+		}
+		detected++
+	})
+	return detected
+}
+
+// ResolveUnits marks the parameters with a decimal unit as token amounts of its decimals, the chain data
+// generators convert amounts with. Parameters already annotated with decimals are left unchanged.
+// It returns the number of parameters marked.
+func ResolveUnits(c *ContractIR) int {
+	resolved := 0
+	walkParameters(c, func(p *Parameter) {
+		decimals, fromContract, ok := UnitDecimals(p.Unit)
+		if !ok || !isScalarUint(p.Type) {
+			return
+		}
+		if _, annotated := p.Type.ChainData[DecimalsKey]; annotated {
+			return
+		}
+		p.Type.ChainData = copyChainData(p.Type.ChainData)
+		if fromContract {
+			p.Type.ChainData[DecimalsKey] = DecimalsFromContract
+		} else {
+			p.Type.ChainData[DecimalsKey] = decimals
+		}
+		resolved++
+	})
+	return resolved
+}
+
+// walkParameters calls visit with every function input and output and error parameter,
+// including tuple components
+func walkParameters(c *ContractIR, visit func(*Parameter)) {
+	var walk func(parameters []Parameter)
+	walk = func(parameters []Parameter) {
+		for i := range parameters {
+			visit(&parameters[i])
+			walk(parameters[i].Type.Components)
+		}
+	}
+	for i := range c.Functions {
+		walk(c.Functions[i].Inputs)
+		walk(c.Functions[i].Outputs)
+	}
+	for i := range c.Errors {
+		walk(c.Errors[i].Parameters)
+	}
+}
+
+// unitErrors reports unknown units, and units on parameters that are not single unsigned integers
+func unitErrors(p *Parameter) []ValidationError {
+	if p.Unit == "" {
+		return nil
+	}
+	if !IsUnit(p.Unit) {
+		return []ValidationError{{
+			Code:    CodeInvalidUnit,
+			Field:   "Unit",
+			Message: fmt.Sprintf("unknown unit %q (expected %s, %s:<decimals>, %s, %s, %s, %s or %s)", p.Unit, UnitToken, UnitToken, UnitWei, UnitGwei, UnitSeconds, UnitTimestamp, UnitBasisPoints),
+		}}
+	}
+	if !isScalarUint(p.Type) {
+		return []ValidationError{{Code: CodeInvalidUnit, Field: "Unit", Message: "units apply to single unsigned integers"}}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,66 @@
+package ir
+
+import (
+	"testing"
+)
+
+func TestUnitDecimals(t *testing.T) {
+	tests := []struct {
+		unit         string
+		decimals     int
+		fromContract bool
+		ok           bool
+	}{
+		{UnitToken, 0, true, true},
+		{"token:6", 6, false, true},
+		{UnitWei, 18, false, true},
+		{UnitGwei, 9, false, true},
+		{"token:78", 78, false, false},
+		{"token:x", 0, false, false},
+		{UnitSeconds, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			decimals, fromContract, ok := UnitDecimals(tt.unit)
+			if decimals != tt.decimals || fromContract != tt.fromContract || ok != tt.ok {
+				t.Errorf("Expected (%d, %v, %v) but got (%d, %v, %v)", tt.decimals, tt.fromContract, tt.ok, decimals, fromContract, ok)
+			}
+		})
+	}
+}
+
+func TestDetectAndResolveUnits(t *testing.T) {
+	uint256 := ParameterType{BaseType: "uint256"}
+	contract := &ContractIR{
+		Functions: []Function{
+			{Name: "lock", StateMutability: Nonpayable, Inputs: []Parameter{
+				{Name: "lockPeriod", Type: uint256},
+				{Name: "deadline", Type: uint256},
+				{Name: "feeBps", Type: ParameterType{BaseType: "uint16"}},
+				{Name: "price", Type: uint256, Unit: UnitGwei},
+				{Name: "timeout", Type: ParameterType{BaseType: "string"}},
+			}},
+		},
+	}
+
+	if detected := DetectUnits(contract); detected != 3 {
+		t.Errorf("Expected 3 detected units but got %d", detected)
+	}
+	inputs := contract.Functions[0].Inputs
+	for i, expected := range []string{UnitSeconds, UnitTimestamp, UnitBasisPoints, UnitGwei, ""} {
+		if inputs[i].Unit != expected {
+			t.Errorf("Expected %s to have unit %q but got %q", inputs[i].Name, expected, inputs[i].Unit)
+		}
+	}
+
+	if resolved := ResolveUnits(contract); resolved != 1 {
+		t.Errorf("Expected 1 resolved amount but got %d", resolved)
+	}
+	if decimals, fromContract, ok := inputs[3].Type.AmountDecimals(); decimals != 9 || fromContract || !ok {
+		t.Errorf("Expected the gwei price to have 9 decimals but got (%d, %v, %v)", decimals, fromContract, ok)
+	}
+	if _, _, ok := inputs[0].Type.AmountDecimals(); ok {
+		t.Error("Expected durations not to be amounts")
+	}
+}
\ No newline at end of file
@@ -2,13 +2,60 @@ package ir
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
-// ValidationError represents an error found during IR validation
+// Validation codes; IR0xx are warnings, IR1xx errors
+const (
+	// CodeMissingDescription: a function or event has no description, so its tool is described by its signature only
+	CodeMissingDescription = "IR001"
+
+	// CodeUnnamedParameter: a function input has no name, so its tool argument is named by position
+	CodeUnnamedParameter = "IR002"
+
+	// CodeNoFunctions: the contract has no functions, so no tools are generated
+	CodeNoFunctions = "IR003"
+
+	// CodeSchemaMismatch: an IR document does not match the IR JSON Schema
+	CodeSchemaMismatch = "IR100"
+
+	// CodeRequired: a required field is missing
+	CodeRequired = "IR101"
+
+	// CodeInvalidValue: a field has a value outside its allowed set
+	CodeInvalidValue = "IR102"
+
+	// CodeInvalidDecimals: token amount decimals are out of range or on a type that is not an unsigned integer
+	CodeInvalidDecimals = "IR103"
+
+	// CodeMissingDecimalsFunction: decimals are read from a contract without a decimals() function
+	CodeMissingDecimalsFunction = "IR104"
+
+	// CodeUnknownType: a tuple names a custom type that is not declared
+	CodeUnknownType = "IR105"
+
+	// CodeInvalidAccess: an access restriction is malformed
+	CodeInvalidAccess = "IR107"
+
+	// CodeInvalidUnit: a parameter unit is unknown or on a type that is not an unsigned integer
+	CodeInvalidUnit = "IR108"
+
+	// CodeInvalidValueConstraint: a value constraint is on a function that is not payable, or its amounts are malformed
+	CodeInvalidValueConstraint = "IR109"
+
+	// CodeInvalidTranslation: a translated description is keyed by an invalid language tag or empty
+	CodeInvalidTranslation = "IR110"
+
+	// CodeInvalidDefault: a parameter default is of the wrong type or not allowed for the parameter
+	CodeInvalidDefault = "IR111"
+)
+
+// ValidationError represents an error or warning found during IR validation
 type ValidationError struct {
-	Field   string
-	Message string
+	Code    string `json:"code"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // Error implements the error interface for ValidationError
@@ -16,6 +63,78 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// String formats the error as a line of a report, e.g. "IR100 Functions[0].Name: function name is required"
+func (e ValidationError) String() string {
+	return fmt.Sprintf("%s %s", e.Code, e.Error())
+}
+
+// ValidationReport splits the findings of validating an IR into errors, which make it unusable, and warnings
+type ValidationReport struct {
+	Errors   []ValidationError `json:"errors"`
+	Warnings []ValidationError `json:"warnings"`
+}
+
+// Report validates the ContractIR, returning its errors and warnings
+func (c *ContractIR) Report() *ValidationReport {
+	report := &ValidationReport{Errors: c.Validate(), Warnings: c.Warnings()}
+	if report.Errors == nil {
+		report.Errors = []ValidationError{}
+	}
+	if report.Warnings == nil {
+		report.Warnings = []ValidationError{}
+	}
+	return report
+}
+
+// Failed reports whether the IR has errors, or in strict mode any warnings
+func (r *ValidationReport) Failed(strict bool) bool {
+	return len(r.Errors) > 0 || strict && len(r.Warnings) > 0
+}
+
+// Warnings returns what makes the generated tools of a valid ContractIR less usable
+func (c *ContractIR) Warnings() []ValidationError {
+	var warnings []ValidationError
+	if len(c.Functions) == 0 {
+		warnings = append(warnings, ValidationError{
+			Code:    CodeNoFunctions,
+			Field:   "Functions",
+			Message: "contract has no functions",
+		})
+	}
+	for i, function := range c.Functions {
+		if function.Hidden || function.IsConstructor {
+			continue
+		}
+		fieldPrefix := fmt.Sprintf("Functions[%d]", i)
+		if strings.TrimSpace(function.Description) == "" {
+			warnings = append(warnings, ValidationError{
+				Code:    CodeMissingDescription,
+				Field:   fieldPrefix + ".Description",
+				Message: fmt.Sprintf("function %s has no description", function.Name),
+			})
+		}
+		for j, input := range function.Inputs {
+			if strings.TrimSpace(input.Name) == "" {
+				warnings = append(warnings, ValidationError{
+					Code:    CodeUnnamedParameter,
+					Field:   fmt.Sprintf("%s.Inputs[%d].Name", fieldPrefix, j),
+					Message: fmt.Sprintf("input %d of function %s has no name", j, function.Name),
+				})
+			}
+		}
+	}
+	for i, event := range c.Events {
+		if !event.Hidden && strings.TrimSpace(event.Description) == "" {
+			warnings = append(warnings, ValidationError{
+				Code:    CodeMissingDescription,
+				Field:   fmt.Sprintf("Events[%d].Description", i),
+				Message: fmt.Sprintf("event %s has no description", event.Name),
+			})
+		}
+	}
+	return warnings
+}
+
 // Validate checks if the ContractIR is valid and returns a list of validation errors
 func (c *ContractIR) Validate() []ValidationError {
 	var errors []ValidationError
@@ -34,6 +153,14 @@ func (c *ContractIR) Validate() []ValidationError {
 		}
 	}
 
+	// Amounts with decimals read from the contract need its decimals() function
+	if !c.HasDecimalsFunction() {
+		for i, function := range c.Functions {
+			errors = append(errors, missingDecimalsErrors(fmt.Sprintf("Functions[%d].Inputs", i), function.Inputs)...)
+			errors = append(errors, missingDecimalsErrors(fmt.Sprintf("Functions[%d].Outputs", i), function.Outputs)...)
+		}
+	}
+
 	// Validate events
 	for i, event := range c.Events {
 		fieldPrefix := fmt.Sprintf("Events[%d]", i)
@@ -64,6 +191,57 @@ func (c *ContractIR) Validate() []ValidationError {
 		}
 	}
 
+	// Tuples must name declared custom types
+	types := make(map[string]bool, len(c.Types))
+	for _, customType := range c.Types {
+		types[customType.Name] = true
+	}
+	for i, function := range c.Functions {
+		for j, input := range function.Inputs {
+			errors = append(errors, unknownTypeNameErrors(fmt.Sprintf("Functions[%d].Inputs[%d].Type", i, j), input.Type, types)...)
+		}
+		for j, output := range function.Outputs {
+			errors = append(errors, unknownTypeNameErrors(fmt.Sprintf("Functions[%d].Outputs[%d].Type", i, j), output.Type, types)...)
+		}
+	}
+	for i, event := range c.Events {
+		for j, parameter := range event.Parameters {
+			errors = append(errors, unknownTypeNameErrors(fmt.Sprintf("Events[%d].Parameters[%d].Type", i, j), parameter.Type, types)...)
+		}
+	}
+
+	return errors
+}
+
+// missingDecimalsErrors reports the parameters whose decimals are read from the contract,
+// for contracts without a decimals() function
+func missingDecimalsErrors(fieldPrefix string, parameters []Parameter) []ValidationError {
+	var errors []ValidationError
+	for i, parameter := range parameters {
+		if _, fromContract, ok := parameter.Type.AmountDecimals(); ok && fromContract {
+			errors = append(errors, ValidationError{
+				Code:    CodeMissingDecimalsFunction,
+				Field:   fmt.Sprintf("%s[%d].Type.ChainData.%s", fieldPrefix, i, DecimalsKey),
+				Message: "decimals are read from the contract, but it has no decimals() function",
+			})
+		}
+	}
+	return errors
+}
+
+// unknownTypeNameErrors reports the tuples of a type, including nested ones, naming a custom type that is not declared
+func unknownTypeNameErrors(field string, t ParameterType, types map[string]bool) []ValidationError {
+	var errors []ValidationError
+	if t.TypeName != "" && !types[t.TypeName] {
+		errors = append(errors, ValidationError{
+			Code:    CodeUnknownType,
+			Field:   field + ".TypeName",
+			Message: fmt.Sprintf("no custom type named %s", t.TypeName),
+		})
+	}
+	for i, component := range t.Components {
+		errors = append(errors, unknownTypeNameErrors(fmt.Sprintf("%s.Components[%d].Type", field, i), component.Type, types)...)
+	}
 	return errors
 }
 
@@ -74,6 +252,7 @@ func (m *ContractMetadata) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(m.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "contract name is required",
 		})
@@ -82,11 +261,22 @@ func (m *ContractMetadata) Validate() []ValidationError {
 	// Chain is required
 	if strings.TrimSpace(m.Chain) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Chain",
 			Message: "chain identifier is required",
 		})
 	}
 
+	// Deployments are keyed by chain ID
+	chainIDs := make([]string, 0, len(m.Deployments))
+	for chainID := range m.Deployments {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+	for _, chainID := range chainIDs {
+		errors = append(errors, deploymentErrors(chainID, m.Deployments[chainID])...)
+	}
+
 	// Validate source info if present
 	if m.Source != nil {
 		sourceErrors := m.Source.Validate()
@@ -96,6 +286,8 @@ func (m *ContractMetadata) Validate() []ValidationError {
 		}
 	}
 
+	errors = append(errors, translationErrors(m.Descriptions)...)
+
 	return errors
 }
 
@@ -106,6 +298,7 @@ func (s *SourceInfo) Validate() []ValidationError {
 	// Language is required
 	if strings.TrimSpace(s.Language) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Language",
 			Message: "programming language is required",
 		})
@@ -121,6 +314,7 @@ func (f *Function) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(f.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "function name is required",
 		})
@@ -149,6 +343,7 @@ func (f *Function) Validate() []ValidationError {
 	// Validate state mutability
 	if err := validateStateMutability(f.StateMutability); err != nil {
 		errors = append(errors, ValidationError{
+			Code:    valueCode(string(f.StateMutability)),
 			Field:   "StateMutability",
 			Message: err.Error(),
 		})
@@ -158,12 +353,18 @@ func (f *Function) Validate() []ValidationError {
 	if f.Visibility != "" {
 		if err := validateVisibility(f.Visibility); err != nil {
 			errors = append(errors, ValidationError{
+				Code:    CodeInvalidValue,
 				Field:   "Visibility",
 				Message: err.Error(),
 			})
 		}
 	}
 
+	errors = append(errors, accessErrors(f.Access)...)
+	errors = append(errors, valueErrors(f)...)
+	errors = append(errors, categoryErrors(f.Category)...)
+	errors = append(errors, translationErrors(f.Descriptions)...)
+
 	return errors
 }
 
@@ -174,6 +375,7 @@ func (e *Event) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(e.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "event name is required",
 		})
@@ -189,6 +391,8 @@ func (e *Event) Validate() []ValidationError {
 		}
 	}
 
+	errors = append(errors, translationErrors(e.Descriptions)...)
+
 	return errors
 }
 
@@ -199,6 +403,7 @@ func (p *EventParameter) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(p.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "parameter name is required",
 		})
@@ -225,6 +430,9 @@ func (p *Parameter) Validate() []ValidationError {
 		errors = append(errors, err)
 	}
 
+	errors = append(errors, unitErrors(p)...)
+	errors = append(errors, defaultErrors(p)...)
+	errors = append(errors, translationErrors(p.Descriptions)...)
 	return errors
 }
 
@@ -235,6 +443,7 @@ func (t *ParameterType) Validate() []ValidationError {
 	// BaseType is required
 	if strings.TrimSpace(t.BaseType) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "BaseType",
 			Message: "base type is required",
 		})
@@ -243,6 +452,7 @@ func (t *ParameterType) Validate() []ValidationError {
 	// If it's an array, validate array size
 	if t.IsArray && t.ArraySize < 0 {
 		errors = append(errors, ValidationError{
+			Code:    CodeInvalidValue,
 			Field:   "ArraySize",
 			Message: "array size must be non-negative (0 for dynamic arrays)",
 		})
@@ -251,11 +461,23 @@ func (t *ParameterType) Validate() []ValidationError {
 	// If it's a map, validate key type
 	if t.IsMap && strings.TrimSpace(t.MapKeyType) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "MapKeyType",
 			Message: "map key type is required for maps",
 		})
 	}
 
+	// Token amount decimals apply to single unsigned integers
+	if _, annotated := t.ChainData[DecimalsKey]; annotated {
+		if _, _, ok := t.AmountDecimals(); !ok {
+			errors = append(errors, ValidationError{
+				Code:    CodeInvalidDecimals,
+				Field:   "ChainData." + DecimalsKey,
+				Message: fmt.Sprintf("decimals must be 0-%d or %q, on an unsigned integer", MaxDecimals, DecimalsFromContract),
+			})
+		}
+	}
+
 	// Validate components if present
 	if len(t.Components) > 0 {
 		for i, component := range t.Components {
@@ -278,6 +500,7 @@ func (e *ContractError) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(e.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "error name is required",
 		})
@@ -293,6 +516,8 @@ func (e *ContractError) Validate() []ValidationError {
 		}
 	}
 
+	errors = append(errors, translationErrors(e.Descriptions)...)
+
 	return errors
 }
 
@@ -303,6 +528,7 @@ func (t *CustomType) Validate() []ValidationError {
 	// Name is required
 	if strings.TrimSpace(t.Name) == "" {
 		errors = append(errors, ValidationError{
+			Code:    CodeRequired,
 			Field:   "Name",
 			Message: "type name is required",
 		})
@@ -318,9 +544,19 @@ func (t *CustomType) Validate() []ValidationError {
 		}
 	}
 
+	errors = append(errors, translationErrors(t.Descriptions)...)
+
 	return errors
 }
 
+// valueCode returns the code of a missing or invalid value
+func valueCode(value string) string {
+	if value == "" {
+		return CodeRequired
+	}
+	return CodeInvalidValue
+}
+
 // validateStateMutability checks if the state mutability is valid
 func validateStateMutability(sm StateMutability) error {
 	switch sm {
@@ -413,4 +413,47 @@ func TestVisibilityValidation(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestValidationReport(t *testing.T) {
+	contract := &ContractIR{
+		Metadata: ContractMetadata{Name: "Token", Chain: "ethereum"},
+		Functions: []Function{
+			{Name: "transfer", StateMutability: Nonpayable, Inputs: []Parameter{{Type: ParameterType{BaseType: "address"}}}},
+			{Name: "owner", Description: "Returns the owner", StateMutability: "constant"},
+			{Name: "upgradeTo", StateMutability: Nonpayable, Hidden: true},
+		},
+		Events: []Event{{Name: "Transfer"}},
+	}
+	report := contract.Report()
+
+	if len(report.Errors) != 1 || report.Errors[0].Code != CodeInvalidValue || report.Errors[0].Field != "Functions[1].StateMutability" {
+		t.Errorf("Expected an invalid state mutability error, got %v", report.Errors)
+	}
+	var codes []string
+	for _, warning := range report.Warnings {
+		codes = append(codes, warning.Code+" "+warning.Field)
+	}
+	expected := []string{
+		CodeMissingDescription + " Functions[0].Description",
+		CodeUnnamedParameter + " Functions[0].Inputs[0].Name",
+		CodeMissingDescription + " Events[0].Description",
+	}
+	if len(codes) != len(expected) {
+		t.Fatalf("Expected warnings %v, got %v", expected, codes)
+	}
+	for i := range expected {
+		if codes[i] != expected[i] {
+			t.Errorf("Expected warning %s, got %s", expected[i], codes[i])
+		}
+	}
+	if got := report.Errors[0].String(); got != "IR102 Functions[1].StateMutability: invalid state mutability: constant" {
+		t.Errorf("Unexpected finding line %q", got)
+	}
+
+	contract.Functions = contract.Functions[:1]
+	report = contract.Report()
+	if report.Failed(false) || !report.Failed(true) {
+		t.Errorf("Expected a report with only warnings to fail in strict mode only, got %+v", report)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,152 @@
+package ir
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// DefaultCurrency is the native currency values are documented in when a constraint names none
+const DefaultCurrency = "ETH"
+
+// nativeDecimals is the number of decimals of native currencies: 1 ETH is 10^18 wei
+const nativeDecimals = 18
+
+// nativeAmountPattern matches amounts of native currency in whole units, e.g. "0.05"
+var nativeAmountPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ValueConstraint documents and bounds the native currency sent to a payable function, e.g. the price of a mint
+// Amounts are decimal strings in whole units of the currency (e.g., "0.05" for 0.05 ETH); generated tools take the
+// value in wei and reject values out of range.
+type ValueConstraint struct {
+	// Smallest value accepted
+	Min string `json:"min,omitempty"`
+
+	// Largest value accepted
+	Max string `json:"max,omitempty"`
+
+	// Value usually sent, used as the example of the value input
+	Typical string `json:"typical,omitempty"`
+
+	// Symbol of the native currency (default: ETH)
+	Currency string `json:"currency,omitempty"`
+
+	// Whether a value must be sent
+	Required bool `json:"required,omitempty"`
+}
+
+// CurrencySymbol returns the symbol of the constraint's currency
+func (v *ValueConstraint) CurrencySymbol() string {
+	if v == nil || v.Currency == "" {
+		return DefaultCurrency
+	}
+	return v.Currency
+}
+
+// MinWei, MaxWei and TypicalWei return the bounds and typical value in wei, or nil if unset or invalid
+func (v *ValueConstraint) MinWei() *big.Int     { return v.wei(func(v *ValueConstraint) string { return v.Min }) }
+func (v *ValueConstraint) MaxWei() *big.Int     { return v.wei(func(v *ValueConstraint) string { return v.Max }) }
+func (v *ValueConstraint) TypicalWei() *big.Int { return v.wei(func(v *ValueConstraint) string { return v.Typical }) }
+
+func (v *ValueConstraint) wei(amount func(v *ValueConstraint) string) *big.Int {
+	if v == nil || amount(v) == "" {
+		return nil
+	}
+	wei, err := ParseNativeAmount(amount(v))
+	if err != nil {
+		return nil
+	}
+	return wei
+}
+
+// Bounds describes the range of the constraint, e.g. "between 0.01 and 1 ETH", or "" if it sets no bounds
+func (v *ValueConstraint) Bounds() string {
+	if v == nil {
+		return ""
+	}
+	switch currency := v.CurrencySymbol(); {
+	case v.Min != "" && v.Max != "":
+		return fmt.Sprintf("between %s and %s %s", v.Min, v.Max, currency)
+	case v.Min != "":
+		return fmt.Sprintf("at least %s %s", v.Min, currency)
+	case v.Max != "":
+		return fmt.Sprintf("at most %s %s", v.Max, currency)
+	}
+	return ""
+}
+
+// String describes the constraint, e.g. "between 0.01 and 1 ETH, typically 0.05 ETH", or "" if it sets no amounts
+func (v *ValueConstraint) String() string {
+	if v == nil {
+		return ""
+	}
+	var parts []string
+	if bounds := v.Bounds(); bounds != "" {
+		parts = append(parts, bounds)
+	}
+	if v.Typical != "" {
+		parts = append(parts, fmt.Sprintf("typically %s %s", v.Typical, v.CurrencySymbol()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Usage describes the value input of the function's tools, e.g. "Native currency value to send with the transaction,
+// in wei (1 ETH = 10^18 wei): between 0.01 and 1 ETH, typically 0.05 ETH"
+func (v *ValueConstraint) Usage() string {
+	usage := fmt.Sprintf("value to send with the transaction, in wei (1 %s = 10^18 wei)", v.CurrencySymbol())
+	if v != nil && v.Required {
+		usage = "Native currency " + usage
+	} else {
+		usage = "Optional native currency " + usage
+	}
+	if bounds := v.String(); bounds != "" {
+		usage += ": " + bounds
+	}
+	return usage
+}
+
+// ParseNativeAmount converts an amount of native currency in whole units (e.g., "0.05") to wei
+func ParseNativeAmount(amount string) (*big.Int, error) {
+	if !nativeAmountPattern.MatchString(amount) {
+		return nil, fmt.Errorf("invalid amount %q (expected a decimal number such as 0.05)", amount)
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if len(fraction) > nativeDecimals {
+		return nil, fmt.Errorf("invalid amount %q (at most %d decimals)", amount, nativeDecimals)
+	}
+	wei, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", nativeDecimals-len(fraction)), 10)
+	return wei, nil
+}
+
+// valueErrors reports value constraints on functions that are not payable, malformed amounts, and typical values out
+// of bounds
+func valueErrors(f *Function) []ValidationError {
+	v := f.Value
+	if v == nil {
+		return nil
+	}
+	if f.StateMutability != Payable {
+		return []ValidationError{{Code: CodeInvalidValueConstraint, Field: "Value", Message: "only payable functions receive a value"}}
+	}
+	var errors []ValidationError
+	for _, amount := range []struct{ field, value string }{{"Min", v.Min}, {"Max", v.Max}, {"Typical", v.Typical}} {
+		if amount.value == "" {
+			continue
+		}
+		if _, err := ParseNativeAmount(amount.value); err != nil {
+			errors = append(errors, ValidationError{Code: CodeInvalidValueConstraint, Field: "Value." + amount.field, Message: err.Error()})
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	min, max, typical := v.MinWei(), v.MaxWei(), v.TypicalWei()
+	if min != nil && max != nil && min.Cmp(max) > 0 {
+		errors = append(errors, ValidationError{Code: CodeInvalidValueConstraint, Field: "Value", Message: fmt.Sprintf("min %s is larger than max %s", v.Min, v.Max)})
+	}
+	if typical != nil && ((min != nil && typical.Cmp(min) < 0) || (max != nil && typical.Cmp(max) > 0)) {
+		errors = append(errors, ValidationError{Code: CodeInvalidValueConstraint, Field: "Value.Typical", Message: fmt.Sprintf("typical value %s is out of bounds", v.Typical)})
+	}
+	return errors
+}
\ No newline at end of file
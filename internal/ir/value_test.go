@@ -0,0 +1,77 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNativeAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"1", "1000000000000000000"},
+		{"0.05", "50000000000000000"},
+		{"0.000000000000000001", "1"},
+		{"0", "0"},
+	}
+	for _, test := range tests {
+		wei, err := ParseNativeAmount(test.amount)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", test.amount, err)
+		}
+		if wei.String() != test.expected {
+			t.Errorf("Expected %s to be %s wei but got %s", test.amount, test.expected, wei)
+		}
+	}
+
+	for _, amount := range []string{"", "-1", "1e18", ".5", "0.0000000000000000001"} {
+		if _, err := ParseNativeAmount(amount); err == nil {
+			t.Errorf("Expected %q to be rejected", amount)
+		}
+	}
+}
+
+func TestValueConstraintString(t *testing.T) {
+	tests := []struct {
+		value    *ValueConstraint
+		expected string
+	}{
+		{nil, ""},
+		{&ValueConstraint{Required: true}, ""},
+		{&ValueConstraint{Min: "0.01", Max: "1", Typical: "0.05"}, "between 0.01 and 1 ETH, typically 0.05 ETH"},
+		{&ValueConstraint{Min: "0.01", Currency: "MATIC"}, "at least 0.01 MATIC"},
+		{&ValueConstraint{Max: "1", Typical: "0.5"}, "at most 1 ETH, typically 0.5 ETH"},
+	}
+	for _, test := range tests {
+		if got := test.value.String(); got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, got)
+		}
+	}
+}
+
+func TestValueValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		function Function
+		expected string
+	}{
+		{"Not payable", Function{StateMutability: Nonpayable, Value: &ValueConstraint{Min: "1"}}, "only payable functions"},
+		{"Amount", Function{StateMutability: Payable, Value: &ValueConstraint{Max: "1 ETH"}}, `Value.Max: invalid amount "1 ETH"`},
+		{"Bounds", Function{StateMutability: Payable, Value: &ValueConstraint{Min: "2", Max: "1"}}, "min 2 is larger than max 1"},
+		{"Typical", Function{StateMutability: Payable, Value: &ValueConstraint{Min: "0.1", Typical: "0.05"}}, "typical value 0.05 is out of bounds"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := valueErrors(&test.function)
+			if len(errs) != 1 || errs[0].Code != CodeInvalidValueConstraint || !strings.Contains(errs[0].Error(), test.expected) {
+				t.Errorf("Expected an error containing %q but got %v", test.expected, errs)
+			}
+		})
+	}
+
+	valid := Function{StateMutability: Payable, Value: &ValueConstraint{Min: "0.01", Max: "1", Typical: "0.05", Required: true}}
+	if errs := valueErrors(&valid); len(errs) > 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+}
\ No newline at end of file
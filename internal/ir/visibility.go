@@ -0,0 +1,21 @@
+package ir
+
+// Visible returns a copy of the contract without its hidden functions and events, the members tools and documentation
+// are generated for. Deprecated members are kept, for generators to mark as deprecated.
+// The contract itself is left unchanged
+func (c *ContractIR) Visible() *ContractIR {
+	visible := *c
+	visible.Functions = make([]Function, 0, len(c.Functions))
+	for _, f := range c.Functions {
+		if !f.Hidden {
+			visible.Functions = append(visible.Functions, f)
+		}
+	}
+	visible.Events = make([]Event, 0, len(c.Events))
+	for _, e := range c.Events {
+		if !e.Hidden {
+			visible.Events = append(visible.Events, e)
+		}
+	}
+	return &visible
+}
\ No newline at end of file
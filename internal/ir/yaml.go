@@ -0,0 +1,144 @@
+package ir
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsYAMLFile reports whether a path names a YAML file by its .yaml or .yml extension
+func IsYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// YAMLToJSON converts a YAML document to JSON, so it can be validated and decoded with the JSON field names
+// Comments are dropped
+func YAMLToJSON(content []byte) ([]byte, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// MarshalYAML encodes a contract IR as YAML with the field names and order of its JSON form
+// Comments of a previous YAML encoding, e.g. the file being regenerated, are carried over to the entries they
+// were attached to: mapping entries are matched by key, and list items by name or, failing that, position
+func MarshalYAML(c *ContractIR, previous []byte) ([]byte, error) {
+	content, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML, so decoding it into a node keeps the field order
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	resetStyle(&document)
+
+	if len(bytes.TrimSpace(previous)) > 0 {
+		var old yaml.Node
+		if err := yaml.Unmarshal(previous, &old); err != nil {
+			return nil, fmt.Errorf("failed to parse the previous YAML: %w", err)
+		}
+		copyComments(&old, &document)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resetStyle switches nodes decoded from JSON to block style with plain scalars where YAML allows them
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// copyComments copies the comments of an old node tree onto the matching nodes of a new one
+func copyComments(old, new *yaml.Node) {
+	if old.Kind != new.Kind {
+		return
+	}
+	new.HeadComment = old.HeadComment
+	new.LineComment = old.LineComment
+	new.FootComment = old.FootComment
+
+	switch new.Kind {
+	case yaml.DocumentNode:
+		if len(old.Content) > 0 && len(new.Content) > 0 {
+			copyComments(old.Content[0], new.Content[0])
+		}
+
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(new.Content); i += 2 {
+			if j := mappingIndex(old, new.Content[i].Value); j >= 0 {
+				copyComments(old.Content[j], new.Content[i])
+				copyComments(old.Content[j+1], new.Content[i+1])
+			}
+		}
+
+	case yaml.SequenceNode:
+		for i, item := range new.Content {
+			if match := sequenceMatch(old, item, i); match != nil {
+				copyComments(match, item)
+			}
+		}
+	}
+}
+
+// mappingIndex returns the index of a key in a mapping node's content, or -1
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// sequenceMatch returns the item of an old sequence matching a new item: the one with the same name if the
+// items are named, as functions and events are, or the one at the same position otherwise
+func sequenceMatch(old, item *yaml.Node, index int) *yaml.Node {
+	if name := nodeName(item); name != "" {
+		for _, candidate := range old.Content {
+			if nodeName(candidate) == name {
+				return candidate
+			}
+		}
+		return nil
+	}
+	if index < len(old.Content) {
+		return old.Content[index]
+	}
+	return nil
+}
+
+// nodeName returns the name field of a mapping node, or ""
+func nodeName(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	if i := mappingIndex(node, "name"); i >= 0 {
+		return node.Content[i+1].Value
+	}
+	return ""
+}
\ No newline at end of file
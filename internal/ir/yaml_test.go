@@ -0,0 +1,92 @@
+package ir
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalYAMLRoundTrip(t *testing.T) {
+	contract := overlayContract()
+	contract.Metadata.Address = "0x0000000000000000000000000000000000000001"
+	contract.Functions[0].Selector = "0x70a08231"
+	contract.Functions[0].Outputs[0].Type.ChainData = map[string]interface{}{DecimalsKey: DecimalsFromContract}
+
+	content, err := MarshalYAML(contract, nil)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "metadata:\n  name: Token\n") {
+		t.Errorf("Expected fields in JSON order but got\n%s", content)
+	}
+	if !strings.Contains(string(content), `selector: "0x70a08231"`) {
+		t.Errorf("Expected hex strings to be quoted so they are not read back as numbers but got\n%s", content)
+	}
+
+	document, err := YAMLToJSON(content)
+	if err != nil {
+		t.Fatalf("Failed to convert to JSON: %v", err)
+	}
+	decoded := &ContractIR{}
+	if err := json.Unmarshal(document, decoded); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	expected, _ := json.Marshal(contract)
+	got, _ := json.Marshal(decoded)
+	if string(expected) != string(got) {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, got)
+	}
+}
+
+func TestMarshalYAMLKeepsComments(t *testing.T) {
+	contract := overlayContract()
+	previous, err := MarshalYAML(contract, nil)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	edited := "# Token IR\n" + strings.Replace(string(previous), "  - name: mint\n", "  # Mints new tokens\n  - name: mint\n", 1)
+	edited = strings.Replace(edited, "    signature: balanceOf(address)\n", "    signature: balanceOf(address) # ERC-20\n", 1)
+
+	// Functions are matched by name, so comments follow them when the order changes
+	contract.Functions[0], contract.Functions[1] = contract.Functions[1], contract.Functions[0]
+	content, err := MarshalYAML(contract, []byte(edited))
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	for _, expected := range []string{
+		"# Token IR\nmetadata:",
+		"functions:\n  # Mints new tokens\n  - name: mint\n",
+		"signature: balanceOf(address) # ERC-20\n",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected %q in\n%s", expected, content)
+		}
+	}
+
+	if _, err := MarshalYAML(contract, []byte("functions: [")); err == nil {
+		t.Errorf("Expected an error for an invalid previous document")
+	}
+}
+
+func TestIsYAMLFile(t *testing.T) {
+	for path, expected := range map[string]bool{"ir.yaml": true, "IR.YML": true, "ir.json": false, "": false} {
+		if got := IsYAMLFile(path); got != expected {
+			t.Errorf("IsYAMLFile(%q): expected %v but got %v", path, expected, got)
+		}
+	}
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	document, err := YAMLToJSON([]byte("functions:\n  - name: mint # comment\n    inputs: []\n"))
+	if err != nil {
+		t.Fatalf("Failed to convert: %v", err)
+	}
+	var got, expected interface{}
+	json.Unmarshal(document, &got)
+	json.Unmarshal([]byte(`{"functions": [{"name": "mint", "inputs": []}]}`), &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v but got %v", expected, got)
+	}
+}
\ No newline at end of file
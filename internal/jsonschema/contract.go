@@ -0,0 +1,238 @@
+package jsonschema
+
+// unitPattern matches the units of parameters
+const unitPattern = `^(token(:[0-9]+)?|wei|gwei|seconds|timestamp|bps)$`
+
+// ForContractIR returns the JSON Schema of contract IR documents, the JSON form of ir.ContractIR
+// Objects are closed, so misspelled fields are reported rather than silently ignored; chain data is free-form
+func ForContractIR() *Schema {
+	schema := closedObject(
+		Property{"metadata", ref("metadata")},
+		Property{"functions", arrayOf(ref("function"), "Functions defined in the contract")},
+		Property{"events", arrayOf(ref("event"), "Events that can be emitted by the contract")},
+		Property{"errors", arrayOf(ref("error"), "Errors that can be thrown by the contract")},
+		Property{"types", arrayOf(ref("customType"), "Custom types defined in the contract")},
+	)
+	schema.Schema = Draft
+	schema.Title = "Contract IR"
+	schema.Description = "Intermediate representation of a smart contract, from which MCP servers are generated"
+	schema.Required = []string{"metadata", "functions"}
+
+	metadata := closedObject(
+		Property{"name", text("Name of the contract")},
+		Property{"description", text("Description of the contract's purpose")},
+		Property{"descriptions", translations()},
+		Property{"address", text("Address where the contract is deployed (if known)")},
+		Property{"chain", text("Chain identifier (e.g., \"ethereum\", \"solana\")")},
+		Property{"deployments", deployments()},
+		Property{"chainData", chainData()},
+		Property{"source", ref("source")},
+	)
+	metadata.Description = "Information about the contract itself"
+	metadata.Required = []string{"name", "chain"}
+
+	firstBlock := 0
+	deployment := closedObject(
+		Property{"address", text("Address of the contract on the network")},
+		Property{"block", &Schema{Type: "integer", Minimum: &firstBlock, Description: "Block the contract was deployed in, before which it has no events (optional)"}},
+	)
+	deployment.Description = "Where a contract is deployed on one network"
+	deployment.Required = []string{"address"}
+
+	source := closedObject(
+		Property{"language", text("Programming language")},
+		Property{"compiler", text("Compiler version")},
+		Property{"sourceUrl", text("Source code URL or path")},
+	)
+	source.Description = "Information about the contract's source code"
+	source.Required = []string{"language"}
+
+	function := closedObject(
+		Property{"name", text("Function name")},
+		Property{"description", text("Human-readable description")},
+		Property{"descriptions", translations()},
+		Property{"signature", text("Function signature (e.g., \"transfer(address,uint256)\")")},
+		Property{"selector", text("Function selector (e.g., \"0xa9059cbb\" for EVM)")},
+		Property{"inputs", arrayOf(ref("parameter"), "Input parameters")},
+		Property{"outputs", arrayOf(ref("parameter"), "Output parameters")},
+		Property{"stateMutability", &Schema{Enum: []interface{}{"pure", "view", "nonpayable", "payable"}, Description: "How the function interacts with contract state"}},
+		Property{"visibility", &Schema{Enum: []interface{}{"external", "public", "internal", "private"}, Description: "Function visibility"}},
+		Property{"isConstructor", flag("Whether this is a constructor")},
+		Property{"isFallback", flag("Whether this is a fallback function")},
+		Property{"isReceive", flag("Whether this is a receive function (EVM specific)")},
+		Property{"tags", arrayOf(text(""), "Labels grouping the function (e.g., \"admin\", \"liquidity\")")},
+		Property{"category", text("Category generated tools are grouped by (e.g., \"read\", \"admin\", \"liquidity\")")},
+		Property{"danger", &Schema{Enum: []interface{}{"low", "medium", "high"}, Description: "How much harm a mistaken call can do"}},
+		Property{"examples", arrayOf(&Schema{Type: "object"}, "Example arguments by input name, for documentation")},
+		Property{"access", ref("accessRestriction")},
+		Property{"deprecated", flag("Whether the function is deprecated; its tool is still generated but marked as deprecated")},
+		Property{"hidden", flag("Whether the function is kept in the IR but left out of generated tools")},
+		Property{"overload", ref("overload")},
+		Property{"value", ref("valueConstraint")},
+		Property{"chainData", chainData()},
+	)
+	function.Description = "A callable function in the contract"
+	function.Required = []string{"name", "inputs", "outputs", "stateMutability"}
+
+	firstIndex := 0
+	overload := closedObject(
+		Property{"name", text("ABI name shared by the overloads (e.g., \"safeTransferFrom\")")},
+		Property{"index", &Schema{Type: "integer", Minimum: &firstIndex, Description: "Position of the function among the overloads, in ABI order"}},
+		Property{"signature", text("Canonical signature the function is called by (e.g., \"safeTransferFrom(address,address,uint256,bytes)\")")},
+	)
+	overload.Description = "One of several functions sharing an ABI name"
+	overload.Required = []string{"name", "index", "signature"}
+
+	access := closedObject(
+		Property{"onlyOwner", flag("Only the contract owner may call the function (e.g., Ownable's onlyOwner)")},
+		Property{"roles", arrayOf(text(""), "Callers need one of these roles (e.g., AccessControl's \"MINTER_ROLE\")")},
+		Property{"allowlist", text("Name of an allowlist callers must be on (e.g., \"whitelist\")")},
+	)
+	access.Description = "Who may call a function"
+
+	amount := func(description string) *Schema {
+		return &Schema{Type: "string", Pattern: `^[0-9]+(\.[0-9]+)?$`, Description: description}
+	}
+	value := closedObject(
+		Property{"min", amount("Smallest value accepted, in whole units of the currency (e.g., \"0.01\")")},
+		Property{"max", amount("Largest value accepted, in whole units of the currency")},
+		Property{"typical", amount("Value usually sent, in whole units of the currency")},
+		Property{"currency", text("Symbol of the native currency (default: ETH)")},
+		Property{"required", flag("Whether a value must be sent")},
+	)
+	value.Description = "Bounds and documentation of the native currency sent to a payable function"
+
+	event := closedObject(
+		Property{"name", text("Event name")},
+		Property{"description", text("Human-readable description")},
+		Property{"descriptions", translations()},
+		Property{"signature", text("Event signature")},
+		Property{"topic", text("Topic of the event's logs (e.g., the Keccak-256 hash of the signature for EVM); empty for anonymous events")},
+		Property{"parameters", arrayOf(ref("eventParameter"), "Parameters included in the event")},
+		Property{"tags", arrayOf(text(""), "Labels grouping the event (e.g., \"transfers\")")},
+		Property{"deprecated", flag("Whether the event is deprecated; its log tool is still generated but marked as deprecated")},
+		Property{"hidden", flag("Whether the event is kept in the IR but left out of generated tools")},
+		Property{"chainData", chainData()},
+	)
+	event.Description = "An event that can be emitted by the contract"
+	event.Required = []string{"name", "parameters"}
+
+	eventParameter := closedObject(
+		Property{"name", text("Parameter name")},
+		Property{"type", ref("parameterType")},
+		Property{"indexed", flag("Whether the parameter is indexed (for efficient filtering)")},
+	)
+	eventParameter.Description = "A parameter in an event"
+	eventParameter.Required = []string{"name", "type"}
+
+	parameter := closedObject(
+		Property{"name", text("Parameter name")},
+		Property{"type", ref("parameterType")},
+		Property{"description", text("Human-readable description")},
+		Property{"descriptions", translations()},
+		Property{"unit", &Schema{Type: "string", Pattern: unitPattern, Description: "Unit of an unsigned integer (e.g., \"token\", \"token:18\", \"wei\", \"seconds\", \"bps\")"}},
+		Property{"examples", arrayOf(&Schema{}, "Example values as tools take them (e.g., token amounts in whole tokens), for documentation and test fixtures")},
+		Property{"default", &Schema{Description: "Value tools use when the argument is left out, as tools take it (e.g., \"latest\"); timestamps may default to a number of seconds after the call (e.g., \"+1200\")"}},
+	)
+	parameter.Description = "A function parameter (input or output)"
+	parameter.Required = []string{"name", "type"}
+
+	arraySize := 0
+	parameterType := closedObject(
+		Property{"baseType", text("Base type (e.g., \"uint256\", \"address\", \"string\")")},
+		Property{"isArray", flag("Whether this is an array")},
+		Property{"arraySize", &Schema{Type: "integer", Minimum: &arraySize, Description: "Fixed array size (0 means dynamic)"}},
+		Property{"isMap", flag("Whether this is a map/dictionary")},
+		Property{"mapKeyType", text("If this is a map, the key type")},
+		Property{"components", arrayOf(ref("parameter"), "If this is a custom struct type, the fields")},
+		Property{"typeName", text("If this is a custom struct type, the name of the CustomType declaring its fields")},
+		Property{"chainData", chainData()},
+	)
+	parameterType.Description = "The type of a parameter"
+	parameterType.Required = []string{"baseType"}
+
+	contractError := closedObject(
+		Property{"name", text("Error name")},
+		Property{"description", text("Human-readable description")},
+		Property{"descriptions", translations()},
+		Property{"parameters", arrayOf(ref("parameter"), "Error parameters")},
+	)
+	contractError.Description = "A custom error that can be thrown by the contract"
+	contractError.Required = []string{"name"}
+
+	customType := closedObject(
+		Property{"name", text("Type name")},
+		Property{"description", text("Human-readable description")},
+		Property{"descriptions", translations()},
+		Property{"fields", arrayOf(ref("parameter"), "Fields in the custom type")},
+	)
+	customType.Description = "A custom type defined in the contract"
+	customType.Required = []string{"name", "fields"}
+
+	schema.Defs = Properties{
+		{"metadata", metadata},
+		{"source", source},
+		{"function", function},
+		{"overload", overload},
+		{"accessRestriction", access},
+		{"valueConstraint", value},
+		{"deployment", deployment},
+		{"event", event},
+		{"eventParameter", eventParameter},
+		{"parameter", parameter},
+		{"parameterType", parameterType},
+		{"error", contractError},
+		{"customType", customType},
+	}
+	return schema
+}
+
+// closedObject returns an object schema allowing only the given properties
+func closedObject(properties ...Property) *Schema {
+	closed := false
+	return &Schema{
+		Type:                 "object",
+		Properties:           properties,
+		AdditionalProperties: &closed,
+	}
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+func arrayOf(items *Schema, description string) *Schema {
+	return &Schema{Type: "array", Items: items, Description: description}
+}
+
+func text(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+func flag(description string) *Schema {
+	return &Schema{Type: "boolean", Description: description}
+}
+
+// deployments is the schema of deployments keyed by decimal chain ID
+func deployments() *Schema {
+	schema := closedObject()
+	schema.PatternProperties = Properties{{"^[1-9][0-9]*$", ref("deployment")}}
+	schema.Description = "Addresses of the contract across networks, keyed by decimal chain ID (e.g., \"1\" for Ethereum mainnet)"
+	return schema
+}
+
+// chainData is the schema of free-form chain-specific data
+// translations returns the schema of translated descriptions, keyed by language tag
+func translations() *Schema {
+	closed := false
+	return &Schema{
+		Type:                 "object",
+		PatternProperties:    Properties{{`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`, text("")}},
+		AdditionalProperties: &closed,
+		Description:          "Translated descriptions by language tag (e.g., \"ja\"), used instead of the description for that locale",
+	}
+}
+
+func chainData() *Schema {
+	return &Schema{Type: "object", Description: "Chain-specific data"}
+}
\ No newline at end of file
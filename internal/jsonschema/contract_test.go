@@ -0,0 +1,207 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/parser/evm"
+)
+
+func TestForContractIRCoversIR(t *testing.T) {
+	schema := ForContractIR()
+	definitions := map[string]reflect.Type{
+		"metadata":          reflect.TypeOf(ir.ContractMetadata{}),
+		"source":            reflect.TypeOf(ir.SourceInfo{}),
+		"function":          reflect.TypeOf(ir.Function{}),
+		"overload":          reflect.TypeOf(ir.Overload{}),
+		"accessRestriction": reflect.TypeOf(ir.AccessRestriction{}),
+		"valueConstraint":   reflect.TypeOf(ir.ValueConstraint{}),
+		"deployment":        reflect.TypeOf(ir.Deployment{}),
+		"event":             reflect.TypeOf(ir.Event{}),
+		"eventParameter":    reflect.TypeOf(ir.EventParameter{}),
+		"parameter":         reflect.TypeOf(ir.Parameter{}),
+		"parameterType":     reflect.TypeOf(ir.ParameterType{}),
+		"error":             reflect.TypeOf(ir.ContractError{}),
+		"customType":        reflect.TypeOf(ir.CustomType{}),
+	}
+
+	check := func(name string, schema *Schema, typ reflect.Type) {
+		if schema == nil {
+			t.Errorf("Missing schema for %s", name)
+			return
+		}
+		// Unexported fields are not serialized
+		fields := 0
+		for i := 0; i < typ.NumField(); i++ {
+			if !typ.Field(i).IsExported() {
+				continue
+			}
+			fields++
+			field := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+			if schema.Properties.Get(field) == nil {
+				t.Errorf("%s schema is missing the %s field of %s", name, field, typ.Name())
+			}
+		}
+		if len(schema.Properties) != fields {
+			t.Errorf("%s schema has %d properties but %s has %d fields", name, len(schema.Properties), typ.Name(), fields)
+		}
+	}
+
+	check("root", schema, reflect.TypeOf(ir.ContractIR{}))
+	for name, typ := range definitions {
+		check(name, schema.Defs.Get(name), typ)
+	}
+	if len(schema.Defs) != len(definitions) {
+		t.Errorf("Expected %d definitions but got %d", len(definitions), len(schema.Defs))
+	}
+}
+
+// The published schema is regenerated with make schema
+func TestForContractIRPublished(t *testing.T) {
+	published, err := os.ReadFile(filepath.Join("..", "..", "schema", "contract-ir.schema.json"))
+	if err != nil {
+		t.Fatalf("Failed to read the published schema: %v", err)
+	}
+	content, err := json.MarshalIndent(ForContractIR(), "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal the schema: %v", err)
+	}
+	if string(published) != string(content)+"\n" {
+		t.Errorf("schema/contract-ir.schema.json is out of date; run make schema")
+	}
+}
+
+func TestValidateContractIRParsedExamples(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "examples", "*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list examples: %v", err)
+	}
+
+	parsed := 0
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", path, err)
+		}
+		contract, err := evm.NewABIParser().Parse(file, ir.ContractMetadata{Name: "Example", Chain: "ethereum"})
+		file.Close()
+		if err != nil {
+			// Not every example is a bare ABI
+			continue
+		}
+		parsed++
+		ir.DetectTokenAmounts(contract)
+
+		document, err := json.Marshal(contract)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", path, err)
+		}
+		mismatches, err := ValidateContractIR(document)
+		if err != nil {
+			t.Fatalf("Failed to validate %s: %v", path, err)
+		}
+		for _, mismatch := range mismatches {
+			t.Errorf("%s: %s", path, mismatch)
+		}
+	}
+	if parsed == 0 {
+		t.Errorf("Expected at least one example ABI")
+	}
+}
+
+func TestValidateContractIR(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{
+			"Valid",
+			`{"metadata": {"name": "Token", "chain": "ethereum"}, "functions": [{"name": "totalSupply", "inputs": [], "outputs": [{"name": "", "type": {"baseType": "uint256"}}], "stateMutability": "view"}], "events": []}`,
+			nil,
+		},
+		{
+			"Missing Metadata",
+			`{"functions": []}`,
+			[]string{`(root): missing required property "metadata"`},
+		},
+		{
+			"Invalid Values",
+			`{"metadata": {"name": "Token", "chain": "ethereum", "chainData": {"any": 1}}, "functions": [{"name": "f", "inputs": [{"name": "a", "type": {"baseType": "uint256", "isArray": "yes", "arraySize": 1.5}}], "outputs": [], "stateMutability": "viewable"}]}`,
+			[]string{
+				`/functions/0/inputs/0/type/arraySize: expected integer but got number`,
+				`/functions/0/inputs/0/type/isArray: expected boolean but got string`,
+				`/functions/0/stateMutability: expected one of "pure", "view", "nonpayable", "payable" but got "viewable"`,
+			},
+		},
+		{
+			"Unknown Property",
+			`{"metadata": {"name": "Token", "chain": "ethereum", "a/b": true}, "functions": []}`,
+			[]string{`/metadata/a~1b: unknown property "a/b"`},
+		},
+		{
+			"Deployments",
+			`{"metadata": {"name": "Token", "chain": "ethereum", "deployments": {"1": {"address": "0x01", "block": 100}, "10": {"block": -1}, "mainnet": {"address": "0x01"}}}, "functions": []}`,
+			[]string{
+				`/metadata/deployments/10: missing required property "address"`,
+				`/metadata/deployments/10/block: -1 is less than the minimum 0`,
+				`/metadata/deployments/mainnet: unknown property "mainnet"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches, err := ValidateContractIR([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("Failed to validate: %v", err)
+			}
+			var got []string
+			for _, mismatch := range mismatches {
+				got = append(got, mismatch.Error())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := ValidateContractIR([]byte(`{"metadata": `)); err == nil {
+		t.Errorf("Expected an error for invalid JSON")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	size := 2
+	schema := &Schema{
+		Type: "array",
+		Items: &Schema{
+			Type:    "string",
+			Pattern: addressPattern,
+		},
+		MinItems: &size,
+		MaxItems: &size,
+	}
+
+	mismatches, err := Validate(schema, []byte(`["0x0000000000000000000000000000000000000001", "0x1", 3]`))
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	expected := []ValidationError{
+		{Pointer: "", Message: "expected at most 2 items but got 3"},
+		{Pointer: "/1", Message: `"0x1" does not match ^0x[0-9a-fA-F]{40}$`},
+		{Pointer: "/2", Message: "expected string but got number"},
+	}
+	if !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("Expected %v but got %v", expected, mismatches)
+	}
+
+	if _, err := Validate(&Schema{Ref: "#/$defs/missing"}, []byte(`{}`)); err == nil {
+		t.Errorf("Expected an error for an unresolvable reference")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,469 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// Schema is the subset of JSON Schema (draft 2020-12) emitted for contract parameters
+type Schema struct {
+	// Meta-schema URI, set on root schemas only
+	Schema string `json:"$schema,omitempty"`
+
+	// Title of the schema, set on root schemas only
+	Title string `json:"title,omitempty"`
+
+	// Reference to a schema defined elsewhere in the document
+	Ref string `json:"$ref,omitempty"`
+
+	// JSON type (e.g., "string", "object", "array")
+	Type string `json:"type,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Regular expression the string value must match
+	Pattern string `json:"pattern,omitempty"`
+
+	// Allowed values
+	Enum []interface{} `json:"enum,omitempty"`
+
+	// Example values, for documentation
+	Examples []interface{} `json:"examples,omitempty"`
+
+	// Value used when the property is left out
+	Default interface{} `json:"default,omitempty"`
+
+	// Object properties in declaration order
+	Properties Properties `json:"properties,omitempty"`
+
+	// Required object properties
+	Required []string `json:"required,omitempty"`
+
+	// Schemas of object properties whose names match a regular expression, by expression
+	PatternProperties Properties `json:"patternProperties,omitempty"`
+
+	// Whether properties other than the declared ones are allowed
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	// Schemas of the leading array items, in order
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+
+	// Schema of array items
+	Items *Schema `json:"items,omitempty"`
+
+	// Minimum number of array items
+	MinItems *int `json:"minItems,omitempty"`
+
+	// Maximum number of array items
+	MaxItems *int `json:"maxItems,omitempty"`
+
+	// Inclusive lower bound of a number
+	Minimum *int `json:"minimum,omitempty"`
+
+	// Inclusive upper bound of a number
+	Maximum *int `json:"maximum,omitempty"`
+
+	// Schemas referenced as "#/$defs/<name>", set on root schemas only
+	Defs Properties `json:"$defs,omitempty"`
+}
+
+// Property is a named object property
+type Property struct {
+	// Property name
+	Name string
+
+	// Property schema
+	Schema *Schema
+}
+
+// Properties is an ordered list of object properties serialized as a JSON object
+type Properties []Property
+
+// MarshalJSON implements json.Marshaler, preserving declaration order
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, property := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(property.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get returns the schema of the named property, or nil
+func (p Properties) Get(name string) *Schema {
+	for _, property := range p {
+		if property.Name == name {
+			return property.Schema
+		}
+	}
+	return nil
+}
+
+// Draft is the meta-schema URI stamped on root schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	addressPattern  = "^0x[0-9a-fA-F]{40}$"
+	bytesPattern    = "^0x([0-9a-fA-F]{2})*$"
+	uintPattern     = "^([0-9]+|0x[0-9a-fA-F]+)$"
+	intPattern      = "^(-?[0-9]+|0x[0-9a-fA-F]+)$"
+	amountPattern   = "^[0-9]+(\\.[0-9]+)?$"
+	valueParamName  = "value"
+	msgValueName    = "msgValue"
+	valueParamUsage = "Optional native currency value to send with the transaction (in wei)"
+	sendParamName   = "send"
+	sendTxParamName = "sendTransaction"
+	sendParamUsage  = "Sign and send the transaction instead of only building it (requires a signer)"
+	fromParamName   = "from"
+	callerParamName = "caller"
+	fromParamUsage  = "Account to simulate the call from (default: the configured signer, if any)"
+	overridesName   = "stateOverrides"
+	simOverrideName = "simulationOverrides"
+	overridesUsage  = "State overrides applied during the simulation, keyed by account address: balance, nonce, code, state or stateDiff (storage slot to value)"
+	offsetName      = "offset"
+	offsetUsage     = "Index of the first array element to return (default: 0)"
+	limitName       = "limit"
+	limitUsage      = "Maximum number of array elements to return (default: 100)"
+	blockPattern    = "^([0-9]+|0x[0-9a-fA-F]+|latest|earliest|pending|safe|finalized)$"
+
+	// MaxLogLimit is the largest page of logs an event log tool returns
+	MaxLogLimit = 1000
+
+	// MaxPageSize is the largest page of array elements a view tool returns
+	MaxPageSize = 1000
+)
+
+// FromParameter compiles an IR parameter into a JSON Schema
+func FromParameter(p ir.Parameter) *Schema {
+	schema := FromParameterType(p.Type)
+	if unit := ir.UnitDescription(p.Unit); unit != "" {
+		schema.Description = strings.TrimPrefix(schema.Description+"; "+unit, "; ")
+	}
+	if p.Description != "" {
+		if schema.Description != "" {
+			schema.Description = p.Description + " (" + schema.Description + ")"
+		} else {
+			schema.Description = p.Description
+		}
+	}
+	schema.Examples = p.Examples
+
+	// Relative defaults are resolved at call time, so they are only described
+	if p.Default != nil {
+		schema.Description = strings.TrimPrefix(schema.Description+"; default: "+p.DefaultDescription(), "; ")
+		if _, relative := p.RelativeDefault(); !relative {
+			schema.Default = p.Default
+		}
+	}
+	return schema
+}
+
+// FromParameterType compiles an IR parameter type into a JSON Schema
+func FromParameterType(t ir.ParameterType) *Schema {
+	element := elementSchema(t)
+	if !t.IsArray {
+		return element
+	}
+
+	schema := &Schema{
+		Type:  "array",
+		Items: element,
+	}
+	if t.ArraySize > 0 {
+		size := t.ArraySize
+		schema.MinItems = &size
+		schema.MaxItems = &size
+		schema.Description = fmt.Sprintf("exactly %d items", size)
+	}
+	return schema
+}
+
+// elementSchema compiles the element type of an IR parameter type
+func elementSchema(t ir.ParameterType) *Schema {
+	if len(t.Components) > 0 {
+		return objectSchema(t.Components)
+	}
+
+	if decimals, fromContract, ok := t.AmountDecimals(); ok {
+		unit := "the token's decimals"
+		if !fromContract {
+			unit = fmt.Sprintf("%d decimals", decimals)
+		}
+		return &Schema{
+			Type:        "string",
+			Pattern:     amountPattern,
+			Description: fmt.Sprintf("%s amount in token units, e.g. \"1.5\" (converted with %s)", t.BaseType, unit),
+		}
+	}
+
+	if values := t.EnumValues(); len(values) > 0 {
+		return &Schema{
+			Enum:        values,
+			Description: t.BaseType + " enum",
+		}
+	}
+
+	switch base := t.BaseType; {
+	case base == ir.APIBoolean:
+		return &Schema{Type: "boolean"}
+	case base == ir.APIInteger, base == ir.APINumber, base == ir.APIObject:
+		return &Schema{Type: base}
+	case base == ir.APIAny:
+		return &Schema{Description: "any JSON value"}
+	case base == "address":
+		return &Schema{Type: "string", Pattern: addressPattern, Description: "0x-prefixed 20-byte hex address"}
+	case base == "bool":
+		return &Schema{Type: "boolean"}
+	case base == "string":
+		return &Schema{Type: "string"}
+	case base == "bytes":
+		return &Schema{Type: "string", Pattern: bytesPattern, Description: "0x-prefixed hex bytes"}
+	case strings.HasPrefix(base, "bytes"):
+		if size, err := strconv.Atoi(strings.TrimPrefix(base, "bytes")); err == nil {
+			return &Schema{
+				Type:        "string",
+				Pattern:     fmt.Sprintf("^0x[0-9a-fA-F]{%d}$", size*2),
+				Description: fmt.Sprintf("0x-prefixed hex of exactly %d bytes", size),
+			}
+		}
+	case strings.HasPrefix(base, "uint"):
+		return &Schema{Type: "string", Pattern: uintPattern, Description: base + " as a decimal or 0x-prefixed hex string"}
+	case strings.HasPrefix(base, "int"):
+		return &Schema{Type: "string", Pattern: intPattern, Description: base + " as a decimal or 0x-prefixed hex string"}
+	}
+
+	return &Schema{Type: "string", Description: t.BaseType}
+}
+
+// objectSchema compiles tuple components into an object schema
+func objectSchema(components []ir.Parameter) *Schema {
+	closed := false
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           Properties{},
+		Required:             []string{},
+		AdditionalProperties: &closed,
+	}
+	for i, component := range components {
+		name := component.Name
+		if name == "" {
+			name = fmt.Sprintf("field%d", i)
+		}
+		schema.Properties = append(schema.Properties, Property{Name: name, Schema: FromParameter(component)})
+		if !component.IsOptional() {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// ForFunctionInputs compiles a function's inputs into a tool input schema
+// Payable functions additionally accept a value, optional unless the function's value constraint requires one
+func ForFunctionInputs(f ir.Function) *Schema {
+	schema := objectSchema(f.Inputs)
+	schema.Schema = Draft
+
+	if f.StateMutability == ir.Payable {
+		value := FromParameterType(ir.ParameterType{BaseType: "uint256"})
+		value.Description = valueParamUsage
+		if f.Value != nil {
+			value.Description = f.Value.Usage()
+			if typical := f.Value.TypicalWei(); typical != nil {
+				value.Examples = []interface{}{typical.String()}
+			}
+			if f.Value.Required {
+				schema.Required = append(schema.Required, TransactionValue(f))
+			}
+		}
+		schema.Properties = append(schema.Properties, Property{Name: TransactionValue(f), Schema: value})
+	}
+
+	return schema
+}
+
+// ForTransactionInputs compiles a write function's inputs into a tool input schema
+// In addition to ForFunctionInputs, it accepts an optional flag to sign and send the transaction
+func ForTransactionInputs(f ir.Function) *Schema {
+	schema := ForFunctionInputs(f)
+	schema.Properties = append(schema.Properties, Property{
+		Name:   TransactionSend(f),
+		Schema: &Schema{Type: "boolean", Description: sendParamUsage},
+	})
+	return schema
+}
+
+// ForSimulationInputs compiles a write function's inputs into a simulation tool input schema
+// In addition to ForFunctionInputs, it accepts the sender and state overrides of the simulated call
+func ForSimulationInputs(f ir.Function) *Schema {
+	schema := ForFunctionInputs(f)
+
+	from := FromParameterType(ir.ParameterType{BaseType: "address"})
+	from.Description = fromParamUsage
+	schema.Properties = append(schema.Properties,
+		Property{Name: SimulationSender(f), Schema: from},
+		Property{Name: SimulationOverrides(f), Schema: &Schema{Type: "object", Description: overridesUsage}},
+	)
+
+	return schema
+}
+
+// TransactionValue returns the name of the input giving the native currency value sent to a payable function: value,
+// or msgValue for functions taking a value input of their own
+func TransactionValue(f ir.Function) string {
+	return controlName(f, valueParamName, msgValueName)
+}
+
+// TransactionSend returns the name of the write tool input signing and sending the transaction: send, or
+// sendTransaction for functions taking a send input of their own
+func TransactionSend(f ir.Function) string {
+	return controlName(f, sendParamName, sendTxParamName)
+}
+
+// SimulationSender returns the name of the simulation input giving the account to simulate a call from: from, or
+// caller for functions taking a from input of their own, such as transferFrom
+func SimulationSender(f ir.Function) string {
+	return controlName(f, fromParamName, callerParamName)
+}
+
+// SimulationOverrides returns the name of the simulation input giving state overrides: stateOverrides, or
+// simulationOverrides for functions taking a stateOverrides input of their own
+func SimulationOverrides(f ir.Function) string {
+	return controlName(f, overridesName, simOverrideName)
+}
+
+// controlName returns the name of an input tools add to a function's own: name, or else fallback, suffixed with
+// underscores until no input of the function has it
+func controlName(f ir.Function, name, fallback string) string {
+	taken := map[string]bool{}
+	for _, input := range f.Inputs {
+		taken[input.Name] = true
+	}
+	if taken[name] {
+		name = fallback
+	}
+	for taken[name] {
+		name += "_"
+	}
+	return name
+}
+
+// IsPaginated reports whether a function's tool pages through its outputs
+// View and pure functions returning a dynamic array are paginated; API operations return what the API returns
+func IsPaginated(f ir.Function) bool {
+	if f.StateMutability != ir.View && f.StateMutability != ir.Pure || f.IsAPIOperation() {
+		return false
+	}
+	for _, output := range f.Outputs {
+		if output.Type.IsArray && output.Type.ArraySize == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ForViewInputs compiles a view function's inputs into a tool input schema
+// In addition to ForFunctionInputs, paginated functions accept the offset and limit of the page
+func ForViewInputs(f ir.Function) *Schema {
+	schema := ForFunctionInputs(f)
+	if !IsPaginated(f) {
+		return schema
+	}
+
+	minOffset, minLimit, maxLimit := 0, 1, MaxPageSize
+	options := []Property{
+		{Name: offsetName, Schema: &Schema{Type: "integer", Minimum: &minOffset, Description: offsetUsage}},
+		{Name: limitName, Schema: &Schema{Type: "integer", Minimum: &minLimit, Maximum: &maxLimit, Description: limitUsage}},
+	}
+	for _, option := range options {
+		if schema.Properties.Get(option.Name) == nil {
+			schema.Properties = append(schema.Properties, option)
+		}
+	}
+
+	return schema
+}
+
+// IsFilterable reports whether logs can be filtered by an event parameter
+// Only indexed parameters are filterable; indexed arrays and tuples are stored as hashes of their encoding
+func IsFilterable(p ir.EventParameter) bool {
+	return p.Indexed && !p.Type.IsArray && len(p.Type.Components) == 0
+}
+
+// EventParameterName returns the name of an event parameter, or argN for unnamed ones
+func EventParameterName(p ir.EventParameter, index int) string {
+	if p.Name == "" {
+		return fmt.Sprintf("arg%d", index)
+	}
+	return p.Name
+}
+
+// ForEventSubscription compiles an event into an event subscription tool input schema
+// Every filterable indexed parameter is an optional filter
+func ForEventSubscription(e ir.Event) *Schema {
+	closed := false
+	schema := &Schema{
+		Schema:               Draft,
+		Type:                 "object",
+		Properties:           Properties{},
+		AdditionalProperties: &closed,
+	}
+	for i, p := range e.Parameters {
+		if !IsFilterable(p) {
+			continue
+		}
+		name := EventParameterName(p, i)
+		filter := FromParameterType(p.Type)
+		filter.Description = strings.TrimSpace("Only logs whose " + name + " equals this value " + parenthesize(filter.Description))
+		schema.Properties = append(schema.Properties, Property{Name: name, Schema: filter})
+	}
+	return schema
+}
+
+// ForEventFilter compiles an event into an event log tool input schema
+// The subscription filters are followed by the block range and pagination options
+func ForEventFilter(e ir.Event) *Schema {
+	schema := ForEventSubscription(e)
+
+	minLimit, maxLimit := 1, MaxLogLimit
+	options := []Property{
+		{Name: "fromBlock", Schema: &Schema{Type: "string", Pattern: blockPattern, Description: "First block to search, as a number or tag (default: 10000 blocks before toBlock)"}},
+		{Name: "toBlock", Schema: &Schema{Type: "string", Pattern: blockPattern, Description: "Last block to search, as a number or tag (default: latest)"}},
+		{Name: "limit", Schema: &Schema{Type: "integer", Minimum: &minLimit, Maximum: &maxLimit, Description: "Maximum number of logs to return (default: 100)"}},
+		{Name: "cursor", Schema: &Schema{Type: "string", Description: "nextCursor returned by a previous call, to fetch the next page"}},
+	}
+	for _, option := range options {
+		if schema.Properties.Get(option.Name) == nil {
+			schema.Properties = append(schema.Properties, option)
+		}
+	}
+
+	return schema
+}
+
+// parenthesize wraps a non-empty string in parentheses
+func parenthesize(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(" + s + ")"
+}
\ No newline at end of file
@@ -0,0 +1,315 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestFromParameterType(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      ir.ParameterType
+		expected string
+	}{
+		{
+			"Address",
+			ir.ParameterType{BaseType: "address"},
+			`{"type":"string","description":"0x-prefixed 20-byte hex address","pattern":"^0x[0-9a-fA-F]{40}$"}`,
+		},
+		{
+			"Bool",
+			ir.ParameterType{BaseType: "bool"},
+			`{"type":"boolean"}`,
+		},
+		{
+			"Bytes32",
+			ir.ParameterType{BaseType: "bytes32"},
+			`{"type":"string","description":"0x-prefixed hex of exactly 32 bytes","pattern":"^0x[0-9a-fA-F]{64}$"}`,
+		},
+		{
+			"Fixed Array",
+			ir.ParameterType{BaseType: "bool", IsArray: true, ArraySize: 3},
+			`{"type":"array","description":"exactly 3 items","items":{"type":"boolean"},"minItems":3,"maxItems":3}`,
+		},
+		{
+			"Token Amount",
+			ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: ir.DecimalsFromContract}},
+			`{"type":"string","description":"uint256 amount in token units, e.g. \"1.5\" (converted with the token's decimals)","pattern":"^[0-9]+(\\.[0-9]+)?$"}`,
+		},
+		{
+			"Fixed Decimals Amount",
+			ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: 6}},
+			`{"type":"string","description":"uint256 amount in token units, e.g. \"1.5\" (converted with 6 decimals)","pattern":"^[0-9]+(\\.[0-9]+)?$"}`,
+		},
+		{
+			"Enum",
+			ir.ParameterType{BaseType: "Status", ChainData: map[string]interface{}{"enumValues": []string{"Active", "Paused"}}},
+			`{"description":"Status enum","enum":["Active","Paused"]}`,
+		},
+		{
+			"Tuple",
+			ir.ParameterType{
+				BaseType: "tuple",
+				Components: []ir.Parameter{
+					{Name: "to", Type: ir.ParameterType{BaseType: "bool"}},
+					{Type: ir.ParameterType{BaseType: "string"}},
+				},
+			},
+			`{"type":"object","properties":{"to":{"type":"boolean"},"field1":{"type":"string"}},"required":["to","field1"],"additionalProperties":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(FromParameterType(tt.typ))
+			if err != nil {
+				t.Fatalf("Failed to marshal schema: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestForFunctionInputs(t *testing.T) {
+	function := ir.Function{
+		Name:            "deposit",
+		StateMutability: ir.Payable,
+		Inputs: []ir.Parameter{
+			{Name: "receiver", Type: ir.ParameterType{BaseType: "address"}, Description: "Account credited"},
+		},
+	}
+
+	schema := ForFunctionInputs(function)
+	if schema.Schema != Draft || schema.Type != "object" {
+		t.Errorf("Expected a root object schema, got %+v", schema)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "receiver" {
+		t.Errorf("Expected only receiver to be required, got %v", schema.Required)
+	}
+	if receiver := schema.Properties.Get("receiver"); receiver == nil || receiver.Description != "Account credited (0x-prefixed 20-byte hex address)" {
+		t.Errorf("Unexpected receiver schema: %+v", receiver)
+	}
+	if value := schema.Properties.Get("value"); value == nil || value.Pattern != uintPattern {
+		t.Errorf("Expected payable functions to accept an optional value, got %+v", value)
+	}
+
+	function.Value = &ir.ValueConstraint{Min: "0.01", Max: "1", Typical: "0.05", Required: true}
+	schema = ForFunctionInputs(function)
+	if len(schema.Required) != 2 || schema.Required[1] != "value" {
+		t.Errorf("Expected a required value, got %v", schema.Required)
+	}
+	value := schema.Properties.Get("value")
+	if value.Description != "Native currency value to send with the transaction, in wei (1 ETH = 10^18 wei): between 0.01 and 1 ETH, typically 0.05 ETH" {
+		t.Errorf("Unexpected value description: %s", value.Description)
+	}
+	if len(value.Examples) != 1 || value.Examples[0] != "50000000000000000" {
+		t.Errorf("Expected the typical value in wei as example, got %v", value.Examples)
+	}
+
+	function.Value = nil
+	function.Inputs = append(function.Inputs,
+		ir.Parameter{Name: "deadline", Type: ir.ParameterType{BaseType: "uint256"}, Unit: ir.UnitTimestamp, Default: "+1200"},
+		ir.Parameter{Name: "memo", Type: ir.ParameterType{BaseType: "string"}, Default: "none"},
+	)
+	schema = ForFunctionInputs(function)
+	if len(schema.Required) != 1 {
+		t.Errorf("Expected parameters with defaults to be optional, got %v", schema.Required)
+	}
+	if deadline := schema.Properties.Get("deadline"); deadline.Default != nil || !strings.HasSuffix(deadline.Description, "; default: 1200 seconds from now") {
+		t.Errorf("Expected a described relative default, got %+v", deadline)
+	}
+	if memo := schema.Properties.Get("memo"); memo.Default != "none" || memo.Description != `default: "none"` {
+		t.Errorf("Expected a default value, got %+v", memo)
+	}
+
+	function.StateMutability = ir.View
+	if ForFunctionInputs(function).Properties.Get("value") != nil {
+		t.Errorf("Unexpected value property for a view function")
+	}
+}
+
+func TestForTransactionInputs(t *testing.T) {
+	function := ir.Function{
+		Name:            "transfer",
+		StateMutability: ir.Nonpayable,
+		Inputs: []ir.Parameter{
+			{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+			{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+	}
+
+	schema := ForTransactionInputs(function)
+	if send := schema.Properties.Get("send"); send == nil || send.Type != "boolean" {
+		t.Errorf("Expected an optional send flag, got %+v", send)
+	}
+	if len(schema.Required) != 2 {
+		t.Errorf("Expected only the function inputs to be required, got %v", schema.Required)
+	}
+	if schema.Properties.Get("value") != nil {
+		t.Errorf("Unexpected value property for a nonpayable function")
+	}
+
+	// Control inputs are renamed rather than replacing function inputs of the same name
+	function = ir.Function{
+		Name:            "relay",
+		StateMutability: ir.Payable,
+		Inputs: []ir.Parameter{
+			{Name: "send", Type: ir.ParameterType{BaseType: "address"}},
+			{Name: "value", Type: ir.ParameterType{BaseType: "bytes"}},
+		},
+	}
+	schema = ForTransactionInputs(function)
+	var names []string
+	for _, property := range schema.Properties {
+		names = append(names, property.Name)
+	}
+	expected := []string{"send", "value", "msgValue", "sendTransaction"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected properties %v but got %v", expected, names)
+	}
+	if send := schema.Properties.Get("send"); send.Pattern != addressPattern {
+		t.Errorf("Expected the function's send input to be kept, got %+v", send)
+	}
+	if value := schema.Properties.Get("value"); value.Pattern != bytesPattern {
+		t.Errorf("Expected the function's value input to be kept, got %+v", value)
+	}
+}
+
+func TestForSimulationInputs(t *testing.T) {
+	function := ir.Function{
+		Name:            "deposit",
+		StateMutability: ir.Payable,
+		Inputs: []ir.Parameter{
+			{Name: "from", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+	}
+
+	schema := ForSimulationInputs(function)
+	var names []string
+	for _, property := range schema.Properties {
+		names = append(names, property.Name)
+	}
+	expected := []string{"from", "value", "caller", "stateOverrides"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected properties %v but got %v", expected, names)
+	}
+	if from := schema.Properties.Get("from"); from.Pattern != uintPattern {
+		t.Errorf("Expected the function's from input to take precedence, got %+v", from)
+	}
+	if caller := schema.Properties.Get("caller"); caller.Pattern != addressPattern || SimulationSender(function) != "caller" {
+		t.Errorf("Expected the account to simulate from as caller, got %+v", caller)
+	}
+	function.Inputs = append(function.Inputs, ir.Parameter{Name: "caller", Type: ir.ParameterType{BaseType: "address"}})
+	if sender := SimulationSender(function); sender != "caller_" {
+		t.Errorf("Expected caller_ when the function takes from and caller inputs, got %s", sender)
+	}
+	if schema.Properties.Get("send") != nil {
+		t.Errorf("Simulations must not accept a send flag")
+	}
+	if len(schema.Required) != 1 {
+		t.Errorf("Expected only the function inputs to be required, got %v", schema.Required)
+	}
+
+	function.Inputs = append(function.Inputs, ir.Parameter{Name: "stateOverrides", Type: ir.ParameterType{BaseType: "bytes"}})
+	schema = ForSimulationInputs(function)
+	if overrides := schema.Properties.Get("simulationOverrides"); overrides == nil || overrides.Type != "object" {
+		t.Errorf("Expected state overrides as simulationOverrides, got %+v", overrides)
+	}
+	if overrides := schema.Properties.Get("stateOverrides"); overrides.Pattern != bytesPattern {
+		t.Errorf("Expected the function's stateOverrides input to be kept, got %+v", overrides)
+	}
+}
+
+func TestForViewInputs(t *testing.T) {
+	function := ir.Function{
+		Name:            "holders",
+		StateMutability: ir.View,
+		Inputs: []ir.Parameter{
+			{Name: "limit", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+		Outputs: []ir.Parameter{
+			{Name: "accounts", Type: ir.ParameterType{BaseType: "address", IsArray: true}},
+		},
+	}
+
+	schema := ForViewInputs(function)
+	var names []string
+	for _, property := range schema.Properties {
+		names = append(names, property.Name)
+	}
+	expected := []string{"limit", "offset"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected properties %v but got %v", expected, names)
+	}
+	if offset := schema.Properties.Get("offset"); offset.Type != "integer" || offset.Minimum == nil || *offset.Minimum != 0 {
+		t.Errorf("Expected a non-negative integer offset, got %+v", offset)
+	}
+
+	function.Outputs[0].Type.ArraySize = 3
+	if IsPaginated(function) {
+		t.Errorf("Fixed-size array outputs must not be paginated")
+	}
+	if ForViewInputs(function).Properties.Get("offset") != nil {
+		t.Errorf("Unpaginated functions must not accept an offset")
+	}
+}
+
+func TestForEventFilter(t *testing.T) {
+	event := ir.Event{
+		Name: "Transfer",
+		Parameters: []ir.EventParameter{
+			{Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+			{Type: ir.ParameterType{BaseType: "uint256"}, Indexed: true},
+			{Name: "ids", Type: ir.ParameterType{BaseType: "uint256", IsArray: true}, Indexed: true},
+			{Name: "value", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+	}
+
+	schema := ForEventFilter(event)
+	var names []string
+	for _, property := range schema.Properties {
+		names = append(names, property.Name)
+	}
+	expected := []string{"arg1", "from", "fromBlock", "toBlock", "limit", "cursor"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected properties %v but got %v", expected, names)
+	}
+	for _, name := range expected {
+		if schema.Properties.Get(name) == nil {
+			t.Errorf("Expected property %s in %v", name, names)
+		}
+	}
+	if len(schema.Required) != 0 {
+		t.Errorf("Expected every filter to be optional, got required %v", schema.Required)
+	}
+	if from := schema.Properties.Get("from"); from.Description != "Only logs whose from equals this value (0x-prefixed 20-byte hex address)" {
+		t.Errorf("Unexpected filter description %q", from.Description)
+	}
+	if limit := schema.Properties.Get("limit"); *limit.Maximum != MaxLogLimit {
+		t.Errorf("Expected limit to be capped at %d", MaxLogLimit)
+	}
+}
+
+func TestForEventSubscription(t *testing.T) {
+	event := ir.Event{
+		Name: "Transfer",
+		Parameters: []ir.EventParameter{
+			{Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+			{Name: "value", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+	}
+
+	got, err := json.Marshal(ForEventSubscription(event))
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{"from":{"type":"string","description":"Only logs whose from equals this value (0x-prefixed 20-byte hex address)","pattern":"^0x[0-9a-fA-F]{40}$"}},"additionalProperties":false}`
+	if string(got) != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+}
\ No newline at end of file
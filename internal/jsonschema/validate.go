@@ -0,0 +1,273 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError is a value that does not match its schema
+type ValidationError struct {
+	// JSON pointer (RFC 6901) to the value, empty for the document itself
+	Pointer string
+
+	// What is wrong with the value
+	Message string
+}
+
+// Error implements the error interface for ValidationError
+func (e ValidationError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "(root)"
+	}
+	return fmt.Sprintf("%s: %s", pointer, e.Message)
+}
+
+// Validate validates a JSON document against a root schema, returning every mismatch, with object properties visited in lexical order
+// Only the keywords of Schema are supported; references must point into the root schema's $defs
+func Validate(schema *Schema, document []byte) ([]ValidationError, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			return nil, fmt.Errorf("invalid JSON at byte %d: %w", syntaxError.Offset, err)
+		}
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	v := &validator{root: schema, patterns: map[string]*regexp.Regexp{}}
+	if err := v.validate(schema, value, ""); err != nil {
+		return nil, err
+	}
+	return v.errors, nil
+}
+
+// ValidateContractIR validates a contract IR document against the IR schema
+func ValidateContractIR(document []byte) ([]ValidationError, error) {
+	return Validate(ForContractIR(), document)
+}
+
+// validator collects the mismatches found while walking a document
+type validator struct {
+	root     *Schema
+	patterns map[string]*regexp.Regexp
+	errors   []ValidationError
+}
+
+func (v *validator) fail(pointer, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate validates a decoded value, failing only for schemas that cannot be applied
+func (v *validator) validate(schema *Schema, value interface{}, pointer string) error {
+	if schema.Ref != "" {
+		resolved := v.root.Defs.Get(strings.TrimPrefix(schema.Ref, "#/$defs/"))
+		if resolved == nil || !strings.HasPrefix(schema.Ref, "#/$defs/") {
+			return fmt.Errorf("unresolvable schema reference %s", schema.Ref)
+		}
+		schema = resolved
+	}
+
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		v.fail(pointer, "expected %s but got %s", schema.Type, typeOf(value))
+		return nil
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		allowed := make([]string, len(schema.Enum))
+		for i, item := range schema.Enum {
+			allowed[i] = fmt.Sprintf("%q", fmt.Sprint(item))
+		}
+		v.fail(pointer, "expected one of %s but got %s", strings.Join(allowed, ", "), describe(value))
+		return nil
+	}
+
+	switch value := value.(type) {
+	case string:
+		if schema.Pattern != "" {
+			pattern, err := v.pattern(schema.Pattern)
+			if err != nil {
+				return err
+			}
+			if !pattern.MatchString(value) {
+				v.fail(pointer, "%q does not match %s", value, schema.Pattern)
+			}
+		}
+
+	case json.Number:
+		number, ok := new(big.Float).SetString(value.String())
+		if !ok {
+			v.fail(pointer, "invalid number %s", value)
+			break
+		}
+		if schema.Minimum != nil && number.Cmp(big.NewFloat(float64(*schema.Minimum))) < 0 {
+			v.fail(pointer, "%s is less than the minimum %d", value, *schema.Minimum)
+		}
+		if schema.Maximum != nil && number.Cmp(big.NewFloat(float64(*schema.Maximum))) > 0 {
+			v.fail(pointer, "%s is greater than the maximum %d", value, *schema.Maximum)
+		}
+
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			v.fail(pointer, "expected at least %d items but got %d", *schema.MinItems, len(value))
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			v.fail(pointer, "expected at most %d items but got %d", *schema.MaxItems, len(value))
+		}
+		for i, item := range value {
+			itemSchema := schema.Items
+			if i < len(schema.PrefixItems) {
+				itemSchema = schema.PrefixItems[i]
+			}
+			if itemSchema == nil {
+				continue
+			}
+			if err := v.validate(itemSchema, item, fmt.Sprintf("%s/%d", pointer, i)); err != nil {
+				return err
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				v.fail(pointer, "missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propertyPointer := pointer + "/" + escapePointer(name)
+			propertySchema := schema.Properties.Get(name)
+			if propertySchema == nil {
+				pattern, err := v.patternProperty(schema, name)
+				if err != nil {
+					return err
+				}
+				propertySchema = pattern
+			}
+			if propertySchema == nil {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					v.fail(propertyPointer, "unknown property %q", name)
+				}
+				continue
+			}
+			if err := v.validate(propertySchema, value[name], propertyPointer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patternProperty returns the schema of the first pattern property matching a property name, or nil
+func (v *validator) patternProperty(schema *Schema, name string) (*Schema, error) {
+	for _, property := range schema.PatternProperties {
+		pattern, err := v.pattern(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		if pattern.MatchString(name) {
+			return property.Schema, nil
+		}
+	}
+	return nil, nil
+}
+
+// pattern returns a compiled schema pattern
+func (v *validator) pattern(expression string) (*regexp.Regexp, error) {
+	pattern, ok := v.patterns[expression]
+	if !ok {
+		var err error
+		if pattern, err = regexp.Compile(expression); err != nil {
+			return nil, fmt.Errorf("invalid schema pattern %s: %w", expression, err)
+		}
+		v.patterns[expression] = pattern
+	}
+	return pattern, nil
+}
+
+// hasType reports whether a decoded value is of a JSON Schema type
+func hasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		parsed, ok := new(big.Float).SetString(number.String())
+		return ok && parsed.IsInt()
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	default:
+		return typeOf(value) == schemaType
+	}
+}
+
+// typeOf returns the JSON type of a decoded value
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// describe formats a scalar value for an error message
+func describe(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	if value == nil {
+		return "null"
+	}
+	if number, ok := value.(json.Number); ok {
+		return number.String()
+	}
+	return typeOf(value)
+}
+
+// inEnum reports whether a decoded scalar value is one of the enum values
+func inEnum(value interface{}, enum []interface{}) bool {
+	switch value.(type) {
+	case []interface{}, map[string]interface{}:
+		return false
+	}
+	for _, item := range enum {
+		if number, ok := value.(json.Number); ok {
+			if number.String() == fmt.Sprint(item) {
+				return true
+			}
+			continue
+		}
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePointer escapes a property name as a JSON pointer reference token
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
\ No newline at end of file
@@ -0,0 +1,255 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log message
+type Level int
+
+// Levels of log messages, from the most verbose
+const (
+	// LevelDebug messages detail the work done, e.g. phase timings; shown with --verbose
+	LevelDebug Level = iota
+
+	// LevelInfo messages report progress; hidden with --quiet
+	LevelInfo
+
+	// LevelWarn messages report problems that do not stop the work
+	LevelWarn
+
+	// LevelError messages report failures
+	LevelError
+)
+
+// String returns the name of a level as written in JSON logs
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	}
+	return "error"
+}
+
+// Formats of log output
+const (
+	// FormatText writes messages as lines of text, warnings and errors prefixed with their level
+	FormatText = "text"
+
+	// FormatJSON writes a JSON object per message, with its time, level, message and fields
+	FormatJSON = "json"
+)
+
+// Logger writes leveled messages as text or JSON lines
+// A nil Logger discards messages, so that helpers can log without callers having to configure logging.
+type Logger struct {
+	mu     sync.Mutex
+	out    io.Writer
+	level  Level
+	format string
+	now    func() time.Time
+
+	// Whether text warnings and errors are colored, for terminals
+	color bool
+
+	// Logger of a buffered logger, which its messages are flushed to
+	parent *Logger
+
+	// Logger a recording logger writes its messages to, and the record it keeps
+	next   *Logger
+	record *Record
+}
+
+// Record keeps the warnings and phase timings of a logger, whatever its level, e.g. to report them once the work is
+// done
+type Record struct {
+	mu       sync.Mutex
+	warnings []string
+	timings  []Timing
+}
+
+// Timing is how long a phase took, as logged by Logger.Phase
+type Timing struct {
+	Phase      string  `json:"phase"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// Warnings returns the warnings recorded, in the order they were logged
+func (r *Record) Warnings() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.warnings...)
+}
+
+// Timings returns the phase timings recorded, in the order the phases ended
+func (r *Record) Timings() []Timing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Timing{}, r.timings...)
+}
+
+// add records a warning or phase timing
+func (r *Record) add(level Level, message string, fields map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if level == LevelWarn {
+		r.warnings = append(r.warnings, message)
+	}
+	if phase, ok := fields["phase"].(string); ok {
+		duration, _ := fields["durationMs"].(float64)
+		r.timings = append(r.timings, Timing{Phase: phase, DurationMs: duration})
+	}
+}
+
+// New returns a logger writing messages of at least a level to out in a format
+func New(out io.Writer, level Level, format string) (*Logger, error) {
+	if format != FormatText && format != FormatJSON {
+		return nil, fmt.Errorf("unsupported log format: %s (expected %s or %s)", format, FormatText, FormatJSON)
+	}
+	return &Logger{out: out, level: level, format: format, now: time.Now}, nil
+}
+
+// LevelOf returns the level of the --verbose and --quiet flags: debug when verbose, warn when quiet, info otherwise
+func LevelOf(verbose, quiet bool) (Level, error) {
+	switch {
+	case verbose && quiet:
+		return 0, fmt.Errorf("--verbose and --quiet exclude each other")
+	case verbose:
+		return LevelDebug, nil
+	case quiet:
+		return LevelWarn, nil
+	}
+	return LevelInfo, nil
+}
+
+// SetColor sets whether the prefixes of text warnings and errors are colored with ANSI escapes, which only terminals
+// render; loggers write plain text unless set
+func (l *Logger) SetColor(color bool) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.color = color
+}
+
+// Buffered returns a logger at the same level and in the same format that keeps its messages until Flush, so that
+// concurrent tasks log their messages together rather than interleaved
+func (l *Logger) Buffered() *Logger {
+	if l == nil {
+		return nil
+	}
+	return &Logger{out: &bytes.Buffer{}, level: l.level, format: l.format, now: l.now, color: l.color, parent: l}
+}
+
+// Recording returns a logger writing its messages to l, which also keeps its warnings and phase timings in a record;
+// a nil l only records them
+func (l *Logger) Recording(record *Record) *Logger {
+	if l == nil {
+		return &Logger{out: io.Discard, level: LevelError + 1, format: FormatText, now: time.Now, record: record}
+	}
+	return &Logger{level: l.level, format: l.format, now: l.now, next: l, record: record}
+}
+
+// Flush writes the messages kept by a buffered logger to the logger it was created from
+func (l *Logger) Flush() {
+	if l == nil || l.parent == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buffer := l.out.(*bytes.Buffer)
+	l.parent.mu.Lock()
+	defer l.parent.mu.Unlock()
+	l.parent.out.Write(buffer.Bytes())
+	buffer.Reset()
+}
+
+// Debugf, Infof, Warnf and Errorf log a message formatted like fmt.Sprintf at their level
+func (l *Logger) Debugf(format string, args ...interface{}) { l.log(LevelDebug, fmt.Sprintf(format, args...), nil) }
+func (l *Logger) Infof(format string, args ...interface{})  { l.log(LevelInfo, fmt.Sprintf(format, args...), nil) }
+func (l *Logger) Warnf(format string, args ...interface{})  { l.log(LevelWarn, fmt.Sprintf(format, args...), nil) }
+func (l *Logger) Errorf(format string, args ...interface{}) { l.log(LevelError, fmt.Sprintf(format, args...), nil) }
+
+// Phase logs at debug level how long a phase took since its start, e.g. "Timing: parse took 12ms"; JSON messages
+// carry the phase and its duration in milliseconds as fields
+// It returns the current time, the start of the next phase.
+func (l *Logger) Phase(name string, start time.Time) time.Time {
+	if l == nil {
+		return time.Now()
+	}
+	end := l.now()
+	elapsed := end.Sub(start)
+	l.log(LevelDebug, fmt.Sprintf("Timing: %s took %s", name, elapsed.Round(time.Microsecond)), map[string]interface{}{
+		"phase":      name,
+		"durationMs": float64(elapsed.Microseconds()) / 1000,
+	})
+	return end
+}
+
+// Enabled reports whether messages of a level are written, e.g. to skip computing verbose output
+func (l *Logger) Enabled(level Level) bool {
+	return l != nil && level >= l.level
+}
+
+func (l *Logger) log(level Level, message string, fields map[string]interface{}) {
+	if l != nil && l.record != nil {
+		l.record.add(level, message, fields)
+	}
+	if l != nil && l.next != nil {
+		l.next.log(level, message, fields)
+		return
+	}
+	if !l.Enabled(level) {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.format == FormatJSON {
+		entry := map[string]interface{}{}
+		for key, value := range fields {
+			entry[key] = value
+		}
+		entry["time"] = l.now().UTC().Format(time.RFC3339Nano)
+		entry["level"] = level.String()
+		entry["msg"] = message
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		l.out.Write(append(line, '\n'))
+		return
+	}
+
+	switch level {
+	case LevelWarn:
+		message = l.colored("Warning:", yellow) + " " + message
+	case LevelError:
+		message = l.colored("Error:", red) + " " + message
+	}
+	fmt.Fprintln(l.out, message)
+}
+
+// ANSI colors of text prefixes
+const (
+	red    = "31"
+	yellow = "33"
+)
+
+// colored returns text in an ANSI color if the logger is colored
+func (l *Logger) colored(text, color string) string {
+	if !l.color {
+		return text
+	}
+	return "\x1b[" + color + "m" + text + "\x1b[0m"
+}
\ No newline at end of file
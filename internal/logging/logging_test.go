@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTextLevels(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New(&out, LevelInfo, FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debugf("hidden %d", 1)
+	logger.Infof("Parsed: %d functions", 3)
+	logger.Warnf("overlay %s matches nothing", "names.yaml")
+	logger.Errorf("failed")
+
+	expected := "Parsed: 3 functions\nWarning: overlay names.yaml matches nothing\nError: failed\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+	}
+}
+
+func TestColor(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, LevelInfo, FormatText)
+	logger.SetColor(true)
+	logger.Infof("Parsed")
+	logger.Buffered().Warnf("unmatched")
+	buffered := logger.Buffered()
+	buffered.Errorf("failed")
+	buffered.Flush()
+
+	// Only the level prefixes are colored, and buffered loggers color like the logger they flush to
+	expected := "Parsed\n\x1b[31mError:\x1b[0m failed\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+	}
+
+	out.Reset()
+	logger.SetColor(false)
+	logger.Warnf("unmatched")
+	if out.String() != "Warning: unmatched\n" {
+		t.Errorf("Expected an uncolored warning but got %q", out.String())
+	}
+}
+
+func TestQuietAndVerbose(t *testing.T) {
+	if level, _ := LevelOf(false, true); level != LevelWarn {
+		t.Errorf("Expected --quiet to log warnings but got %s", level)
+	}
+	if level, _ := LevelOf(true, false); level != LevelDebug {
+		t.Errorf("Expected --verbose to log debug messages but got %s", level)
+	}
+	if _, err := LevelOf(true, true); err == nil {
+		t.Error("Expected --verbose and --quiet to be rejected together")
+	}
+
+	var out bytes.Buffer
+	logger, _ := New(&out, LevelWarn, FormatText)
+	logger.Infof("progress")
+	if out.Len() != 0 {
+		t.Errorf("Expected quiet logs to skip progress but got %q", out.String())
+	}
+}
+
+func TestJSONPhase(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, LevelDebug, FormatJSON)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger.now = func() time.Time { return start.Add(1500 * time.Microsecond) }
+
+	if end := logger.Phase("parse", start); !end.Equal(start.Add(1500 * time.Microsecond)) {
+		t.Errorf("Expected the phase to end now but got %v", end)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON line but got %q: %v", out.String(), err)
+	}
+	if entry["level"] != "debug" || entry["phase"] != "parse" || entry["durationMs"] != 1.5 || entry["msg"] != "Timing: parse took 1.5ms" {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+	if entry["time"] != "2024-01-01T00:00:00.0015Z" {
+		t.Errorf("Expected the time of the message but got %v", entry["time"])
+	}
+}
+
+func TestNilLogger(t *testing.T) {
+	var logger *Logger
+	logger.Infof("discarded")
+	logger.Phase("render", time.Now())
+	if logger.Enabled(LevelError) {
+		t.Error("Expected a nil logger to be disabled")
+	}
+}
+
+func TestBuffered(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, LevelInfo, FormatText)
+	first, second := logger.Buffered(), logger.Buffered()
+	first.Infof("[1/2] Token.json")
+	second.Infof("[2/2] Vault.json")
+	second.Debugf("hidden")
+	first.Warnf("overlay names.yaml matches nothing")
+	if out.Len() != 0 {
+		t.Fatalf("Expected buffered messages to be kept until flushed but got %q", out.String())
+	}
+	second.Flush()
+	first.Flush()
+	first.Flush()
+
+	expected := "[2/2] Vault.json\n[1/2] Token.json\nWarning: overlay names.yaml matches nothing\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, LevelInfo, "xml"); err == nil {
+		t.Error("Expected an unsupported format to be rejected")
+	}
+}
+
+func TestRecording(t *testing.T) {
+	var out bytes.Buffer
+	logger, _ := New(&out, LevelWarn, FormatText)
+	record := &Record{}
+	recording := logger.Recording(record)
+	start := time.Now()
+	recording.Infof("progress")
+	recording.Warnf("overlay %s matches nothing", "names.yaml")
+	recording.Phase("parse", start)
+
+	// Warnings and timings are recorded whatever the level, and messages written at the logger's level
+	if out.String() != "Warning: overlay names.yaml matches nothing\n" {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+	if warnings := record.Warnings(); len(warnings) != 1 || warnings[0] != "overlay names.yaml matches nothing" {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+	if timings := record.Timings(); len(timings) != 1 || timings[0].Phase != "parse" || timings[0].DurationMs < 0 {
+		t.Errorf("Unexpected timings %+v", timings)
+	}
+
+	// Without a logger, messages are only recorded
+	var nilLogger *Logger
+	nilLogger.Recording(record).Warnf("dropped")
+	if warnings := record.Warnings(); len(warnings) != 2 {
+		t.Errorf("Expected the warning to be recorded but got %v", warnings)
+	}
+}
\ No newline at end of file
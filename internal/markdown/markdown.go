@@ -0,0 +1,365 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// Generate renders a contract IR as standalone Markdown API documentation: its functions, events, errors and custom
+// types, with their signatures, selectors and parameters.
+// Only the visible members are documented, deprecated ones under a deprecation note.
+func Generate(contract *ir.ContractIR) []byte {
+	contract = contract.Visible()
+	d := newDocument(contract)
+
+	d.line("# %s", contract.Metadata.Name)
+	if contract.Metadata.Description != "" {
+		d.line("")
+		d.line("%s", contract.Metadata.Description)
+	}
+	d.line("")
+	d.line("- **Chain**: %s", contract.Metadata.Chain)
+	if contract.Metadata.Address != "" {
+		d.line("- **Address**: `%s`", contract.Metadata.Address)
+	}
+	for _, chainID := range contract.Metadata.ChainIDs() {
+		deployment, _ := contract.Metadata.DeploymentOn(chainID)
+		block := ""
+		if deployment.Block > 0 {
+			block = fmt.Sprintf(" (deployed in block %d)", deployment.Block)
+		}
+		d.line("- **Chain %d**: `%s`%s", chainID, deployment.Address, block)
+	}
+	if source := contract.Metadata.Source; source != nil {
+		d.line("- **Language**: %s", strings.TrimSpace(source.Language+" "+source.Compiler))
+	}
+
+	d.contents(contract)
+	d.functions(contract.Functions)
+	d.events(contract.Events)
+	d.errors(contract.Errors)
+	d.customTypes(contract.Types)
+	return d.buf.Bytes()
+}
+
+// document accumulates the Markdown of a contract
+type document struct {
+	buf bytes.Buffer
+
+	// Anchors of the member headings, by section and index
+	anchors map[string][]string
+
+	// Anchors of the declared custom types, by name, which tuple types link to
+	types map[string]string
+}
+
+// newDocument assigns every heading of a contract's documentation its anchor, in document order, so repeated names
+// (overloads, or an event named like a function) get the "-1", "-2", ... suffixes GitHub gives them
+func newDocument(c *ir.ContractIR) *document {
+	d := &document{anchors: map[string][]string{}, types: map[string]string{}}
+	seen := map[string]int{}
+	unique := func(heading string) string {
+		slug := anchor(heading)
+		n := seen[slug]
+		seen[slug]++
+		if n > 0 {
+			return fmt.Sprintf("%s-%d", slug, n)
+		}
+		return slug
+	}
+	section := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		d.anchors[title] = []string{unique(title)}
+		for _, name := range names {
+			d.anchors[title] = append(d.anchors[title], unique(name))
+		}
+	}
+
+	unique(c.Metadata.Name)
+	unique("Contents")
+	var functions, events, errors, types []string
+	for _, f := range c.Functions {
+		functions = append(functions, f.Name)
+	}
+	for _, e := range c.Events {
+		events = append(events, e.Name)
+	}
+	for _, e := range c.Errors {
+		errors = append(errors, e.Name)
+	}
+	for _, t := range c.Types {
+		types = append(types, t.Name)
+	}
+	section("Functions", functions)
+	section("Events", events)
+	section("Errors", errors)
+	section("Types", types)
+
+	for i, name := range types {
+		d.types[name] = d.anchor("Types", i)
+	}
+	return d
+}
+
+// anchor returns the anchor of the i-th entry of a section
+func (d *document) anchor(section string, i int) string {
+	return d.anchors[section][i+1]
+}
+
+func (d *document) line(format string, args ...interface{}) {
+	fmt.Fprintf(&d.buf, format, args...)
+	d.buf.WriteByte('\n')
+}
+
+// contents lists the sections of the document and their entries
+func (d *document) contents(c *ir.ContractIR) {
+	d.line("")
+	d.line("## Contents")
+	d.line("")
+	section := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		links := make([]string, len(names))
+		for i, name := range names {
+			links[i] = fmt.Sprintf("[%s](#%s)", name, d.anchor(title, i))
+		}
+		d.line("- [%s](#%s): %s", title, d.anchors[title][0], strings.Join(links, ", "))
+	}
+	var functions, events, errors, types []string
+	for _, f := range c.Functions {
+		functions = append(functions, f.Name)
+	}
+	for _, e := range c.Events {
+		events = append(events, e.Name)
+	}
+	for _, e := range c.Errors {
+		errors = append(errors, e.Name)
+	}
+	for _, t := range c.Types {
+		types = append(types, t.Name)
+	}
+	section("Functions", functions)
+	section("Events", events)
+	section("Errors", errors)
+	section("Types", types)
+}
+
+func (d *document) functions(functions []ir.Function) {
+	if len(functions) == 0 {
+		return
+	}
+	d.line("")
+	d.line("## Functions")
+	for _, f := range functions {
+		d.line("")
+		d.line("### %s", f.Name)
+		d.description(f.Description, f.Deprecated)
+		d.line("")
+		if operation := f.API(); operation.HTTPMethod != "" {
+			d.line("- **Operation**: `%s %s`", operation.HTTPMethod, operation.HTTPPath)
+		} else if operation.RPCMethod != "" {
+			d.line("- **Method**: `%s`", operation.RPCMethod)
+		} else if f.Signature != "" {
+			d.line("- **Signature**: `%s`", f.Signature)
+		} else {
+			d.line("- **Signature**: `%s`", Signature(f.ABIName(), f.Inputs))
+		}
+		if f.Selector != "" {
+			d.line("- **Selector**: `%s`", f.Selector)
+		}
+		d.line("- **State mutability**: %s", f.StateMutability)
+		if kind := functionKind(f); kind != "" {
+			d.line("- **Kind**: %s", kind)
+		}
+		if !f.Access.IsEmpty() {
+			d.line("- **Access**: %s", f.Access)
+		}
+		if f.Danger != "" {
+			d.line("- **Danger**: %s", f.Danger)
+		}
+		if bounds := f.Value.String(); bounds != "" {
+			d.line("- **Value**: %s", bounds)
+		}
+		if len(f.Tags) > 0 {
+			d.line("- **Tags**: %s", strings.Join(f.Tags, ", "))
+		}
+		d.parameters("Parameters", f.Inputs)
+		d.parameters("Returns", f.Outputs)
+	}
+}
+
+func (d *document) events(events []ir.Event) {
+	if len(events) == 0 {
+		return
+	}
+	d.line("")
+	d.line("## Events")
+	for _, e := range events {
+		d.line("")
+		d.line("### %s", e.Name)
+		d.description(e.Description, e.Deprecated)
+		d.line("")
+		signature := e.Signature
+		if signature == "" {
+			parameters := make([]ir.Parameter, len(e.Parameters))
+			for i, p := range e.Parameters {
+				parameters[i] = ir.Parameter{Name: p.Name, Type: p.Type}
+			}
+			signature = Signature(e.Name, parameters)
+		}
+		d.line("- **Signature**: `%s`", signature)
+		if e.Topic != "" {
+			d.line("- **Topic**: `%s`", e.Topic)
+		}
+		if len(e.Parameters) == 0 {
+			continue
+		}
+		d.line("")
+		d.line("| Name | Type | Indexed |")
+		d.line("| --- | --- | --- |")
+		for i, p := range e.Parameters {
+			indexed := "no"
+			if p.Indexed {
+				indexed = "yes"
+			}
+			d.line("| %s | %s | %s |", parameterName(p.Name, i), d.typeName(p.Type), indexed)
+		}
+	}
+}
+
+func (d *document) errors(errors []ir.ContractError) {
+	if len(errors) == 0 {
+		return
+	}
+	d.line("")
+	d.line("## Errors")
+	for _, e := range errors {
+		d.line("")
+		d.line("### %s", e.Name)
+		d.description(e.Description, false)
+		d.line("")
+		d.line("- **Signature**: `%s`", Signature(e.Name, e.Parameters))
+		d.parameters("Parameters", e.Parameters)
+	}
+}
+
+func (d *document) customTypes(types []ir.CustomType) {
+	if len(types) == 0 {
+		return
+	}
+	d.line("")
+	d.line("## Types")
+	for _, t := range types {
+		d.line("")
+		d.line("### %s", t.Name)
+		d.description(t.Description, false)
+		d.parameters("Fields", t.Fields)
+	}
+}
+
+// description writes a description paragraph, with a deprecation notice first
+func (d *document) description(description string, deprecated bool) {
+	if deprecated {
+		d.line("")
+		d.line("> **Deprecated.**")
+	}
+	if description != "" {
+		d.line("")
+		d.line("%s", description)
+	}
+}
+
+// parameters writes a titled table of parameters
+func (d *document) parameters(title string, parameters []ir.Parameter) {
+	if len(parameters) == 0 {
+		return
+	}
+	d.line("")
+	d.line("**%s**", title)
+	d.line("")
+	d.line("| Name | Type | Description |")
+	d.line("| --- | --- | --- |")
+	for i, p := range parameters {
+		description := p.Description
+		if unit := ir.UnitDescription(p.Unit); unit != "" {
+			description = strings.TrimPrefix(description+"; "+unit, "; ")
+		} else if p.Unit != "" {
+			description = strings.TrimPrefix(description+"; in "+p.Unit+" units", "; ")
+		}
+		if p.Default != nil {
+			description = strings.TrimPrefix(description+"; default: "+p.DefaultDescription(), "; ")
+		}
+		d.line("| %s | %s | %s |", parameterName(p.Name, i), d.typeName(p.Type), cell(description))
+	}
+}
+
+// typeName returns the ABI type of a parameter, linking tuples of declared custom types to their section
+func (d *document) typeName(t ir.ParameterType) string {
+	typeAnchor, ok := d.types[t.TypeName]
+	if !ok {
+		return "`" + t.ABIType() + "`"
+	}
+	link := fmt.Sprintf("[%s](#%s)", t.TypeName, typeAnchor)
+	if !t.IsArray {
+		return link
+	}
+	if t.ArraySize > 0 {
+		return fmt.Sprintf("%s`[%d]`", link, t.ArraySize)
+	}
+	return link + "`[]`"
+}
+
+// Signature returns the canonical ABI signature of a function, event or error (e.g., "transfer(address,uint256)")
+func Signature(name string, parameters []ir.Parameter) string {
+	types := make([]string, len(parameters))
+	for i, p := range parameters {
+		types[i] = p.Type.ABIType()
+	}
+	return name + "(" + strings.Join(types, ",") + ")"
+}
+
+// functionKind names the special kinds of functions
+func functionKind(f ir.Function) string {
+	switch {
+	case f.IsConstructor:
+		return "constructor"
+	case f.IsFallback:
+		return "fallback"
+	case f.IsReceive:
+		return "receive"
+	}
+	return ""
+}
+
+// parameterName returns a parameter's name, or its position for unnamed parameters
+func parameterName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("_%d_", index)
+	}
+	return "`" + name + "`"
+}
+
+// anchor returns the GitHub anchor of a heading
+func anchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// cell escapes text for a Markdown table cell
+func cell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
\ No newline at end of file
@@ -0,0 +1,138 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/parser/evm"
+)
+
+// testContract has a function taking a struct, a deprecated and a hidden function, two events (one named like a function), an error and a type
+func testContract() *ir.ContractIR {
+	order := ir.ParameterType{BaseType: "tuple", TypeName: "Order", Components: []ir.Parameter{
+		{Name: "maker", Type: ir.ParameterType{BaseType: "address"}},
+		{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+	}}
+	return &ir.ContractIR{
+		Metadata: ir.ContractMetadata{
+			Name:        "Exchange",
+			Description: "Fills orders",
+			Chain:       "ethereum",
+			Deployments: map[string]ir.Deployment{"1": {Address: "0x00000000000000000000000000000000000000aa", Block: 100}},
+		},
+		Functions: []ir.Function{
+			{
+				Name:            "fill",
+				Description:     "Fills an order | partially",
+				StateMutability: ir.Payable,
+				Selector:        "0x12345678",
+				Inputs: []ir.Parameter{
+					{Name: "order", Type: order},
+					{Name: "deadline", Type: ir.ParameterType{BaseType: "uint256"}, Unit: ir.UnitTimestamp},
+				},
+				Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "bool"}}},
+			},
+			{Name: "legacyFill", Deprecated: true, StateMutability: ir.Nonpayable},
+			{Name: "internalHook", Hidden: true, StateMutability: ir.Nonpayable},
+		},
+		Events: []ir.Event{
+			{
+				Name: "Filled",
+				Parameters: []ir.EventParameter{
+					{Name: "maker", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+					{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+				},
+			},
+			{Name: "fill"},
+		},
+		Errors: []ir.ContractError{{Name: "Expired", Parameters: []ir.Parameter{{Name: "deadline", Type: ir.ParameterType{BaseType: "uint256"}}}}},
+		Types:  []ir.CustomType{{Name: "Order", Fields: order.Components}},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	doc := string(Generate(testContract()))
+
+	for _, want := range []string{
+		"# Exchange\n\nFills orders\n",
+		"- **Chain 1**: `0x00000000000000000000000000000000000000aa` (deployed in block 100)",
+		"- [Functions](#functions): [fill](#fill), [legacyFill](#legacyfill)",
+		"- **Signature**: `fill((address,uint256),uint256)`",
+		"- **Selector**: `0x12345678`",
+		"- **State mutability**: payable",
+		"Fills an order | partially",
+		"| `order` | [Order](#order) |  |",
+		"| _0_ | `bool` |  |",
+		"### legacyFill\n\n> **Deprecated.**",
+		"- [Events](#events): [Filled](#filled), [fill](#fill-1)",
+		"- **Signature**: `Filled(address,uint256)`",
+		"| `maker` | `address` | yes |",
+		"- **Signature**: `Expired(uint256)`",
+		"## Types\n\n### Order\n\n**Fields**",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected the documentation to contain %q, got:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "internalHook") {
+		t.Errorf("Expected hidden functions to be left out, got:\n%s", doc)
+	}
+	if strings.Contains(doc, "- **Selector**: ``") {
+		t.Errorf("Expected no selector for functions without one, got:\n%s", doc)
+	}
+}
+
+func TestGenerateAPIOperations(t *testing.T) {
+	doc := string(Generate(&ir.ContractIR{
+		Metadata: ir.ContractMetadata{Name: "Petstore", Chain: ir.ChainOpenAPI},
+		Functions: []ir.Function{{
+			Name:            "listPets",
+			StateMutability: ir.View,
+			ChainData:       map[string]interface{}{ir.HTTPMethodKey: "GET", ir.HTTPPathKey: "/pets"},
+		}},
+	}))
+	if !strings.Contains(doc, "- **Operation**: `GET /pets`") || strings.Contains(doc, "**Signature**") {
+		t.Errorf("Expected the operation instead of a signature, got:\n%s", doc)
+	}
+}
+
+func TestCell(t *testing.T) {
+	if got := cell("a | b\nc"); got != "a \\| b c" {
+		t.Errorf("Expected escaped cell, got %q", got)
+	}
+}
+
+func TestGenerateParsedABI(t *testing.T) {
+	abi := `[
+		{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+			"inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "uint256"}],
+			"outputs": [{"name": "", "type": "bool"}]},
+		{"type": "function", "name": "fill", "stateMutability": "nonpayable",
+			"inputs": [{"name": "orders", "type": "tuple[]", "components": [{"name": "maker", "type": "address"}, {"name": "amount", "type": "uint256"}]}],
+			"outputs": []},
+		{"type": "event", "name": "Transfer", "anonymous": false, "inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}]},
+		{"type": "event", "name": "Anonymous", "anonymous": true, "inputs": []}
+	]`
+	contract, err := evm.NewABIParser().Parse(strings.NewReader(abi), ir.ContractMetadata{Name: "Token", Chain: "ethereum"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	doc := string(Generate(contract))
+
+	for _, want := range []string{
+		"- **Signature**: `transfer(address,uint256)`\n- **Selector**: `0xa9059cbb`",
+		"- **Signature**: `fill((address,uint256)[])`\n- **Selector**: `" + ir.Selector("fill((address,uint256)[])") + "`",
+		"- **Signature**: `Transfer(address,address,uint256)`\n- **Topic**: `0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef`",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Generate() missing %q in:\n%s", want, doc)
+		}
+	}
+	if strings.Count(doc, "- **Topic**") != 1 {
+		t.Errorf("Generate() rendered a topic for the anonymous event:\n%s", doc)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,172 @@
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// DefaultProtocolVersion is the MCP protocol version answered to clients that do not request one
+const DefaultProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is an MCP tool: its name, description and JSON Schema of its arguments, and the handler calling it
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+
+	// Handler calls the tool with its arguments and returns its text result
+	// Errors are reported to the client as tool results marked isError, so that agents can read and act on them.
+	Handler func(ctx context.Context, arguments json.RawMessage) (string, error) `json:"-"`
+}
+
+// Server serves tools over the MCP stdio transport: JSON-RPC 2.0 messages, one per line
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	mu      sync.Mutex
+}
+
+// New returns a server of a name and version serving tools, listed in the order given
+func New(name, version string, tools []Tool) *Server {
+	return &Server{name: name, version: version, tools: tools}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// content is a text item of a tool result
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Serve reads requests from in and writes their responses to out until in ends or the context is done
+// Requests are handled one at a time, in order.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	encoder := json.NewEncoder(out)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if reply := s.handle(ctx, line); reply != nil {
+			if err := encoder.Encode(reply); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handle returns the response to a message, or nil for notifications
+func (s *Server) handle(ctx context.Context, message []byte) *response {
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, fmt.Sprintf("invalid JSON: %v", err))
+	}
+	if len(req.ID) == 0 {
+		// Notifications, e.g. notifications/initialized, need no response
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid JSON-RPC 2.0 request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := params.ProtocolVersion
+		if version == "" {
+			version = DefaultProtocolVersion
+		}
+		return resultResponse(req.ID, map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": s.name, "version": s.version},
+		})
+	case "ping":
+		return resultResponse(req.ID, map[string]interface{}{})
+	case "tools/list":
+		return resultResponse(req.ID, map[string]interface{}{"tools": s.tools})
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, fmt.Sprintf("invalid tool call: %v", err))
+		}
+		tool, ok := s.lookup(params.Name)
+		if !ok {
+			return errorResponse(req.ID, codeInvalidParams, fmt.Sprintf("unknown tool: %s", params.Name))
+		}
+		if len(params.Arguments) == 0 {
+			params.Arguments = json.RawMessage("{}")
+		}
+		s.mu.Lock()
+		text, err := tool.Handler(ctx, params.Arguments)
+		s.mu.Unlock()
+		if err != nil {
+			if text != "" {
+				text += "\n"
+			}
+			return resultResponse(req.ID, map[string]interface{}{"content": []content{{Type: "text", Text: text + err.Error()}}, "isError": true})
+		}
+		return resultResponse(req.ID, map[string]interface{}{"content": []content{{Type: "text", Text: text}}})
+	}
+	return errorResponse(req.ID, codeMethodNotFound, fmt.Sprintf("method not found: %s", req.Method))
+}
+
+func (s *Server) lookup(name string) (Tool, bool) {
+	for _, tool := range s.tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+func resultResponse(id json.RawMessage, result interface{}) *response {
+	return &response{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: message}}
+}
\ No newline at end of file
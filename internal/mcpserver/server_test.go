@@ -0,0 +1,97 @@
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func serve(t *testing.T, server *Server, messages ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+	var replies []map[string]interface{}
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var reply map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &reply); err != nil {
+			t.Fatalf("Expected JSON lines but got %q: %v", out.String(), err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+func echoServer() *Server {
+	return New("test", "1.0.0", []Tool{{
+		Name:        "echo",
+		Description: "Echo a message",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+			var args struct {
+				Message string `json:"message"`
+			}
+			json.Unmarshal(arguments, &args)
+			if args.Message == "" {
+				return "", errors.New("message is required")
+			}
+			return args.Message, nil
+		},
+	}})
+}
+
+func TestInitializeAndList(t *testing.T) {
+	replies := serve(t, echoServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	if len(replies) != 2 {
+		t.Fatalf("Expected 2 replies (none to the notification) but got %v", replies)
+	}
+	result := replies[0]["result"].(map[string]interface{})
+	if result["protocolVersion"] != "2025-03-26" {
+		t.Errorf("Expected the requested protocol version but got %v", result["protocolVersion"])
+	}
+	if info := result["serverInfo"].(map[string]interface{}); info["name"] != "test" {
+		t.Errorf("Unexpected server info: %v", info)
+	}
+	tools := replies[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 1 || tools[0].(map[string]interface{})["name"] != "echo" {
+		t.Errorf("Unexpected tools: %v", tools)
+	}
+}
+
+func TestCallTool(t *testing.T) {
+	replies := serve(t, echoServer(),
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"message":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"missing"}}`,
+	)
+	first := replies[0]["result"].(map[string]interface{})
+	if text := first["content"].([]interface{})[0].(map[string]interface{})["text"]; text != "hi" || first["isError"] != nil {
+		t.Errorf("Unexpected result: %v", first)
+	}
+	second := replies[1]["result"].(map[string]interface{})
+	if second["isError"] != true || second["content"].([]interface{})[0].(map[string]interface{})["text"] != "message is required" {
+		t.Errorf("Expected the tool error as result but got %v", second)
+	}
+	if code := replies[2]["error"].(map[string]interface{})["code"]; code != float64(codeInvalidParams) {
+		t.Errorf("Expected an invalid params error for unknown tools but got %v", replies[2])
+	}
+}
+
+func TestInvalidMessages(t *testing.T) {
+	replies := serve(t, echoServer(), `{not json`, `{"jsonrpc":"2.0","id":"a","method":"resources/list"}`)
+	if code := replies[0]["error"].(map[string]interface{})["code"]; code != float64(codeParseError) {
+		t.Errorf("Expected a parse error but got %v", replies[0])
+	}
+	if code := replies[1]["error"].(map[string]interface{})["code"]; code != float64(codeMethodNotFound) || replies[1]["id"] != "a" {
+		t.Errorf("Expected method not found for id a but got %v", replies[1])
+	}
+}
\ No newline at end of file
@@ -0,0 +1,437 @@
+// Package npm resolves artifacts distributed in npm packages, e.g.
+// npm:@openzeppelin/contracts/build/contracts/ERC20.json, from installed packages or from tarballs fetched from the
+// registry and cached on disk
+package npm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openhands/mcp-generator/internal/offline"
+)
+
+// Scheme prefixes the artifact references of files of npm packages, e.g.
+// npm:@openzeppelin/contracts@5.0.2/build/contracts/ERC20.json
+const Scheme = "npm:"
+
+// DefaultRegistry is the URL of the public npm registry
+const DefaultRegistry = "https://registry.npmjs.org"
+
+// RegistryEnv is the environment variable npm reads its registry URL from
+const RegistryEnv = "NPM_CONFIG_REGISTRY"
+
+// namePattern matches npm package names, scoped or not
+var namePattern = regexp.MustCompile(`^(@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+
+// exactVersionPattern matches exact semantic versions, which name immutable releases
+var exactVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// tagPattern matches dist-tags such as latest or next
+var tagPattern = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z._-]*$`)
+
+// Reference names a file of an npm package: its package, the version or dist-tag wanted if any, and its path in the
+// package
+type Reference struct {
+	Package string
+	Version string
+	File    string
+}
+
+// String returns the reference as given with --artifact
+func (r Reference) String() string {
+	spec := r.Package
+	if r.Version != "" {
+		spec += "@" + r.Version
+	}
+	return Scheme + spec + "/" + r.File
+}
+
+// IsReference reports whether an artifact path is an npm reference
+func IsReference(path string) bool {
+	return strings.HasPrefix(path, Scheme)
+}
+
+// ParseReference parses an npm reference, npm:<package>[@<version>]/<file>; versions are exact versions or
+// dist-tags, as ranges would resolve differently over time
+func ParseReference(reference string) (Reference, error) {
+	invalid := func(reason string) (Reference, error) {
+		return Reference{}, fmt.Errorf("invalid npm reference %s: %s (expected %s<package>[@<version>]/<file>)", reference, reason, Scheme)
+	}
+	if !IsReference(reference) {
+		return invalid("missing " + Scheme)
+	}
+	segments := strings.Split(strings.TrimPrefix(reference, Scheme), "/")
+	nameSegments := 1
+	if strings.HasPrefix(segments[0], "@") {
+		nameSegments = 2
+	}
+	if len(segments) <= nameSegments {
+		return invalid("no file")
+	}
+	spec := strings.Join(segments[:nameSegments], "/")
+	ref := Reference{Package: spec, File: path.Clean(strings.Join(segments[nameSegments:], "/"))}
+	if at := strings.LastIndex(spec, "@"); at > 0 {
+		ref.Package, ref.Version = spec[:at], spec[at+1:]
+	}
+	if !namePattern.MatchString(ref.Package) {
+		return invalid(ref.Package + " is not a package name")
+	}
+	if ref.Version != "" && !exactVersionPattern.MatchString(ref.Version) && !tagPattern.MatchString(ref.Version) {
+		return invalid("version " + ref.Version + " is neither an exact version nor a dist-tag; ranges are not supported")
+	}
+	ref.Version = strings.TrimPrefix(ref.Version, "v")
+	if ref.File == "." || strings.HasPrefix(ref.File, "../") {
+		return invalid("file " + ref.File + " is not in the package")
+	}
+	return ref, nil
+}
+
+// Options configure resolving
+type Options struct {
+	// Directory installed packages are looked up from, in its node_modules and those of its parents (default: the
+	// working directory)
+	Dir string
+
+	// Directory fetched packages are cached in; empty disables caching, so packages are fetched into a temporary
+	// directory
+	CacheDir string
+
+	// Fetch packages even if cached, refreshing the cache
+	NoCache bool
+
+	// URL of the registry (default: NPM_CONFIG_REGISTRY, or else DefaultRegistry)
+	Registry string
+
+	// HTTP client (default: one with a 60 second timeout)
+	Client *http.Client
+}
+
+// Source names where a resolved file was found
+const (
+	SourceInstalled = "node_modules"
+	SourceCache     = "cache"
+	SourceRegistry  = "registry"
+)
+
+// Resolved is the file of a reference on disk
+type Resolved struct {
+	// Path of the file
+	Path string
+
+	// Version of the package the file is from
+	Version string
+
+	// Where the package was found: SourceInstalled, SourceCache or SourceRegistry
+	Source string
+}
+
+// Resolve returns the file of a reference on disk: in the installed package if its version matches (any version
+// does when the reference has none), or else in the package fetched from the registry, which is cached. Exact
+// versions are resolved from the cache without a request, so they also resolve offline once fetched.
+func Resolve(ctx context.Context, ref Reference, options Options) (*Resolved, error) {
+	if options.Registry == "" {
+		options.Registry = os.Getenv(RegistryEnv)
+	}
+	if options.Registry == "" {
+		options.Registry = DefaultRegistry
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	if dir, version, err := installed(options.Dir, ref.Package); err != nil {
+		return nil, err
+	} else if dir != "" && (ref.Version == "" || ref.Version == version) {
+		return resolvedFile(ref, dir, version, SourceInstalled)
+	}
+
+	version := ref.Version
+	if version == "" {
+		version = "latest"
+	}
+	if exactVersionPattern.MatchString(version) && !options.NoCache {
+		if dir := cachePath(options.CacheDir, ref.Package, version); dir != "" {
+			if _, err := os.Stat(dir); err == nil {
+				return resolvedFile(ref, dir, version, SourceCache)
+			}
+		}
+	}
+	if err := offline.Check("fetching "+ref.String(), options.Registry); err != nil {
+		return nil, fmt.Errorf("%w, and %s is neither installed nor cached", err, ref)
+	}
+
+	manifest, err := fetchManifest(ctx, ref.Package, version, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	// A dist-tag may point to a version fetched before
+	dir := cachePath(options.CacheDir, ref.Package, manifest.Version)
+	if dir != "" && !options.NoCache {
+		if _, err := os.Stat(dir); err == nil {
+			return resolvedFile(ref, dir, manifest.Version, SourceCache)
+		}
+	}
+	if dir == "" {
+		temp, err := os.MkdirTemp("", "mcpgen-npm-")
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(temp, "package")
+	}
+	if err := fetchPackage(ctx, manifest, dir, options); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	return resolvedFile(ref, dir, manifest.Version, SourceRegistry)
+}
+
+// installed returns the directory and version of a package installed in the node_modules of a directory or of its
+// nearest parent that has it, or "" if none has
+func installed(dir, pkg string) (string, string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	for {
+		candidate := filepath.Join(dir, "node_modules", filepath.FromSlash(pkg))
+		content, err := os.ReadFile(filepath.Join(candidate, "package.json"))
+		if err == nil {
+			var manifest struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(content, &manifest); err != nil {
+				return "", "", fmt.Errorf("invalid package.json of %s: %w", candidate, err)
+			}
+			return candidate, manifest.Version, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// resolvedFile returns the file of a reference in a package directory, failing if the package has no such file
+func resolvedFile(ref Reference, dir, version, source string) (*Resolved, error) {
+	file := filepath.Join(dir, filepath.FromSlash(ref.File))
+	info, err := os.Stat(file)
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("%s is a directory", ref.File)
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = fmt.Errorf("%s@%s has no file %s", ref.Package, version, ref.File)
+		}
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return &Resolved{Path: file, Version: version, Source: source}, nil
+}
+
+// manifest is the part of a registry's version manifest needed to fetch the package
+type manifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dist    struct {
+		Tarball   string `json:"tarball"`
+		Integrity string `json:"integrity"`
+		Shasum    string `json:"shasum"`
+	} `json:"dist"`
+}
+
+// fetchManifest fetches the manifest of a version or dist-tag of a package from the registry
+func fetchManifest(ctx context.Context, pkg, version string, options Options) (*manifest, error) {
+	// Scoped names keep their @ but escape their slash, as the registry expects
+	endpoint := strings.TrimSuffix(options.Registry, "/") + "/" + strings.Replace(pkg, "/", "%2f", 1) + "/" + url.PathEscape(version)
+	body, err := options.get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	result := &manifest{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if result.Version == "" || result.Dist.Tarball == "" {
+		return nil, errors.New("invalid manifest: no version or tarball")
+	}
+	return result, nil
+}
+
+// fetchPackage downloads the tarball of a package version, checks its integrity and extracts it into a directory,
+// which only appears once complete
+func fetchPackage(ctx context.Context, manifest *manifest, dir string, options Options) error {
+	tarball, err := options.get(ctx, manifest.Dist.Tarball)
+	if err != nil {
+		return err
+	}
+	if err := checkIntegrity(tarball, manifest.Dist.Integrity, manifest.Dist.Shasum); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	temp, err := os.MkdirTemp(filepath.Dir(dir), ".extract-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(temp)
+	if err := extract(tarball, temp); err != nil {
+		return err
+	}
+	os.RemoveAll(dir)
+	return os.Rename(temp, dir)
+}
+
+// checkIntegrity checks a tarball against the sha512 subresource integrity of its manifest, or else its SHA-1 shasum
+func checkIntegrity(tarball []byte, integrity, shasum string) error {
+	for _, hash := range strings.Fields(integrity) {
+		if strings.HasPrefix(hash, "sha512-") {
+			sum := sha512.Sum512(tarball)
+			if base64.StdEncoding.EncodeToString(sum[:]) != strings.TrimPrefix(hash, "sha512-") {
+				return errors.New("tarball does not match its sha512 integrity")
+			}
+			return nil
+		}
+	}
+	if shasum != "" {
+		sum := sha1.Sum(tarball)
+		if hex.EncodeToString(sum[:]) != strings.ToLower(shasum) {
+			return errors.New("tarball does not match its shasum")
+		}
+	}
+	return nil
+}
+
+// extract extracts the regular files of a gzipped package tarball into a directory, without the tarball's top-level
+// directory (package/ for tarballs packed by npm)
+func extract(tarball []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(tarball))
+	if err != nil {
+		return fmt.Errorf("invalid tarball: %w", err)
+	}
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid tarball: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, name, ok := strings.Cut(path.Clean(strings.TrimPrefix(header.Name, "/")), "/")
+		if !ok || name == ".." || strings.HasPrefix(name, "../") {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, archive)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// get sends a GET request and returns its body
+func (o Options) get(ctx context.Context, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.New("not found in the registry")
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// cachePath returns the cache directory of a package version, or "" if caching is disabled
+func cachePath(dir, pkg, version string) string {
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, filepath.FromSlash(pkg), version)
+}
+
+// DefaultCacheDir returns the per-user directory fetched packages are cached in
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-generator", "npm")
+}
+
+// ClearCache removes the cached packages of a cache directory and returns how many there were
+func ClearCache(dir string) (int, error) {
+	if dir == "" {
+		return 0, nil
+	}
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() || path == dir {
+			return err
+		}
+		// Package directories are <name>/<version> or @<scope>/<name>/<version>
+		rel, _ := filepath.Rel(dir, path)
+		depth := len(strings.Split(filepath.ToSlash(rel), "/"))
+		if depth == 2 && !strings.HasPrefix(rel, "@") || depth == 3 {
+			count++
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, os.RemoveAll(dir)
+}
\ No newline at end of file
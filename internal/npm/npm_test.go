@@ -0,0 +1,149 @@
+package npm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/testutil"
+)
+
+func TestParseReference(t *testing.T) {
+	for reference, expected := range map[string]Reference{
+		"npm:@openzeppelin/contracts/build/contracts/ERC20.json":       {Package: "@openzeppelin/contracts", File: "build/contracts/ERC20.json"},
+		"npm:@openzeppelin/contracts@5.0.2/build/contracts/ERC20.json": {Package: "@openzeppelin/contracts", Version: "5.0.2", File: "build/contracts/ERC20.json"},
+		"npm:@uniswap/v3-core@v1.0.1/artifacts/UniswapV3Pool.json":     {Package: "@uniswap/v3-core", Version: "1.0.1", File: "artifacts/UniswapV3Pool.json"},
+		"npm:erc20-abi@next/abi.json":                                  {Package: "erc20-abi", Version: "next", File: "abi.json"},
+		"npm:erc20-abi/./dist/../abi.json":                             {Package: "erc20-abi", File: "abi.json"},
+	} {
+		ref, err := ParseReference(reference)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", reference, err)
+			continue
+		}
+		if ref != expected {
+			t.Errorf("Expected %s to parse as %+v but got %+v", reference, expected, ref)
+		}
+	}
+	for _, invalid := range []string{"npm:@openzeppelin/contracts", "npm:erc20-abi", "npm:Erc20/abi.json", "npm:erc20-abi@^1.0.0/abi.json", "npm:erc20-abi/../abi.json", "abi.json"} {
+		if _, err := ParseReference(invalid); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+}
+
+func TestResolveInstalled(t *testing.T) {
+	project := t.TempDir()
+	pkg := filepath.Join(project, "node_modules", "@openzeppelin", "contracts")
+	testutil.WriteFile(t, filepath.Join(pkg, "package.json"), `{"name": "@openzeppelin/contracts", "version": "5.0.2"}`)
+	testutil.WriteFile(t, filepath.Join(pkg, "build", "contracts", "ERC20.json"), `{"abi": []}`)
+
+	// Packages are found in the node_modules of parent directories too
+	dir := filepath.Join(project, "packages", "server")
+	options := Options{Dir: dir, CacheDir: t.TempDir(), Registry: "http://127.0.0.1:0"}
+	for _, reference := range []string{"npm:@openzeppelin/contracts/build/contracts/ERC20.json", "npm:@openzeppelin/contracts@5.0.2/build/contracts/ERC20.json"} {
+		ref, _ := ParseReference(reference)
+		resolved, err := Resolve(context.Background(), ref, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved.Path != filepath.Join(pkg, "build", "contracts", "ERC20.json") || resolved.Version != "5.0.2" || resolved.Source != SourceInstalled {
+			t.Errorf("Unexpected resolution of %s: %+v", reference, resolved)
+		}
+	}
+
+	ref, _ := ParseReference("npm:@openzeppelin/contracts/build/contracts/Missing.json")
+	if _, err := Resolve(context.Background(), ref, options); err == nil || !strings.Contains(err.Error(), "@openzeppelin/contracts@5.0.2 has no file build/contracts/Missing.json") {
+		t.Errorf("Expected a missing file error but got %v", err)
+	}
+}
+
+func TestResolveRegistry(t *testing.T) {
+	tarball := packTarball(t, map[string]string{
+		"package/package.json":     `{"name": "@acme/abis", "version": "1.2.0"}`,
+		"package/build/Token.json": `{"abi": [{"type": "function", "name": "name"}]}`,
+		"package/../escape.json":   `{}`,
+	})
+	sum := sha512.Sum512(tarball)
+	requests := map[string]int{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.EscapedPath()]++
+		switch r.URL.EscapedPath() {
+		case "/@acme%2fabis/latest", "/@acme%2fabis/1.2.0":
+			fmt.Fprintf(w, `{"name": "@acme/abis", "version": "1.2.0", "dist": {"tarball": "%s/tarballs/abis-1.2.0.tgz", "integrity": "sha512-%s"}}`, server.URL, base64.StdEncoding.EncodeToString(sum[:]))
+		case "/@acme%2fabis/1.3.0":
+			fmt.Fprintf(w, `{"name": "@acme/abis", "version": "1.3.0", "dist": {"tarball": "%s/tarballs/abis-1.2.0.tgz", "integrity": "sha512-AAAA"}}`, server.URL)
+		case "/tarballs/abis-1.2.0.tgz":
+			w.Write(tarball)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cache := t.TempDir()
+	options := Options{Dir: t.TempDir(), CacheDir: cache, Registry: server.URL}
+	ref, _ := ParseReference("npm:@acme/abis/build/Token.json")
+	resolved, err := Resolve(context.Background(), ref, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Path != filepath.Join(cache, "@acme", "abis", "1.2.0", "build", "Token.json") || resolved.Version != "1.2.0" || resolved.Source != SourceRegistry {
+		t.Errorf("Unexpected resolution %+v", resolved)
+	}
+	if _, err := os.Stat(filepath.Join(cache, "@acme", "abis", "escape.json")); err == nil {
+		t.Error("Expected entries outside the package to be skipped")
+	}
+
+	// Exact versions are read from the cache without a request; dist-tags are resolved again
+	ref.Version = "1.2.0"
+	if resolved, err = Resolve(context.Background(), ref, options); err != nil || resolved.Source != SourceCache || requests["/@acme%2fabis/1.2.0"] != 0 {
+		t.Errorf("Expected the cached package but got %+v (%v)", resolved, err)
+	}
+	ref.Version = ""
+	if resolved, err = Resolve(context.Background(), ref, options); err != nil || resolved.Source != SourceCache || requests["/@acme%2fabis/latest"] != 2 || requests["/tarballs/abis-1.2.0.tgz"] != 1 {
+		t.Errorf("Expected the tag resolved to the cached package but got %+v (%v, %v)", resolved, err, requests)
+	}
+
+	ref.Version = "1.3.0"
+	if _, err := Resolve(context.Background(), ref, options); err == nil || !strings.Contains(err.Error(), "sha512 integrity") {
+		t.Errorf("Expected an integrity error but got %v", err)
+	}
+	ref.Version = "2.0.0"
+	if _, err := Resolve(context.Background(), ref, options); err == nil || !strings.Contains(err.Error(), "not found in the registry") {
+		t.Errorf("Expected a not found error but got %v", err)
+	}
+
+	count, err := ClearCache(cache)
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 cached package cleared but got %d (%v)", count, err)
+	}
+}
+
+// packTarball returns a gzipped tarball of files
+func packTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		archive.Write([]byte(content))
+	}
+	archive.Close()
+	gz.Close()
+	return buf.Bytes()
+}
\ No newline at end of file
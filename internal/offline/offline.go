@@ -0,0 +1,84 @@
+// Package offline enforces --offline: once enabled, the generator makes no network requests beyond the loopback
+// interface, so that it can run in air-gapped and compliance-sensitive build environments
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// EnvVar is set to 1 in the environment of plugins when offline mode is enabled, so that they make no network
+// requests either
+const EnvVar = "MCPGEN_OFFLINE"
+
+// ErrOffline is the error of network access attempted in offline mode
+var ErrOffline = errors.New("network access is disabled by --offline")
+
+var (
+	mu      sync.Mutex
+	enabled bool
+)
+
+// Enable turns offline mode on for the rest of the process: HTTP requests of clients using the default transport
+// fail unless they go to the loopback interface, and plugins are told to stay offline
+// Offline mode cannot be turned off again, so that commands run in-process by serve-mcp stay offline too.
+func Enable() {
+	mu.Lock()
+	defer mu.Unlock()
+	if enabled {
+		return
+	}
+	enabled = true
+	http.DefaultTransport = &transport{next: http.DefaultTransport}
+	os.Setenv(EnvVar, "1")
+}
+
+// Enabled reports whether offline mode is on
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// Check returns an error wrapping ErrOffline if offline mode is on and a URL is not on the loopback interface, naming
+// what needed it, e.g. "LLM enrichment with openai"
+func Check(what, rawURL string) error {
+	if !Enabled() {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%s: invalid URL %s: %w", what, rawURL, err)
+	}
+	if isLoopback(u.Hostname()) {
+		return nil
+	}
+	return fmt.Errorf("%s needs %s, but %w", what, u.Host, ErrOffline)
+}
+
+// isLoopback reports whether a host is the loopback interface: localhost, or a loopback IP address
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// transport refuses requests to hosts other than the loopback interface
+type transport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sends a request to the loopback interface, and fails any other
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := Check(req.Method+" "+req.URL.Redacted(), req.URL.String()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
\ No newline at end of file
@@ -0,0 +1,44 @@
+package offline
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	if err := Check("LLM enrichment with openai", "https://api.openai.com/v1"); err != nil {
+		t.Fatalf("Expected network access before offline mode is enabled but got %v", err)
+	}
+	Enable()
+	if !Enabled() || os.Getenv(EnvVar) != "1" {
+		t.Fatalf("Expected offline mode enabled, with %s set for plugins", EnvVar)
+	}
+
+	err := Check("LLM enrichment with openai", "https://api.openai.com/v1")
+	if !errors.Is(err, ErrOffline) || err.Error() != "LLM enrichment with openai needs api.openai.com, but network access is disabled by --offline" {
+		t.Errorf("Expected the request refused but got %v", err)
+	}
+	for _, allowed := range []string{"http://localhost:11434/v1", "http://127.0.0.1:8080", "http://[::1]:8080"} {
+		if err := Check("LLM enrichment with local", allowed); err != nil {
+			t.Errorf("Expected %s allowed as loopback but got %v", allowed, err)
+		}
+	}
+
+	// Clients on the default transport reach the loopback interface only
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected the loopback server reachable but got %v", err)
+	}
+	response.Body.Close()
+	if _, err := http.Get("https://example.com"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected the request refused but got %v", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,237 @@
+// Package onchain reads what a deployed EVM contract tells about itself over JSON-RPC: the name, symbol and decimals
+// of tokens, and the implementation and admin of EIP-1967 proxies
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/openhands/mcp-generator/internal/offline"
+	"golang.org/x/crypto/sha3"
+)
+
+// EIP-1967 storage slots of proxies: keccak256("eip1967.proxy.implementation") - 1 and keccak256("eip1967.proxy.admin") - 1
+const (
+	ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	AdminSlot          = "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"
+)
+
+// Selectors of the ERC-20 metadata functions
+const (
+	nameSelector     = "0x06fdde03"
+	symbolSelector   = "0x95d89b41"
+	decimalsSelector = "0x313ce567"
+)
+
+// Info is what a contract tells about itself; fields it does not tell are empty
+type Info struct {
+	// Token name, symbol and decimals, from name(), symbol() and decimals()
+	Name     string
+	Symbol   string
+	Decimals *int
+
+	// Implementation and admin of an EIP-1967 proxy, as checksummed addresses
+	Implementation string
+	Admin          string
+}
+
+// IsToken reports whether the contract told a token symbol or decimals
+func (i *Info) IsToken() bool {
+	return i.Symbol != "" || i.Decimals != nil
+}
+
+// IsProxy reports whether the contract is an EIP-1967 proxy
+func (i *Info) IsProxy() bool {
+	return i.Implementation != ""
+}
+
+// Options configure lookups
+type Options struct {
+	// HTTP client (default: one with a 10 second timeout)
+	Client *http.Client
+}
+
+// Lookup calls the token metadata functions of the contract at an address and reads its EIP-1967 slots
+// Calls that revert, as for contracts without the function, leave their field empty; failing to reach the RPC fails.
+func Lookup(ctx context.Context, rpcURL, address string, options Options) (*Info, error) {
+	if err := offline.Check("looking up "+address, rpcURL); err != nil {
+		return nil, err
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	c := &client{url: rpcURL, http: options.Client}
+
+	info := &Info{}
+	var err error
+	if info.Name, err = c.callString(ctx, address, nameSelector); err != nil {
+		return nil, err
+	}
+	if info.Symbol, err = c.callString(ctx, address, symbolSelector); err != nil {
+		return nil, err
+	}
+	word, err := c.call(ctx, "eth_call", map[string]string{"to": address, "data": decimalsSelector}, "latest")
+	if err != nil {
+		return nil, err
+	}
+	if decimals, ok := uint8Word(word); ok {
+		info.Decimals = &decimals
+	}
+	if info.Implementation, err = c.slotAddress(ctx, address, ImplementationSlot); err != nil {
+		return nil, err
+	}
+	if info.Admin, err = c.slotAddress(ctx, address, AdminSlot); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// client sends JSON-RPC requests to an RPC URL
+type client struct {
+	url  string
+	http *http.Client
+	id   int
+}
+
+// call sends a JSON-RPC request and returns its hex result, or nil if the node answered with an error (e.g. the call
+// reverted); failing to reach the node is an error
+func (c *client) call(ctx context.Context, method string, params ...interface{}) ([]byte, error) {
+	c.id++
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": c.id, "method": method, "params": params})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: HTTP %d: %s", method, resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+	var response struct {
+		Result string          `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("%s: invalid response: %w", method, err)
+	}
+	if len(response.Error) > 0 && string(response.Error) != "null" {
+		return nil, nil
+	}
+	result, err := hex.DecodeString(strings.TrimPrefix(response.Result, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid result %s", method, response.Result)
+	}
+	return result, nil
+}
+
+// callString calls a function returning a string, or a bytes32 as some older tokens do, returning "" if it reverts
+// or returns anything else
+func (c *client) callString(ctx context.Context, address, selector string) (string, error) {
+	result, err := c.call(ctx, "eth_call", map[string]string{"to": address, "data": selector}, "latest")
+	if err != nil {
+		return "", err
+	}
+	return decodeString(result), nil
+}
+
+// slotAddress reads the address stored in a storage slot of a contract, or "" if the slot is empty
+func (c *client) slotAddress(ctx context.Context, address, slot string) (string, error) {
+	word, err := c.call(ctx, "eth_getStorageAt", address, slot, "latest")
+	if err != nil || len(word) != 32 || isZero(word) {
+		return "", err
+	}
+	if !isZero(word[:12]) {
+		return "", nil
+	}
+	return checksumAddress(word[12:]), nil
+}
+
+// decodeString decodes an ABI-encoded string, or a zero-padded bytes32, returning "" if the result is neither or
+// not printable text
+func decodeString(result []byte) string {
+	var text []byte
+	switch {
+	case len(result) == 32:
+		text = bytes.TrimRight(result, "\x00")
+	case len(result) >= 64:
+		offset, ok := wordInt(result[:32])
+		if !ok || offset+32 > len(result) {
+			return ""
+		}
+		length, ok := wordInt(result[offset : offset+32])
+		if !ok || offset+32+length > len(result) {
+			return ""
+		}
+		text = result[offset+32 : offset+32+length]
+	}
+	if !utf8.Valid(text) {
+		return ""
+	}
+	for _, r := range string(text) {
+		if unicode.IsControl(r) {
+			return ""
+		}
+	}
+	return strings.TrimSpace(string(text))
+}
+
+// uint8Word decodes a 32-byte ABI word holding a uint8
+func uint8Word(word []byte) (int, bool) {
+	if len(word) != 32 || !isZero(word[:31]) {
+		return 0, false
+	}
+	return int(word[31]), true
+}
+
+// wordInt decodes a 32-byte ABI word holding an offset or length, which fit in 4 bytes
+func wordInt(word []byte) (int, bool) {
+	if !isZero(word[:28]) {
+		return 0, false
+	}
+	return int(word[28])<<24 | int(word[29])<<16 | int(word[30])<<8 | int(word[31]), true
+}
+
+// isZero reports whether bytes are all zero
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// checksumAddress returns the EIP-55 mixed-case checksummed form of a 20-byte address
+func checksumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		if c >= 'a' && digest[i/2]>>(4*uint(1-i%2))&0xf >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
\ No newline at end of file
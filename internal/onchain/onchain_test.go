@@ -0,0 +1,130 @@
+package onchain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	proxy          = "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+	implementation = "0x2Ce6311ddAE708829bc0784C967b7d77D19FD779"
+)
+
+// word returns a 32-byte ABI word holding a number
+func word(n int) string {
+	return fmt.Sprintf("%064x", n)
+}
+
+// abiString returns the ABI encoding of a string returned by a function
+func abiString(s string) string {
+	data := hex.EncodeToString([]byte(s))
+	if padding := len(data) % 64; padding != 0 {
+		data += strings.Repeat("0", 64-padding)
+	}
+	return "0x" + word(32) + word(len(s)) + data
+}
+
+// rpcServer answers eth_call and eth_getStorageAt requests with results by address and data or slot, and with an
+// execution reverted error otherwise
+func rpcServer(t *testing.T, results map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+		}
+		var key string
+		switch request.Method {
+		case "eth_call":
+			var call struct{ To, Data string }
+			json.Unmarshal(request.Params[0], &call)
+			key = call.To + " " + call.Data
+		case "eth_getStorageAt":
+			var address, slot string
+			json.Unmarshal(request.Params[0], &address)
+			json.Unmarshal(request.Params[1], &slot)
+			key = address + " " + slot
+		}
+		if result, ok := results[key]; ok {
+			fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": "%s"}`, request.ID, result)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "error": {"code": 3, "message": "execution reverted"}}`, request.ID)
+	}))
+}
+
+func TestLookup(t *testing.T) {
+	const legacy = "0x9f8f72aa9304c8b593d555f12ef6589cc3a579a2"
+	server := rpcServer(t, map[string]string{
+		proxy + " " + nameSelector:                                   abiString("USD Coin"),
+		proxy + " " + symbolSelector:                                 abiString("USDC"),
+		proxy + " " + decimalsSelector:                               "0x" + word(6),
+		proxy + " " + ImplementationSlot:                             "0x000000000000000000000000" + strings.ToLower(implementation[2:]),
+		proxy + " " + AdminSlot:                                      "0x" + word(0),
+		legacy + " " + nameSelector:                                  "0x4d616b6572000000000000000000000000000000000000000000000000000000",
+		legacy + " " + symbolSelector:                                "0x4d4b520000000000000000000000000000000000000000000000000000000000",
+		legacy + " " + decimalsSelector:                              "0x" + word(18),
+		"0x0000000000000000000000000000000000000001 " + nameSelector: "0x",
+	})
+	defer server.Close()
+
+	info, err := Lookup(context.Background(), server.URL, proxy, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "USD Coin" || info.Symbol != "USDC" || info.Decimals == nil || *info.Decimals != 6 || !info.IsToken() {
+		t.Errorf("Unexpected token metadata %+v", info)
+	}
+	if info.Implementation != implementation || info.Admin != "" || !info.IsProxy() {
+		t.Errorf("Unexpected proxy slots %+v", info)
+	}
+
+	// Older tokens return bytes32 names and symbols
+	if info, err = Lookup(context.Background(), server.URL, legacy, Options{}); err != nil || info.Name != "Maker" || info.Symbol != "MKR" || *info.Decimals != 18 || info.IsProxy() {
+		t.Errorf("Unexpected legacy token %+v (%v)", info, err)
+	}
+
+	// Contracts without the functions tell nothing, but are found
+	if info, err = Lookup(context.Background(), server.URL, "0x0000000000000000000000000000000000000001", Options{}); err != nil || *info != (Info{}) || info.IsToken() {
+		t.Errorf("Expected nothing but got %+v (%v)", info, err)
+	}
+
+	// Unreachable RPCs fail
+	server.Close()
+	if _, err := Lookup(context.Background(), server.URL, proxy, Options{}); err == nil {
+		t.Error("Expected an error from an unreachable RPC")
+	}
+}
+
+func TestDecodeString(t *testing.T) {
+	for result, expected := range map[string]string{
+		abiString("Wrapped Ether"):  "Wrapped Ether",
+		abiString(""):               "",
+		abiString("bad\x00name"):    "",
+		"0x" + word(32) + word(100): "",
+		"0x" + word(6):              "",
+	} {
+		data, _ := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+		if decoded := decodeString(data); decoded != expected {
+			t.Errorf("Expected %q but got %q", expected, decoded)
+		}
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	for _, address := range []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB"} {
+		raw, _ := hex.DecodeString(strings.ToLower(address[2:]))
+		if checksummed := checksumAddress(raw); checksummed != address {
+			t.Errorf("Expected %s but got %s", address, checksummed)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Tags grouping operations by the kind of tool they describe
+const (
+	// TagRead groups view and pure function tools
+	TagRead = "read"
+
+	// TagWrite groups transaction and simulation tools
+	TagWrite = "write"
+
+	// TagEvents groups event log tools
+	TagEvents = "events"
+)
+
+const (
+	jsonContent     = "application/json"
+	decimalPattern  = "^-?[0-9]+$"
+	hexPattern      = "^0x([0-9a-fA-F]{2})*$"
+	hashPattern     = "^0x[0-9a-fA-F]{64}$"
+	defaultVersion  = "1.0.0"
+	errorSchemaName = "Error"
+)
+
+// Document is the subset of an OpenAPI 3.1 document emitted for a contract
+type Document struct {
+	// OpenAPI version
+	OpenAPI string `json:"openapi"`
+
+	// API title, version and description
+	Info Info `json:"info"`
+
+	// Default JSON Schema dialect of the schemas
+	JSONSchemaDialect string `json:"jsonSchemaDialect"`
+
+	// Base URLs the operations are served under
+	Servers []Server `json:"servers,omitempty"`
+
+	// Operations by path
+	Paths map[string]*PathItem `json:"paths"`
+
+	// Schemas shared by operations
+	Components Components `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	// API title
+	Title string `json:"title"`
+
+	// API version
+	Version string `json:"version"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	// Base URL
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path; every tool is a POST operation
+type PathItem struct {
+	// Operation invoking the tool
+	Post *Operation `json:"post"`
+}
+
+// Operation describes one tool
+type Operation struct {
+	// Tool name
+	OperationID string `json:"operationId"`
+
+	// Short summary
+	Summary string `json:"summary,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Kind of tool (read, write, events)
+	Tags []string `json:"tags,omitempty"`
+
+	// Whether the tool's function or event is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Tool arguments
+	RequestBody *RequestBody `json:"requestBody"`
+
+	// Responses by status code
+	Responses map[string]*Response `json:"responses"`
+}
+
+// RequestBody describes the tool arguments
+type RequestBody struct {
+	// Whether a body must be sent
+	Required bool `json:"required"`
+
+	// Body schema by media type
+	Content map[string]MediaType `json:"content"`
+}
+
+// Response describes a tool result
+type Response struct {
+	// Human-readable description
+	Description string `json:"description"`
+
+	// Body schema by media type
+	Content map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	// Body schema
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// Components holds reusable schemas
+type Components struct {
+	// Schemas by name
+	Schemas map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// Options selects the operations of a generated document
+type Options struct {
+	// API version (default: 1.0.0)
+	Version string
+
+	// Base URL of the API, if known
+	ServerURL string
+
+	// Whether write functions are described as transaction and simulation operations
+	EnableWrites bool
+}
+
+// Generate builds an OpenAPI document describing the same operations as the generated MCP tools
+// Every tool is a POST operation at /tools/<name> taking the tool arguments as its JSON body, flagged as deprecated
+// when its function or event is.
+func Generate(contract *ir.ContractIR, options Options) *Document {
+	contract = contract.Visible()
+	version := options.Version
+	if version == "" {
+		version = defaultVersion
+	}
+	description := contract.Metadata.Description
+	if description == "" {
+		description = fmt.Sprintf("Operations of the %s contract, mirroring the tools of its MCP server", contract.Metadata.Name)
+	}
+
+	doc := &Document{
+		OpenAPI:           Version,
+		Info:              Info{Title: contract.Metadata.Name + " API", Version: version, Description: description},
+		JSONSchemaDialect: jsonschema.Draft,
+		Paths:             map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*jsonschema.Schema{
+			errorSchemaName: objectOf(jsonschema.Property{Name: "error", Schema: &jsonschema.Schema{Type: "string", Description: "What went wrong"}}),
+		}},
+	}
+	if options.ServerURL != "" {
+		doc.Servers = []Server{{URL: options.ServerURL}}
+	}
+
+	for _, f := range contract.Functions {
+		if f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		switch f.StateMutability {
+		case ir.View, ir.Pure:
+			doc.add(f.Name, TagRead, describe(f), jsonschema.ForViewInputs(f), "Function result", ResultSchema(f)).Deprecated = f.Deprecated
+		case ir.Payable, ir.Nonpayable:
+			if !options.EnableWrites {
+				continue
+			}
+			doc.add(f.Name, TagWrite, describe(f), jsonschema.ForTransactionInputs(f), "Transaction request, and its hash if sent", TransactionSchema()).Deprecated = f.Deprecated
+			doc.add("simulate"+title(f.Name), TagWrite, fmt.Sprintf("Dry-run %s without sending a transaction, optionally from another account and with state overrides", f.Name), jsonschema.ForSimulationInputs(f), "Simulated call", SimulationSchema()).Deprecated = f.Deprecated
+		}
+	}
+
+	for _, e := range contract.Events {
+		if e.EVM().Anonymous {
+			continue
+		}
+		doc.add("get"+e.Name+"Logs", TagEvents, fmt.Sprintf("Query decoded %s logs", e.Name), jsonschema.ForEventFilter(e), "Page of decoded logs", LogsSchema(e)).Deprecated = e.Deprecated
+	}
+
+	return doc
+}
+
+// add describes a tool as a POST operation and returns it
+func (d *Document) add(name, tag, description string, input *jsonschema.Schema, outputDescription string, output *jsonschema.Schema) *Operation {
+	// The document sets the dialect, so request schemas do not repeat it
+	body := *input
+	body.Schema = ""
+
+	operation := &Operation{
+		OperationID: name,
+		Summary:     name,
+		Description: description,
+		Tags:        []string{tag},
+		RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{jsonContent: {Schema: &body}}},
+		Responses: map[string]*Response{
+			"200": {Description: outputDescription, Content: map[string]MediaType{jsonContent: {Schema: output}}},
+			"400": {Description: "Invalid arguments, or the call reverted", Content: map[string]MediaType{jsonContent: {Schema: &jsonschema.Schema{Ref: "#/components/schemas/" + errorSchemaName}}}},
+		},
+	}
+	d.Paths["/tools/"+name] = &PathItem{Post: operation}
+	return operation
+}
@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// testContract has a view function, a write function and a named and an anonymous event
+func testContract() *ir.ContractIR {
+	return &ir.ContractIR{
+		Metadata: ir.ContractMetadata{Name: "TestToken"},
+		Functions: []ir.Function{
+			{
+				Name:            "balanceOf",
+				StateMutability: ir.View,
+				Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address"}}},
+				Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: 6}}}},
+			},
+			{
+				Name:            "position",
+				StateMutability: ir.View,
+				Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "tuple", Components: []ir.Parameter{
+					{Name: "owner", Type: ir.ParameterType{BaseType: "address"}},
+					{Name: "size", Type: ir.ParameterType{BaseType: "uint64"}},
+				}}}},
+			},
+			{
+				Name:            "transfer",
+				StateMutability: ir.Nonpayable,
+				Inputs: []ir.Parameter{
+					{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+					{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+				},
+			},
+			{Name: "", IsConstructor: true, StateMutability: ir.Nonpayable},
+		},
+		Events: []ir.Event{
+			{
+				Name: "Registered",
+				Parameters: []ir.EventParameter{
+					{Name: "name", Type: ir.ParameterType{BaseType: "string"}, Indexed: true},
+					{Name: "owner", Type: ir.ParameterType{BaseType: "address"}},
+				},
+			},
+			{Name: "Anonymous", ChainData: map[string]interface{}{"anonymous": true}},
+		},
+	}
+}
+
+// marshal encodes a value as compact JSON
+func marshal(t *testing.T, value interface{}) string {
+	t.Helper()
+	content, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	return string(content)
+}
+
+func TestGenerate(t *testing.T) {
+	doc := Generate(testContract(), Options{ServerURL: "https://api.example.com"})
+	if doc.OpenAPI != Version || doc.Info.Title != "TestToken API" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Unexpected document header: %s %+v", doc.OpenAPI, doc.Info)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("Expected the server URL but got %+v", doc.Servers)
+	}
+
+	// Write tools and anonymous events have no MCP tool without the matching options
+	expected := []string{"/tools/balanceOf", "/tools/position", "/tools/getRegisteredLogs"}
+	if len(doc.Paths) != len(expected) {
+		t.Errorf("Expected %d paths but got %d", len(expected), len(doc.Paths))
+	}
+	for _, path := range expected {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("Expected path %s not found", path)
+		}
+	}
+
+	operation := doc.Paths["/tools/balanceOf"].Post
+	if operation.OperationID != "balanceOf" || operation.Tags[0] != TagRead {
+		t.Errorf("Unexpected operation %+v", operation)
+	}
+	if got := operation.RequestBody.Content[jsonContent].Schema; got.Schema != "" || got.Properties.Get("account") == nil {
+		t.Errorf("Unexpected request schema %s", marshal(t, got))
+	}
+	if got := marshal(t, operation.Responses["200"].Content[jsonContent].Schema); got != `{"type":"object","properties":{"formatted":{"type":"string","description":"Amount in token units"},"raw":{"type":"string","description":"Amount in the token's smallest unit","pattern":"^-?[0-9]+$"},"decimals":{"type":"integer","description":"Decimals of the token"}},"required":["formatted","raw","decimals"]}` {
+		t.Errorf("Unexpected amount response schema %s", got)
+	}
+	if got := marshal(t, operation.Responses["400"].Content[jsonContent].Schema); got != `{"$ref":"#/components/schemas/Error"}` {
+		t.Errorf("Unexpected error response schema %s", got)
+	}
+	if _, ok := doc.Components.Schemas[errorSchemaName]; !ok {
+		t.Errorf("Error schema not defined")
+	}
+}
+
+func TestGenerateWrites(t *testing.T) {
+	doc := Generate(testContract(), Options{EnableWrites: true})
+	for _, path := range []string{"/tools/transfer", "/tools/simulateTransfer"} {
+		item, ok := doc.Paths[path]
+		if !ok {
+			t.Fatalf("Expected path %s not found", path)
+		}
+		if item.Post.Tags[0] != TagWrite {
+			t.Errorf("Expected %s to be tagged %s", path, TagWrite)
+		}
+	}
+	if doc.Paths["/tools/transfer"].Post.RequestBody.Content[jsonContent].Schema.Properties.Get("send") == nil {
+		t.Errorf("Transaction operation does not accept send")
+	}
+	if doc.Paths["/tools/simulateTransfer"].Post.RequestBody.Content[jsonContent].Schema.Properties.Get("stateOverrides") == nil {
+		t.Errorf("Simulation operation does not accept stateOverrides")
+	}
+}
+
+func TestResultSchemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			"Tuple",
+			"/tools/position",
+			`{"type":"array","description":"tuple (owner, size) as an array","prefixItems":[{"type":"string","description":"owner (0x-prefixed 20-byte hex address)","pattern":"^0x[0-9a-fA-F]{40}$"},{"type":"string","description":"size (uint64 as a decimal string)","pattern":"^-?[0-9]+$"}],"minItems":2,"maxItems":2}`,
+		},
+		{
+			"Logs",
+			"/tools/getRegisteredLogs",
+			`"args":{"type":"object","properties":{"name":{"type":"object","properties":{"hash":`,
+		},
+	}
+
+	doc := Generate(testContract(), Options{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := marshal(t, doc.Paths[tt.path].Post.Responses["200"].Content[jsonContent].Schema)
+			if !strings.Contains(got, tt.expected) {
+				t.Errorf("Expected schema containing %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
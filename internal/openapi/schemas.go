@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// describe returns a function's description, or a generic one
+func describe(f ir.Function) string {
+	if f.Description != "" {
+		return f.Description
+	}
+	return fmt.Sprintf("Call %s", f.Name)
+}
+
+// title uppercases the first character of a tool name
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// objectOf builds an object schema of the given properties, all required
+func objectOf(properties ...jsonschema.Property) *jsonschema.Schema {
+	schema := &jsonschema.Schema{Type: "object", Properties: properties, Required: []string{}}
+	for _, property := range properties {
+		schema.Required = append(schema.Required, property.Name)
+	}
+	return schema
+}
+
+// optional drops properties from the required ones of an object schema
+func optional(schema *jsonschema.Schema, names ...string) *jsonschema.Schema {
+	required := []string{}
+	for _, name := range schema.Required {
+		keep := true
+		for _, n := range names {
+			keep = keep && n != name
+		}
+		if keep {
+			required = append(required, name)
+		}
+	}
+	schema.Required = required
+	return schema
+}
+
+// integerSchema is a JSON integer
+func integerSchema(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "integer", Description: description}
+}
+
+// stringSchema is a JSON string matching a pattern
+func stringSchema(pattern, description string) *jsonschema.Schema {
+	return &jsonschema.Schema{Type: "string", Pattern: pattern, Description: description}
+}
+
+// ResultSchema describes the JSON a view function tool responds with
+// Single return values are unwrapped, multiple ones are returned as an array
+func ResultSchema(f ir.Function) *jsonschema.Schema {
+	paginated := jsonschema.IsPaginated(f)
+	if len(f.Outputs) == 1 {
+		return outputSchema(f.Outputs[0], paginated)
+	}
+
+	size := len(f.Outputs)
+	schema := &jsonschema.Schema{Type: "array", MinItems: &size, MaxItems: &size, PrefixItems: []*jsonschema.Schema{}}
+	for _, output := range f.Outputs {
+		schema.PrefixItems = append(schema.PrefixItems, outputSchema(output, paginated))
+	}
+	return schema
+}
+
+// outputSchema describes a return value as a view function tool reports it
+// Token amounts are reported in token units alongside their raw value, and dynamic arrays of paginated functions one page at a time
+func outputSchema(p ir.Parameter, paginated bool) *jsonschema.Schema {
+	schema := valueSchema(p.Type)
+	if _, _, ok := p.Type.AmountDecimals(); ok {
+		schema = objectOf(
+			jsonschema.Property{Name: "formatted", Schema: stringSchema("", "Amount in token units")},
+			jsonschema.Property{Name: "raw", Schema: stringSchema(decimalPattern, "Amount in the token's smallest unit")},
+			jsonschema.Property{Name: "decimals", Schema: integerSchema("Decimals of the token")},
+		)
+	} else if paginated && p.Type.IsArray && p.Type.ArraySize == 0 {
+		schema = optional(objectOf(
+			jsonschema.Property{Name: "items", Schema: schema},
+			jsonschema.Property{Name: "offset", Schema: integerSchema("Index of the first returned element")},
+			jsonschema.Property{Name: "total", Schema: integerSchema("Number of elements of the whole array")},
+			jsonschema.Property{Name: "nextOffset", Schema: integerSchema("offset of the next page, absent on the last page")},
+		), "nextOffset")
+	}
+	if unit := ir.UnitDescription(p.Unit); unit != "" {
+		schema.Description = strings.TrimPrefix(schema.Description+"; "+unit, "; ")
+	}
+	if p.Description != "" {
+		schema.Description = strings.TrimSpace(p.Description + " " + parenthesize(schema.Description))
+	}
+	return schema
+}
+
+// valueSchema describes a decoded ABI value as the generated server encodes it
+// Integers are decimal strings and tuples arrays of their components in order
+func valueSchema(t ir.ParameterType) *jsonschema.Schema {
+	if t.IsArray {
+		element := t
+		element.IsArray = false
+		element.ArraySize = 0
+		schema := &jsonschema.Schema{Type: "array", Items: valueSchema(element)}
+		if t.ArraySize > 0 {
+			size := t.ArraySize
+			schema.MinItems = &size
+			schema.MaxItems = &size
+		}
+		return schema
+	}
+
+	if len(t.Components) > 0 {
+		size := len(t.Components)
+		schema := &jsonschema.Schema{Type: "array", MinItems: &size, MaxItems: &size, PrefixItems: []*jsonschema.Schema{}}
+		names := make([]string, size)
+		for i, component := range t.Components {
+			item := valueSchema(component.Type)
+			names[i] = component.Name
+			if component.Name != "" {
+				item.Description = strings.TrimSpace(component.Name + " " + parenthesize(item.Description))
+			}
+			schema.PrefixItems = append(schema.PrefixItems, item)
+		}
+		schema.Description = fmt.Sprintf("tuple (%s) as an array", strings.Join(names, ", "))
+		return schema
+	}
+
+	if base := t.BaseType; strings.HasPrefix(base, "uint") || strings.HasPrefix(base, "int") {
+		return stringSchema(decimalPattern, base+" as a decimal string")
+	}
+	// Scalars other than integers are encoded as they are given
+	return jsonschema.FromParameterType(ir.ParameterType{BaseType: t.BaseType})
+}
+
+// TransactionSchema describes the JSON a write function tool responds with
+func TransactionSchema() *jsonschema.Schema {
+	return optional(objectOf(
+		jsonschema.Property{Name: "to", Schema: valueSchema(ir.ParameterType{BaseType: "address"})},
+		jsonschema.Property{Name: "from", Schema: valueSchema(ir.ParameterType{BaseType: "address"})},
+		jsonschema.Property{Name: "data", Schema: stringSchema(hexPattern, "Encoded calldata")},
+		jsonschema.Property{Name: "value", Schema: stringSchema(decimalPattern, "Native currency sent, in wei")},
+		jsonschema.Property{Name: "estimatedGas", Schema: stringSchema(decimalPattern, "Estimated gas")},
+		jsonschema.Property{Name: "estimateGasError", Schema: stringSchema("", "Why gas could not be estimated, e.g. the call would revert")},
+		jsonschema.Property{Name: "fees", Schema: &jsonschema.Schema{Type: "object", Description: "Suggested fees and the total cost of the transaction"}},
+		jsonschema.Property{Name: "feesError", Schema: stringSchema("", "Why fees could not be suggested")},
+		jsonschema.Property{Name: "hash", Schema: stringSchema(hashPattern, "Hash of the sent transaction, if send was set")},
+	), "from", "estimatedGas", "estimateGasError", "fees", "feesError", "hash")
+}
+
+// SimulationSchema describes the JSON a simulation tool responds with
+func SimulationSchema() *jsonschema.Schema {
+	return optional(objectOf(
+		jsonschema.Property{Name: "to", Schema: valueSchema(ir.ParameterType{BaseType: "address"})},
+		jsonschema.Property{Name: "from", Schema: valueSchema(ir.ParameterType{BaseType: "address"})},
+		jsonschema.Property{Name: "data", Schema: stringSchema(hexPattern, "Encoded calldata")},
+		jsonschema.Property{Name: "value", Schema: stringSchema(decimalPattern, "Native currency sent, in wei")},
+		jsonschema.Property{Name: "success", Schema: &jsonschema.Schema{Type: "boolean", Description: "Whether the call succeeded"}},
+		jsonschema.Property{Name: "returnData", Schema: stringSchema(hexPattern, "Raw return data of a successful call")},
+		jsonschema.Property{Name: "result", Schema: &jsonschema.Schema{Type: "array", Description: "Decoded return values of a successful call"}},
+		jsonschema.Property{Name: "revert", Schema: &jsonschema.Schema{Type: "object", Description: "Revert data, reason and decoded custom error of a failed call"}},
+	), "from", "returnData", "result", "revert")
+}
+
+// LogsSchema describes the JSON an event log tool responds with
+// Indexed dynamic values are only recoverable as their hash
+func LogsSchema(e ir.Event) *jsonschema.Schema {
+	args := objectOf()
+	for i, p := range e.Parameters {
+		value := valueSchema(p.Type)
+		if p.Indexed && (p.Type.IsArray || len(p.Type.Components) > 0 || p.Type.BaseType == "string" || p.Type.BaseType == "bytes") {
+			value = objectOf(jsonschema.Property{Name: "hash", Schema: stringSchema(hashPattern, "Keccak-256 hash of the indexed value")})
+		}
+		name := jsonschema.EventParameterName(p, i)
+		args.Properties = append(args.Properties, jsonschema.Property{Name: name, Schema: value})
+		args.Required = append(args.Required, name)
+	}
+
+	log := objectOf(
+		jsonschema.Property{Name: "blockNumber", Schema: integerSchema("")},
+		jsonschema.Property{Name: "blockHash", Schema: stringSchema(hashPattern, "")},
+		jsonschema.Property{Name: "transactionHash", Schema: stringSchema(hashPattern, "")},
+		jsonschema.Property{Name: "logIndex", Schema: integerSchema("")},
+		jsonschema.Property{Name: "args", Schema: args},
+	)
+	return optional(objectOf(
+		jsonschema.Property{Name: "fromBlock", Schema: integerSchema("First searched block")},
+		jsonschema.Property{Name: "toBlock", Schema: integerSchema("Last searched block")},
+		jsonschema.Property{Name: "logs", Schema: &jsonschema.Schema{Type: "array", Items: log}},
+		jsonschema.Property{Name: "nextCursor", Schema: stringSchema("", "cursor of the next page, absent on the last page")},
+	), "nextCursor")
+}
+
+// parenthesize wraps a non-empty string in parentheses
+func parenthesize(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(" + s + ")"
+}
\ No newline at end of file
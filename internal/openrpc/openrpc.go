@@ -0,0 +1,233 @@
+package openrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+	"github.com/openhands/mcp-generator/internal/openapi"
+)
+
+// Version is the OpenRPC version of generated documents
+const Version = "1.3.2"
+
+// JSON-RPC error codes of generated methods
+const (
+	// CodeInvalidParams is returned for arguments not matching a method's parameters
+	CodeInvalidParams = -32602
+
+	// CodeExecutionReverted is returned when the contract call reverts, as Ethereum nodes report it
+	CodeExecutionReverted = 3
+)
+
+const (
+	defaultVersion = "1.0.0"
+	byName         = "by-name"
+)
+
+// Document is the subset of an OpenRPC document emitted for a contract
+type Document struct {
+	// OpenRPC version
+	OpenRPC string `json:"openrpc"`
+
+	// API title, version and description
+	Info Info `json:"info"`
+
+	// Endpoints the methods are served under
+	Servers []Server `json:"servers,omitempty"`
+
+	// JSON-RPC methods, one per tool
+	Methods []*Method `json:"methods"`
+}
+
+// Info describes the API
+type Info struct {
+	// API title
+	Title string `json:"title"`
+
+	// API version
+	Version string `json:"version"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+}
+
+// Server is an endpoint of the API
+type Server struct {
+	// Server name
+	Name string `json:"name"`
+
+	// Endpoint URL
+	URL string `json:"url"`
+}
+
+// Method describes one tool as a JSON-RPC method
+type Method struct {
+	// Method name, the tool name
+	Name string `json:"name"`
+
+	// Short summary
+	Summary string `json:"summary,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Kind of tool (read, write, events)
+	Tags []Tag `json:"tags,omitempty"`
+
+	// Parameters are passed by name, as the tool arguments object
+	ParamStructure string `json:"paramStructure"`
+
+	// Tool arguments
+	Params []*ContentDescriptor `json:"params"`
+
+	// Tool result
+	Result *ContentDescriptor `json:"result"`
+
+	// Errors the method may return
+	Errors []Error `json:"errors,omitempty"`
+
+	// Whether the tool's function or event is deprecated
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// Tag groups methods
+type Tag struct {
+	// Tag name
+	Name string `json:"name"`
+}
+
+// ContentDescriptor describes a parameter or result
+type ContentDescriptor struct {
+	// Parameter or result name
+	Name string `json:"name"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Whether the parameter must be given
+	Required bool `json:"required,omitempty"`
+
+	// Value schema
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// Error is a JSON-RPC error a method may return
+type Error struct {
+	// Error code
+	Code int `json:"code"`
+
+	// Error message
+	Message string `json:"message"`
+}
+
+// Options selects the methods of a generated document
+type Options struct {
+	// API version (default: 1.0.0)
+	Version string
+
+	// JSON-RPC endpoint, if known
+	ServerURL string
+
+	// Whether write functions are described as transaction and simulation methods
+	EnableWrites bool
+}
+
+// Generate builds an OpenRPC document describing the same operations as the generated MCP tools
+// Every tool is a JSON-RPC method of the same name taking the tool arguments as named parameters; methods of
+// deprecated functions and events are deprecated too.
+func Generate(contract *ir.ContractIR, options Options) *Document {
+	contract = contract.Visible()
+	version := options.Version
+	if version == "" {
+		version = defaultVersion
+	}
+	description := contract.Metadata.Description
+	if description == "" {
+		description = fmt.Sprintf("Methods of the %s contract, mirroring the tools of its MCP server", contract.Metadata.Name)
+	}
+
+	doc := &Document{
+		OpenRPC: Version,
+		Info:    Info{Title: contract.Metadata.Name + " API", Version: version, Description: description},
+		Methods: []*Method{},
+	}
+	if options.ServerURL != "" {
+		doc.Servers = []Server{{Name: contract.Metadata.Name, URL: options.ServerURL}}
+	}
+
+	for _, f := range contract.Functions {
+		if f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		switch f.StateMutability {
+		case ir.View, ir.Pure:
+			doc.add(f.Name, openapi.TagRead, describe(f), jsonschema.ForViewInputs(f), openapi.ResultSchema(f)).Deprecated = f.Deprecated
+		case ir.Payable, ir.Nonpayable:
+			if !options.EnableWrites {
+				continue
+			}
+			doc.add(f.Name, openapi.TagWrite, describe(f), jsonschema.ForTransactionInputs(f), openapi.TransactionSchema()).Deprecated = f.Deprecated
+			doc.add("simulate"+title(f.Name), openapi.TagWrite, fmt.Sprintf("Dry-run %s without sending a transaction, optionally from another account and with state overrides", f.Name), jsonschema.ForSimulationInputs(f), openapi.SimulationSchema()).Deprecated = f.Deprecated
+		}
+	}
+
+	for _, e := range contract.Events {
+		if e.EVM().Anonymous {
+			continue
+		}
+		doc.add("get"+e.Name+"Logs", openapi.TagEvents, fmt.Sprintf("Query decoded %s logs", e.Name), jsonschema.ForEventFilter(e), openapi.LogsSchema(e)).Deprecated = e.Deprecated
+	}
+
+	return doc
+}
+
+// add describes a tool as a method, its input schema's properties becoming named parameters, and returns it
+func (d *Document) add(name, tag, description string, input, output *jsonschema.Schema) *Method {
+	required := map[string]bool{}
+	for _, property := range input.Required {
+		required[property] = true
+	}
+	params := []*ContentDescriptor{}
+	for _, property := range input.Properties {
+		params = append(params, &ContentDescriptor{
+			Name:        property.Name,
+			Description: property.Schema.Description,
+			Required:    required[property.Name],
+			Schema:      property.Schema,
+		})
+	}
+
+	method := &Method{
+		Name:           name,
+		Summary:        name,
+		Description:    description,
+		Tags:           []Tag{{Name: tag}},
+		ParamStructure: byName,
+		Params:         params,
+		Result:         &ContentDescriptor{Name: name + "Result", Schema: output},
+		Errors: []Error{
+			{Code: CodeInvalidParams, Message: "Invalid arguments"},
+			{Code: CodeExecutionReverted, Message: "Execution reverted"},
+		},
+	}
+	d.Methods = append(d.Methods, method)
+	return method
+}
+
+// describe returns a function's description, or a generic one
+func describe(f ir.Function) string {
+	if f.Description != "" {
+		return f.Description
+	}
+	return fmt.Sprintf("Call %s", f.Name)
+}
+
+// title uppercases the first character of a tool name
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
\ No newline at end of file
@@ -0,0 +1,109 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/openapi"
+)
+
+// testContract has a view function, a write function, a hidden function and a named and an anonymous event
+func testContract() *ir.ContractIR {
+	return &ir.ContractIR{
+		Metadata: ir.ContractMetadata{Name: "TestToken"},
+		Functions: []ir.Function{
+			{
+				Name:            "balanceOf",
+				Description:     "Balance of an account",
+				StateMutability: ir.View,
+				Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address"}}},
+				Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}},
+			},
+			{
+				Name:            "transfer",
+				StateMutability: ir.Nonpayable,
+				Deprecated:      true,
+				Inputs: []ir.Parameter{
+					{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+					{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+				},
+			},
+			{Name: "rescue", Hidden: true, StateMutability: ir.View},
+			{Name: "", IsConstructor: true, StateMutability: ir.Nonpayable},
+		},
+		Events: []ir.Event{
+			{Name: "Registered", Parameters: []ir.EventParameter{{Name: "owner", Type: ir.ParameterType{BaseType: "address"}, Indexed: true}}},
+			{Name: "Anonymous", ChainData: map[string]interface{}{"anonymous": true}},
+		},
+	}
+}
+
+// names returns the names of a document's methods
+func names(doc *Document) []string {
+	names := []string{}
+	for _, method := range doc.Methods {
+		names = append(names, method.Name)
+	}
+	return names
+}
+
+func TestGenerate(t *testing.T) {
+	doc := Generate(testContract(), Options{ServerURL: "https://rpc.example.com"})
+	if doc.OpenRPC != Version || doc.Info.Title != "TestToken API" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Unexpected document header: %s %+v", doc.OpenRPC, doc.Info)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://rpc.example.com" {
+		t.Errorf("Expected the server URL but got %+v", doc.Servers)
+	}
+
+	// Write methods and anonymous events have no MCP tool without the matching options
+	if got := strings.Join(names(doc), ","); got != "balanceOf,getRegisteredLogs" {
+		t.Errorf("Unexpected methods %s", got)
+	}
+
+	method := doc.Methods[0]
+	if method.Description != "Balance of an account" || method.Tags[0].Name != openapi.TagRead || method.ParamStructure != "by-name" {
+		t.Errorf("Unexpected method %+v", method)
+	}
+	if len(method.Params) != 1 || method.Params[0].Name != "account" || !method.Params[0].Required || method.Params[0].Schema.Pattern == "" {
+		t.Errorf("Unexpected params %+v", method.Params)
+	}
+	content, err := json.Marshal(method.Result)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if got := string(content); got != `{"name":"balanceOfResult","schema":{"type":"string","description":"uint256 as a decimal string","pattern":"^-?[0-9]+$"}}` {
+		t.Errorf("Unexpected result %s", got)
+	}
+	if len(method.Errors) != 2 || method.Errors[1].Code != CodeExecutionReverted {
+		t.Errorf("Unexpected errors %+v", method.Errors)
+	}
+
+	// Event filters are optional
+	for _, param := range doc.Methods[1].Params {
+		if param.Required {
+			t.Errorf("Expected event filter param %s to be optional", param.Name)
+		}
+	}
+}
+
+func TestGenerateWrites(t *testing.T) {
+	doc := Generate(testContract(), Options{EnableWrites: true})
+	if got := strings.Join(names(doc), ","); got != "balanceOf,transfer,simulateTransfer,getRegisteredLogs" {
+		t.Fatalf("Unexpected methods %s", got)
+	}
+	transfer, simulate := doc.Methods[1], doc.Methods[2]
+	if !transfer.Deprecated || !simulate.Deprecated || transfer.Tags[0].Name != openapi.TagWrite {
+		t.Errorf("Expected deprecated write methods, got %+v and %+v", transfer, simulate)
+	}
+
+	params := map[string]*ContentDescriptor{}
+	for _, param := range transfer.Params {
+		params[param.Name] = param
+	}
+	if params["to"] == nil || !params["to"].Required || params["send"] == nil || params["send"].Required {
+		t.Errorf("Unexpected transaction params %+v", transfer.Params)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Reasons existing files of an output directory conflict with the generated files
+const (
+	// ConflictModified files were generated, then edited outside their protected regions
+	ConflictModified = "modified"
+
+	// ConflictUntracked files were not generated, as far as the directory's lock file tells; without a lock file,
+	// every existing file is untracked
+	ConflictUntracked = "untracked"
+)
+
+// Markers of the hunks of merged files, as git writes them: the current lines, then the generated ones
+const (
+	MergeCurrent   = "<<<<<<< current"
+	MergeSeparator = "======="
+	MergeGenerated = ">>>>>>> generated"
+)
+
+// Conflict is an existing file of an output directory that writing the generated files would clobber
+type Conflict struct {
+	// Slash-separated path of the file in the project
+	Path string `json:"path"`
+
+	// Why the file conflicts: modified or untracked
+	Reason string `json:"reason"`
+}
+
+// Conflicts returns the files of an output directory that writing the generated files would clobber, in path order:
+// files the lock file of the previous generation lists that were modified outside their protected regions since,
+// and files it does not list, which users added. Files that already have their generated content, and the lock file
+// itself, never conflict.
+func Conflicts(files map[string][]byte, dir string, lock *Lock) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, path := range Paths(files) {
+		if path == LockFile {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if bytes.Equal(current, files[path]) {
+			continue
+		}
+		switch {
+		case lock == nil || lock.Files[path] == "":
+			conflicts = append(conflicts, Conflict{Path: path, Reason: ConflictUntracked})
+		case lock.Modified(path, current):
+			conflicts = append(conflicts, Conflict{Path: path, Reason: ConflictModified})
+		}
+	}
+	return conflicts, nil
+}
+
+// Merge merges the generated content of the conflicting files into their current content in the output directory:
+// lines both have are kept once, and where they differ, the current and generated lines are marked as git marks
+// merge conflicts. It returns the files with conflict hunks, and the binary files, which cannot be merged and are
+// kept as they are.
+func Merge(files map[string][]byte, dir string, conflicts []Conflict) (merged, kept []string, err error) {
+	for _, conflict := range conflicts {
+		current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(conflict.Path)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", conflict.Path, err)
+		}
+		if bytes.IndexByte(current, 0) >= 0 || bytes.IndexByte(files[conflict.Path], 0) >= 0 {
+			files[conflict.Path] = current
+			kept = append(kept, conflict.Path)
+			continue
+		}
+		content, hunks := mergeContent(current, files[conflict.Path])
+		files[conflict.Path] = content
+		if hunks > 0 {
+			merged = append(merged, conflict.Path)
+		}
+	}
+	return merged, kept, nil
+}
+
+// mergeContent merges two versions of a text file line by line, marking the hunks where they differ, and returns the
+// merged content with the number of hunks
+func mergeContent(current, generated []byte) ([]byte, int) {
+	a, b := splitLines(current), splitLines(generated)
+	var merged bytes.Buffer
+	hunks := 0
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		if op.Tag == 'e' {
+			merged.WriteString(strings.Join(a[op.I1:op.I2], ""))
+			continue
+		}
+		hunks++
+		merged.WriteString(MergeCurrent + "\n")
+		writeLines(&merged, a[op.I1:op.I2])
+		merged.WriteString(MergeSeparator + "\n")
+		writeLines(&merged, b[op.J1:op.J2])
+		merged.WriteString(MergeGenerated + "\n")
+	}
+	return merged.Bytes(), hunks
+}
+
+// splitLines splits content into lines, each with its line ending except a last line without one
+func splitLines(content []byte) []string {
+	result := strings.SplitAfter(string(content), "\n")
+	if result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// writeLines writes the lines of a hunk, ending the last one with a line ending if it has none, as a marker follows
+func writeLines(buf *bytes.Buffer, lines []string) {
+	for _, line := range lines {
+		buf.WriteString(line)
+	}
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		buf.WriteByte('\n')
+	}
+}
\ No newline at end of file
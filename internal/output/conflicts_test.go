@@ -0,0 +1,91 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConflicts(t *testing.T) {
+	generated := map[string][]byte{
+		"src/server.ts": []byte("main();\n// mcpgen:begin setup\n// mcpgen:end setup\n"),
+		"README.md":     []byte("# Server\n"),
+		"package.json":  []byte("{}\n"),
+	}
+	lock := &Lock{LockVersion: LockVersion}
+	lock.SetFiles(generated)
+
+	dir := t.TempDir()
+	if err := WriteDir(generated, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	// Code inside protected regions belongs to users, so only the README's edit is a modification
+	edits := map[string][]byte{
+		"src/server.ts": []byte("main();\n// mcpgen:begin setup\nsetup();\n// mcpgen:end setup\n"),
+		"README.md":     []byte("# My server\n"),
+		"src/custom.ts": []byte("// user file\n"),
+	}
+	if err := WriteDir(edits, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"src/server.ts": []byte("main(v2);\n// mcpgen:begin setup\n// mcpgen:end setup\n"),
+		"README.md":     []byte("# Server v2\n"),
+		"package.json":  []byte("{\"v\": 2}\n"),
+		"src/custom.ts": []byte("// generated\n"),
+	}
+	conflicts, err := Conflicts(files, dir, lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Conflict{{"README.md", ConflictModified}, {"src/custom.ts", ConflictUntracked}}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("Expected conflicts %v but got %v", expected, conflicts)
+	}
+	if modified, _, _ := lock.Changes(dir); !reflect.DeepEqual(modified, []string{"README.md"}) {
+		t.Errorf("Expected only the README modified but got %v", modified)
+	}
+
+	// Without a lock file, every existing file that would change conflicts
+	if conflicts, _ := Conflicts(files, dir, nil); len(conflicts) != 4 {
+		t.Errorf("Expected 4 untracked conflicts but got %v", conflicts)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	current := map[string][]byte{
+		"README.md": []byte("# Server\n\nMy notes\n\n## Usage\n"),
+		"logo.png":  {0x89, 'P', 'N', 'G', 0},
+		"unchanged": []byte("same"),
+	}
+	if err := WriteDir(current, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"README.md": []byte("# Server v2\n\n## Usage\n"),
+		"logo.png":  {0x89, 'P', 'N', 'G', 0, 1},
+		"unchanged": []byte("same"),
+	}
+	conflicts := []Conflict{{"README.md", ConflictModified}, {"logo.png", ConflictUntracked}}
+	merged, kept, err := Merge(files, dir, conflicts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(merged, []string{"README.md"}) || !reflect.DeepEqual(kept, []string{"logo.png"}) {
+		t.Errorf("Expected the README merged and the logo kept but got %v and %v", merged, kept)
+	}
+	expected := "<<<<<<< current\n# Server\n\nMy notes\n=======\n# Server v2\n>>>>>>> generated\n\n## Usage\n"
+	if string(files["README.md"]) != expected {
+		t.Errorf("Expected the merged README\n%s\nbut got\n%s", expected, files["README.md"])
+	}
+	if !reflect.DeepEqual(files["logo.png"], current["logo.png"]) {
+		t.Error("Expected the binary file kept as it is")
+	}
+
+	// A last line without a line ending is ended before the markers
+	if content, hunks := mergeContent([]byte("a\nb"), []byte("a\nc")); hunks != 1 || string(content) != "a\n<<<<<<< current\nb\n=======\nc\n>>>>>>> generated\n" {
+		t.Errorf("Unexpected merge of %d hunks:\n%s", hunks, content)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Kinds of changes between generated files and an output directory
+const (
+	// ChangeAdded files are generated but not in the directory
+	ChangeAdded = "added"
+
+	// ChangeModified files differ from those in the directory
+	ChangeModified = "modified"
+
+	// ChangeRemoved files are in the directory and its lock file, but no longer generated
+	ChangeRemoved = "removed"
+)
+
+// FileChange is a difference between a generated file and the output directory
+type FileChange struct {
+	// Slash-separated path of the file in the project
+	Path string `json:"path"`
+
+	// Kind of change: added, modified or removed
+	Kind string `json:"kind"`
+
+	// Unified diff from the directory's file to the generated one; empty for binary files
+	Diff string `json:"-"`
+}
+
+// DiffDir compares generated files with an output directory, as writing them would change it, and returns the
+// changes in path order
+// Files of the directory not generated any more are removed if its lock file lists them; other files of the
+// directory were added by users and are left out. The lock file itself, which records the generator's version, is
+// not compared.
+func DiffDir(files map[string][]byte, dir string) ([]FileChange, error) {
+	var changes []FileChange
+	for _, path := range Paths(files) {
+		if path == LockFile {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, FileChange{Path: path, Kind: ChangeAdded, Diff: unifiedDiff(path, nil, files[path])})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		case !bytes.Equal(current, files[path]):
+			changes = append(changes, FileChange{Path: path, Kind: ChangeModified, Diff: unifiedDiff(path, current, files[path])})
+		}
+	}
+
+	lock, err := ReadLock(dir)
+	if err != nil {
+		return nil, err
+	}
+	if lock != nil {
+		for _, path := range lock.Paths() {
+			if _, generated := files[path]; generated {
+				continue
+			}
+			current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			changes = append(changes, FileChange{Path: path, Kind: ChangeRemoved, Diff: unifiedDiff(path, current, nil)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// unifiedDiff returns the unified diff of a file from its current to its generated content, with a/ and b/ path
+// prefixes as git writes them; absent files are /dev/null, and binary files have no diff
+func unifiedDiff(path string, current, generated []byte) string {
+	if bytes.IndexByte(current, 0) >= 0 || bytes.IndexByte(generated, 0) >= 0 {
+		return ""
+	}
+	from, to := "a/"+path, "b/"+path
+	if current == nil {
+		from = "/dev/null"
+	}
+	if generated == nil {
+		to = "/dev/null"
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        lines(current),
+		B:        lines(generated),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	return diff
+}
+
+// lines splits content into lines, each with its line ending; a last line without one gets a marker, as in diff
+func lines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	result := difflib.SplitLines(string(content))
+	// SplitLines appends a newline to the last line
+	last := len(result) - 1
+	if content[len(content)-1] != '\n' {
+		result[last] = result[last][:len(result[last])-1] + "\n\\ No newline at end of file\n"
+	} else {
+		result = result[:last]
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffDir(t *testing.T) {
+	files := testFiles()
+	files["src/tools.ts"] = []byte("export const tools = [];\n")
+	lock := &Lock{LockVersion: LockVersion}
+	lock.SetFiles(files)
+	files[LockFile], _ = lock.Encode()
+
+	dir := t.TempDir()
+	if err := WriteDir(files, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteDir(map[string][]byte{"NOTES.md": []byte("user file\n")}, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := DiffDir(files, dir); err != nil || len(changes) != 0 {
+		t.Errorf("Expected no changes but got %+v (%v)", changes, err)
+	}
+
+	// The next generation changes a file, adds one and no longer generates another; a new lock file is not a change
+	next := testFiles()
+	next["src/server.ts"] = []byte("// server v2")
+	next["tsconfig.json"] = []byte("{}\n")
+	next[LockFile] = []byte("{}")
+	changes, err := DiffDir(next, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, change := range changes {
+		kinds = append(kinds, change.Path+" "+change.Kind)
+	}
+	if expected := []string{"src/server.ts modified", "src/tools.ts removed", "tsconfig.json added"}; !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("Expected changes %v but got %v", expected, kinds)
+	}
+
+	expected := `--- a/src/server.ts
++++ b/src/server.ts
+@@ -1 +1 @@
+-// server
+\ No newline at end of file
++// server v2
+\ No newline at end of file
+`
+	if changes[0].Diff != expected {
+		t.Errorf("Expected diff\n%s\nbut got\n%s", expected, changes[0].Diff)
+	}
+	if !strings.HasPrefix(changes[1].Diff, "--- a/src/tools.ts\n+++ /dev/null\n") || !strings.HasPrefix(changes[2].Diff, "--- /dev/null\n+++ b/tsconfig.json\n") {
+		t.Errorf("Expected removed and added files diffed against /dev/null but got\n%s%s", changes[1].Diff, changes[2].Diff)
+	}
+
+	if err := os.Remove(filepath.Join(dir, LockFile)); err != nil {
+		t.Fatal(err)
+	}
+	if changes, _ := DiffDir(next, dir); len(changes) != 2 {
+		t.Errorf("Expected files of a directory without a lock file not to be removed but got %+v", changes)
+	}
+}
\ No newline at end of file
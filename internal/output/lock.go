@@ -0,0 +1,260 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LockFile is the name of the lock file written at the root of every generated project
+const LockFile = "mcpgen.lock.json"
+
+// LockVersion is the version of the lock file format; lock files of later versions are rejected
+const LockVersion = 1
+
+// hashPrefix names the hash function of lock file hashes
+const hashPrefix = "sha256:"
+
+// Lock records how a project was generated: from which inputs, by which generator and templates, with which options,
+// and the hash of every generated file, so that later runs can tell generated files from user files and detect
+// manual modifications
+// Lock files hold no timestamps, so generating the same project twice writes the same lock file.
+type Lock struct {
+	LockVersion int `json:"lockVersion"`
+
+	// Generator that wrote the project
+	Generator LockGenerator `json:"generator"`
+
+	// Output language, and the template pack the project was rendered with, if any
+	Language     string    `json:"language"`
+	TemplatePack *LockPack `json:"templatePack,omitempty"`
+
+	// Files the project was generated from, e.g. the artifact, overlays and project config
+	Inputs []LockInput `json:"inputs"`
+
+	// Content hash of the IR the project was rendered from (see ir.ContentHash)
+	IRHash string `json:"irHash"`
+
+	// Generation options given on the command line or in the project config, by flag name: strings, or lists of
+	// strings for repeatable flags
+	Options map[string]interface{} `json:"options,omitempty"`
+
+	// Hashes of the generated files by slash-separated path, without the lock file itself; the bodies of protected
+	// regions are left out, as they belong to users
+	Files map[string]string `json:"files"`
+}
+
+// LockGenerator identifies the generator of a project
+type LockGenerator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// LockPack identifies the template pack of a project
+type LockPack struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+
+	// Hash of the pack's templates
+	Hash string `json:"hash,omitempty"`
+}
+
+// LockInput is a file a project was generated from
+type LockInput struct {
+	// What the input is, e.g. artifact, overlay, annotations, config or templates
+	Kind string `json:"kind"`
+
+	// Path of the input as given, or - for stdin
+	Path string `json:"path"`
+
+	// Hash of the input's content; of directories, the hash of their files' paths and hashes
+	Hash string `json:"hash"`
+}
+
+// Hash returns the hash of content as recorded in lock files, e.g. "sha256:3f2a..."
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// HashFile returns the hash of a file's content
+func HashFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return Hash(content), nil
+}
+
+// HashDir returns the hash of a directory: the hash of the sorted slash-separated paths and hashes of its files
+func HashDir(dir string) (string, error) {
+	return HashFS(os.DirFS(dir))
+}
+
+// HashFS returns the hash of a file system's files, as HashDir
+func HashFS(fsys fs.FS) (string, error) {
+	var listing []byte
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		listing = append(listing, path+" "+Hash(content)+"\n"...)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return Hash(listing), nil
+}
+
+// SetFiles records the hashes of a project's files, except the lock file
+func (l *Lock) SetFiles(files map[string][]byte) {
+	l.Files = make(map[string]string, len(files))
+	for path, content := range files {
+		if path != LockFile {
+			l.Files[path] = Hash(stripRegions(content))
+		}
+	}
+}
+
+// Modified reports whether a locked file's content was changed outside its protected regions since it was generated
+// Hashes of earlier lock files, which include the bodies of protected regions, are recognized too.
+func (l *Lock) Modified(path string, content []byte) bool {
+	hash := l.Files[path]
+	return hash != Hash(stripRegions(content)) && hash != Hash(content)
+}
+
+// Paths returns the paths of the locked files in lexical order
+func (l *Lock) Paths() []string {
+	paths := make([]string, 0, len(l.Files))
+	for path := range l.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Encode returns the lock file content: indented JSON, with map keys sorted
+func (l *Lock) Encode() ([]byte, error) {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// ReadLock reads the lock file of a generated project directory, or returns nil if it has none
+func ReadLock(dir string) (*Lock, error) {
+	path := filepath.Join(dir, LockFile)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	lock := &Lock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if lock.LockVersion < 1 || lock.LockVersion > LockVersion {
+		return nil, fmt.Errorf("%s has lock version %d, which this generator does not support (expected at most %d)", path, lock.LockVersion, LockVersion)
+	}
+	return lock, nil
+}
+
+// Changes returns the locked files of a project directory that were modified outside their protected regions since
+// they were generated, and those that were removed, in lexical order
+func (l *Lock) Changes(dir string) (modified, removed []string, err error) {
+	for _, path := range l.Paths() {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			removed = append(removed, path)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if l.Modified(path, content) {
+			modified = append(modified, path)
+		}
+	}
+	return modified, removed, nil
+}
+
+// Clean removes the locked files of a project directory, then the lock file and the directories left empty inside
+// it, and returns the removed files; user files, which the lock does not list, are kept
+// Files modified since they were generated are kept and returned with the lock file, unless force is set. With dryRun
+// nothing is removed, and the files that would be are returned.
+func (l *Lock) Clean(dir string, force, dryRun bool) (removed, kept []string, err error) {
+	modified, _, err := l.Changes(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	isModified := map[string]bool{}
+	for _, path := range modified {
+		isModified[path] = true
+	}
+
+	// The lock file is kept with modified files, which stay generated files rather than becoming user files
+	paths := l.Paths()
+	for _, path := range paths {
+		if isModified[path] && !force {
+			kept = append(kept, path)
+		}
+	}
+	if len(kept) == 0 {
+		paths = append(paths, LockFile)
+	} else {
+		kept = append(kept, LockFile)
+	}
+
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		if isModified[path] && !force {
+			continue
+		}
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		if _, err := os.Lstat(fullPath); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		removed = append(removed, path)
+		if dryRun {
+			continue
+		}
+		if err := os.Remove(fullPath); err != nil {
+			return removed, kept, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		for parent := filepath.Dir(fullPath); parent != filepath.Clean(dir); parent = filepath.Dir(parent) {
+			dirs[parent] = true
+		}
+	}
+	if dryRun {
+		return removed, kept, nil
+	}
+
+	// Deeper directories are removed first, so their parents can be empty; non-empty ones fail to be removed
+	parents := make([]string, 0, len(dirs))
+	for parent := range dirs {
+		parents = append(parents, parent)
+	}
+	sort.Slice(parents, func(i, j int) bool { return len(parents[i]) > len(parents[j]) })
+	for _, parent := range parents {
+		if entries, err := os.ReadDir(parent); err == nil && len(entries) == 0 {
+			if err := os.Remove(parent); err != nil {
+				return removed, kept, fmt.Errorf("failed to remove directory %s: %w", parent, err)
+			}
+		}
+	}
+	return removed, kept, nil
+}
\ No newline at end of file
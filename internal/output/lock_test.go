@@ -0,0 +1,125 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	files := testFiles()
+	lock := &Lock{LockVersion: LockVersion, Generator: LockGenerator{Name: "generate-mcp", Version: "dev"}, Language: "ts", Options: map[string]interface{}{"overlay": []string{"a.yaml"}}}
+	lock.SetFiles(files)
+	content, err := lock.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files[LockFile] = content
+	if again, _ := lock.Encode(); !bytes.Equal(again, content) {
+		t.Error("Expected the same lock to encode to the same content")
+	}
+
+	dir := t.TempDir()
+	if err := WriteDir(files, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read.Paths(), Paths(testFiles())) || read.Files["package.json"] != Hash([]byte("{}")) {
+		t.Errorf("Expected every file but the lock file to be locked but got %v", read.Files)
+	}
+	if modified, removed, err := read.Changes(dir); err != nil || modified != nil || removed != nil {
+		t.Errorf("Expected no changes but got %v, %v (%v)", modified, removed, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "edited"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "src", "server.ts")); err != nil {
+		t.Fatal(err)
+	}
+	modified, removed, err := read.Changes(dir)
+	if err != nil || !reflect.DeepEqual(modified, []string{"package.json"}) || !reflect.DeepEqual(removed, []string{"src/server.ts"}) {
+		t.Errorf("Expected package.json modified and src/server.ts removed but got %v, %v (%v)", modified, removed, err)
+	}
+}
+
+func TestReadLock(t *testing.T) {
+	dir := t.TempDir()
+	if lock, err := ReadLock(dir); lock != nil || err != nil {
+		t.Errorf("Expected no lock in an empty directory but got %v (%v)", lock, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, LockFile), []byte(`{"lockVersion": 99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadLock(dir); err == nil || !strings.Contains(err.Error(), "lock version 99") {
+		t.Errorf("Expected a later lock version to be rejected but got %v", err)
+	}
+}
+
+func TestHashDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteDir(testFiles(), dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	first, err := HashDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "server.ts"), []byte("// edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if second, _ := HashDir(dir); second == first || !strings.HasPrefix(second, "sha256:") {
+		t.Errorf("Expected editing a file to change the directory hash but got %s and %s", first, second)
+	}
+}
+
+func TestClean(t *testing.T) {
+	files := testFiles()
+	files["src/tools/read.ts"] = []byte("// tools")
+	lock := &Lock{LockVersion: LockVersion}
+	lock.SetFiles(files)
+	files[LockFile], _ = lock.Encode()
+
+	dir := t.TempDir()
+	if err := WriteDir(files, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteDir(map[string][]byte{"src/custom.ts": []byte("// user file")}, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "edited"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed, _, err := lock.Clean(dir, false, true); err != nil || len(removed) != 3 {
+		t.Errorf("Expected a dry run to list the 3 unmodified files but got %v (%v)", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "src", "server.ts")); err != nil {
+		t.Errorf("Expected a dry run to remove nothing but got %v", err)
+	}
+
+	removed, kept, err := lock.Clean(dir, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(removed, []string{"README.md", "src/server.ts", "src/tools/read.ts"}) || !reflect.DeepEqual(kept, []string{"package.json", LockFile}) {
+		t.Errorf("Expected the modified file kept with the lock file but removed %v and kept %v", removed, kept)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "src", "tools")); err == nil {
+		t.Error("Expected the emptied src/tools directory to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "src", "custom.ts")); err != nil {
+		t.Errorf("Expected the user file to be kept but got %v", err)
+	}
+
+	if removed, kept, err := lock.Clean(dir, true, false); err != nil || !reflect.DeepEqual(removed, []string{"package.json", LockFile}) || kept != nil {
+		t.Errorf("Expected --force to remove the modified file and the lock file but removed %v and kept %v (%v)", removed, kept, err)
+	}
+}
\ No newline at end of file
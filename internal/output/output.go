@@ -0,0 +1,225 @@
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultModTime is the modification time of archived files unless SOURCE_DATE_EPOCH is set
+// It is the earliest time zip archives can represent, so archives of the same files are byte-identical
+var DefaultModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Formats generated projects can be emitted in
+const (
+	// FormatDir writes the files into a directory
+	FormatDir = "dir"
+
+	// FormatZip writes a zip archive
+	FormatZip = "zip"
+
+	// FormatTar writes a gzip-compressed tar archive
+	FormatTar = "tar"
+
+	// FormatStdout streams an uncompressed tar archive to standard output
+	FormatStdout = "stdout"
+)
+
+// ModTime returns the modification time set by SOURCE_DATE_EPOCH (seconds since the Unix epoch), or the zero time if it is unset
+// See https://reproducible-builds.org/docs/source-date-epoch/
+func ModTime() (time.Time, error) {
+	epoch := strings.TrimSpace(os.Getenv("SOURCE_DATE_EPOCH"))
+	if epoch == "" {
+		return time.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: expected a non-negative number of seconds", epoch)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// Path returns where a project is emitted for a format given the output path
+// Archive extensions are appended unless the path already has them; streamed output has no path
+func Path(format, output string) (string, error) {
+	switch format {
+	case FormatDir:
+		return output, nil
+	case FormatZip:
+		return withExtension(output, ".zip"), nil
+	case FormatTar:
+		if strings.HasSuffix(output, ".tgz") {
+			return output, nil
+		}
+		return withExtension(output, ".tar.gz"), nil
+	case FormatStdout:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported output format: %s (expected %s, %s, %s or %s)", format, FormatDir, FormatZip, FormatTar, FormatStdout)
+	}
+}
+
+// withExtension appends an extension to a path unless it already ends with it
+func withExtension(path, extension string) string {
+	path = strings.TrimSuffix(path, string(filepath.Separator))
+	if strings.HasSuffix(path, extension) {
+		return path
+	}
+	return path + extension
+}
+
+// Write emits the files of a generated project in a format
+// Files are keyed by their slash-separated path relative to the project root. Written files get modTime if it is set;
+// archived files get DefaultModTime otherwise, so the same files always produce the same archive
+func Write(files map[string][]byte, format, output string, stdout io.Writer, modTime time.Time) error {
+	path, err := Path(format, output)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatDir {
+		return WriteDir(files, path, modTime)
+	}
+	if modTime.IsZero() {
+		modTime = DefaultModTime
+	}
+
+	switch format {
+	case FormatStdout:
+		return WriteTar(stdout, files, modTime)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if format == FormatZip {
+		err = WriteZip(file, files, modTime)
+	} else {
+		err = WriteTarGz(file, files, modTime)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// WriteDir writes the files into a directory, creating it and any parent directories
+// Unless modTime is zero, the files are given it as their access and modification time
+func WriteDir(files map[string][]byte, dir string, modTime time.Time) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, path := range Paths(files) {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(fullPath, files[path], 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", path, err)
+		}
+		if !modTime.IsZero() {
+			if err := os.Chtimes(fullPath, modTime, modTime); err != nil {
+				return fmt.Errorf("failed to set the modification time of %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteZip writes the files as a zip archive
+func WriteZip(w io.Writer, files map[string][]byte, modTime time.Time) error {
+	archive := zip.NewWriter(w)
+	for _, path := range Paths(files) {
+		header := &zip.FileHeader{Name: path, Method: zip.Deflate, Modified: modTime}
+		header.SetMode(0644)
+		entry, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(files[path]); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// WriteTar writes the files as an uncompressed tar archive
+func WriteTar(w io.Writer, files map[string][]byte, modTime time.Time) error {
+	archive := tar.NewWriter(w)
+	for _, path := range Paths(files) {
+		header := &tar.Header{Name: path, Mode: 0644, Size: int64(len(files[path])), ModTime: modTime, Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := archive.Write(files[path]); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// WriteTarGz writes the files as a gzip-compressed tar archive
+func WriteTarGz(w io.Writer, files map[string][]byte, modTime time.Time) error {
+	compressed := gzip.NewWriter(w)
+	if err := WriteTar(compressed, files, modTime); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// Paths returns the paths of the files in lexical order, so archives list them in the same order every time
+func Paths(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Collisions returns the groups of files that cannot all be written: paths naming the same file on case-insensitive
+// file systems or after cleaning (e.g. README.md and readme.md, or ./a.ts and a.ts), and files whose path is a
+// directory of others (e.g. src and src/server.ts)
+func Collisions(files map[string][]byte) [][]string {
+	groups := map[string][]string{}
+	var keys []string
+	for _, file := range Paths(files) {
+		key := strings.ToLower(path.Clean(file))
+		if groups[key] == nil {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], file)
+	}
+
+	var collisions [][]string
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			collisions = append(collisions, groups[key])
+		}
+		for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if files := groups[dir]; files != nil {
+				collisions = append(collisions, append(append([]string{}, files...), groups[key]...))
+				break
+			}
+		}
+	}
+	return collisions
+}
@@ -0,0 +1,219 @@
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testFiles is a small generated project
+func testFiles() map[string][]byte {
+	return map[string][]byte{
+		"src/server.ts": []byte("// server"),
+		"package.json":  []byte("{}"),
+		"README.md":     []byte("# Token"),
+	}
+}
+
+var testTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		format   string
+		output   string
+		expected string
+	}{
+		{FormatDir, "./mcp-server", "./mcp-server"},
+		{FormatZip, "./mcp-server", "./mcp-server.zip"},
+		{FormatZip, "dist/server.zip", "dist/server.zip"},
+		{FormatTar, "./mcp-server/", "./mcp-server.tar.gz"},
+		{FormatTar, "server.tgz", "server.tgz"},
+		{FormatStdout, "./mcp-server", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.output, func(t *testing.T) {
+			got, err := Path(tt.format, tt.output)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Path("rar", "./mcp-server"); err == nil {
+		t.Errorf("Expected an error for an unsupported format")
+	}
+}
+
+func TestWriteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mcp-server")
+	if err := Write(testFiles(), FormatDir, dir, nil, testTime); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "src", "server.ts"))
+	if err != nil || string(content) != "// server" {
+		t.Errorf("Unexpected src/server.ts: %q (%v)", content, err)
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp-server")
+	if err := Write(testFiles(), FormatZip, path, nil, testTime); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	archive, err := zip.OpenReader(path + ".zip")
+	if err != nil {
+		t.Fatalf("Failed to open the archive: %v", err)
+	}
+	defer archive.Close()
+
+	names := []string{}
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		if !file.Modified.Equal(testTime) {
+			t.Errorf("Expected %s to be modified at %s but got %s", file.Name, testTime, file.Modified)
+		}
+	}
+	if got, expected := len(names), 3; got != expected || names[0] != "README.md" || names[2] != "src/server.ts" {
+		t.Errorf("Expected the files in lexical order but got %v", names)
+	}
+}
+
+func TestWriteTar(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := Write(testFiles(), FormatStdout, "ignored", &stdout, testTime); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	entries := readTar(t, &stdout)
+	if string(entries["src/server.ts"]) != "// server" || len(entries) != 3 {
+		t.Errorf("Unexpected streamed archive %v", entries)
+	}
+
+	path := filepath.Join(t.TempDir(), "mcp-server")
+	if err := Write(testFiles(), FormatTar, path, nil, testTime); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	file, err := os.Open(path + ".tar.gz")
+	if err != nil {
+		t.Fatalf("Failed to open the archive: %v", err)
+	}
+	defer file.Close()
+	compressed, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Archive is not gzip-compressed: %v", err)
+	}
+	if entries := readTar(t, compressed); string(entries["package.json"]) != "{}" {
+		t.Errorf("Unexpected archive %v", entries)
+	}
+}
+
+// readTar reads the files of a tar archive
+func readTar(t *testing.T, r io.Reader) map[string][]byte {
+	t.Helper()
+	entries := map[string][]byte{}
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("Failed to read the archive: %v", err)
+		}
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", header.Name, err)
+		}
+		entries[header.Name] = content
+	}
+}
+
+func TestReproducibleArchives(t *testing.T) {
+	for _, write := range []struct {
+		name  string
+		write func(io.Writer, map[string][]byte, time.Time) error
+	}{{"Zip", WriteZip}, {"Tar", WriteTar}, {"Tar Gz", WriteTarGz}} {
+		t.Run(write.name, func(t *testing.T) {
+			var first, second bytes.Buffer
+			if err := write.write(&first, testFiles(), DefaultModTime); err != nil {
+				t.Fatalf("Failed to write: %v", err)
+			}
+			if err := write.write(&second, testFiles(), DefaultModTime); err != nil {
+				t.Fatalf("Failed to write: %v", err)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Errorf("Archives of the same files differ")
+			}
+		})
+	}
+
+	// Archives default to a fixed time rather than the current one
+	var stdout bytes.Buffer
+	if err := Write(testFiles(), FormatStdout, "", &stdout, time.Time{}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	header, err := tar.NewReader(&stdout).Next()
+	if err != nil {
+		t.Fatalf("Failed to read the archive: %v", err)
+	}
+	if !header.ModTime.Equal(DefaultModTime) {
+		t.Errorf("Expected %s but got %s", DefaultModTime, header.ModTime)
+	}
+}
+
+func TestModTime(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	if got, err := ModTime(); err != nil || !got.IsZero() {
+		t.Errorf("Expected the zero time without SOURCE_DATE_EPOCH but got %s (%v)", got, err)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1704164645")
+	got, err := ModTime()
+	if err != nil || !got.Equal(testTime) {
+		t.Errorf("Expected %s but got %s (%v)", testTime, got, err)
+	}
+
+	// Written files take the time too
+	dir := t.TempDir()
+	if err := WriteDir(testFiles(), dir, got); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to stat README.md: %v", err)
+	}
+	if !info.ModTime().Equal(testTime) {
+		t.Errorf("Expected README.md to be modified at %s but got %s", testTime, info.ModTime())
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, err := ModTime(); err == nil {
+		t.Errorf("Expected an error for an invalid SOURCE_DATE_EPOCH")
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	if collisions := Collisions(testFiles()); len(collisions) != 0 {
+		t.Errorf("Expected no collisions but got %v", collisions)
+	}
+
+	files := testFiles()
+	files["readme.md"] = []byte("# token")
+	files["./package.json"] = []byte("{}")
+	files["src"] = []byte("")
+	expected := [][]string{{"./package.json", "package.json"}, {"README.md", "readme.md"}, {"src", "src/server.ts"}}
+	if collisions := Collisions(files); !reflect.DeepEqual(collisions, expected) {
+		t.Errorf("Expected collisions %v but got %v", expected, collisions)
+	}
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Markers of protected regions: lines containing them, in any comment syntax, start and end a region named by the
+// word following them, e.g. "// mcpgen:begin imports" and "// mcpgen:end imports"
+const (
+	RegionBegin = "mcpgen:begin"
+	RegionEnd   = "mcpgen:end"
+)
+
+// region is a protected region of a file: the byte offsets of its body, the lines between its markers
+type region struct {
+	name       string
+	start, end int
+}
+
+// regions returns the protected regions of a file's content in order
+// Regions cannot nest, and each name is used once per file.
+func regions(content []byte) ([]region, error) {
+	var result []region
+	var open *region
+	seen := map[string]bool{}
+	offset := 0
+	for number := 1; offset < len(content); number++ {
+		end := bytes.IndexByte(content[offset:], '\n')
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += offset + 1
+		}
+		line := string(content[offset:end])
+		switch {
+		case strings.Contains(line, RegionBegin):
+			name := markerName(line, RegionBegin)
+			if name == "" {
+				return nil, fmt.Errorf("line %d: %s without a region name", number, RegionBegin)
+			}
+			if open != nil {
+				return nil, fmt.Errorf("line %d: region %s starts inside region %s", number, name, open.name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("line %d: region %s is defined twice", number, name)
+			}
+			seen[name] = true
+			open = &region{name: name, start: end}
+		case strings.Contains(line, RegionEnd):
+			name := markerName(line, RegionEnd)
+			if open == nil || name != open.name {
+				return nil, fmt.Errorf("line %d: %s %s without a matching %s", number, RegionEnd, name, RegionBegin)
+			}
+			open.end = offset
+			result = append(result, *open)
+			open = nil
+		}
+		offset = end
+	}
+	if open != nil {
+		return nil, fmt.Errorf("region %s has no %s", open.name, RegionEnd)
+	}
+	return result, nil
+}
+
+// stripRegions returns content with the bodies of its protected regions left out, or content itself if it has no
+// regions or invalid ones
+func stripRegions(content []byte) []byte {
+	found, err := regions(content)
+	if err != nil || len(found) == 0 {
+		return content
+	}
+	stripped := make([]byte, 0, len(content))
+	last := 0
+	for _, r := range found {
+		stripped = append(stripped, content[last:r.start]...)
+		last = r.end
+	}
+	return append(stripped, content[last:]...)
+}
+
+// markerName returns the region name following a marker in a line
+func markerName(line, marker string) string {
+	fields := strings.Fields(line[strings.Index(line, marker)+len(marker):])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// PreserveRegions carries the bodies of the protected regions of an output directory's files into the generated
+// files, so that code users write inside them survives regeneration, and returns the regions with content that are
+// no longer generated, as <path>#<name> in lexical order
+// Generated files without regions, and files not in the directory yet, are left as rendered.
+func PreserveRegions(files map[string][]byte, dir string) ([]string, error) {
+	var dropped []string
+	for _, path := range Paths(files) {
+		generatedRegions, err := regions(files[path])
+		if err != nil {
+			return nil, fmt.Errorf("generated file %s has invalid protected regions: %w", path, err)
+		}
+		current, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		currentRegions, err := regions(current)
+		if err != nil {
+			return nil, fmt.Errorf("%s has invalid protected regions: %w", path, err)
+		}
+
+		bodies := map[string][]byte{}
+		for _, r := range currentRegions {
+			bodies[r.name] = current[r.start:r.end]
+		}
+		// Bodies are replaced from the last region, so the offsets of earlier ones stay valid
+		content := append([]byte(nil), files[path]...)
+		for i := len(generatedRegions) - 1; i >= 0; i-- {
+			r := generatedRegions[i]
+			body, ok := bodies[r.name]
+			if !ok {
+				continue
+			}
+			delete(bodies, r.name)
+			content = append(content[:r.start], append(append([]byte(nil), body...), content[r.end:]...)...)
+		}
+		files[path] = content
+		for name, body := range bodies {
+			if len(bytes.TrimSpace(body)) > 0 {
+				dropped = append(dropped, path+"#"+name)
+			}
+		}
+	}
+	sort.Strings(dropped)
+	return dropped, nil
+}
\ No newline at end of file
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreserveRegions(t *testing.T) {
+	dir := t.TempDir()
+	edited := map[string][]byte{
+		"src/server.ts": []byte("import a;\n// mcpgen:begin imports\nimport { extra } from \"./extra.js\";\n// mcpgen:end imports\nmain();\n// mcpgen:begin hooks\nhook();\n// mcpgen:end hooks\n"),
+		"README.md":     []byte("# Server\n<!-- mcpgen:begin notes -->\n<!-- mcpgen:end notes -->\n"),
+	}
+	if err := WriteDir(edited, dir, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next generation changes the code around the regions and no longer has the hooks region
+	files := map[string][]byte{
+		"src/server.ts": []byte("import a;\nimport b;\n// mcpgen:begin imports\n// mcpgen:end imports\nmain(b);\n"),
+		"README.md":     []byte("# Server v2\n<!-- mcpgen:begin notes -->\n<!-- mcpgen:end notes -->\n"),
+		"package.json":  []byte("{}\n"),
+	}
+	dropped, err := PreserveRegions(files, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "import a;\nimport b;\n// mcpgen:begin imports\nimport { extra } from \"./extra.js\";\n// mcpgen:end imports\nmain(b);\n"; string(files["src/server.ts"]) != expected {
+		t.Errorf("Expected the imports region kept in\n%s\nbut got\n%s", expected, files["src/server.ts"])
+	}
+	if expected := "# Server v2\n<!-- mcpgen:begin notes -->\n<!-- mcpgen:end notes -->\n"; string(files["README.md"]) != expected {
+		t.Errorf("Expected the README as generated but got\n%s", files["README.md"])
+	}
+	if expected := []string{"src/server.ts#hooks"}; !reflect.DeepEqual(dropped, expected) {
+		t.Errorf("Expected dropped regions %v but got %v", expected, dropped)
+	}
+}
+
+func TestPreserveRegionsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"// mcpgen:begin a\n":                                     "region a has no mcpgen:end",
+		"// mcpgen:end a\n":                                       "line 1: mcpgen:end a without a matching mcpgen:begin",
+		"// mcpgen:begin a\n// mcpgen:begin b\n":                  "line 2: region b starts inside region a",
+		"// mcpgen:begin\n":                                       "line 1: mcpgen:begin without a region name",
+		"// mcpgen:begin a\n// mcpgen:end a\n// mcpgen:begin a\n": "line 3: region a is defined twice",
+	}
+	for content, expected := range tests {
+		dir := t.TempDir()
+		if err := WriteDir(map[string][]byte{"server.ts": []byte(content)}, dir, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := PreserveRegions(map[string][]byte{"server.ts": []byte("// server\n")}, dir)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q for %q but got %v", expected, content, err)
+		}
+	}
+}
\ No newline at end of file
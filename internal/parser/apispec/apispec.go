@@ -0,0 +1,298 @@
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// maxDepth bounds the nesting of converted schemas, so recursive schemas end in values of any type
+const maxDepth = 8
+
+// Parser parses OpenAPI 3.x and OpenRPC documents (JSON or YAML) into the intermediate representation,
+// so off-chain APIs are wrapped by the same generation pipeline as contracts
+type Parser struct{}
+
+// NewParser creates a new API description parser
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Detect returns the chain of an OpenAPI or OpenRPC document in JSON (ir.ChainOpenAPI or ir.ChainOpenRPC),
+// or "" if the content is not one
+func Detect(content []byte) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(content, &fields) != nil {
+		return ""
+	}
+	switch {
+	case fields["openapi"] != nil && fields["paths"] != nil:
+		return ir.ChainOpenAPI
+	case fields["openrpc"] != nil && fields["methods"] != nil:
+		return ir.ChainOpenRPC
+	}
+	return ""
+}
+
+// Parse parses an OpenAPI or OpenRPC document into the intermediate representation
+// Every operation or method becomes a function, its parameters and request body fields the function's inputs
+// and its response the function's output.
+func (p *Parser) Parse(reader io.Reader, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API description: %w", err)
+	}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] != '{' {
+		if content, err = ir.YAMLToJSON(content); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML API description: %w", err)
+		}
+	}
+
+	chain := Detect(content)
+	if chain == "" {
+		return nil, fmt.Errorf("not an OpenAPI 3.x or OpenRPC document")
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode API description: %w", err)
+	}
+
+	metadata.Chain = chain
+	contract := &ir.ContractIR{Metadata: metadata, Functions: []ir.Function{}, Events: []ir.Event{}, Errors: []ir.ContractError{}}
+	info := object(document["info"])
+	if contract.Metadata.Name == "" {
+		contract.Metadata.Name = text(info["title"])
+	}
+	if contract.Metadata.Description == "" {
+		contract.Metadata.Description = text(info["description"])
+	}
+	if servers := list(document["servers"]); len(servers) > 0 {
+		if url := text(object(servers[0])["url"]); url != "" {
+			contract.Metadata.ChainData = ir.APIServer{BaseURL: url}.ChainData()
+		}
+	}
+
+	d := &description{root: document, names: map[string]int{}}
+	if chain == ir.ChainOpenAPI {
+		contract.Functions = d.operations()
+	} else {
+		contract.Functions = d.methods()
+	}
+	return contract, nil
+}
+
+// description is an API description being converted
+type description struct {
+	// Decoded document, which references are resolved in
+	root map[string]interface{}
+
+	// Number of functions named after each tool name, to tell repeated names apart
+	names map[string]int
+}
+
+// resolve follows the local references ("#/components/schemas/Pet") of a schema, parameter or content descriptor
+func (d *description) resolve(value interface{}) map[string]interface{} {
+	node := object(value)
+	for i := 0; i < maxDepth; i++ {
+		ref, ok := node["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		var target interface{} = d.root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			target = object(target)[token]
+		}
+		node = object(target)
+	}
+	return node
+}
+
+// parameterType converts a JSON Schema into an IR type named after the JSON Schema types
+// Objects with properties become structs of their fields, sorted by name; unions and nested arrays become values of
+// any type.
+func (d *description) parameterType(value interface{}, depth int) ir.ParameterType {
+	schema := d.resolve(value)
+	if depth > maxDepth {
+		return ir.ParameterType{BaseType: ir.APIAny}
+	}
+
+	// A union of one type and null is that type, and allOf an object with the properties of all its members
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if variants := d.nonNull(list(schema[key])); len(variants) == 1 {
+			return d.parameterType(variants[0], depth+1)
+		} else if len(variants) > 1 {
+			return ir.ParameterType{BaseType: ir.APIAny}
+		}
+	}
+	if members := list(schema["allOf"]); len(members) > 0 {
+		schema = d.mergeAll(members)
+	}
+
+	switch schemaType(schema) {
+	case "array":
+		element := d.parameterType(schema["items"], depth+1)
+		if element.IsArray {
+			return ir.ParameterType{BaseType: ir.APIAny, IsArray: true}
+		}
+		element.IsArray = true
+		return element
+	case "object":
+		t := ir.ParameterType{BaseType: ir.APIObject}
+		properties := object(schema["properties"])
+		required := map[string]bool{}
+		for _, name := range list(schema["required"]) {
+			required[text(name)] = true
+		}
+		for _, name := range sortedKeys(properties) {
+			field := d.resolve(properties[name])
+			fieldType := d.parameterType(field, depth+1)
+			if !required[name] {
+				setChainData(&fieldType, ir.OptionalKey, true)
+			}
+			t.Components = append(t.Components, ir.Parameter{Name: name, Type: fieldType, Description: text(field["description"]), Default: field["default"]})
+		}
+		return t
+	case ir.APIString, ir.APIBoolean, ir.APIInteger, ir.APINumber:
+		t := ir.ParameterType{BaseType: schemaType(schema)}
+		if values := list(schema["enum"]); len(values) > 0 {
+			setChainData(&t, ir.EnumValuesKey, values)
+		}
+		return t
+	}
+	return ir.ParameterType{BaseType: ir.APIAny}
+}
+
+// nonNull returns the variants of a union that are not the null type
+func (d *description) nonNull(variants []interface{}) []interface{} {
+	result := []interface{}{}
+	for _, variant := range variants {
+		if schemaType(d.resolve(variant)) != "null" {
+			result = append(result, variant)
+		}
+	}
+	return result
+}
+
+// mergeAll combines the members of an allOf into one object schema
+func (d *description) mergeAll(members []interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+	for _, member := range members {
+		schema := d.resolve(member)
+		if nested := list(schema["allOf"]); len(nested) > 0 {
+			schema = d.mergeAll(nested)
+		}
+		for name, property := range object(schema["properties"]) {
+			properties[name] = property
+		}
+		required = append(required, list(schema["required"])...)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+}
+
+// toolName turns an operation ID or method name into a unique camelCase function name (e.g., "list-pets" becomes
+// "listPets", and a second "listPets" becomes "listPets2")
+func (d *description) toolName(name string) string {
+	words := nonIdentifier.Split(name, -1)
+	var b strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(word)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	result := b.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "call" + result
+	}
+
+	d.names[result]++
+	if n := d.names[result]; n > 1 {
+		result = fmt.Sprintf("%s%d", result, n)
+	}
+	return result
+}
+
+// nonIdentifier matches the characters separating the words of a tool name
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// schemaType returns the JSON type of a schema, guessing it from its keywords if it names none
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		// OpenAPI 3.1 spells nullable types as ["string", "null"]
+		for _, name := range t {
+			if name != "null" {
+				return text(name)
+			}
+		}
+		return "null"
+	}
+	switch {
+	case schema["properties"] != nil:
+		return "object"
+	case schema["items"] != nil:
+		return "array"
+	case schema["enum"] != nil:
+		return ir.APIString
+	}
+	return ""
+}
+
+// setChainData sets a chain data entry of a type
+func setChainData(t *ir.ParameterType, key string, value interface{}) {
+	if t.ChainData == nil {
+		t.ChainData = map[string]interface{}{}
+	}
+	t.ChainData[key] = value
+}
+
+// sortedKeys returns the keys of an object in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// object returns a JSON value as an object, or nil if it is not one
+func object(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// list returns a JSON value as an array, or nil if it is not one
+func list(value interface{}) []interface{} {
+	l, _ := value.([]interface{})
+	return l
+}
+
+// text returns a JSON value as a string, or "" if it is not one
+func text(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// flag returns a JSON value as a boolean, or false if it is not one
+func flag(value interface{}) bool {
+	b, _ := value.(bool)
+	return b
+}
\ No newline at end of file
@@ -0,0 +1,173 @@
+package apispec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/stretchr/testify/assert"
+)
+
+const petstore = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Petstore", "description": "Pets for sale", "version": "1.0.0"},
+	"servers": [{"url": "https://petstore.example.com/v1"}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "list-pets",
+				"summary": "List pets",
+				"tags": ["pets"],
+				"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "default": 20}}],
+				"responses": {"200": {"description": "A page of pets", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/pets/{petId}": {
+			"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"delete": {"deprecated": true, "responses": {"204": {"description": "Deleted"}}}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string", "description": "Pet name"},
+					"status": {"type": "string", "enum": ["available", "sold"]},
+					"tag": {"oneOf": [{"type": "string"}, {"type": "null"}]}
+				}
+			}
+		}
+	}
+}`
+
+const rpc = `
+openrpc: 1.2.6
+info:
+  title: Counter
+  version: 1.0.0
+methods:
+  - name: counter.get
+    summary: Current count
+    result:
+      name: count
+      schema: {type: integer}
+  - name: counter_add
+    paramStructure: by-position
+    params:
+      - $ref: '#/components/contentDescriptors/Amount'
+      - name: note
+        schema: {type: string}
+    result:
+      name: count
+      schema: {type: integer}
+components:
+  contentDescriptors:
+    Amount:
+      name: amount
+      description: Amount to add
+      required: true
+      schema: {type: number}
+`
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, ir.ChainOpenAPI, Detect([]byte(petstore)))
+	assert.Equal(t, ir.ChainOpenRPC, Detect([]byte(`{"openrpc": "1.2.6", "methods": []}`)))
+	assert.Equal(t, "", Detect([]byte(`[{"type": "function"}]`)))
+}
+
+func TestParseOpenAPI(t *testing.T) {
+	contract, err := NewParser().Parse(strings.NewReader(petstore), ir.ContractMetadata{})
+	assert.NoError(t, err)
+	assert.Equal(t, ir.ChainOpenAPI, contract.Metadata.Chain)
+	assert.Equal(t, "Petstore", contract.Metadata.Name)
+	assert.Equal(t, "Pets for sale", contract.Metadata.Description)
+	assert.Equal(t, "https://petstore.example.com/v1", contract.Metadata.ChainData[ir.BaseURLKey])
+	assert.Len(t, contract.Functions, 3)
+
+	list := contract.Functions[0]
+	assert.Equal(t, "listPets", list.Name)
+	assert.Equal(t, "List pets", list.Description)
+	assert.Equal(t, ir.View, list.StateMutability)
+	assert.Equal(t, []string{"pets"}, list.Tags)
+	assert.Equal(t, "GET", list.ChainData[ir.HTTPMethodKey])
+	assert.Equal(t, "/pets", list.ChainData[ir.HTTPPathKey])
+	assert.Equal(t, "limit", list.Inputs[0].Name)
+	assert.Equal(t, ir.APIInteger, list.Inputs[0].Type.BaseType)
+	assert.Equal(t, "query", list.Inputs[0].Type.ChainData[ir.ParameterInKey])
+	assert.True(t, list.Inputs[0].Type.IsOptional())
+	assert.Equal(t, float64(20), list.Inputs[0].Default)
+
+	// Array responses of $ref objects become arrays of structs with sorted fields
+	result := list.Outputs[0].Type
+	assert.True(t, result.IsArray)
+	assert.Equal(t, ir.APIObject, result.BaseType)
+	assert.Equal(t, "name", result.Components[0].Name)
+	assert.False(t, result.Components[0].Type.IsOptional())
+	assert.Equal(t, []interface{}{"available", "sold"}, result.Components[1].Type.ChainData["enumValues"])
+	assert.Equal(t, ir.APIString, result.Components[2].Type.BaseType)
+	assert.True(t, result.Components[2].Type.IsOptional())
+
+	// Object request bodies are flattened into inputs
+	create := contract.Functions[1]
+	assert.Equal(t, ir.Nonpayable, create.StateMutability)
+	assert.Len(t, create.Inputs, 3)
+	assert.Equal(t, "body", create.Inputs[0].Type.ChainData[ir.ParameterInKey])
+	assert.False(t, create.Inputs[0].Type.IsOptional())
+	assert.Empty(t, create.Outputs)
+
+	// Operations without IDs are named after their method and path, with the path's parameters
+	remove := contract.Functions[2]
+	assert.Equal(t, "deletePetsPetId", remove.Name)
+	assert.True(t, remove.Deprecated)
+	assert.Equal(t, "path", remove.Inputs[0].Type.ChainData[ir.ParameterInKey])
+	assert.False(t, remove.Inputs[0].Type.IsOptional())
+
+	assert.Empty(t, contract.Validate())
+	for _, finding := range ir.Lint(contract, ir.LintConfig{}) {
+		assert.NotEqual(t, ir.RuleUnsupportedType, finding.Rule, finding.Message)
+	}
+}
+
+func TestParseOpenRPC(t *testing.T) {
+	contract, err := NewParser().Parse(strings.NewReader(rpc), ir.ContractMetadata{Name: "MyCounter"})
+	assert.NoError(t, err)
+	assert.Equal(t, ir.ChainOpenRPC, contract.Metadata.Chain)
+	assert.Equal(t, "MyCounter", contract.Metadata.Name)
+	assert.Len(t, contract.Functions, 2)
+
+	get := contract.Functions[0]
+	assert.Equal(t, "counterGet", get.Name)
+	assert.Equal(t, "counter.get", get.ChainData[ir.RPCMethodKey])
+	assert.Equal(t, "Current count", get.Description)
+	assert.Equal(t, ir.View, get.StateMutability)
+	assert.Equal(t, ir.APIInteger, get.Outputs[0].Type.BaseType)
+
+	add := contract.Functions[1]
+	assert.Equal(t, "counterAdd", add.Name)
+	assert.Equal(t, true, add.ChainData[ir.ParamsByPositionKey])
+	assert.Equal(t, "amount", add.Inputs[0].Name)
+	assert.Equal(t, "Amount to add", add.Inputs[0].Description)
+	assert.Equal(t, ir.APINumber, add.Inputs[0].Type.BaseType)
+	assert.False(t, add.Inputs[0].Type.IsOptional())
+	assert.True(t, add.Inputs[1].Type.IsOptional())
+}
+
+func TestToolName(t *testing.T) {
+	d := &description{names: map[string]int{}}
+	assert.Equal(t, "listPets", d.toolName("list-pets"))
+	assert.Equal(t, "listPets2", d.toolName("list_pets"))
+	assert.Equal(t, "getUsersUserIdPosts", d.toolName("get /users/{userId}/posts"))
+	assert.Equal(t, "call2fa", d.toolName("2fa"))
+}
+
+func TestParseRejectsOtherDocuments(t *testing.T) {
+	_, err := NewParser().Parse(strings.NewReader(`{"swagger": "2.0"}`), ir.ContractMetadata{})
+	assert.Error(t, err)
+}
\ No newline at end of file
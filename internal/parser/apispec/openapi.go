@@ -0,0 +1,122 @@
+package apispec
+
+import (
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// httpMethods are the operations of an OpenAPI path item, in the order they are converted
+var httpMethods = []string{"get", "head", "options", "put", "post", "patch", "delete", "trace"}
+
+// jsonContent is the media type request and response bodies are read from
+const jsonContent = "application/json"
+
+// operations converts the operations of an OpenAPI document into functions, in path order
+// GET, HEAD and OPTIONS operations become view functions and the others nonpayable ones, so they are only exposed
+// with writes enabled.
+func (d *description) operations() []ir.Function {
+	functions := []ir.Function{}
+	paths := object(d.root["paths"])
+	for _, path := range sortedKeys(paths) {
+		item := d.resolve(paths[path])
+		for _, method := range httpMethods {
+			operation := object(item[method])
+			if operation == nil {
+				continue
+			}
+			functions = append(functions, d.operation(path, method, operation, list(item["parameters"])))
+		}
+	}
+	return functions
+}
+
+// operation converts one operation, with the parameters shared by its path
+func (d *description) operation(path, method string, operation map[string]interface{}, shared []interface{}) ir.Function {
+	name := text(operation["operationId"])
+	if name == "" {
+		name = method + " " + path
+	}
+	f := ir.Function{
+		Name:            d.toolName(name),
+		Description:     text(operation["description"]),
+		Inputs:          []ir.Parameter{},
+		Outputs:         []ir.Parameter{},
+		StateMutability: ir.Nonpayable,
+		Deprecated:      flag(operation["deprecated"]),
+		ChainData:       ir.APIOperation{HTTPMethod: strings.ToUpper(method), HTTPPath: path}.ChainData(),
+	}
+	if f.Description == "" {
+		f.Description = text(operation["summary"])
+	}
+	if method == "get" || method == "head" || method == "options" {
+		f.StateMutability = ir.View
+	}
+	for _, tag := range list(operation["tags"]) {
+		f.Tags = append(f.Tags, text(tag))
+	}
+
+	// Operation parameters override the path's parameters of the same name and location
+	seen := map[string]bool{}
+	add := func(p ir.Parameter) {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			f.Inputs = append(f.Inputs, p)
+		}
+	}
+	for _, value := range append(list(operation["parameters"]), shared...) {
+		parameter := d.resolve(value)
+		in := text(parameter["in"])
+		if in == "cookie" {
+			continue
+		}
+		t := d.parameterType(parameter["schema"], 0)
+		setChainData(&t, ir.ParameterInKey, in)
+		if !flag(parameter["required"]) && in != "path" {
+			setChainData(&t, ir.OptionalKey, true)
+		}
+		add(ir.Parameter{Name: text(parameter["name"]), Type: t, Description: text(parameter["description"]), Default: d.resolve(parameter["schema"])["default"]})
+	}
+
+	// Fields of object request bodies are inputs of their own, other bodies a single requestBody input
+	if body := d.resolve(operation["requestBody"]); body != nil {
+		required := flag(body["required"])
+		t := d.parameterType(object(object(body["content"])[jsonContent])["schema"], 0)
+		if t.BaseType == ir.APIObject && !t.IsArray && len(t.Components) > 0 {
+			for _, field := range t.Components {
+				setChainData(&field.Type, ir.ParameterInKey, "body")
+				if !required {
+					setChainData(&field.Type, ir.OptionalKey, true)
+				}
+				add(field)
+			}
+		} else if object(body["content"])[jsonContent] != nil {
+			setChainData(&t, ir.ParameterInKey, "requestBody")
+			if !required {
+				setChainData(&t, ir.OptionalKey, true)
+			}
+			add(ir.Parameter{Name: "requestBody", Type: t, Description: text(body["description"])})
+		}
+	}
+
+	if response := d.successResponse(object(operation["responses"])); response != nil {
+		if content := object(object(response["content"])[jsonContent]); content != nil {
+			f.Outputs = append(f.Outputs, ir.Parameter{
+				Name:        "result",
+				Type:        d.parameterType(content["schema"], 0),
+				Description: text(response["description"]),
+			})
+		}
+	}
+	return f
+}
+
+// successResponse returns the first success response of an operation, or its default response
+func (d *description) successResponse(responses map[string]interface{}) map[string]interface{} {
+	for _, status := range sortedKeys(responses) {
+		if strings.HasPrefix(status, "2") {
+			return d.resolve(responses[status])
+		}
+	}
+	return d.resolve(responses["default"])
+}
\ No newline at end of file
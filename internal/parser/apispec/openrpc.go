@@ -0,0 +1,56 @@
+package apispec
+
+import (
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// methods converts the methods of an OpenRPC document into functions, in document order
+// OpenRPC does not tell reads from writes, so every method becomes a view function; overlays hide the ones that
+// should not be exposed.
+func (d *description) methods() []ir.Function {
+	functions := []ir.Function{}
+	for _, value := range list(d.root["methods"]) {
+		method := d.resolve(value)
+		name := text(method["name"])
+		f := ir.Function{
+			Name:            d.toolName(name),
+			Description:     text(method["description"]),
+			Inputs:          []ir.Parameter{},
+			Outputs:         []ir.Parameter{},
+			StateMutability: ir.View,
+			Deprecated:      flag(method["deprecated"]),
+			ChainData: ir.APIOperation{
+				RPCMethod:        name,
+				ParamsByPosition: text(method["paramStructure"]) == "by-position",
+			}.ChainData(),
+		}
+		if f.Description == "" {
+			f.Description = text(method["summary"])
+		}
+		for _, tag := range list(method["tags"]) {
+			f.Tags = append(f.Tags, text(d.resolve(tag)["name"]))
+		}
+
+		for _, param := range list(method["params"]) {
+			descriptor := d.resolve(param)
+			t := d.parameterType(descriptor["schema"], 0)
+			if !flag(descriptor["required"]) {
+				setChainData(&t, ir.OptionalKey, true)
+			}
+			f.Inputs = append(f.Inputs, ir.Parameter{Name: text(descriptor["name"]), Type: t, Description: contentDescription(descriptor), Default: d.resolve(descriptor["schema"])["default"]})
+		}
+		if result := d.resolve(method["result"]); result != nil {
+			f.Outputs = append(f.Outputs, ir.Parameter{Name: text(result["name"]), Type: d.parameterType(result["schema"], 0), Description: contentDescription(result)})
+		}
+		functions = append(functions, f)
+	}
+	return functions
+}
+
+// contentDescription returns the description of a content descriptor, or its summary
+func contentDescription(descriptor map[string]interface{}) string {
+	if description := text(descriptor["description"]); description != "" {
+		return description
+	}
+	return text(descriptor["summary"])
+}
\ No newline at end of file
@@ -1,6 +1,7 @@
 package evm
 
 import (
+        "bytes"
         "encoding/json"
         "fmt"
         "io"
@@ -11,23 +12,39 @@ import (
 )
 
 // ABIParser parses Ethereum ABI JSON into the intermediate representation
-type ABIParser struct {
-        // Map to track function signatures for handling overloads
-        functionSignatures map[string]int
-}
+type ABIParser struct{}
 
 // NewABIParser creates a new EVM ABI parser
 func NewABIParser() *ABIParser {
-        return &ABIParser{
-                functionSignatures: make(map[string]int),
-        }
+        return &ABIParser{}
 }
 
 // Parse parses an EVM ABI from a reader into the intermediate representation
+// The ABI is a JSON array, or the abi field of an artifact object such as those of Hardhat, Foundry, Truffle and
+// hardhat-deploy, and the contract is named after the artifact's contractName unless the metadata names it.
 func (p *ABIParser) Parse(reader io.Reader, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+        var content json.RawMessage
+        if err := json.NewDecoder(reader).Decode(&content); err != nil {
+                return nil, fmt.Errorf("failed to decode ABI JSON: %w", err)
+        }
+        if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+                var artifact struct {
+                        ContractName string          `json:"contractName"`
+                        ABI          json.RawMessage `json:"abi"`
+                }
+                if err := json.Unmarshal(content, &artifact); err != nil {
+                        return nil, fmt.Errorf("failed to decode ABI JSON: %w", err)
+                }
+                if len(artifact.ABI) == 0 {
+                        return nil, fmt.Errorf("failed to decode ABI JSON: artifact object has no abi field")
+                }
+                content = artifact.ABI
+                if metadata.Name == "" {
+                        metadata.Name = artifact.ContractName
+                }
+        }
         var abiItems []ABIItem
-        decoder := json.NewDecoder(reader)
-        if err := decoder.Decode(&abiItems); err != nil {
+        if err := json.Unmarshal(content, &abiItems); err != nil {
                 return nil, fmt.Errorf("failed to decode ABI JSON: %w", err)
         }
 
@@ -84,6 +101,12 @@ func (p *ABIParser) Parse(reader io.Reader, metadata ir.ContractMetadata) (*ir.C
                 }
         }
 
+        // Overloads share their ABI name; their tools are told apart by suffixes until a generator picks another naming
+        ir.MarkOverloads(contract)
+        if err := ir.NameOverloads(contract, ir.OverloadSuffix); err != nil {
+                return nil, err
+        }
+
         return contract, nil
 }
 
@@ -102,65 +125,8 @@ func (p *ABIParser) parseFunction(item ABIItem) (ir.Function, error) {
         // Build function signature
         signature := buildFunctionSignature(item.Name, item.Inputs)
 
-        // Handle function overloads
-        functionName := item.Name
-        if count, exists := p.functionSignatures[item.Name]; exists {
-                // This is an overloaded function, append a suffix to make it unique
-                p.functionSignatures[item.Name] = count + 1
-                functionName = fmt.Sprintf("%s_%d", item.Name, count)
-        } else {
-                p.functionSignatures[item.Name] = 1
-        }
-
-        // Generate a better description based on the function name and inputs
-        description := item.Name
-        if len(inputs) > 0 {
-                description += " - Parameters: "
-                for i, input := range inputs {
-                        if i > 0 {
-                                description += ", "
-                        }
-                        typeStr := string(input.Type.BaseType)
-                        if input.Type.IsArray {
-                                if input.Type.ArraySize > 0 {
-                                        typeStr += fmt.Sprintf("[%d]", input.Type.ArraySize)
-                                } else {
-                                        typeStr += "[]"
-                                }
-                        } else if len(input.Type.Components) > 0 {
-                                typeStr = "tuple"
-                        }
-                        description += input.Name + " (" + typeStr + ")"
-                }
-        }
-        
-        if len(outputs) > 0 {
-                description += " - Returns: "
-                for i, output := range outputs {
-                        if i > 0 {
-                                description += ", "
-                        }
-                        outputName := output.Name
-                        if outputName == "" {
-                                outputName = fmt.Sprintf("output%d", i)
-                        }
-                        typeStr := string(output.Type.BaseType)
-                        if output.Type.IsArray {
-                                if output.Type.ArraySize > 0 {
-                                        typeStr += fmt.Sprintf("[%d]", output.Type.ArraySize)
-                                } else {
-                                        typeStr += "[]"
-                                }
-                        } else if len(output.Type.Components) > 0 {
-                                typeStr = "tuple"
-                        }
-                        description += outputName + " (" + typeStr + ")"
-                }
-        }
-
         // Calculate function selector (first 4 bytes of keccak256 hash of the signature)
-        // In a real implementation, we would compute this, but for now we'll leave it empty
-        selector := ""
+        selector := ir.Selector(signature)
 
         // Determine state mutability
         stateMutability := ir.StateMutability(item.StateMutability)
@@ -176,31 +142,51 @@ func (p *ABIParser) parseFunction(item ABIItem) (ir.Function, error) {
         }
 
         // Create chain-specific data
-        chainData := make(map[string]interface{})
-        if item.Constant {
-                chainData["constant"] = item.Constant
-        }
-        if item.Payable {
-                chainData["payable"] = item.Payable
-        }
-        
-        // Store original name and signature for overloaded functions
-        if functionName != item.Name {
-                chainData["originalName"] = item.Name
-                chainData["originalSignature"] = signature
-        }
+        chainData := ir.EVMFunction{Constant: item.Constant, Payable: item.Payable}
 
-        return ir.Function{
-                Name:            functionName,
-                Description:     description,
+        function := ir.Function{
+                Name:            item.Name,
                 Signature:       signature,
                 Selector:        selector,
                 Inputs:          inputs,
                 Outputs:         outputs,
                 StateMutability: stateMutability,
                 Visibility:      ir.Public, // Default to public for EVM functions in ABI
-                ChainData:       chainData,
-        }, nil
+                ChainData:       chainData.ChainData(),
+        }
+
+        // Name unnamed parameters first, so the description calls them what generated tools do
+        ir.NameFunctionParameters(&function)
+
+        // Generate a better description based on the function name and inputs
+        function.Description = item.Name
+        if len(function.Inputs) > 0 {
+                function.Description += " - Parameters: " + describeParameters(function.Inputs)
+        }
+        if len(function.Outputs) > 0 {
+                function.Description += " - Returns: " + describeParameters(function.Outputs)
+        }
+
+        return function, nil
+}
+
+// describeParameters lists parameters by name and type for descriptions, e.g. "to (address), amounts (uint256[])"
+func describeParameters(parameters []ir.Parameter) string {
+        described := make([]string, len(parameters))
+        for i, parameter := range parameters {
+                typeStr := string(parameter.Type.BaseType)
+                if parameter.Type.IsArray {
+                        if parameter.Type.ArraySize > 0 {
+                                typeStr += fmt.Sprintf("[%d]", parameter.Type.ArraySize)
+                        } else {
+                                typeStr += "[]"
+                        }
+                } else if len(parameter.Type.Components) > 0 {
+                        typeStr = "tuple"
+                }
+                described[i] = parameter.Name + " (" + typeStr + ")"
+        }
+        return strings.Join(described, ", ")
 }
 
 // parseEvent converts an ABI event item to IR Event
@@ -213,6 +199,7 @@ func (p *ABIParser) parseEvent(item ABIItem) (ir.Event, error) {
                 if err != nil {
                         return ir.Event{}, fmt.Errorf("failed to parse event parameter type: %w", err)
                 }
+                recordInternalType(&paramType, input.InternalType)
                 
                 // Count indexed parameters (EVM allows up to 3)
                 if input.Indexed {
@@ -226,17 +213,15 @@ func (p *ABIParser) parseEvent(item ABIItem) (ir.Event, error) {
                 }
         }
 
-        // Build event signature
+        // Build event signature, and the topic of its logs unless the event is anonymous
         signature := buildEventSignature(item.Name, item.Inputs)
-
-        // Create chain-specific data
-        chainData := make(map[string]interface{})
-        if item.Anonymous {
-                chainData["anonymous"] = item.Anonymous
+        topic := ""
+        if !item.Anonymous {
+                topic = ir.Topic(signature)
         }
-        
-        // Add indexed parameters information
-        chainData["indexedCount"] = indexedCount
+
+        // Create chain-specific data, with indexed parameters information
+        chainData := ir.EVMEvent{Anonymous: item.Anonymous, IndexedCount: indexedCount}
         
         // Generate a better description that includes indexed parameters
         description := fmt.Sprintf("%s event", item.Name)
@@ -270,8 +255,9 @@ func (p *ABIParser) parseEvent(item ABIItem) (ir.Event, error) {
                 Name:        item.Name,
                 Description: description,
                 Signature:   signature,
+                Topic:       topic,
                 Parameters:  parameters,
-                ChainData:   chainData,
+                ChainData:   chainData.ChainData(),
         }, nil
 }
 
@@ -364,6 +350,8 @@ func (p *ABIParser) parseParameters(inputs []ABIInput) ([]ir.Parameter, error) {
                         return nil, err
                 }
 
+                recordInternalType(&paramType, input.InternalType)
+
                 parameters[i] = ir.Parameter{
                         Name: input.Name,
                         Type: paramType,
@@ -372,6 +360,13 @@ func (p *ABIParser) parseParameters(inputs []ABIInput) ([]ir.Parameter, error) {
         return parameters, nil
 }
 
+// recordInternalType records the Solidity type of a tuple (e.g., "struct Pool.Key"), from which struct names are derived
+func recordInternalType(paramType *ir.ParameterType, internalType string) {
+        if internalType != "" && len(paramType.Components) > 0 {
+                paramType.ChainData[ir.InternalTypeKey] = internalType
+        }
+}
+
 // parseParameterType converts an ABI type string to IR ParameterType
 func (p *ABIParser) parseParameterType(typeStr string, components []ABIInput) (ir.ParameterType, error) {
         paramType := ir.ParameterType{}
@@ -415,8 +410,7 @@ func (p *ABIParser) parseParameterType(typeStr string, components []ABIInput) (i
                 paramType.Components = componentParams
                 
                 // Add additional metadata for complex types
-                chainData := make(map[string]interface{})
-                chainData["isTuple"] = true
+                chainData := ir.EVMType{IsTuple: true}
                 
                 // Create a type description for the tuple
                 typeDesc := "{"
@@ -435,9 +429,9 @@ func (p *ABIParser) parseParameterType(typeStr string, components []ABIInput) (i
                         typeDesc += comp.Name + ": " + compTypeStr
                 }
                 typeDesc += "}"
-                chainData["typeDescription"] = typeDesc
+                chainData.TypeDescription = typeDesc
                 
-                paramType.ChainData = chainData
+                paramType.ChainData = chainData.ChainData()
         }
 
         // Handle mapping types (not directly supported in ABI, but we can detect some common patterns)
@@ -457,16 +451,15 @@ func (p *ABIParser) parseParameterType(typeStr string, components []ABIInput) (i
         
         // Add array-specific metadata
         if paramType.IsArray {
-                if paramType.ChainData == nil {
-                        paramType.ChainData = make(map[string]interface{})
-                }
-                paramType.ChainData["isArray"] = true
+                chainData := paramType.EVM()
+                chainData.IsArray = true
                 if paramType.ArraySize > 0 {
-                        paramType.ChainData["isFixedArray"] = true
-                        paramType.ChainData["arraySize"] = paramType.ArraySize
+                        chainData.IsFixedArray = true
+                        chainData.ArraySize = paramType.ArraySize
                 } else {
-                        paramType.ChainData["isDynamicArray"] = true
+                        chainData.IsDynamicArray = true
                 }
+                paramType.ChainData = chainData.ChainData()
         }
 
         return paramType, nil
@@ -477,7 +470,7 @@ func buildFunctionSignature(name string, inputs []ABIInput) string {
         signature := name + "("
         inputTypes := make([]string, len(inputs))
         for i, input := range inputs {
-                inputTypes[i] = input.Type
+                inputTypes[i] = canonicalType(input)
         }
         signature += strings.Join(inputTypes, ",") + ")"
         return signature
@@ -488,7 +481,7 @@ func buildEventSignature(name string, inputs []ABIInput) string {
         signature := name + "("
         inputTypes := make([]string, len(inputs))
         for i, input := range inputs {
-                inputTypes[i] = input.Type
+                inputTypes[i] = canonicalType(input)
         }
         signature += strings.Join(inputTypes, ",") + ")"
         return signature
@@ -499,12 +492,25 @@ func buildErrorSignature(name string, inputs []ABIInput) string {
         signature := name + "("
         inputTypes := make([]string, len(inputs))
         for i, input := range inputs {
-                inputTypes[i] = input.Type
+                inputTypes[i] = canonicalType(input)
         }
         signature += strings.Join(inputTypes, ",") + ")"
         return signature
 }
 
+// canonicalType returns the type of a parameter as written in canonical signatures, expanding tuples into their
+// component types (e.g., "(address,uint256)[]" for tuple[])
+func canonicalType(input ABIInput) string {
+        if !strings.HasPrefix(input.Type, "tuple") {
+                return input.Type
+        }
+        components := make([]string, len(input.Components))
+        for i, component := range input.Components {
+                components[i] = canonicalType(component)
+        }
+        return "(" + strings.Join(components, ",") + ")" + strings.TrimPrefix(input.Type, "tuple")
+}
+
 // ABIItem represents an item in the Ethereum ABI
 type ABIItem struct {
         Type            string     `json:"type"`
@@ -519,8 +525,9 @@ type ABIItem struct {
 
 // ABIInput represents an input or output parameter in the Ethereum ABI
 type ABIInput struct {
-        Name       string     `json:"name"`
-        Type       string     `json:"type"`
-        Components []ABIInput `json:"components"`
-        Indexed    bool       `json:"indexed"`
+        Name         string     `json:"name"`
+        Type         string     `json:"type"`
+        InternalType string     `json:"internalType,omitempty"`
+        Components   []ABIInput `json:"components"`
+        Indexed      bool       `json:"indexed"`
 }
\ No newline at end of file
@@ -70,6 +70,8 @@ func TestABIParser_Parse(t *testing.T) {
 	assert.Equal(t, "address", balanceOf.Inputs[0].Type.BaseType)
 	assert.Len(t, balanceOf.Outputs, 1)
 	assert.Equal(t, "uint256", balanceOf.Outputs[0].Type.BaseType)
+	assert.Equal(t, "balance", balanceOf.Outputs[0].Name)
+	assert.Equal(t, "balanceOf - Parameters: owner (address) - Returns: balance (uint256)", balanceOf.Description)
 
 	// Check transfer function
 	transfer := contractIR.Functions[1]
@@ -82,6 +84,7 @@ func TestABIParser_Parse(t *testing.T) {
 	assert.Equal(t, "uint256", transfer.Inputs[1].Type.BaseType)
 	assert.Len(t, transfer.Outputs, 1)
 	assert.Equal(t, "bool", transfer.Outputs[0].Type.BaseType)
+	assert.Equal(t, "transfer - Parameters: to (address), value (uint256) - Returns: success (bool)", transfer.Description)
 
 	// Check events
 	assert.Len(t, contractIR.Events, 1)
@@ -119,6 +122,7 @@ func TestABIParser_ComplexTypes(t *testing.T) {
 				{
 					"name": "person",
 					"type": "tuple",
+					"internalType": "struct Registry.Person",
 					"components": [
 						{"name": "name", "type": "string"},
 						{"name": "age", "type": "uint256"},
@@ -174,6 +178,7 @@ func TestABIParser_ComplexTypes(t *testing.T) {
 	assert.Equal(t, "person", processPerson.Inputs[0].Name)
 	assert.Equal(t, "tuple", processPerson.Inputs[0].Type.BaseType)
 	assert.Len(t, processPerson.Inputs[0].Type.Components, 3)
+	assert.Equal(t, "struct Registry.Person", processPerson.Inputs[0].Type.ChainData["internalType"])
 	
 	// Check struct components
 	components := processPerson.Inputs[0].Type.Components
@@ -229,6 +234,11 @@ func TestABIParser_FunctionOverloads(t *testing.T) {
 	
 	// Signatures should be different
 	assert.NotEqual(t, contractIR.Functions[0].Signature, contractIR.Functions[1].Signature)
+
+	// Both record the overload set they belong to, by which they are called
+	assert.Equal(t, &ir.Overload{Name: "setValue", Index: 0, Signature: "setValue(uint256)"}, contractIR.Functions[0].Overload)
+	assert.Equal(t, &ir.Overload{Name: "setValue", Index: 1, Signature: "setValue(string)"}, contractIR.Functions[1].Overload)
+	assert.Equal(t, "setValue", contractIR.Functions[1].ABIName())
 }
 
 func TestABIParser_Errors(t *testing.T) {
@@ -335,4 +345,32 @@ func TestABIParser_SpecialFunctions(t *testing.T) {
 	assert.Equal(t, "receive", receive.Name)
 	assert.True(t, receive.IsReceive)
 	assert.Equal(t, ir.Payable, receive.StateMutability)
+}
+func TestABIParser_ArtifactObject(t *testing.T) {
+	parser := NewABIParser()
+	metadata := ir.ContractMetadata{Name: "Counter"}
+
+	// Hardhat, Foundry, Truffle and hardhat-deploy artifacts hold the ABI in their abi field
+	artifact := `{
+		"address": "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		"abi": [{"type": "function", "name": "number", "inputs": [], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"}],
+		"bytecode": "0x6080"
+	}`
+	contractIR, err := parser.Parse(strings.NewReader(artifact), metadata)
+	assert.NoError(t, err)
+	assert.Len(t, contractIR.Functions, 1)
+	assert.Equal(t, "number", contractIR.Functions[0].Name)
+	assert.Equal(t, "Counter", contractIR.Metadata.Name)
+
+	// Without a name in the metadata, the contract is named after the artifact's contractName
+	named := `{"contractName": "Lock", "abi": []}`
+	contractIR, err = parser.Parse(strings.NewReader(named), ir.ContractMetadata{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lock", contractIR.Metadata.Name)
+	contractIR, err = parser.Parse(strings.NewReader(named), metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, "Counter", contractIR.Metadata.Name)
+
+	_, err = parser.Parse(strings.NewReader(`{"bytecode": "0x6080"}`), metadata)
+	assert.EqualError(t, err, "failed to decode ABI JSON: artifact object has no abi field")
 }
\ No newline at end of file
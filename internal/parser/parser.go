@@ -1,9 +1,13 @@
 package parser
 
 import (
+	"fmt"
 	"io"
+	"sort"
+	"sync"
 
 	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/parser/apispec"
 	"github.com/openhands/mcp-generator/internal/parser/evm"
 )
 
@@ -16,4 +20,125 @@ type Parser interface {
 // NewEVMABIParser creates a new EVM ABI parser
 func NewEVMABIParser() Parser {
 	return evm.NewABIParser()
+}
+
+// NewAPISpecParser creates a new parser of OpenAPI and OpenRPC documents
+func NewAPISpecParser() Parser {
+	return apispec.NewParser()
+}
+
+// Registration describes a parser registered for a chain
+type Registration struct {
+	// Chain identifier selecting the parser with --chain (e.g., "ethereum")
+	Chain string `json:"chain"`
+
+	// Other identifiers selecting the parser (e.g., "evm")
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Kind of artifact parsed, as named in errors (e.g., "EVM ABI")
+	Artifact string `json:"artifact"`
+
+	// Human-readable description
+	Description string `json:"description"`
+
+	// Whether artifacts are recognized by their content, whatever the chain selected
+	Detected bool `json:"detected,omitempty"`
+
+	// Names of the generate-mcp flags specific to the chain's contracts (e.g., "overload-naming")
+	Flags []string `json:"flags,omitempty"`
+
+	// Path of the plugin providing the parser, empty for built-in parsers
+	Plugin string `json:"plugin,omitempty"`
+
+	// New creates a parser
+	New func() Parser `json:"-"`
+}
+
+var (
+	registrationsMu sync.RWMutex
+	registrations   = make(map[string]Registration)
+)
+
+func init() {
+	for _, registration := range []Registration{
+		{
+			Chain:       "ethereum",
+			Aliases:     []string{"evm"},
+			Artifact:    "EVM ABI",
+			Description: "Solidity and Vyper contract ABIs (JSON) of Ethereum and other EVM chains",
+			Flags:       []string{"address", "deployment", "standard", "lookup", "overload-naming", "detect-amounts", "detect-access"},
+			New:         NewEVMABIParser,
+		},
+		{
+			Chain:       ir.ChainOpenAPI,
+			Artifact:    "API description",
+			Description: "OpenAPI 3 documents (JSON or YAML), whose operations become tools of an HTTP API",
+			Detected:    true,
+			New:         NewAPISpecParser,
+		},
+		{
+			Chain:       ir.ChainOpenRPC,
+			Artifact:    "API description",
+			Description: "OpenRPC documents (JSON or YAML), whose methods become tools of a JSON-RPC API",
+			Detected:    true,
+			New:         NewAPISpecParser,
+		},
+	} {
+		if err := Register(registration); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register makes a parser available by its chain identifier and aliases
+func Register(registration Registration) error {
+	if registration.Chain == "" || registration.New == nil {
+		return fmt.Errorf("parser registrations need a chain and a constructor")
+	}
+
+	registrationsMu.Lock()
+	defer registrationsMu.Unlock()
+
+	for _, chain := range append([]string{registration.Chain}, registration.Aliases...) {
+		if _, exists := registrations[chain]; exists {
+			return fmt.Errorf("a parser for chain %s is already registered", chain)
+		}
+	}
+	for _, chain := range append([]string{registration.Chain}, registration.Aliases...) {
+		registrations[chain] = registration
+	}
+	return nil
+}
+
+// Lookup returns the parser registered for a chain identifier or alias
+func Lookup(chain string) (Registration, bool) {
+	registrationsMu.RLock()
+	defer registrationsMu.RUnlock()
+
+	registration, ok := registrations[chain]
+	return registration, ok
+}
+
+// Registrations returns the registered parsers, sorted by chain
+func Registrations() []Registration {
+	registrationsMu.RLock()
+	defer registrationsMu.RUnlock()
+
+	var list []Registration
+	for chain, registration := range registrations {
+		if chain == registration.Chain {
+			list = append(list, registration)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Chain < list[j].Chain })
+	return list
+}
+
+// Chains returns the chain identifiers of the registered parsers, sorted
+func Chains() []string {
+	var chains []string
+	for _, registration := range Registrations() {
+		chains = append(chains, registration.Chain)
+	}
+	return chains
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestRegistry(t *testing.T) {
+	if expected := []string{"ethereum", ir.ChainOpenAPI, ir.ChainOpenRPC}; !reflect.DeepEqual(Chains(), expected) {
+		t.Errorf("Expected chains %v but got %v", expected, Chains())
+	}
+
+	registration, ok := Lookup("evm")
+	if !ok || registration.Chain != "ethereum" {
+		t.Fatalf("Expected the evm alias to select the ethereum parser but got %+v", registration)
+	}
+	contractIR, err := registration.New().Parse(strings.NewReader(`[{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`), ir.ContractMetadata{Name: "Token", Chain: "evm"})
+	if err != nil || len(contractIR.Functions) != 1 {
+		t.Fatalf("Expected the registered parser to parse the ABI but got %v, %v", contractIR, err)
+	}
+
+	if err := Register(Registration{Chain: "sol", Aliases: []string{"ethereum"}, New: NewEVMABIParser}); err == nil {
+		t.Error("Expected registering a taken alias to fail")
+	}
+	if _, ok := Lookup("sol"); ok {
+		t.Error("Expected a failed registration to register nothing")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,300 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/logging"
+	"github.com/openhands/mcp-generator/internal/parser"
+	"github.com/openhands/mcp-generator/internal/template"
+)
+
+// ProtocolVersion is the version of the plugin protocol, sent with every request and expected in describe responses
+const ProtocolVersion = 1
+
+// Methods of the plugin protocol
+const (
+	// MethodDescribe asks a plugin for the parsers and generators it provides
+	MethodDescribe = "describe"
+
+	// MethodParse asks a plugin to parse an artifact into an IR
+	MethodParse = "parse"
+
+	// MethodGenerate asks a plugin to render the files of a server from an IR
+	MethodGenerate = "generate"
+)
+
+// describeTimeout bounds how long plugins may take to describe themselves, as every command runs describe
+const describeTimeout = 10 * time.Second
+
+// Request is a request to a plugin, written as JSON to its stdin
+// Plugins are run once per request and answer with a Response on stdout; stderr is reported with failures.
+type Request struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	Method          string `json:"method"`
+
+	// Chain of the parser and contract metadata given on the command line, and the artifact's content (parse)
+	Chain    string               `json:"chain,omitempty"`
+	Metadata *ir.ContractMetadata `json:"metadata,omitempty"`
+	Artifact string               `json:"artifact,omitempty"`
+
+	// Language of the generator, the IR and the values of the generator's flags, keyed by flag name (generate)
+	Language string            `json:"language,omitempty"`
+	IR       *ir.ContractIR    `json:"ir,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// Response is the answer of a plugin to a request
+type Response struct {
+	ProtocolVersion int `json:"protocolVersion,omitempty"`
+
+	// Parsers and generators the plugin provides (describe)
+	Parsers    []ParserDescription    `json:"parsers,omitempty"`
+	Generators []GeneratorDescription `json:"generators,omitempty"`
+
+	// IR of the artifact (parse)
+	IR *ir.ContractIR `json:"ir,omitempty"`
+
+	// Files of the server by path, base64-encoded (generate)
+	Files map[string][]byte `json:"files,omitempty"`
+
+	// Error message of a failed request
+	Error string `json:"error,omitempty"`
+}
+
+// ParserDescription describes a parser of a plugin, as registered for --chain
+type ParserDescription struct {
+	Chain       string   `json:"chain"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Artifact    string   `json:"artifact,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Flags       []string `json:"flags,omitempty"`
+}
+
+// GeneratorDescription describes a generator of a plugin, as registered for --lang
+// Flags are the names of generate-mcp flags whose values the generator receives as options.
+type GeneratorDescription struct {
+	Language    string   `json:"language"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Flags       []string `json:"flags,omitempty"`
+
+	// Commands checking that a generated server builds, run in a copy of the project by --verify
+	Verify [][]string `json:"verify,omitempty"`
+}
+
+// DefaultDir returns the default plugins directory: mcp-generator/plugins in the user's config directory
+func DefaultDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mcp-generator", "plugins")
+}
+
+// Discover returns the executables of a plugins directory, sorted; a missing directory has none
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory %s: %w", dir, err)
+	}
+	var plugins []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			if !strings.EqualFold(filepath.Ext(entry.Name()), ".exe") {
+				continue
+			}
+		} else if info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		plugins = append(plugins, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(plugins)
+	return plugins, nil
+}
+
+var (
+	loadedMu sync.Mutex
+	loaded   = make(map[string]bool)
+)
+
+// Load registers the parsers and generators of the plugins of a directory
+// Plugins that fail to describe themselves, or whose chains or languages are already registered, are skipped with a
+// warning. Plugins are loaded once per process, so that commands run repeatedly (e.g. by serve-mcp) can load them.
+func Load(dir string, logger *logging.Logger) error {
+	if dir == "" {
+		return nil
+	}
+	plugins, err := Discover(dir)
+	if err != nil {
+		return err
+	}
+
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+
+	for _, path := range plugins {
+		if loaded[path] {
+			continue
+		}
+		loaded[path] = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+		response, err := Call(ctx, path, Request{Method: MethodDescribe})
+		cancel()
+		if err != nil {
+			logger.Warnf("skipping plugin %s: %v", path, err)
+			continue
+		}
+		if response.ProtocolVersion != ProtocolVersion {
+			logger.Warnf("skipping plugin %s: protocol version %d is not supported (expected %d)", path, response.ProtocolVersion, ProtocolVersion)
+			continue
+		}
+		for _, description := range response.Parsers {
+			if err := parser.Register(parserRegistration(path, description)); err != nil {
+				logger.Warnf("skipping parser of plugin %s: %v", path, err)
+				continue
+			}
+			logger.Debugf("Plugin %s: parser for chain %s", path, description.Chain)
+		}
+		for _, description := range response.Generators {
+			if err := template.RegisterGenerator(generator(path, description)); err != nil {
+				logger.Warnf("skipping generator of plugin %s: %v", path, err)
+				continue
+			}
+			logger.Debugf("Plugin %s: generator for language %s", path, description.Language)
+		}
+	}
+	return nil
+}
+
+// Call runs a plugin with a request and returns its response; failures of the plugin, exiting non-zero or
+// answering an error, are errors
+func Call(ctx context.Context, path string, request Request) (*Response, error) {
+	request.ProtocolVersion = ProtocolVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, path)
+	command.Stdin = bytes.NewReader(input)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	runErr := command.Run()
+
+	var response Response
+	decodeErr := json.Unmarshal(stdout.Bytes(), &response)
+	switch {
+	case decodeErr == nil && response.Error != "":
+		return nil, errors.New(response.Error)
+	case runErr != nil:
+		return nil, fmt.Errorf("%s failed: %w%s", request.Method, runErr, stderrSuffix(stderr.String()))
+	case decodeErr != nil:
+		return nil, fmt.Errorf("invalid %s response: %v%s", request.Method, decodeErr, stderrSuffix(stderr.String()))
+	}
+	return &response, nil
+}
+
+// stderrSuffix returns what a plugin wrote to stderr, to append to its failures
+func stderrSuffix(stderr string) string {
+	message := strings.TrimSpace(stderr)
+	if message == "" {
+		return ""
+	}
+	return ": " + message
+}
+
+// parserRegistration returns the registration of a plugin's parser
+func parserRegistration(path string, description ParserDescription) parser.Registration {
+	artifact := description.Artifact
+	if artifact == "" {
+		artifact = description.Chain + " artifact"
+	}
+	return parser.Registration{
+		Chain:       description.Chain,
+		Aliases:     description.Aliases,
+		Artifact:    artifact,
+		Description: description.Description,
+		Flags:       description.Flags,
+		Plugin:      path,
+		New: func() parser.Parser {
+			return &pluginParser{path: path, chain: description.Chain}
+		},
+	}
+}
+
+// pluginParser parses artifacts by calling a plugin
+type pluginParser struct {
+	path  string
+	chain string
+}
+
+// Parse sends an artifact to the plugin and returns the IR it answers
+func (p *pluginParser) Parse(reader io.Reader, metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+	artifact, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	response, err := Call(context.Background(), p.path, Request{Method: MethodParse, Chain: p.chain, Metadata: &metadata, Artifact: string(artifact)})
+	if err != nil {
+		return nil, err
+	}
+	if response.IR == nil {
+		return nil, fmt.Errorf("plugin %s answered no IR", p.path)
+	}
+	return response.IR, nil
+}
+
+// generator returns the registration of a plugin's generator
+func generator(path string, description GeneratorDescription) template.Generator {
+	var verify func(map[string]string) [][]string
+	if len(description.Verify) > 0 {
+		verify = func(map[string]string) [][]string { return description.Verify }
+	}
+	return template.Generator{
+		Language:    description.Language,
+		Aliases:     description.Aliases,
+		Description: description.Description,
+		Flags:       description.Flags,
+		Plugin:      path,
+		Verify:      verify,
+		Render: func(contractIR *ir.ContractIR, options map[string]string) (map[string][]byte, error) {
+			response, err := Call(context.Background(), path, Request{Method: MethodGenerate, Language: description.Language, IR: contractIR, Options: options})
+			if err != nil {
+				return nil, err
+			}
+			if len(response.Files) == 0 {
+				return nil, fmt.Errorf("plugin %s answered no files", path)
+			}
+			for file := range response.Files {
+				if clean := filepath.ToSlash(filepath.Clean(file)); filepath.IsAbs(file) || clean == ".." || strings.HasPrefix(clean, "../") {
+					return nil, fmt.Errorf("plugin %s answered a file outside the project: %s", path, file)
+				}
+			}
+			return response.Files, nil
+		},
+	}
+}
\ No newline at end of file
@@ -0,0 +1,113 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/parser"
+	"github.com/openhands/mcp-generator/internal/template"
+)
+
+// TestHelperPlugin is not a test: it is the plugin run by the tests, re-executing the test binary
+func TestHelperPlugin(t *testing.T) {
+	if os.Getenv("MCPGEN_TEST_PLUGIN") != "1" {
+		return
+	}
+	var request Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	var response Response
+	switch request.Method {
+	case MethodDescribe:
+		response = Response{
+			ProtocolVersion: ProtocolVersion,
+			Parsers:         []ParserDescription{{Chain: "testchain", Aliases: []string{"tc"}, Artifact: "test IDL", Description: "Test chain"}},
+			Generators:      []GeneratorDescription{{Language: "testlang", Description: "Test language", Flags: []string{"transport"}}},
+		}
+	case MethodParse:
+		if strings.TrimSpace(request.Artifact) == "" {
+			response.Error = "empty artifact"
+			break
+		}
+		response.IR = &ir.ContractIR{Metadata: ir.ContractMetadata{Name: request.Metadata.Name, Chain: request.Chain}, Functions: []ir.Function{{Name: strings.TrimSpace(request.Artifact)}}}
+	case MethodGenerate:
+		response.Files = map[string][]byte{"server.txt": []byte(request.IR.Metadata.Name + " over " + request.Options["transport"])}
+	}
+	json.NewEncoder(os.Stdout).Encode(response)
+	os.Exit(0)
+}
+
+// writePlugin writes a plugin running TestHelperPlugin into a new plugins directory
+func writePlugin(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a POSIX shell")
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nMCPGEN_TEST_PLUGIN=1 exec %q -test.run=TestHelperPlugin\n", executable)
+	if err := os.WriteFile(filepath.Join(dir, "testchain"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadPlugin(t *testing.T) {
+	dir := writePlugin(t)
+	if plugins, err := Discover(dir); err != nil || len(plugins) != 1 {
+		t.Fatalf("Expected the one executable to be discovered but got %v (%v)", plugins, err)
+	}
+	if err := Load(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(dir, nil); err != nil {
+		t.Fatalf("Expected loading again to be a no-op but got %v", err)
+	}
+
+	registration, ok := parser.Lookup("tc")
+	if !ok || registration.Chain != "testchain" || registration.Plugin == "" {
+		t.Fatalf("Expected the plugin's parser to be registered but got %+v", registration)
+	}
+	contractIR, err := registration.New().Parse(strings.NewReader("hello\n"), ir.ContractMetadata{Name: "Token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contractIR.Metadata.Chain != "testchain" || len(contractIR.Functions) != 1 || contractIR.Functions[0].Name != "hello" {
+		t.Errorf("Unexpected IR: %+v", contractIR)
+	}
+	if _, err := registration.New().Parse(strings.NewReader(" "), ir.ContractMetadata{}); err == nil || err.Error() != "empty artifact" {
+		t.Errorf("Expected the plugin's error but got %v", err)
+	}
+
+	generator, ok := template.LookupGenerator("testlang")
+	if !ok || generator.Render == nil {
+		t.Fatalf("Expected the plugin's generator to be registered but got %+v", generator)
+	}
+	files, err := generator.Render(contractIR, map[string]string{"transport": "http"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(files["server.txt"]) != "Token over http" {
+		t.Errorf("Unexpected files: %v", files)
+	}
+}
+
+func TestMissingDirectory(t *testing.T) {
+	if plugins, err := Discover(filepath.Join(t.TempDir(), "missing")); err != nil || plugins != nil {
+		t.Errorf("Expected a missing directory to have no plugins but got %v (%v)", plugins, err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,167 @@
+// Package standards bundles the canonical ABIs of common token standards with curated descriptions, so that servers
+// for standard contracts can be generated from their address alone
+package standards
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/output"
+	"github.com/openhands/mcp-generator/internal/parser/evm"
+)
+
+// Scheme prefixes the artifact references of bundled standards, e.g. standard:erc20
+const Scheme = "standard:"
+
+// data holds the ABI of each standard, <name>.json, and the overlays describing it, <name>.yaml
+//
+//go:embed data
+var data embed.FS
+
+// Standard is a token standard whose ABI is bundled
+type Standard struct {
+	// Name the standard is selected by (e.g., "erc20"), and other names it is selected by
+	Name    string
+	Aliases []string
+
+	// Name of the standard as written in its specification (e.g., "ERC-20")
+	Title string
+
+	// Name of the contracts generated from the standard unless --name is given (e.g., "ERC20")
+	Contract string
+
+	// One-line summary
+	Summary string
+
+	// Overlays describing the standard's functions and events, applied in order; extensions of ERC-20 are
+	// described by the ERC-20 overlay, then their own
+	overlays []string
+}
+
+// standards are the bundled standards
+var standards = []Standard{
+	{Name: "erc20", Title: "ERC-20", Contract: "ERC20", Summary: "Fungible token", overlays: []string{"erc20"}},
+	{Name: "erc20-permit", Aliases: []string{"permit", "erc2612"}, Title: "ERC-20 with ERC-2612 permits", Contract: "ERC20Permit", Summary: "Fungible token with allowances set from off-chain signatures", overlays: []string{"erc20", "erc20-permit"}},
+	{Name: "erc721", Title: "ERC-721", Contract: "ERC721", Summary: "Non-fungible token (NFT) collection with metadata", overlays: []string{"erc721"}},
+	{Name: "erc1155", Title: "ERC-1155", Contract: "ERC1155", Summary: "Multi token contract of fungible and non-fungible token types", overlays: []string{"erc1155"}},
+	{Name: "erc4626", Title: "ERC-4626", Contract: "ERC4626", Summary: "Tokenized vault issuing shares of an underlying ERC-20 asset", overlays: []string{"erc20", "erc4626"}},
+}
+
+// List returns the bundled standards
+func List() []Standard {
+	return append([]Standard(nil), standards...)
+}
+
+// Names returns the names of the bundled standards
+func Names() []string {
+	names := make([]string, len(standards))
+	for i, s := range standards {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Lookup returns the standard of a name or alias, ignoring case, dashes and underscores (e.g., "ERC-20")
+func Lookup(name string) (Standard, error) {
+	key := normalize(name)
+	for _, s := range standards {
+		if normalize(s.Name) == key {
+			return s, nil
+		}
+		for _, alias := range s.Aliases {
+			if normalize(alias) == key {
+				return s, nil
+			}
+		}
+	}
+	return Standard{}, fmt.Errorf("unknown standard: %s (expected one of %s)", name, strings.Join(Names(), ", "))
+}
+
+// normalize returns a standard name in lower case, without dashes and underscores
+func normalize(name string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// IsReference reports whether an artifact path is a standard reference
+func IsReference(path string) bool {
+	return strings.HasPrefix(path, Scheme)
+}
+
+// ParseReference returns the standard of a reference, standard:<name>
+func ParseReference(path string) (Standard, error) {
+	if !IsReference(path) {
+		return Standard{}, fmt.Errorf("invalid standard reference %s (expected %s<name>)", path, Scheme)
+	}
+	return Lookup(strings.TrimPrefix(path, Scheme))
+}
+
+// Reference returns the artifact reference of the standard
+func (s Standard) Reference() string {
+	return Scheme + s.Name
+}
+
+// ABI returns the standard's canonical ABI, a JSON array
+func (s Standard) ABI() []byte {
+	content, err := data.ReadFile("data/" + s.Name + ".json")
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// Overlays returns the overlays describing the standard, in the order they apply
+func (s Standard) Overlays() ([]*ir.Overlay, error) {
+	overlays := make([]*ir.Overlay, 0, len(s.overlays))
+	for _, name := range s.overlays {
+		content, err := data.ReadFile("data/" + name + ".yaml")
+		if err != nil {
+			return nil, err
+		}
+		overlay, err := ir.ParseOverlay(content, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overlay of %s: %w", s.Title, err)
+		}
+		overlays = append(overlays, overlay)
+	}
+	return overlays, nil
+}
+
+// Hash returns the hash of the standard's ABI and overlays, which change with the generator's version
+func (s Standard) Hash() string {
+	var content bytes.Buffer
+	content.Write(s.ABI())
+	for _, name := range s.overlays {
+		overlay, _ := data.ReadFile("data/" + name + ".yaml")
+		content.Write(overlay)
+	}
+	return output.Hash(content.Bytes())
+}
+
+// Load parses the standard's ABI into an IR described by its overlays, named after the standard unless the metadata
+// names it
+func (s Standard) Load(metadata ir.ContractMetadata) (*ir.ContractIR, error) {
+	if metadata.Name == "" {
+		metadata.Name = s.Contract
+	}
+	contractIR, err := evm.NewABIParser().Parse(bytes.NewReader(s.ABI()), metadata)
+	if err != nil {
+		return nil, err
+	}
+	overlays, err := s.Overlays()
+	if err != nil {
+		return nil, err
+	}
+	for _, overlay := range overlays {
+		unmatched, err := overlay.Apply(contractIR)
+		if err != nil {
+			return nil, err
+		}
+		if len(unmatched) > 0 {
+			return nil, fmt.Errorf("overlay of %s matches nothing for %s", s.Title, strings.Join(unmatched, ", "))
+		}
+	}
+	return contractIR, nil
+}
\ No newline at end of file
@@ -0,0 +1,65 @@
+package standards
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestLookup(t *testing.T) {
+	for name, expected := range map[string]string{"erc20": "erc20", "ERC-20": "erc20", "permit": "erc20-permit", "ERC_2612": "erc20-permit", "erc4626": "erc4626"} {
+		s, err := Lookup(name)
+		if err != nil || s.Name != expected {
+			t.Errorf("Expected %s to be %s but got %s (%v)", name, expected, s.Name, err)
+		}
+	}
+	if _, err := Lookup("erc777"); err == nil || !strings.Contains(err.Error(), "erc20, erc20-permit, erc721, erc1155, erc4626") {
+		t.Errorf("Expected an unknown standard error listing the standards but got %v", err)
+	}
+	if s, err := ParseReference("standard:erc721"); err != nil || s.Reference() != "standard:erc721" {
+		t.Errorf("Unexpected standard %+v (%v)", s, err)
+	}
+}
+
+// Every function, input and event of every standard is described, and every overlay key matches
+func TestLoad(t *testing.T) {
+	for _, s := range List() {
+		contractIR, err := s.Load(ir.ContractMetadata{Address: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"})
+		if err != nil {
+			t.Errorf("Failed to load %s: %v", s.Name, err)
+			continue
+		}
+		if contractIR.Metadata.Name != s.Contract || contractIR.Metadata.Address == "" || contractIR.Metadata.Description == "" {
+			t.Errorf("%s: unexpected metadata %+v", s.Name, contractIR.Metadata)
+		}
+		for _, f := range contractIR.Functions {
+			if f.Description == "" {
+				t.Errorf("%s: function %s has no description", s.Name, f.Name)
+			}
+			for _, input := range f.Inputs {
+				if input.Description == "" {
+					t.Errorf("%s: input %s of %s has no description", s.Name, input.Name, f.Name)
+				}
+			}
+		}
+		for _, e := range contractIR.Events {
+			if e.Description == "" {
+				t.Errorf("%s: event %s has no description", s.Name, e.Name)
+			}
+		}
+		if errors := contractIR.Validate(); len(errors) > 0 {
+			t.Errorf("%s: invalid IR: %v", s.Name, errors)
+		}
+	}
+
+	// Extensions of ERC-20 keep its functions, described by its overlay, and describe the contract themselves
+	vault, _ := Lookup("erc4626")
+	contractIR, err := vault.Load(ir.ContractMetadata{Name: "sDAI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contractIR.Metadata.Name != "sDAI" || !strings.HasPrefix(contractIR.Metadata.Description, "ERC-4626") || len(contractIR.Functions) != 25 {
+		t.Errorf("Unexpected vault %s (%s) with %d functions", contractIR.Metadata.Name, contractIR.Metadata.Description, len(contractIR.Functions))
+	}
+}
\ No newline at end of file
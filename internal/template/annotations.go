@@ -0,0 +1,74 @@
+package template
+
+import (
+	"encoding/json"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// isReadOnly reports whether a function only reads contract state
+func isReadOnly(f ir.Function) bool {
+	return f.StateMutability == ir.View || f.StateMutability == ir.Pure
+}
+
+// ToolAnnotations returns the MCP tool annotations of a function's tool as a JSON object
+// View and pure function tools are read-only; write function tools are destructive and not idempotent,
+// and payable ones are flagged with payableHint since they can send native currency. Annotated danger levels
+// of write functions are passed on as dangerLevel, so clients can ask for confirmation of high danger calls.
+func ToolAnnotations(f ir.Function) string {
+	if isReadOnly(f) {
+		return readOnlyAnnotations(f.Name)
+	}
+
+	annotations := sampleObject{
+		{"title", f.Name},
+		{"readOnlyHint", false},
+		{"destructiveHint", true},
+		{"idempotentHint", false},
+		{"openWorldHint", true},
+	}
+	if f.StateMutability == ir.Payable {
+		annotations = append(annotations, sampleField{"payableHint", true})
+	}
+	if f.Danger != "" {
+		annotations = append(annotations, sampleField{"dangerLevel", f.Danger})
+	}
+	return indentJSON(annotations)
+}
+
+// SimulationAnnotations returns the MCP tool annotations of a write function's simulation tool
+func SimulationAnnotations(f ir.Function) string {
+	return readOnlyAnnotations("Simulate " + f.Name)
+}
+
+// LogsAnnotations returns the MCP tool annotations of an event log tool
+func LogsAnnotations(e ir.Event) string {
+	return readOnlyAnnotations(e.Name + " logs")
+}
+
+// readOnlyAnnotations are the annotations of tools that only read chain state
+func readOnlyAnnotations(title string) string {
+	return indentJSON(sampleObject{
+		{"title", title},
+		{"readOnlyHint", true},
+		{"openWorldHint", true},
+	})
+}
+// AccessNote returns the sentence a write tool's description mentions its function's access restriction with,
+// e.g. "Restricted to the owner.", or "" for unrestricted functions
+func AccessNote(f ir.Function) string {
+	if f.Access.IsEmpty() {
+		return ""
+	}
+	return "Restricted to " + f.Access.String() + "."
+}
+
+// accessCheck returns the TypeScript object literal of the restrictions a generated server can check before
+// sending a transaction, owners and roles, or "" if there are none
+func accessCheck(f ir.Function) (string, error) {
+	if f.Access.IsEmpty() || (!f.Access.OnlyOwner && len(f.Access.Roles) == 0) {
+		return "", nil
+	}
+	check, err := json.Marshal(ir.AccessRestriction{OnlyOwner: f.Access.OnlyOwner, Roles: f.Access.Roles})
+	return string(check), err
+}
\ No newline at end of file
@@ -0,0 +1,65 @@
+package template
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestToolAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		function ir.Function
+		expected map[string]interface{}
+	}{
+		{
+			"View",
+			ir.Function{Name: "balanceOf", StateMutability: ir.View},
+			map[string]interface{}{"title": "balanceOf", "readOnlyHint": true, "openWorldHint": true},
+		},
+		{
+			"Nonpayable",
+			ir.Function{Name: "transfer", StateMutability: ir.Nonpayable},
+			map[string]interface{}{"title": "transfer", "readOnlyHint": false, "destructiveHint": true, "idempotentHint": false, "openWorldHint": true},
+		},
+		{
+			"Payable",
+			ir.Function{Name: "deposit", StateMutability: ir.Payable},
+			map[string]interface{}{"title": "deposit", "readOnlyHint": false, "destructiveHint": true, "idempotentHint": false, "openWorldHint": true, "payableHint": true},
+		},
+		{
+			"Danger",
+			ir.Function{Name: "transferOwnership", StateMutability: ir.Nonpayable, Danger: ir.DangerHigh},
+			map[string]interface{}{"title": "transferOwnership", "readOnlyHint": false, "destructiveHint": true, "idempotentHint": false, "openWorldHint": true, "dangerLevel": "high"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]interface{}
+			if err := json.Unmarshal([]byte(ToolAnnotations(tt.function)), &got); err != nil {
+				t.Fatalf("Failed to parse annotations: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSimulationAndLogsAnnotations(t *testing.T) {
+	for _, annotations := range []string{
+		SimulationAnnotations(ir.Function{Name: "transfer", StateMutability: ir.Nonpayable}),
+		LogsAnnotations(ir.Event{Name: "Transfer"}),
+	} {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(annotations), &got); err != nil {
+			t.Fatalf("Failed to parse annotations: %v", err)
+		}
+		if got["readOnlyHint"] != true {
+			t.Errorf("Expected read-only annotations but got %v", got)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,74 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// apiFiles returns the output paths and templates of a server wrapping an off-chain API imported from an OpenAPI
+// or OpenRPC document: a stdio Node.js server whose tools send HTTP or JSON-RPC requests instead of contract calls.
+// Options that only apply to contracts are rejected rather than ignored.
+func (r *TypeScriptTemplateRenderer) apiFiles() (map[string]string, error) {
+	unsupported := func(option string) error {
+		return fmt.Errorf("%s is not supported for servers generated from API descriptions", option)
+	}
+	switch {
+	case r.mode != ModeServer:
+		return nil, unsupported("the " + r.mode + " output mode")
+	case r.runtime != RuntimeNode:
+		return nil, unsupported("the " + r.runtime + " runtime")
+	case r.transport != TransportStdio:
+		return nil, unsupported("the " + r.transport + " transport")
+	case r.subscriptions:
+		return nil, unsupported("event subscriptions")
+	case r.cache:
+		return nil, unsupported("result caching")
+	case r.accessLists:
+		return nil, unsupported("access lists")
+	case len(r.signers) > 0:
+		return nil, unsupported("the " + r.signers[0] + " signer")
+	case r.docker:
+		return nil, unsupported("Docker output")
+	case r.ci != CINone:
+		return nil, unsupported("CI workflow generation")
+	}
+	return map[string]string{
+		"src/server.ts": "api/server.ts.tmpl",
+		"src/tools.ts":  "api/tools.ts.tmpl",
+		"package.json":  "api/package.json.tmpl",
+		"tsconfig.json": "tsconfig.json.tmpl",
+		"README.md":     "api/README.md.tmpl",
+	}, nil
+}
+
+// apiBaseURL returns the server URL of an API description, or "" if it lists none
+func apiBaseURL(m ir.ContractMetadata) string {
+	return m.API().BaseURL
+}
+
+// apiOperation returns the JavaScript object telling the generated server how to send a function's requests:
+// the HTTP method, path template and location of every input of OpenAPI operations, or the method name and, for
+// parameters passed by position, their order of OpenRPC methods
+func apiOperation(chain string, f ir.Function) (string, error) {
+	var operation sampleObject
+	if chain == ir.ChainOpenRPC {
+		operation = sampleObject{{"method", f.API().RPCMethod}}
+		if f.API().ParamsByPosition {
+			params := make([]string, len(f.Inputs))
+			for i, input := range f.Inputs {
+				params[i] = input.Name
+			}
+			operation = append(operation, sampleField{"params", params})
+		}
+	} else {
+		in := sampleObject{}
+		for _, input := range f.Inputs {
+			in = append(in, sampleField{input.Name, input.Type.API().In})
+		}
+		operation = sampleObject{{"method", f.API().HTTPMethod}, {"path", f.API().HTTPPath}, {"in", in}}
+	}
+	content, err := json.Marshal(operation)
+	return string(content), err
+}
\ No newline at end of file
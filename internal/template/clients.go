@@ -0,0 +1,143 @@
+package template
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// MCP clients configuration snippets are generated for
+const (
+	// ClientClaudeDesktop is Claude Desktop's claude_desktop_config.json
+	ClientClaudeDesktop = "claude-desktop"
+
+	// ClientVSCode is a VS Code workspace's .vscode/mcp.json
+	ClientVSCode = "vscode"
+
+	// ClientCursor is a Cursor project's .cursor/mcp.json
+	ClientCursor = "cursor"
+
+	// ClientGeneric is the mcpServers format most other clients read from an mcp.json
+	ClientGeneric = "mcp"
+)
+
+const (
+	// defaultHTTPURL is the MCP endpoint of a generated HTTP server with the default HOST and PORT
+	defaultHTTPURL = "http://127.0.0.1:3000/mcp"
+
+	// defaultRPCURL is the RPC URL generated servers fall back to without a network preset
+	defaultRPCURL = "https://eth.llamarpc.com"
+)
+
+// ClientOptions describes the generated server a client configuration launches or connects to
+type ClientOptions struct {
+	// Target JavaScript runtime (node, deno, bun)
+	Runtime string
+
+	// MCP transport (stdio, http)
+	Transport string
+
+	// Whether write tools may need a signer
+	EnableWrites bool
+}
+
+// ServerName returns the name a contract's server is registered under in client configurations
+func ServerName(contract *ir.ContractIR) string {
+	return strings.ReplaceAll(strings.ToLower(contract.Metadata.Name), " ", "-") + "-mcp-server"
+}
+
+// ServerCommand returns the command line starting a generated stdio server of the given runtime
+// The server entry point is resolved against root, the directory the server was generated into
+func ServerCommand(runtime, root string) []string {
+	switch runtime {
+	case RuntimeDeno:
+		return []string{"deno", "run", "--allow-net", "--allow-env", "--allow-read", path.Join(root, "src/server.ts")}
+	case RuntimeBun:
+		return []string{"bun", "run", path.Join(root, "src/server.ts")}
+	default:
+		return []string{"node", path.Join(root, "dist/server.js")}
+	}
+}
+
+// ClientConfig returns the configuration registering a contract's server with an MCP client as indented JSON
+// Stdio servers are launched from where they were generated and HTTP servers reached at their default endpoint.
+// Secrets are placeholders: VS Code prompts for them, Cursor reads them from the environment,
+// and other clients take them as empty values to fill in
+func ClientConfig(client string, contract *ir.ContractIR, options ClientOptions) (string, error) {
+	name := ServerName(contract)
+	http := options.Transport == TransportHTTP
+
+	switch client {
+	case ClientClaudeDesktop:
+		server := stdioServer(options.Runtime, "/path/to/"+name, clientEnv(contract, options, rpcURL(contract), ""))
+		if http {
+			// Claude Desktop only launches local servers, so remote ones are bridged with mcp-remote
+			server = sampleObject{
+				{"command", "npx"},
+				{"args", []string{"-y", "mcp-remote", defaultHTTPURL, "--header", "Authorization: Bearer ${MCP_AUTH_TOKEN}"}},
+				{"env", sampleObject{{"MCP_AUTH_TOKEN", ""}}},
+			}
+		}
+		return indentJSON(sampleObject{{"mcpServers", sampleObject{{name, server}}}}), nil
+	case ClientGeneric:
+		server := stdioServer(options.Runtime, "/path/to/"+name, clientEnv(contract, options, rpcURL(contract), ""))
+		if http {
+			server = sampleObject{{"type", "http"}, {"url", defaultHTTPURL}, {"headers", sampleObject{{"Authorization", "Bearer <MCP_AUTH_TOKEN>"}}}}
+		}
+		return indentJSON(sampleObject{{"mcpServers", sampleObject{{name, server}}}}), nil
+	case ClientVSCode:
+		inputs := []sampleObject{promptInput("rpc-url", "Ethereum RPC URL", rpcURL(contract))}
+		if options.EnableWrites {
+			inputs = append(inputs, promptInput("private-key", "Hex private key write tools sign with (leave empty to run read-only)", ""))
+		}
+		server := append(sampleObject{{"type", "stdio"}}, stdioServer(options.Runtime, "${workspaceFolder}", clientEnv(contract, options, "${input:rpc-url}", "${input:private-key}"))...)
+		if http {
+			inputs = []sampleObject{promptInput("mcp-auth-token", "Bearer token of "+name, "")}
+			server = sampleObject{{"type", "http"}, {"url", defaultHTTPURL}, {"headers", sampleObject{{"Authorization", "Bearer ${input:mcp-auth-token}"}}}}
+		}
+		return indentJSON(sampleObject{{"inputs", inputs}, {"servers", sampleObject{{name, server}}}}), nil
+	case ClientCursor:
+		// Unset variables interpolate to empty values, which the server replaces with its defaults
+		server := stdioServer(options.Runtime, "${workspaceFolder}", clientEnv(contract, options, "${env:RPC_URL}", "${env:PRIVATE_KEY}"))
+		if http {
+			server = sampleObject{{"url", defaultHTTPURL}, {"headers", sampleObject{{"Authorization", "Bearer ${env:MCP_AUTH_TOKEN}"}}}}
+		}
+		return indentJSON(sampleObject{{"mcpServers", sampleObject{{name, server}}}}), nil
+	default:
+		return "", fmt.Errorf("unsupported MCP client: %s", client)
+	}
+}
+
+// stdioServer is the entry of a server clients launch as a child process
+func stdioServer(runtime, root string, env sampleObject) sampleObject {
+	command := ServerCommand(runtime, root)
+	return sampleObject{{"command", command[0]}, {"args", command[1:]}, {"env", env}}
+}
+
+// rpcURL is the RPC URL a contract's server falls back to: the public RPC of its network preset, if any
+func rpcURL(contract *ir.ContractIR) string {
+	if url := contract.Metadata.Network().RPCURL; url != "" {
+		return url
+	}
+	return defaultRPCURL
+}
+
+// clientEnv is the environment passed to a launched server
+func clientEnv(contract *ir.ContractIR, options ClientOptions, rpcURL, privateKey string) sampleObject {
+	env := sampleObject{{"RPC_URL", rpcURL}, {"CONTRACT_ADDRESS", contract.Metadata.Address}}
+	if options.EnableWrites {
+		env = append(env, sampleField{"PRIVATE_KEY", privateKey})
+	}
+	return env
+}
+
+// promptInput is a VS Code input variable prompting for a string, masked unless it has a default
+func promptInput(id, description, value string) sampleObject {
+	input := sampleObject{{"type", "promptString"}, {"id", id}, {"description", description}}
+	if value != "" {
+		return append(input, sampleField{"default", value})
+	}
+	return append(input, sampleField{"password", true})
+}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestServerCommand(t *testing.T) {
+	tests := []struct {
+		runtime  string
+		expected string
+	}{
+		{RuntimeNode, "node /srv/token/dist/server.js"},
+		{RuntimeBun, "bun run /srv/token/src/server.ts"},
+		{RuntimeDeno, "deno run --allow-net --allow-env --allow-read /srv/token/src/server.ts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			if got := strings.Join(ServerCommand(tt.runtime, "/srv/token"), " "); got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientConfig(t *testing.T) {
+	contract := &ir.ContractIR{Metadata: ir.ContractMetadata{Name: "Test Token", Address: "0x1234567890123456789012345678901234567890"}}
+
+	tests := []struct {
+		name     string
+		client   string
+		options  ClientOptions
+		expected []string
+	}{
+		{
+			"Claude Desktop",
+			ClientClaudeDesktop,
+			ClientOptions{Runtime: RuntimeNode, Transport: TransportStdio},
+			[]string{`"mcpServers": {`, `"test-token-mcp-server": {`, `"/path/to/test-token-mcp-server/dist/server.js"`, `"CONTRACT_ADDRESS": "0x1234567890123456789012345678901234567890"`},
+		},
+		{
+			"Claude Desktop HTTP",
+			ClientClaudeDesktop,
+			ClientOptions{Runtime: RuntimeNode, Transport: TransportHTTP},
+			[]string{`"mcp-remote"`, `"http://127.0.0.1:3000/mcp"`, `"Authorization: Bearer ${MCP_AUTH_TOKEN}"`},
+		},
+		{
+			"VS Code",
+			ClientVSCode,
+			ClientOptions{Runtime: RuntimeDeno, Transport: TransportStdio, EnableWrites: true},
+			[]string{`"servers": {`, `"type": "stdio"`, `"${workspaceFolder}/src/server.ts"`, `"RPC_URL": "${input:rpc-url}"`, `"PRIVATE_KEY": "${input:private-key}"`, `"password": true`},
+		},
+		{
+			"Cursor",
+			ClientCursor,
+			ClientOptions{Runtime: RuntimeBun, Transport: TransportStdio},
+			[]string{`"command": "bun"`, `"RPC_URL": "${env:RPC_URL}"`},
+		},
+		{
+			"Generic HTTP",
+			ClientGeneric,
+			ClientOptions{Runtime: RuntimeNode, Transport: TransportHTTP},
+			[]string{`"type": "http"`, `"Authorization": "Bearer <MCP_AUTH_TOKEN>"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClientConfig(tt.client, contract, tt.options)
+			if err != nil {
+				t.Fatalf("Failed to generate the configuration: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(got, expected) {
+					t.Errorf("Expected configuration containing %s but got %s", expected, got)
+				}
+			}
+		})
+	}
+
+	// Signer secrets are only passed to servers with write tools
+	if got, _ := ClientConfig(ClientCursor, contract, ClientOptions{Runtime: RuntimeNode, Transport: TransportStdio}); strings.Contains(got, "PRIVATE_KEY") {
+		t.Errorf("Read-only configuration passes PRIVATE_KEY: %s", got)
+	}
+	if _, err := ClientConfig("unknown", contract, ClientOptions{}); err == nil {
+		t.Errorf("Expected an error for an unknown client")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,168 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// DataField documents one field of the data templates are rendered with
+type DataField struct {
+	// Template expression of the field (e.g., ".Metadata.Name"); "[]" stands for the items of a list or map, as
+	// ranged over with {{ range }}
+	Path string
+
+	// Go type of the field
+	Type string
+
+	// Human-readable description
+	Description string
+}
+
+// dataDescriptions describe the template data fields that are not part of the IR, by path
+var dataDescriptions = map[string]string{
+	".Runtime":                "Target JavaScript runtime (node, deno, bun)",
+	".Docker":                 "Whether container files are generated",
+	".CI":                     "CI provider a workflow is generated for (none, github, gitlab)",
+	".EnableWrites":           "Whether write functions are exposed as tools",
+	".Transport":              "MCP transport (stdio, http)",
+	".Subscriptions":          "Whether event subscription tools are generated",
+	".Amounts":                "Whether any function parameter is a token amount",
+	".TokenDecimals":          "Whether token amounts read their decimals from the contract's decimals() function",
+	".Paginated":              "Whether any view function pages through a dynamic array output",
+	".Cache":                  "Whether view and pure function results are cached in memory",
+	".AccessLists":            "Whether write tools attach access lists to the transactions they build",
+	".Signers":                "Signers supported besides private keys and mnemonics (e.g., \"ledger\")",
+	".Package":                "Metadata of the generated package",
+	".Package.Scope":          "npm or JSR scope, without the leading @ (optional for npm)",
+	".Package.Version":        "Semantic version (default: 1.0.0)",
+	".Package.License":        "SPDX license expression (optional)",
+	".Package.Author":         "Author, e.g. \"Jane Doe <jane@example.com>\" (optional)",
+	".Package.Repository":     "Source repository URL or shorthand such as github:user/repo (optional)",
+	".Categories":             "Function tools grouped by category, in the order tools are listed",
+	".Categories[].Category":  "Category of the functions",
+	".Categories[].Functions": "Functions in the category, in contract order",
+	".IRHash":                 "Content hash of the IR the project is generated from",
+	".Vars":                   "Template variables given with --set, by name; missing variables are empty",
+	".Env":                    "Environment variables the server reads with the generation options, in the order .env.example lists them",
+	".Env[].Name":             "Variable name (e.g., \"RPC_URL\")",
+	".Env[].Section":          "Group of related variables the variable is listed under (e.g., \"Network\")",
+	".Env[].Description":      "Human-readable description",
+	".Env[].Value":            "Value set in .env.example; variables without one are listed commented out, with their default",
+	".Env[].Default":          "Value the server uses when the variable is unset, if any",
+	".Env[].Required":         "Whether the server refuses to start without the variable, so .env.example sets it even if empty",
+	".Env[].Secret":           "Whether the variable holds a secret that must never be committed",
+}
+
+// DataFields lists the fields of the data the TypeScript templates are rendered with, in declaration order
+// Fields of the IR are described by the IR's JSON Schema, so the list follows the IR structs as they change. Struct
+// types are expanded where they first appear; later fields of the same type refer back to them.
+func DataFields() []DataField {
+	root := jsonschema.ForContractIR()
+	var fields []DataField
+	expanded := map[reflect.Type]string{}
+	var walk func(t reflect.Type, schema *jsonschema.Schema, prefix string)
+	walk = func(t reflect.Type, schema *jsonschema.Schema, prefix string) {
+		expanded[t] = prefix
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldType := field.Type
+			var property *jsonschema.Schema
+			if schema != nil {
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				property = resolveRef(root, schema.Properties.Get(name))
+			}
+			if field.Anonymous {
+				// The embedded IR is described by the schema's root
+				if indirect(fieldType) == reflect.TypeOf(ir.ContractIR{}) {
+					property = root
+				}
+				walk(indirect(fieldType), property, prefix)
+				continue
+			}
+
+			path := prefix + "." + field.Name
+			description := dataDescriptions[path]
+			if property != nil && property.Description != "" {
+				description = property.Description
+			}
+			element := indirect(fieldType)
+			itemPath := path
+			if element.Kind() == reflect.Slice || element.Kind() == reflect.Map {
+				element = indirect(element.Elem())
+				itemPath += "[]"
+				property = resolveRef(root, items(property))
+			}
+			if element.Kind() == reflect.Struct {
+				if at, ok := expanded[element]; ok {
+					description = strings.TrimSuffix(description, ".") + " (fields as in " + at + ")"
+				}
+			}
+			fields = append(fields, DataField{Path: path, Type: goTypeName(fieldType), Description: description})
+			if element.Kind() == reflect.Struct {
+				if _, ok := expanded[element]; !ok {
+					walk(element, property, itemPath)
+				}
+			}
+		}
+	}
+	walk(reflect.TypeOf(templateData{}), nil, "")
+	return fields
+}
+
+// resolveRef returns the schema a reference points to in a root schema's definitions, or the schema itself
+func resolveRef(root, schema *jsonschema.Schema) *jsonschema.Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	return root.Defs.Get(strings.TrimPrefix(schema.Ref, "#/$defs/"))
+}
+
+// items returns the schema of the items of an array or map schema, or nil
+func items(schema *jsonschema.Schema) *jsonschema.Schema {
+	switch {
+	case schema == nil:
+		return nil
+	case schema.Items != nil:
+		return schema.Items
+	case len(schema.PatternProperties) == 1:
+		return schema.PatternProperties[0].Schema
+	}
+	return nil
+}
+
+// indirect returns the type pointers point to
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// goTypeName returns the Go type name of a field as templates see it, e.g. "[]ir.Parameter"
+func goTypeName(t reflect.Type) string {
+	return strings.ReplaceAll(t.String(), "interface {}", "any")
+}
+
+// RenderDataDocs renders the Markdown reference of the template data fields
+func RenderDataDocs() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Template Data\n\n")
+	buf.WriteString("Templates are Go text/template files rendered with the data below, plus the Sprig functions and the ")
+	buf.WriteString("generator's own helpers. Fields of list items are shown with `[]` and are reached with `{{ range }}`, e.g. ")
+	buf.WriteString("`{{ range .Functions }}{{ .Name }}{{ end }}`.\n\n")
+	buf.WriteString("This file is generated from the IR structs by `generate-mcp template init`; after upgrading, regenerate it with `generate-mcp template docs > TEMPLATE_DATA.md`.\n\n")
+	buf.WriteString("| Field | Type | Description |\n")
+	buf.WriteString("|-------|------|-------------|\n")
+	for _, field := range DataFields() {
+		fmt.Fprintf(&buf, "| `%s` | `%s` | %s |\n", field.Path, field.Type, strings.ReplaceAll(field.Description, "|", "\\|"))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,32 @@
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataFields(t *testing.T) {
+	paths := map[string]DataField{}
+	for _, field := range DataFields() {
+		if field.Description == "" {
+			t.Errorf("Expected %s described, by the IR schema or dataDescriptions", field.Path)
+		}
+		paths[field.Path] = field
+	}
+	for _, path := range []string{".Metadata.Name", ".Functions[].Inputs[].Type.BaseType", ".Events[].Parameters[].Indexed", ".Runtime", ".Package.Scope", ".Categories[].Functions"} {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("Expected %s documented", path)
+		}
+	}
+	if field := paths[".Functions[].Outputs"]; field.Type != "[]ir.Parameter" || !strings.HasSuffix(field.Description, "(fields as in .Functions[].Inputs[])") {
+		t.Errorf("Expected outputs to refer to the inputs' fields but got %+v", field)
+	}
+	for path := range dataDescriptions {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("dataDescriptions describes %s, which is not a template data field", path)
+		}
+	}
+	if docs := string(RenderDataDocs()); !strings.Contains(docs, "| `.Metadata.Chain` | `string` | Chain identifier") {
+		t.Errorf("Unexpected template data docs:\n%s", docs)
+	}
+}
\ No newline at end of file
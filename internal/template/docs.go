@@ -0,0 +1,235 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// sampleHash is the block and transaction hash used in generated examples
+var sampleHash = "0x" + strings.Repeat("11", 32)
+
+// ToolParameter documents one argument of a generated tool
+type ToolParameter struct {
+	// Argument name
+	Name string
+
+	// ABI type of contract parameters, JSON type of tool options
+	Type string
+
+	// Whether the argument must be given
+	Required bool
+
+	// Human-readable description
+	Description string
+}
+
+// ToolParameters lists the arguments of a tool input schema in order
+// Properties that are contract parameters are typed by their ABI type
+func ToolParameters(schema *jsonschema.Schema, parameters []ir.Parameter) []ToolParameter {
+	types := make(map[string]string, len(parameters))
+	for _, p := range parameters {
+		types[p.Name] = p.Type.ABIType()
+	}
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	result := make([]ToolParameter, 0, len(schema.Properties))
+	for _, property := range schema.Properties {
+		t, ok := types[property.Name]
+		if !ok {
+			t = property.Schema.Type
+		}
+		result = append(result, ToolParameter{
+			Name:        property.Name,
+			Type:        t,
+			Required:    required[property.Name],
+			Description: property.Schema.Description,
+		})
+	}
+	return result
+}
+
+// eventParameters converts an event's parameters to function parameters named as the event log tools name them
+func eventParameters(e ir.Event) []ir.Parameter {
+	parameters := make([]ir.Parameter, len(e.Parameters))
+	for i, p := range e.Parameters {
+		parameters[i] = ir.Parameter{Name: jsonschema.EventParameterName(p, i), Type: p.Type}
+	}
+	return parameters
+}
+
+// sampleObject is a JSON object that keeps its keys in insertion order
+type sampleObject []sampleField
+
+// sampleField is a key of a sampleObject and its value
+type sampleField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON encodes the fields in order
+func (o sampleObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := marshalUnescaped(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalUnescaped(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalUnescaped encodes a value as JSON without escaping HTML characters, which samples are never embedded in
+func marshalUnescaped(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// SampleToolCall returns an example MCP tools/call request for a tool
+func SampleToolCall(name string, arguments []ir.Parameter) string {
+	return sampleToolCall(name, arguments, nil)
+}
+
+// SampleFunctionCall returns an example MCP tools/call request for a function's tool, taking argument values
+// from the function's first annotated example, then from the examples of its parameters, and sampling the rest
+func SampleFunctionCall(f ir.Function) string {
+	var example map[string]interface{}
+	if len(f.Examples) > 0 {
+		example = f.Examples[0]
+	}
+	return sampleToolCall(f.Name, f.Inputs, example)
+}
+
+func sampleToolCall(name string, arguments []ir.Parameter, example map[string]interface{}) string {
+	args := sampleObject{}
+	for _, p := range arguments {
+		value, ok := example[p.Name]
+		if !ok {
+			value = ParameterSample(p)
+		}
+		args = append(args, sampleField{p.Name, value})
+	}
+	return indentJSON(sampleObject{
+		{"method", "tools/call"},
+		{"params", sampleObject{{"name", name}, {"arguments", args}}},
+	})
+}
+
+// SampleToolResponse returns an example of the JSON a view function tool responds with
+// Single return values are unwrapped, multiple ones are returned as an array
+func SampleToolResponse(f ir.Function) string {
+	paginated := jsonschema.IsPaginated(f)
+	values := make([]interface{}, len(f.Outputs))
+	for i, output := range f.Outputs {
+		values[i] = sampleResult(output.Type, paginated)
+	}
+	if len(values) == 1 {
+		return indentJSON(values[0])
+	}
+	return indentJSON(values)
+}
+
+// sampleResult is an example of a return value as a view function tool reports it
+func sampleResult(t ir.ParameterType, paginated bool) interface{} {
+	if decimals, fromContract, ok := t.AmountDecimals(); ok {
+		if fromContract {
+			decimals = 18
+		}
+		return sampleObject{
+			{"formatted", "1.0"},
+			{"raw", "1" + strings.Repeat("0", decimals)},
+			{"decimals", decimals},
+		}
+	}
+
+	value := SampleOutput(t)
+	if paginated && t.IsArray && t.ArraySize == 0 {
+		return sampleObject{{"items", value}, {"offset", 0}, {"total", 1}}
+	}
+	return value
+}
+
+// SampleTransactionResponse returns an example of the JSON a write function tool responds with
+func SampleTransactionResponse(address string) string {
+	if address == "" {
+		address = sampleAddress
+	}
+	return indentJSON(sampleObject{
+		{"to", address},
+		{"data", "0x<selector><encoded arguments>"},
+		{"value", "0"},
+		{"estimatedGas", "50000"},
+		{"fees", sampleObject{
+			{"gasLimit", "50000"},
+			{"maxFeePerGas", "20000000000"},
+			{"maxPriorityFeePerGas", "1000000000"},
+			{"maxGasCost", "1000000000000000"},
+			{"maxTotalCost", "1000000000000000"},
+			{"maxTotalCostNative", "0.001"},
+		}},
+	})
+}
+
+// SampleLogsResponse returns an example of the JSON an event log tool responds with
+func SampleLogsResponse(e ir.Event) string {
+	args := sampleObject{}
+	for i, p := range e.Parameters {
+		var value interface{} = SampleOutput(p.Type)
+		// Indexed dynamic values are only recoverable as their hash
+		if p.Indexed && (p.Type.IsArray || len(p.Type.Components) > 0 || p.Type.BaseType == "string" || p.Type.BaseType == "bytes") {
+			value = sampleObject{{"hash", sampleHash}}
+		}
+		args = append(args, sampleField{jsonschema.EventParameterName(p, i), value})
+	}
+	return indentJSON(sampleObject{
+		{"fromBlock", 1},
+		{"toBlock", 1},
+		{"logs", []interface{}{sampleObject{
+			{"blockNumber", 1},
+			{"blockHash", sampleHash},
+			{"transactionHash", sampleHash},
+			{"logIndex", 0},
+			{"args", args},
+		}}},
+	})
+}
+
+// indentJSON renders a value as indented JSON, leaving <, > and & unescaped for Markdown
+func indentJSON(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+func TestToolParameters(t *testing.T) {
+	f := ir.Function{
+		Name:            "getHolders",
+		StateMutability: ir.View,
+		Inputs:          []ir.Parameter{{Name: "role", Type: ir.ParameterType{BaseType: "bytes32"}}},
+		Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "address", IsArray: true}}},
+	}
+
+	parameters := ToolParameters(jsonschema.ForViewInputs(f), f.Inputs)
+	if len(parameters) != 3 {
+		t.Fatalf("Expected 3 parameters but got %d", len(parameters))
+	}
+	if p := parameters[0]; p.Name != "role" || p.Type != "bytes32" || !p.Required {
+		t.Errorf("Expected required bytes32 role but got %+v", p)
+	}
+	if p := parameters[2]; p.Name != "limit" || p.Type != "integer" || p.Required {
+		t.Errorf("Expected optional integer limit but got %+v", p)
+	}
+}
+
+func TestSampleToolCall(t *testing.T) {
+	got := SampleToolCall("transfer", []ir.Parameter{
+		{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+		{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+	})
+	expected := `{
+  "method": "tools/call",
+  "params": {
+    "name": "transfer",
+    "arguments": {
+      "to": "0x0000000000000000000000000000000000000001",
+      "amount": "1"
+    }
+  }
+}`
+	if got != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, got)
+	}
+}
+
+func TestSampleFunctionCall(t *testing.T) {
+	got := SampleFunctionCall(ir.Function{
+		Name: "transfer",
+		Inputs: []ir.Parameter{
+			{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+			{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+		Examples: []map[string]interface{}{{"amount": "1000000"}},
+	})
+	if !strings.Contains(got, `"to": "0x0000000000000000000000000000000000000001",
+      "amount": "1000000"`) {
+		t.Errorf("Expected the example amount and a sampled recipient but got\n%s", got)
+	}
+}
+
+func TestSampleToolResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		function ir.Function
+		expected string
+	}{
+		{
+			"Single Output",
+			ir.Function{Name: "owner", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "address"}}}},
+			`"0x0000000000000000000000000000000000000001"`,
+		},
+		{
+			"Paginated",
+			ir.Function{Name: "getHolders", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "address", IsArray: true}}}},
+			`"items": [`,
+		},
+		{
+			"Amount",
+			ir.Function{Name: "totalSupply", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: 6}}}}},
+			`"raw": "1000000"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SampleToolResponse(tt.function); !strings.Contains(got, tt.expected) {
+				t.Errorf("Expected response containing %s but got\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSampleLogsResponse(t *testing.T) {
+	got := SampleLogsResponse(ir.Event{
+		Name: "Registered",
+		Parameters: []ir.EventParameter{
+			{Name: "name", Type: ir.ParameterType{BaseType: "string"}, Indexed: true},
+			{Name: "owner", Type: ir.ParameterType{BaseType: "address"}},
+		},
+	})
+	for _, expected := range []string{`"hash": "` + sampleHash + `"`, `"owner": "0x0000000000000000000000000000000000000001"`} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("Expected response containing %s but got\n%s", expected, got)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EnvVar is an environment variable read by a generated server
+type EnvVar struct {
+	// Variable name (e.g., "RPC_URL")
+	Name string
+
+	// Group of related variables the variable is listed under (e.g., "Network")
+	Section string
+
+	// Human-readable description
+	Description string
+
+	// Value set in .env.example; variables without one are listed commented out, with their default
+	Value string
+
+	// Value the server uses when the variable is unset, if any
+	Default string
+
+	// Whether the server refuses to start without the variable, so .env.example sets it even if empty
+	Required bool
+
+	// Whether the variable holds a secret that must never be committed
+	Secret bool
+}
+
+// Set reports whether .env.example sets the variable rather than listing it commented out
+func (v EnvVar) Set() bool {
+	return v.Value != "" || v.Required
+}
+
+// serverEnv returns the environment variables a server reads with the given generation options, in the order
+// .env.example lists them
+func serverEnv(data *templateData) []EnvVar {
+	network := data.Metadata.Network()
+	rpcURL := network.RPCURL
+	if rpcURL == "" {
+		rpcURL = "https://eth.llamarpc.com"
+	}
+	chainID := ""
+	if network.ChainID != 0 {
+		chainID = strconv.Itoa(network.ChainID)
+	}
+
+	env := []EnvVar{
+		{Name: "RPC_URL", Section: "Network", Description: "Ethereum RPC URL", Value: rpcURL},
+		{Name: "RPC_FALLBACK_URLS", Section: "Network", Description: "Comma-separated RPC URLs tried in order when RPC_URL fails"},
+		{Name: "CONTRACT_ADDRESS", Section: "Network", Description: "Contract address", Value: data.Metadata.Address},
+		{Name: "CHAIN_ID", Section: "Network", Description: "Expected chain ID; the server refuses an RPC serving a different chain", Value: chainID},
+	}
+	if data.Subscriptions {
+		env = append(env, EnvVar{Name: "WS_RPC_URL", Section: "Network", Description: "WebSocket RPC URL event subscriptions listen on; subscriptions poll RPC_URL without it"})
+	}
+	env = append(env,
+		EnvVar{Name: "CONFIG_FILE", Section: "Network", Description: "Config file of named networks, replacing the variables above (default: config.json, config.yaml or config.yml if present)"},
+		EnvVar{Name: "NETWORK", Section: "Network", Description: "Network of the config file to start on (default: its defaultNetwork)"},
+		EnvVar{Name: "RPC_RETRIES", Section: "RPC", Description: "Retries of a request that failed on every RPC URL, with exponential backoff", Default: "3"},
+		EnvVar{Name: "RPC_RETRY_DELAY_MS", Section: "RPC", Description: "Wait before the first retry, doubled on every further retry", Default: "500"},
+		EnvVar{Name: "RPC_MAX_REQUESTS_PER_SECOND", Section: "RPC", Description: "Largest number of RPC requests sent per second (0: unlimited)", Default: "0"},
+		EnvVar{Name: "RPC_TIMEOUT_MS", Section: "RPC", Description: "Time after which an RPC request is abandoned and the next URL tried", Default: "30000"},
+		EnvVar{Name: "MAX_RESPONSE_BYTES", Section: "Tools", Description: "Largest tool response returned to the client; larger responses are cut to a preview (0 disables)", Default: "100000"},
+	)
+	if data.Cache {
+		env = append(env,
+			EnvVar{Name: "CACHE_TTL_MS", Section: "Tools", Description: "How long view and pure function results are cached, in milliseconds (0 disables caching)", Default: "15000"},
+			EnvVar{Name: "CACHE_MAX_ENTRIES", Section: "Tools", Description: "Largest number of cached results", Default: "1000"},
+		)
+	}
+	if data.Subscriptions {
+		env = append(env, EnvVar{Name: "POLLING_INTERVAL_MS", Section: "Tools", Description: "How often subscriptions poll for new events without a WebSocket RPC", Default: "4000"})
+	}
+	if data.EnableWrites {
+		modes, pathOf := "private-key, mnemonic, keystore", "MNEMONIC"
+		walletConnect := false
+		for _, signer := range data.Signers {
+			modes += ", " + signer
+			switch signer {
+			case SignerLedger:
+				pathOf = "MNEMONIC and the ledger signer"
+			case SignerWalletConnect:
+				walletConnect = true
+			}
+		}
+		env = append(env,
+			EnvVar{Name: "SIGNER_MODE", Section: "Signer", Description: modes + " or none (read-only); inferred from the variables below when unset"},
+			EnvVar{Name: "PRIVATE_KEY", Section: "Signer", Description: "Hex private key to sign with", Secret: true},
+			EnvVar{Name: "MNEMONIC", Section: "Signer", Description: "BIP-39 mnemonic to derive the signing key from", Secret: true},
+			EnvVar{Name: "DERIVATION_PATH", Section: "Signer", Description: "Derivation path for " + pathOf, Default: "m/44'/60'/0'/0/0"},
+			EnvVar{Name: "KEYSTORE_PATH", Section: "Signer", Description: "Encrypted JSON keystore to decrypt the signing key from"},
+			EnvVar{Name: "KEYSTORE_PASSWORD", Section: "Signer", Description: "Password of the KEYSTORE_PATH keystore", Secret: true},
+		)
+		if len(data.Signers) > 0 {
+			env = append(env, EnvVar{Name: "CONFIRMATION_WAIT_MS", Section: "Signer", Description: "How long write tools wait for a transaction to be confirmed on the signer's device before returning a signing request to poll", Default: "20000"})
+		}
+		if walletConnect {
+			env = append(env, EnvVar{Name: "WALLETCONNECT_PROJECT_ID", Section: "Signer", Description: "WalletConnect Cloud project ID, required by the walletconnect signer"})
+		}
+		env = append(env, EnvVar{Name: "PRICE_FEED_ADDRESS", Section: "Signer", Description: "Chainlink native/USD price feed used to price fee previews in USD"})
+	}
+	if data.Transport == TransportHTTP {
+		env = append(env,
+			EnvVar{Name: "HOST", Section: "HTTP", Description: "Interface the server listens on", Default: "127.0.0.1"},
+			EnvVar{Name: "PORT", Section: "HTTP", Description: "Port the server listens on", Default: "3000"},
+			EnvVar{Name: "MCP_AUTH_TOKENS", Section: "HTTP", Description: "Comma-separated bearer tokens, sent as Authorization: Bearer <token>", Secret: true},
+			EnvVar{Name: "MCP_API_KEYS", Section: "HTTP", Description: "Comma-separated API keys, sent as X-API-Key: <key>; the server refuses to start without these or MCP_AUTH_TOKENS", Required: true, Secret: true},
+			EnvVar{Name: "MCP_AUTHORIZATION_SERVERS", Section: "HTTP", Description: "Comma-separated OAuth authorization server URLs advertised to clients"},
+			EnvVar{Name: "MCP_AUTH_SCOPES", Section: "HTTP", Description: "Comma-separated scopes advertised to clients"},
+			EnvVar{Name: "MCP_RESOURCE_URL", Section: "HTTP", Description: "Canonical URL of the MCP endpoint (default: derived from the request)"},
+			EnvVar{Name: "MCP_AUTH", Section: "HTTP", Description: "Set to none to serve without authentication"},
+		)
+	}
+	return env
+}
+
+// envLine returns the line of a variable in .env.example: set to its value, or commented out with its default
+func envLine(v EnvVar) string {
+	if v.Set() {
+		return fmt.Sprintf("%s=%s", v.Name, envQuote(v.Value))
+	}
+	return fmt.Sprintf("# %s=%s", v.Name, envQuote(v.Default))
+}
+
+// envQuote quotes a .env value if it holds characters dotenv parsers treat specially
+func envQuote(value string) string {
+	for _, c := range value {
+		switch c {
+		case ' ', '#', '"', '\'', '\\', '$':
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
\ No newline at end of file
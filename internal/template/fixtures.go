@@ -0,0 +1,167 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// sampleAddress is the address used in generated fixtures
+// It contains no letters so its checksummed form is identical
+const sampleAddress = "0x0000000000000000000000000000000000000001"
+
+// SampleInput returns an example value for an IR parameter type as an agent would pass it to a tool
+// Integers are decimal strings and structs are objects keyed by component name
+func SampleInput(t ir.ParameterType) interface{} {
+	return sampleValue(t, true)
+}
+
+// SampleOutput returns an example value for an IR parameter type as the generated server reports it
+// Integers are decimal strings and structs are decoded into positional arrays
+func SampleOutput(t ir.ParameterType) interface{} {
+	return sampleValue(t, false)
+}
+
+// ParameterSample returns the first example value of an IR parameter, or else an example value for its type,
+// as an agent would pass it to a tool
+func ParameterSample(p ir.Parameter) interface{} {
+	if example, ok := parameterExample(p); ok {
+		return example
+	}
+	return SampleInput(p.Type)
+}
+
+// parameterEncoded returns the value ethers encodes for the sample of an IR parameter that is not a token amount
+// Examples of structs are given as objects, so structs are always sampled
+func parameterEncoded(p ir.Parameter) interface{} {
+	if example, ok := parameterExample(p); ok && len(p.Type.Components) == 0 {
+		return example
+	}
+	return SampleOutput(p.Type)
+}
+
+// parameterExample returns the first example value of a parameter, with numbers given for integers converted to
+// the decimal strings tools take
+func parameterExample(p ir.Parameter) (interface{}, bool) {
+	if len(p.Examples) == 0 {
+		return nil, false
+	}
+	example := p.Examples[0]
+	if number, ok := example.(float64); ok && (strings.HasPrefix(p.Type.BaseType, "uint") || strings.HasPrefix(p.Type.BaseType, "int")) {
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	}
+	return example, true
+}
+
+// sampleValue builds an example value, optionally rendering structs as objects
+func sampleValue(t ir.ParameterType, structsAsObjects bool) interface{} {
+	element := sampleElement(t, structsAsObjects)
+	if !t.IsArray {
+		return element
+	}
+
+	size := t.ArraySize
+	if size == 0 {
+		size = 1
+	}
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = element
+	}
+	return values
+}
+
+// sampleElement builds an example value for the element type of t
+func sampleElement(t ir.ParameterType, structsAsObjects bool) interface{} {
+	if len(t.Components) > 0 {
+		if structsAsObjects {
+			fields := make(map[string]interface{}, len(t.Components))
+			for i, component := range t.Components {
+				name := component.Name
+				if name == "" {
+					name = fmt.Sprintf("field%d", i)
+				}
+				fields[name] = sampleValue(component.Type, true)
+			}
+			return fields
+		}
+		fields := make([]interface{}, len(t.Components))
+		for i, component := range t.Components {
+			fields[i] = sampleValue(component.Type, false)
+		}
+		return fields
+	}
+
+	switch base := t.BaseType; {
+	case base == "address":
+		return sampleAddress
+	case base == "bool":
+		return true
+	case base == "bytes":
+		return "0x1234"
+	case strings.HasPrefix(base, "bytes"):
+		if size, ok := typeBits(base, "bytes"); ok {
+			return "0x" + strings.Repeat("11", size)
+		}
+	case strings.HasPrefix(base, "uint"), strings.HasPrefix(base, "int"):
+		return "1"
+	}
+	return "example"
+}
+
+// sampleJSON renders a sample value as a JavaScript literal
+func sampleJSON(value interface{}) string {
+	content, _ := json.Marshal(value)
+	return string(content)
+}
+
+// fixtureArguments returns valid arguments for a tool taking the given parameters as a JavaScript literal
+// Tool options are appended unless a parameter has their name
+func fixtureArguments(parameters []ir.Parameter, options ...sampleField) string {
+	args := sampleObject{}
+	names := map[string]bool{}
+	for _, p := range parameters {
+		args = append(args, sampleField{p.Name, ParameterSample(p)})
+		names[p.Name] = true
+	}
+	for _, option := range options {
+		if !names[option.key] {
+			args = append(args, option)
+		}
+	}
+	return sampleJSON(args)
+}
+
+// FunctionSignature returns the canonical ABI signature of a function (e.g., "transfer(address,uint256)")
+// Overloaded functions must be called by signature since their names are ambiguous; it is recorded in their overload set
+func FunctionSignature(f ir.Function) string {
+	if f.Overload != nil && f.Overload.Signature != "" {
+		return f.Overload.Signature
+	}
+	types := make([]string, len(f.Inputs))
+	for i, input := range f.Inputs {
+		types[i] = input.Type.ABIType()
+	}
+	return f.ABIName() + "(" + strings.Join(types, ",") + ")"
+}
+
+// EventSignature returns the canonical ABI signature of an event (e.g., "Transfer(address,address,uint256)")
+func EventSignature(e ir.Event) string {
+	types := make([]string, len(e.Parameters))
+	for i, parameter := range e.Parameters {
+		types[i] = parameter.Type.ABIType()
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// ErrorSignature returns the canonical ABI signature of a custom error (e.g., "InsufficientBalance(uint256,uint256)")
+func ErrorSignature(e ir.ContractError) string {
+	types := make([]string, len(e.Parameters))
+	for i, parameter := range e.Parameters {
+		types[i] = parameter.Type.ABIType()
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
\ No newline at end of file
@@ -0,0 +1,185 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestSampleValues(t *testing.T) {
+	person := ir.ParameterType{
+		BaseType: "tuple",
+		Components: []ir.Parameter{
+			{Name: "name", Type: ir.ParameterType{BaseType: "string"}},
+			{Name: "wallet", Type: ir.ParameterType{BaseType: "address"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		typ            ir.ParameterType
+		expectedInput  string
+		expectedOutput string
+	}{
+		{"Uint", ir.ParameterType{BaseType: "uint256"}, `"1"`, `"1"`},
+		{"Bool", ir.ParameterType{BaseType: "bool"}, `true`, `true`},
+		{"Bytes4", ir.ParameterType{BaseType: "bytes4"}, `"0x11111111"`, `"0x11111111"`},
+		{"Fixed Array", ir.ParameterType{BaseType: "int8", IsArray: true, ArraySize: 2}, `["1","1"]`, `["1","1"]`},
+		{
+			"Tuple",
+			person,
+			`{"name":"example","wallet":"0x0000000000000000000000000000000000000001"}`,
+			`["example","0x0000000000000000000000000000000000000001"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sampleJSON(SampleInput(tt.typ)); got != tt.expectedInput {
+				t.Errorf("Expected input %s but got %s", tt.expectedInput, got)
+			}
+			if got := sampleJSON(SampleOutput(tt.typ)); got != tt.expectedOutput {
+				t.Errorf("Expected output %s but got %s", tt.expectedOutput, got)
+			}
+		})
+	}
+}
+
+func TestParameterSample(t *testing.T) {
+	amount := ir.Parameter{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}, Examples: []interface{}{1e18}}
+	if got := sampleJSON(ParameterSample(amount)); got != `"1000000000000000000"` {
+		t.Errorf("Expected the example as a decimal string but got %s", got)
+	}
+	if got := sampleJSON(parameterEncoded(amount)); got != `"1000000000000000000"` {
+		t.Errorf("Expected the example to be encoded but got %s", got)
+	}
+
+	person := ir.Parameter{
+		Name:     "person",
+		Type:     ir.ParameterType{BaseType: "tuple", Components: []ir.Parameter{{Name: "wallet", Type: ir.ParameterType{BaseType: "address"}}}},
+		Examples: []interface{}{map[string]interface{}{"wallet": "0x0000000000000000000000000000000000000002"}},
+	}
+	if got := sampleJSON(ParameterSample(person)); got != `{"wallet":"0x0000000000000000000000000000000000000002"}` {
+		t.Errorf("Expected the struct example but got %s", got)
+	}
+	if got := sampleJSON(parameterEncoded(person)); got != `["0x0000000000000000000000000000000000000001"]` {
+		t.Errorf("Expected structs to be sampled for encoding but got %s", got)
+	}
+
+	if got := sampleJSON(ParameterSample(ir.Parameter{Type: ir.ParameterType{BaseType: "bool"}})); got != "true" {
+		t.Errorf("Expected a sample without examples but got %s", got)
+	}
+}
+
+func TestFunctionSignature(t *testing.T) {
+	tests := []struct {
+		name     string
+		function ir.Function
+		expected string
+	}{
+		{
+			"No Inputs",
+			ir.Function{Name: "totalSupply"},
+			"totalSupply()",
+		},
+		{
+			"Overload",
+			ir.Function{
+				Name:      "balanceOf_1",
+				ChainData: map[string]interface{}{"originalName": "balanceOf"},
+				Inputs: []ir.Parameter{
+					{Name: "account", Type: ir.ParameterType{BaseType: "address"}},
+					{Name: "id", Type: ir.ParameterType{BaseType: "uint256"}},
+				},
+			},
+			"balanceOf(address,uint256)",
+		},
+		{
+			"Tuple Array",
+			ir.Function{
+				Name: "processPeople",
+				Inputs: []ir.Parameter{
+					{
+						Name: "people",
+						Type: ir.ParameterType{
+							BaseType: "tuple",
+							IsArray:  true,
+							Components: []ir.Parameter{
+								{Name: "name", Type: ir.ParameterType{BaseType: "string"}},
+								{Name: "scores", Type: ir.ParameterType{BaseType: "uint8", IsArray: true, ArraySize: 3}},
+							},
+						},
+					},
+				},
+			},
+			"processPeople((string,uint8[3])[])",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FunctionSignature(tt.function); got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+func TestEventSignature(t *testing.T) {
+	event := ir.Event{
+		Name: "OrderFilled",
+		Parameters: []ir.EventParameter{
+			{Name: "maker", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+			{
+				Name: "order",
+				Type: ir.ParameterType{
+					BaseType: "tuple",
+					Components: []ir.Parameter{
+						{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+						{Name: "data", Type: ir.ParameterType{BaseType: "bytes"}},
+					},
+				},
+			},
+		},
+	}
+
+	if got, expected := EventSignature(event), "OrderFilled(address,(uint256,bytes))"; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+}
+
+func TestErrorSignature(t *testing.T) {
+	contractError := ir.ContractError{
+		Name: "InsufficientBalance",
+		Parameters: []ir.Parameter{
+			{Name: "available", Type: ir.ParameterType{BaseType: "uint256"}},
+			{Name: "required", Type: ir.ParameterType{BaseType: "uint256"}},
+		},
+	}
+
+	if got, expected := ErrorSignature(contractError), "InsufficientBalance(uint256,uint256)"; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+	if got, expected := ErrorSignature(ir.ContractError{Name: "Unauthorized"}), "Unauthorized()"; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+}
+
+func TestFixtureArguments(t *testing.T) {
+	parameters := []ir.Parameter{
+		{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+		{Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+	}
+	if got, expected := fixtureArguments(parameters, sampleField{"send", false}), `{"to":"0x0000000000000000000000000000000000000001","amount":"1","send":false}`; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+
+	// A parameter shadows the tool option of the same name
+	parameters = append(parameters, ir.Parameter{Name: "send", Type: ir.ParameterType{BaseType: "bool"}})
+	if got, expected := fixtureArguments(parameters, sampleField{"send", false}), `{"to":"0x0000000000000000000000000000000000000001","amount":"1","send":true}`; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+
+	if got, expected := fixtureArguments(nil), `{}`; got != expected {
+		t.Errorf("Expected %s but got %s", expected, got)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,112 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// Generator describes an output language servers can be generated in
+type Generator struct {
+	// Language identifier selecting the generator with --lang (e.g., "ts")
+	Language string `json:"language"`
+
+	// Other identifiers selecting the generator (e.g., "typescript")
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description"`
+
+	// Names of the generate-mcp flags the generator supports (e.g., "runtime", "transport")
+	Flags []string `json:"flags,omitempty"`
+
+	// Path of the plugin providing the generator, empty for built-in generators
+	Plugin string `json:"plugin,omitempty"`
+
+	// Verify returns the commands checking that a server generated with the values of the generator's flags builds,
+	// run in a copy of the project by --verify, e.g. npm install then tsc --noEmit; nil if it cannot be verified
+	Verify func(options map[string]string) [][]string `json:"-"`
+
+	// Render renders the files of a server from an IR and the values of the generator's flags, keyed by flag name;
+	// built-in generators are rendered by generate-mcp itself
+	Render func(contractIR *ir.ContractIR, options map[string]string) (map[string][]byte, error) `json:"-"`
+}
+
+var (
+	generatorsMu sync.RWMutex
+	generators   = make(map[string]Generator)
+)
+
+func init() {
+	err := RegisterGenerator(Generator{
+		Language:    "ts",
+		Aliases:     []string{"typescript"},
+		Description: "TypeScript MCP servers on the MCP SDK and ethers, or typed contract packages with --mode types",
+		Flags: []string{
+			"mode", "runtime", "transport", "enable-writes", "enable-subscriptions", "enable-cache", "enable-access-lists",
+			"signers", "tests", "docker", "ci", "templates", "template-pack", "set", "package-scope", "package-version", "license", "author", "repository",
+		},
+		Verify: verifyTypeScript,
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// verifyTypeScript returns the commands type-checking a TypeScript project with its runtime's tooling, installing its
+// dependencies without running their scripts
+func verifyTypeScript(options map[string]string) [][]string {
+	switch options["runtime"] {
+	case "deno":
+		return [][]string{{"deno", "check", "src/server.ts"}}
+	case "bun":
+		return [][]string{{"bun", "install", "--ignore-scripts"}, {"bunx", "tsc", "--noEmit"}}
+	}
+	return [][]string{{"npm", "install", "--ignore-scripts", "--no-audit", "--no-fund"}, {"npx", "--no-install", "tsc", "--noEmit"}}
+}
+
+// RegisterGenerator makes a generator available by its language identifier and aliases
+func RegisterGenerator(generator Generator) error {
+	if generator.Language == "" {
+		return fmt.Errorf("generators need a language")
+	}
+
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+
+	for _, language := range append([]string{generator.Language}, generator.Aliases...) {
+		if _, exists := generators[language]; exists {
+			return fmt.Errorf("a generator for language %s is already registered", language)
+		}
+	}
+	for _, language := range append([]string{generator.Language}, generator.Aliases...) {
+		generators[language] = generator
+	}
+	return nil
+}
+
+// LookupGenerator returns the generator registered for a language identifier or alias
+func LookupGenerator(language string) (Generator, bool) {
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+
+	generator, ok := generators[language]
+	return generator, ok
+}
+
+// Generators returns the registered generators, sorted by language
+func Generators() []Generator {
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+
+	var list []Generator
+	for language, generator := range generators {
+		if language == generator.Language {
+			list = append(list, generator)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Language < list[j].Language })
+	return list
+}
\ No newline at end of file
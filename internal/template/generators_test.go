@@ -0,0 +1,33 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestGeneratorRegistry(t *testing.T) {
+	generator, ok := LookupGenerator("typescript")
+	if !ok || generator.Language != "ts" {
+		t.Fatalf("Expected the typescript alias to select the ts generator but got %+v", generator)
+	}
+
+	if err := RegisterGenerator(Generator{Language: "ts"}); err == nil {
+		t.Error("Expected registering a language twice to fail")
+	}
+	if err := RegisterGenerator(Generator{Language: "rust-test", Aliases: []string{"rs-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		generatorsMu.Lock()
+		delete(generators, "rust-test")
+		delete(generators, "rs-test")
+		generatorsMu.Unlock()
+	}()
+
+	var languages []string
+	for _, generator := range Generators() {
+		languages = append(languages, generator.Language)
+	}
+	if len(languages) != 2 || languages[0] != "rust-test" || languages[1] != "ts" {
+		t.Errorf("Expected each generator once, sorted, but got %v", languages)
+	}
+}
\ No newline at end of file
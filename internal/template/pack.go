@@ -0,0 +1,362 @@
+package template
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// PackManifestFile is the name of the manifest at the root of every template pack
+const PackManifestFile = "pack.json"
+
+// PackManifest describes a template pack
+type PackManifest struct {
+	// Pack name used to select it from the registry (e.g., "ts-viem-hono")
+	Name string `json:"name"`
+
+	// Pack version
+	Version string `json:"version,omitempty"`
+
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+
+	// Output language of the generated server (e.g., "ts", "python")
+	Language string `json:"language,omitempty"`
+
+	// Directory inside the pack holding the templates (defaults to "templates")
+	TemplateDir string `json:"templateDir,omitempty"`
+
+	// Output file paths mapped to the templates that render them
+	Files map[string]string `json:"files"`
+
+	// IR base types mapped to target language types, merged over the default mapping
+	TypeMapping TypeMapping `json:"typeMapping,omitempty"`
+}
+
+// TemplatePack is a loaded set of templates together with its manifest
+type TemplatePack struct {
+	// Manifest of the pack
+	Manifest PackManifest
+
+	// Templates rooted at the manifest's template directory
+	Templates fs.FS
+}
+
+// TypeMapping maps IR base types to target language type names
+// Keys may be exact base types ("uint8"), type families ("uint", "int", "bytes"), or "default"
+type TypeMapping map[string]string
+
+// DefaultTypeMapping returns the IR to TypeScript type mapping used by the built-in templates
+func DefaultTypeMapping() TypeMapping {
+	return TypeMapping{
+		"address": "string",
+		"bool":    "boolean",
+		"string":  "string",
+		"uint8":   "number",
+		"uint":    "string",
+		"int":     "string",
+		"bytes":   "string",
+		"default": "string",
+	}
+}
+
+// merge returns a copy of the mapping with the other mapping's entries taking precedence
+func (m TypeMapping) merge(other TypeMapping) TypeMapping {
+	merged := TypeMapping{}
+	for k, v := range m {
+		merged[k] = v
+	}
+	for k, v := range other {
+		merged[k] = v
+	}
+	return merged
+}
+
+// TypeOf returns the target type for an IR parameter type
+func (m TypeMapping) TypeOf(t ir.ParameterType) string {
+	typeName := m.baseTypeOf(t)
+
+	if !t.IsArray {
+		return typeName
+	}
+
+	// Fixed-size arrays become tuples of the element type
+	if t.ArraySize > 0 {
+		elements := make([]string, t.ArraySize)
+		for i := range elements {
+			elements[i] = typeName
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+	return typeName + "[]"
+}
+
+// baseTypeOf resolves the target type for the element type of t
+func (m TypeMapping) baseTypeOf(t ir.ParameterType) string {
+	if len(t.Components) > 0 {
+		fields := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			name := component.Name
+			if name == "" {
+				name = fmt.Sprintf("field%d", i)
+			}
+			fields[i] = name + ": " + m.TypeOf(component.Type)
+		}
+		return "{ " + strings.Join(fields, "; ") + " }"
+	}
+
+	if typeName, ok := m[t.BaseType]; ok {
+		return typeName
+	}
+
+	// Fall back to the type family (uint256 -> uint, bytes32 -> bytes)
+	family := strings.TrimRight(t.BaseType, "0123456789")
+	if typeName, ok := m[family]; ok {
+		return typeName
+	}
+
+	if typeName, ok := m["default"]; ok {
+		return typeName
+	}
+	return t.BaseType
+}
+
+// Validate checks if the manifest is usable
+func (m *PackManifest) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("pack name is required")
+	}
+	if len(m.Files) == 0 {
+		return fmt.Errorf("pack %s declares no files", m.Name)
+	}
+	for output, templateName := range m.Files {
+		if !fs.ValidPath(output) || !fs.ValidPath(templateName) {
+			return fmt.Errorf("pack %s has an invalid file mapping %s -> %s", m.Name, output, templateName)
+		}
+	}
+	return nil
+}
+
+// LoadTemplatePack loads a template pack from a directory, a .zip archive, or a .tar/.tar.gz archive
+func LoadTemplatePack(location string) (*TemplatePack, error) {
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template pack: %w", err)
+	}
+
+	var root fs.FS
+	switch {
+	case info.IsDir():
+		root = os.DirFS(location)
+	case strings.HasSuffix(location, ".zip"):
+		root, err = readZipArchive(location)
+	case strings.HasSuffix(location, ".tar"), strings.HasSuffix(location, ".tar.gz"), strings.HasSuffix(location, ".tgz"):
+		root, err = readTarArchive(location)
+	default:
+		return nil, fmt.Errorf("unsupported template pack format: %s", location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template pack %s: %w", location, err)
+	}
+
+	return LoadTemplatePackFS(root)
+}
+
+// LoadTemplatePackFS loads a template pack from a file system containing pack.json at its root
+func LoadTemplatePackFS(root fs.FS) (*TemplatePack, error) {
+	content, err := fs.ReadFile(root, PackManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PackManifestFile, err)
+	}
+
+	var manifest PackManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackManifestFile, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	templateDir := manifest.TemplateDir
+	if templateDir == "" {
+		templateDir = "templates"
+	}
+	templates, err := fs.Sub(root, templateDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template directory %s: %w", templateDir, err)
+	}
+
+	return &TemplatePack{
+		Manifest:  manifest,
+		Templates: templates,
+	}, nil
+}
+
+var (
+	packsMu sync.RWMutex
+	packs   = make(map[string]*TemplatePack)
+)
+
+// RegisterTemplatePack makes a template pack available by name
+func RegisterTemplatePack(pack *TemplatePack) error {
+	if err := pack.Manifest.Validate(); err != nil {
+		return err
+	}
+
+	packsMu.Lock()
+	defer packsMu.Unlock()
+
+	if _, exists := packs[pack.Manifest.Name]; exists {
+		return fmt.Errorf("template pack %s is already registered", pack.Manifest.Name)
+	}
+	packs[pack.Manifest.Name] = pack
+	return nil
+}
+
+// LookupTemplatePack returns the registered template pack with the given name
+func LookupTemplatePack(name string) (*TemplatePack, bool) {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+
+	pack, ok := packs[name]
+	return pack, ok
+}
+
+// TemplatePacks returns the names of all registered template packs in sorted order
+func TemplatePacks() []string {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+
+	names := make([]string, 0, len(packs))
+	for name := range packs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveTemplatePack returns the registered pack with the given name, or loads one from a path
+func ResolveTemplatePack(nameOrPath string) (*TemplatePack, error) {
+	if pack, ok := LookupTemplatePack(nameOrPath); ok {
+		return pack, nil
+	}
+	return LoadTemplatePack(nameOrPath)
+}
+
+// readZipArchive reads a zip archive into memory so no file handle outlives the call
+func readZipArchive(location string) (fs.FS, error) {
+	content, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(content), int64(len(content)))
+}
+
+// readTarArchive reads a (optionally gzip-compressed) tar archive into an in-memory file system
+func readTarArchive(location string) (fs.FS, error) {
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if !strings.HasSuffix(location, ".tar") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	files := make(memFS)
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(strings.TrimPrefix(header.Name, "./"))] = content
+	}
+	return files, nil
+}
+
+// memFS is a minimal read-only in-memory file system keyed by slash-separated paths
+type memFS map[string][]byte
+
+// Open implements fs.FS
+func (m memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	content, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFile{name: path.Base(name), Reader: bytes.NewReader(content), size: int64(len(content))}, nil
+}
+
+// ReadFile implements fs.ReadFileFS
+func (m memFS) ReadFile(name string) ([]byte, error) {
+	content, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), content...), nil
+}
+
+// memFile is an open file of a memFS
+type memFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+// Stat implements fs.File
+func (f *memFile) Stat() (fs.FileInfo, error) { return f, nil }
+
+// Close implements fs.File
+func (f *memFile) Close() error { return nil }
+
+// Name implements fs.FileInfo
+func (f *memFile) Name() string { return f.name }
+
+// Size implements fs.FileInfo
+func (f *memFile) Size() int64 { return f.size }
+
+// Mode implements fs.FileInfo
+func (f *memFile) Mode() fs.FileMode { return 0444 }
+
+// ModTime implements fs.FileInfo
+func (f *memFile) ModTime() time.Time { return time.Time{} }
+
+// IsDir implements fs.FileInfo
+func (f *memFile) IsDir() bool { return false }
+
+// Sys implements fs.FileInfo
+func (f *memFile) Sys() interface{} { return nil }
\ No newline at end of file
@@ -0,0 +1,205 @@
+package template
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// testPackFiles is a minimal python pack with a custom type mapping
+var testPackFiles = map[string]string{
+	"pack.json": `{
+		"name": "python-test",
+		"version": "0.1.0",
+		"language": "python",
+		"files": {"server.py": "server.py.tmpl"},
+		"typeMapping": {"uint": "int", "address": "str", "default": "str"}
+	}`,
+	"templates/server.py.tmpl": `{{range .Functions}}{{.Name}}: {{range .Inputs}}{{mapType .Type}}{{end}}
+{{end}}`,
+}
+
+func writeTestPackDir(t *testing.T) string {
+	dir := t.TempDir()
+	for name, content := range testPackFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create pack directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write pack file: %v", err)
+		}
+	}
+	return dir
+}
+
+func writeTestPackZip(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "pack.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for name, content := range testPackFiles {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add zip entry: %v", err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to finish zip: %v", err)
+	}
+	return path
+}
+
+func writeTestPackTarGz(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "pack.tar.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for name, content := range testPackFiles {
+		header := &tar.Header{Name: "./" + name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to add tar entry: %v", err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return path
+}
+
+func TestLoadTemplatePack(t *testing.T) {
+	contract := &ir.ContractIR{
+		Metadata: ir.ContractMetadata{Name: "TestToken", Chain: "ethereum"},
+		Functions: []ir.Function{
+			{
+				Name:            "balanceOf",
+				StateMutability: ir.View,
+				Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address"}}},
+			},
+			{
+				Name:            "allowanceAt",
+				StateMutability: ir.View,
+				Inputs:          []ir.Parameter{{Name: "block", Type: ir.ParameterType{BaseType: "uint64"}}},
+			},
+		},
+	}
+
+	locations := map[string]string{
+		"directory": writeTestPackDir(t),
+		"zip":       writeTestPackZip(t),
+		"tar.gz":    writeTestPackTarGz(t),
+	}
+
+	for format, location := range locations {
+		t.Run(format, func(t *testing.T) {
+			pack, err := LoadTemplatePack(location)
+			if err != nil {
+				t.Fatalf("Failed to load template pack: %v", err)
+			}
+			if pack.Manifest.Name != "python-test" || pack.Manifest.Language != "python" {
+				t.Errorf("Unexpected manifest: %+v", pack.Manifest)
+			}
+
+			files, err := NewTypeScriptTemplateRenderer().WithTemplatePack(pack).Render(contract)
+			if err != nil {
+				t.Fatalf("Failed to render template pack: %v", err)
+			}
+			if len(files) != 1 {
+				t.Errorf("Expected only the files declared by the pack, got %d", len(files))
+			}
+			if got := string(files["server.py"]); got != "balanceOf: str\nallowanceAt: int\n" {
+				t.Errorf("Unexpected pack output: %q", got)
+			}
+		})
+	}
+
+	if _, err := LoadTemplatePack(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Expected an error for a missing pack")
+	}
+}
+
+func TestTemplatePackRegistry(t *testing.T) {
+	pack, err := LoadTemplatePack(writeTestPackDir(t))
+	if err != nil {
+		t.Fatalf("Failed to load template pack: %v", err)
+	}
+	pack.Manifest.Name = "registry-test"
+
+	if err := RegisterTemplatePack(pack); err != nil {
+		t.Fatalf("Failed to register template pack: %v", err)
+	}
+	if err := RegisterTemplatePack(pack); err == nil {
+		t.Errorf("Expected an error when registering a pack twice")
+	}
+
+	resolved, err := ResolveTemplatePack("registry-test")
+	if err != nil || resolved != pack {
+		t.Errorf("Expected the registered pack to be resolved by name, got %v (%v)", resolved, err)
+	}
+
+	found := false
+	for _, name := range TemplatePacks() {
+		if name == "registry-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Registered pack missing from TemplatePacks()")
+	}
+
+	invalid := &TemplatePack{Manifest: PackManifest{Name: "empty"}}
+	if err := RegisterTemplatePack(invalid); err == nil {
+		t.Errorf("Expected an error for a pack without files")
+	}
+}
+
+func TestTypeMapping(t *testing.T) {
+	mapping := DefaultTypeMapping()
+
+	tests := []struct {
+		name     string
+		typ      ir.ParameterType
+		expected string
+	}{
+		{"Address", ir.ParameterType{BaseType: "address"}, "string"},
+		{"Uint8", ir.ParameterType{BaseType: "uint8"}, "number"},
+		{"Uint256 Family", ir.ParameterType{BaseType: "uint256"}, "string"},
+		{"Bool", ir.ParameterType{BaseType: "bool"}, "boolean"},
+		{"Dynamic Array", ir.ParameterType{BaseType: "bool", IsArray: true}, "boolean[]"},
+		{"Fixed Array", ir.ParameterType{BaseType: "uint8", IsArray: true, ArraySize: 2}, "[number, number]"},
+		{
+			"Tuple",
+			ir.ParameterType{
+				BaseType: "tuple",
+				Components: []ir.Parameter{
+					{Name: "owner", Type: ir.ParameterType{BaseType: "address"}},
+					{Type: ir.ParameterType{BaseType: "bool"}},
+				},
+			},
+			"{ owner: string; field1: boolean }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapping.TypeOf(tt.typ); got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
\ No newline at end of file
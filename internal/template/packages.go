@@ -0,0 +1,65 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultPackageVersion is the version of generated packages unless one is given
+const DefaultPackageVersion = "1.0.0"
+
+var (
+	// scopePattern matches an npm scope without its leading @
+	scopePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._~-]*$`)
+
+	// versionPattern matches a semantic version
+	versionPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+)
+
+// PackageInfo is the metadata of the generated package, so it can be published as is
+type PackageInfo struct {
+	// npm or JSR scope, with or without the leading @ (optional for npm)
+	Scope string
+
+	// Semantic version (default: 1.0.0)
+	Version string
+
+	// SPDX license expression (optional)
+	License string
+
+	// Author, e.g. "Jane Doe <jane@example.com>" (optional)
+	Author string
+
+	// Source repository URL or shorthand such as github:user/repo (optional)
+	Repository string
+}
+
+// normalize validates the package metadata and fills in defaults
+func (p PackageInfo) normalize() (PackageInfo, error) {
+	p.Scope = strings.TrimPrefix(strings.TrimSpace(p.Scope), "@")
+	if p.Scope != "" && !scopePattern.MatchString(p.Scope) {
+		return p, fmt.Errorf("invalid package scope %q: use lowercase letters, digits, '-', '.', '_' and '~'", p.Scope)
+	}
+
+	p.Version = strings.TrimPrefix(strings.TrimSpace(p.Version), "v")
+	if p.Version == "" {
+		p.Version = DefaultPackageVersion
+	}
+	if !versionPattern.MatchString(p.Version) {
+		return p, fmt.Errorf("invalid package version %q: expected a semantic version such as 1.2.3", p.Version)
+	}
+
+	p.License = strings.TrimSpace(p.License)
+	p.Author = strings.TrimSpace(p.Author)
+	p.Repository = strings.TrimSpace(p.Repository)
+	return p, nil
+}
+
+// Name returns the package name, prefixed with the scope if there is one
+func (p PackageInfo) Name(name string) string {
+	if p.Scope == "" {
+		return name
+	}
+	return "@" + p.Scope + "/" + name
+}
\ No newline at end of file
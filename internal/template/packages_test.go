@@ -0,0 +1,40 @@
+package template
+
+import "testing"
+
+func TestPackageInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      PackageInfo
+		expected string
+		version  string
+		invalid  bool
+	}{
+		{"Defaults", PackageInfo{}, "token", DefaultPackageVersion, false},
+		{"Scope", PackageInfo{Scope: "@acme", Version: "1.2.3-beta.1"}, "@acme/token", "1.2.3-beta.1", false},
+		{"Scope Without At", PackageInfo{Scope: "acme", Version: "v0.1.0"}, "@acme/token", "0.1.0", false},
+		{"Invalid Scope", PackageInfo{Scope: "Acme Inc"}, "", "", true},
+		{"Invalid Version", PackageInfo{Version: "1.2"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := tt.pkg.normalize()
+			if tt.invalid {
+				if err == nil {
+					t.Errorf("Expected an error for %+v", tt.pkg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := pkg.Name("token"); got != tt.expected {
+				t.Errorf("Expected name %s but got %s", tt.expected, got)
+			}
+			if pkg.Version != tt.version {
+				t.Errorf("Expected version %s but got %s", tt.version, pkg.Version)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/output"
+)
+
+// TemplatesManifestFile is the name of the manifest of a template directory created by InitTemplates
+const TemplatesManifestFile = "templates.json"
+
+// DataDocsFile is the name of the reference of the template data fields written next to the templates
+const DataDocsFile = "TEMPLATE_DATA.md"
+
+// TemplatesManifest describes a template directory created by InitTemplates
+type TemplatesManifest struct {
+	// Version of the generator the templates were copied from
+	GeneratorVersion string `json:"generatorVersion"`
+
+	// Output language of the templates (e.g., "ts")
+	Language string `json:"language"`
+
+	// Template paths mapped to the hashes of the templates as copied, telling customized templates from stock ones
+	Files map[string]string `json:"files"`
+}
+
+// InitTemplates copies the built-in TypeScript templates into a directory, ready to be customized and used with
+// WithTemplateDir, together with a manifest and the reference of the template data fields
+// Existing files are only overwritten if overwrite is set; otherwise the error lists them and nothing is written.
+func InitTemplates(dir, generatorVersion string, overwrite bool) (*TemplatesManifest, error) {
+	templates := TypeScriptTemplates()
+	manifest := &TemplatesManifest{GeneratorVersion: generatorVersion, Language: "ts", Files: map[string]string{}}
+	files := map[string][]byte{}
+	err := fs.WalkDir(templates, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(templates, path)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+		manifest.Files[path] = output.Hash(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[TemplatesManifestFile] = append(content, '\n')
+	files[DataDocsFile] = RenderDataDocs()
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if !overwrite {
+		var existing []string
+		for _, path := range paths {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err == nil {
+				existing = append(existing, path)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("%s already has %d of the files: %s", dir, len(existing), strings.Join(existing, ", "))
+		}
+	}
+	for _, path := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, files[path], 0644); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
\ No newline at end of file
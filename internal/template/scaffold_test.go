@@ -0,0 +1,45 @@
+package template
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitTemplates(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := InitTemplates(dir, "v1.2.3", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.GeneratorVersion != "v1.2.3" || manifest.Files["server.ts.tmpl"] == "" || manifest.Files["ci/github.yml.tmpl"] == "" {
+		t.Errorf("Unexpected manifest %+v", manifest)
+	}
+	for _, path := range []string{"server.ts.tmpl", "ci/github.yml.tmpl", TemplatesManifestFile, DataDocsFile} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("Expected %s written: %v", path, err)
+		}
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, TemplatesManifestFile))
+	var written TemplatesManifest
+	if err := json.Unmarshal(content, &written); err != nil || len(written.Files) != len(manifest.Files) {
+		t.Errorf("Expected the manifest written but got %s (%v)", content, err)
+	}
+
+	// Existing files are listed rather than overwritten
+	os.WriteFile(filepath.Join(dir, "server.ts.tmpl"), []byte("custom"), 0644)
+	if _, err := InitTemplates(dir, "v1.2.3", false); err == nil || !strings.Contains(err.Error(), "server.ts.tmpl") {
+		t.Errorf("Expected the existing files listed but got %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "server.ts.tmpl")); string(content) != "custom" {
+		t.Errorf("Expected the customized template kept but got %q", content)
+	}
+	if _, err := InitTemplates(dir, "v1.2.3", true); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "server.ts.tmpl")); string(content) == "custom" {
+		t.Error("Expected the template overwritten")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,94 @@
+package template
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// embeddedTemplates holds the default template sets shipped with the binary
+//
+//go:embed typescript
+var embeddedTemplates embed.FS
+
+// TypeScriptTemplates returns the default TypeScript template set embedded in the binary
+func TypeScriptTemplates() fs.FS {
+	templates, err := fs.Sub(embeddedTemplates, "typescript")
+	if err != nil {
+		// The directory is embedded at compile time, so this cannot fail
+		panic(err)
+	}
+	return templates
+}
+
+// readTemplate reads a template source from a template file system
+func readTemplate(templates fs.FS, name string) (string, error) {
+	content, err := fs.ReadFile(templates, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("template %s not found", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+	return string(content), nil
+}
+
+// overlayFS is a read-only file system that resolves each file from the first layer containing it
+type overlayFS struct {
+	layers []fs.FS
+}
+
+// NewOverlayFS layers template file systems so that files present in earlier layers
+// override the same paths in later ones, falling back per file
+func NewOverlayFS(layers ...fs.FS) fs.FS {
+	return &overlayFS{layers: layers}
+}
+
+// Open implements fs.FS by opening the file from the first layer that has it
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	for _, layer := range o.layers {
+		file, err := layer.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implements fs.ReadDirFS by merging the directory entries of every layer
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	found := false
+
+	for _, layer := range o.layers {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range layerEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
\ No newline at end of file
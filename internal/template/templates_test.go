@@ -0,0 +1,47 @@
+package template
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOverlayFS(t *testing.T) {
+	overrides := fstest.MapFS{
+		"server.ts.tmpl":           {Data: []byte("custom server")},
+		"inspector-e2e/extra.tmpl": {Data: []byte("extra")},
+	}
+	defaults := fstest.MapFS{
+		"server.ts.tmpl":                       {Data: []byte("default server")},
+		"README.md.tmpl":                       {Data: []byte("default readme")},
+		"inspector-e2e/e2e-tests.spec.ts.tmpl": {Data: []byte("default e2e")},
+	}
+
+	overlay := NewOverlayFS(overrides, defaults)
+
+	// Files present in the override layer win
+	content, err := fs.ReadFile(overlay, "server.ts.tmpl")
+	if err != nil || string(content) != "custom server" {
+		t.Errorf("Expected the overridden server template, got %q (%v)", content, err)
+	}
+
+	// Files missing from the override layer fall back to the defaults
+	content, err = fs.ReadFile(overlay, "README.md.tmpl")
+	if err != nil || string(content) != "default readme" {
+		t.Errorf("Expected the default README template, got %q (%v)", content, err)
+	}
+
+	// Missing everywhere is reported as not found
+	if _, err := fs.ReadFile(overlay, "missing.tmpl"); err == nil {
+		t.Errorf("Expected an error for a missing template")
+	}
+
+	// Directory listings merge every layer
+	entries, err := fs.ReadDir(overlay, "inspector-e2e")
+	if err != nil {
+		t.Fatalf("Failed to read merged directory: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name() != "e2e-tests.spec.ts.tmpl" || entries[1].Name() != "extra.tmpl" {
+		t.Errorf("Unexpected merged directory entries: %v", entries)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,155 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+	"github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// TypeField is a field of a generated interface
+type TypeField struct {
+	// Field name
+	Name string
+
+	// Target language type
+	Type string
+
+	// Whether the field may be omitted
+	Optional bool
+
+	// Human-readable description
+	Description string
+}
+
+// TypeDeclaration is a generated interface
+type TypeDeclaration struct {
+	// Interface name
+	Name string
+
+	// Human-readable description
+	Description string
+
+	// Fields in declaration order
+	Fields []TypeField
+}
+
+// typeDeclarations collects the interfaces of a contract, naming each declaration uniquely
+type typeDeclarations struct {
+	mapping      TypeMapping
+	names        map[string]bool
+	declarations []TypeDeclaration
+
+	// Declared interface names of the contract's custom types
+	types map[string]string
+}
+
+// Declarations returns the interfaces of a contract's custom types, function parameters and return values, events and the tuples they use
+// Custom types come first, and tuples naming one (see ir.DeduplicateTuples) use its interface; other tuples are named after
+// where they are used (e.g. SwapKey for the key parameter of swap) and declared before their first use
+func (m TypeMapping) Declarations(contract *ir.ContractIR) []TypeDeclaration {
+	d := &typeDeclarations{mapping: m, names: map[string]bool{}, types: map[string]string{}}
+
+	for _, t := range contract.Types {
+		d.types[t.Name] = d.declare(typeName(t.Name), t.Description, d.fields(typeName(t.Name), t.Fields, "field"))
+	}
+
+	for _, f := range contract.Functions {
+		if f.IsConstructor || f.IsFallback || f.IsReceive {
+			continue
+		}
+		name := typeName(f.Name)
+
+		if len(f.Inputs) > 0 || f.StateMutability == ir.Payable {
+			fields := d.fields(name, f.Inputs, "arg")
+			if f.StateMutability == ir.Payable {
+				value := TypeField{Name: jsonschema.TransactionValue(f), Type: "string", Optional: true, Description: "Optional ETH value to send with the transaction (in wei)"}
+				if f.Value != nil {
+					value.Optional, value.Description = !f.Value.Required, f.Value.Usage()
+				}
+				fields = append(fields, value)
+			}
+			d.declare(name+"Params", fmt.Sprintf("Parameters of %s", FunctionSignature(f)), fields)
+		}
+		if len(f.Outputs) > 0 {
+			d.declare(name+"Result", fmt.Sprintf("Return values of %s", FunctionSignature(f)), d.fields(name, f.Outputs, "result"))
+		}
+	}
+
+	for _, e := range contract.Events {
+		name := typeName(e.Name)
+		fields := make([]TypeField, len(e.Parameters))
+		for i, p := range e.Parameters {
+			fieldName := p.Name
+			if fieldName == "" {
+				fieldName = fmt.Sprintf("arg%d", i)
+			}
+			fields[i] = TypeField{Name: fieldName, Type: d.typeOf(name+typeName(fieldName), p.Type)}
+			if p.Indexed {
+				fields[i].Description = "indexed"
+				if p.Type.IsArray || len(p.Type.Components) > 0 || p.Type.BaseType == "string" || p.Type.BaseType == "bytes" {
+					fields[i].Description = "indexed; only the hash of the value is recorded"
+				}
+			}
+		}
+		d.declare(name+"Event", fmt.Sprintf("Arguments of the %s event", EventSignature(e)), fields)
+	}
+
+	return d.declarations
+}
+
+// fields converts parameters to interface fields, naming unnamed ones prefix0, prefix1, ...
+func (d *typeDeclarations) fields(owner string, parameters []ir.Parameter, prefix string) []TypeField {
+	fields := make([]TypeField, len(parameters))
+	for i, p := range parameters {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		fields[i] = TypeField{Name: name, Type: d.typeOf(owner+typeName(name), p.Type), Optional: p.Default != nil, Description: p.Description}
+	}
+	return fields
+}
+
+// typeOf returns the target type of t, declaring an interface named name for tuples
+func (d *typeDeclarations) typeOf(name string, t ir.ParameterType) string {
+	if len(t.Components) == 0 {
+		return d.mapping.TypeOf(t)
+	}
+
+	tuple, ok := d.types[t.TypeName]
+	if !ok {
+		tuple = d.declare(name, "", d.fields(name, t.Components, "field"))
+	}
+	if !t.IsArray {
+		return tuple
+	}
+	if t.ArraySize > 0 {
+		elements := make([]string, t.ArraySize)
+		for i := range elements {
+			elements[i] = tuple
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+	return tuple + "[]"
+}
+
+// declare adds an interface, suffixing its name with a number if it is taken, and returns the name used
+func (d *typeDeclarations) declare(name, description string, fields []TypeField) string {
+	unique := name
+	for i := 2; d.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	d.names[unique] = true
+	d.declarations = append(d.declarations, TypeDeclaration{Name: unique, Description: description, Fields: fields})
+	return unique
+}
+
+// typeName uppercases the first character of an identifier
+func typeName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
\ No newline at end of file
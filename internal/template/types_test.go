@@ -0,0 +1,91 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestTypeDeclarations(t *testing.T) {
+	position := ir.ParameterType{
+		BaseType: "tuple",
+		IsArray:  true,
+		Components: []ir.Parameter{
+			{Name: "owner", Type: ir.ParameterType{BaseType: "address"}},
+			{Name: "size", Type: ir.ParameterType{BaseType: "uint8"}},
+		},
+	}
+	contract := &ir.ContractIR{
+		Functions: []ir.Function{
+			{Name: "positions", StateMutability: ir.View, Outputs: []ir.Parameter{{Name: "positions", Type: position}}},
+			{Name: "deposit", StateMutability: ir.Payable},
+			{Name: "deposit", StateMutability: ir.Payable, Inputs: []ir.Parameter{{Name: "to", Type: ir.ParameterType{BaseType: "address"}}}},
+		},
+	}
+
+	declarations := DefaultTypeMapping().Declarations(contract)
+	expected := []string{"PositionsPositions", "PositionsResult", "DepositParams", "DepositParams2"}
+	if len(declarations) != len(expected) {
+		t.Fatalf("Expected %d declarations but got %d", len(expected), len(declarations))
+	}
+	for i, name := range expected {
+		if declarations[i].Name != name {
+			t.Errorf("Expected declaration %d to be %s but got %s", i, name, declarations[i].Name)
+		}
+	}
+
+	// Tuples are declared before their use and referenced by name
+	if got := declarations[1].Fields[0].Type; got != "PositionsPositions[]" {
+		t.Errorf("Expected the tuple array to reference its interface but got %s", got)
+	}
+	if got := declarations[0].Fields[1].Type; got != "number" {
+		t.Errorf("Expected uint8 to map to number but got %s", got)
+	}
+
+	// Payable functions accept an optional value
+	if fields := declarations[3].Fields; len(fields) != 2 || fields[1].Name != "value" || !fields[1].Optional {
+		t.Errorf("Expected an optional value field but got %+v", fields)
+	}
+}
+
+func TestTypeDeclarationsCustomTypes(t *testing.T) {
+	key := ir.ParameterType{
+		BaseType: "tuple",
+		TypeName: "PoolKey",
+		Components: []ir.Parameter{
+			{Name: "token", Type: ir.ParameterType{BaseType: "address"}},
+			{Name: "fee", Type: ir.ParameterType{BaseType: "uint24"}},
+		},
+	}
+	keys := key
+	keys.IsArray = true
+	contract := &ir.ContractIR{
+		Functions: []ir.Function{
+			{Name: "swap", StateMutability: ir.Nonpayable, Inputs: []ir.Parameter{{Name: "key", Type: key}}},
+			{Name: "pools", StateMutability: ir.View, Outputs: []ir.Parameter{{Name: "keys", Type: keys}}},
+		},
+		Types: []ir.CustomType{{Name: "PoolKey", Description: "Identifies a pool", Fields: key.Components}},
+	}
+
+	declarations := DefaultTypeMapping().Declarations(contract)
+	expected := []string{"PoolKey", "SwapParams", "PoolsResult"}
+	if len(declarations) != len(expected) {
+		t.Fatalf("Expected %d declarations but got %+v", len(expected), declarations)
+	}
+	for i, name := range expected {
+		if declarations[i].Name != name {
+			t.Errorf("Expected declaration %d to be %s but got %s", i, name, declarations[i].Name)
+		}
+	}
+	if declarations[0].Description != "Identifies a pool" {
+		t.Errorf("Expected the custom type description but got %q", declarations[0].Description)
+	}
+
+	// Tuples naming a custom type use its interface instead of declaring their own
+	if got := declarations[1].Fields[0].Type; got != "PoolKey" {
+		t.Errorf("Expected the tuple to reference PoolKey but got %s", got)
+	}
+	if got := declarations[2].Fields[0].Type; got != "PoolKey[]" {
+		t.Errorf("Expected the tuple array to reference PoolKey but got %s", got)
+	}
+}
\ No newline at end of file
@@ -2,34 +2,284 @@ package template
 
 import (
         "bytes"
+        "encoding/json"
         "fmt"
-        "io/ioutil"
+        "io/fs"
         "os"
-        "path/filepath"
+        "path"
+        "sort"
+        "strconv"
         "strings"
         "text/template"
 
         "github.com/Masterminds/sprig/v3"
         "github.com/openhands/mcp-generator/internal/ir"
+        "github.com/openhands/mcp-generator/internal/jsonschema"
+)
+
+// Supported JavaScript runtimes for the generated TypeScript server
+const (
+        // RuntimeNode targets Node.js with npm and a tsc build step
+        RuntimeNode = "node"
+
+        // RuntimeDeno targets Deno with an import map and no build step
+        RuntimeDeno = "deno"
+
+        // RuntimeBun targets Bun, which runs the TypeScript sources directly
+        RuntimeBun = "bun"
+)
+
+// Supported MCP transports for the generated server
+const (
+        // TransportStdio serves MCP over standard input and output
+        TransportStdio = "stdio"
+
+        // TransportHTTP serves MCP over Streamable HTTP behind bearer token or API key authentication
+        TransportHTTP = "http"
+)
+
+// Signers generated servers support besides private keys and mnemonics, selected at runtime with SIGNER_MODE
+const (
+        // SignerLedger signs with the Ethereum app of a Ledger connected over USB, each transaction confirmed on the
+        // device
+        SignerLedger = "ledger"
+
+        // SignerWalletConnect has a wallet app paired over WalletConnect v2 sign and send each transaction, keeping keys out
+        // of the server
+        SignerWalletConnect = "walletconnect"
+)
+
+// Supported output modes
+const (
+        // ModeServer generates the MCP server
+        ModeServer = "server"
+
+        // ModeTypes generates only the typed contract package the server is built on:
+        // the contract ABI, a contract factory and interfaces for parameters, return values, events and tuples
+        ModeTypes = "types"
+)
+
+// Supported CI providers for the generated project
+const (
+        // CINone disables CI workflow generation
+        CINone = "none"
+
+        // CIGitHub generates a GitHub Actions workflow
+        CIGitHub = "github"
+
+        // CIGitLab generates a GitLab CI pipeline
+        CIGitLab = "gitlab"
 )
 
 // TypeScriptTemplateRenderer renders TypeScript MCP server templates
 type TypeScriptTemplateRenderer struct {
-        // Template directory path
-        templateDir string
+        // Template file system (defaults to the embedded templates)
+        templates fs.FS
+
+        // Target JavaScript runtime
+        runtime string
+
+        // Output files mapped to templates when rendering a template pack
+        files map[string]string
+
+        // IR to target language type mapping
+        typeMapping TypeMapping
+
+        // Whether to emit a Dockerfile, .dockerignore and docker-compose.yml
+        docker bool
+
+        // CI provider to generate a workflow for
+        ci string
+
+        // Whether payable and nonpayable functions are exposed as transaction tools
+        enableWrites bool
+
+        // MCP transport the server is served over
+        transport string
+
+        // Whether per-event subscription tools push events as MCP notifications
+        subscriptions bool
+
+        // Whether view and pure function results are cached in memory
+        cache bool
+
+        // Whether write tools attach eth_createAccessList access lists to the transactions they build
+        accessLists bool
+
+        // Signers supported besides private keys and mnemonics
+        signers []string
+
+        // Output mode (server, types)
+        mode string
+
+        // Metadata of the generated package
+        pkg PackageInfo
+
+        // Template variables given with --set
+        vars map[string]string
+}
+
+// templateData is the data passed to every template
+type templateData struct {
+        *ir.ContractIR
+
+        // Target JavaScript runtime (node, deno, bun)
+        Runtime string
+
+        // Whether container files are generated
+        Docker bool
+
+        // CI provider a workflow is generated for (none, github, gitlab)
+        CI string
+
+        // Whether write functions are exposed as tools
+        EnableWrites bool
+
+        // MCP transport (stdio, http)
+        Transport string
+
+        // Whether event subscription tools are generated
+        Subscriptions bool
+        
+        // Whether any function parameter is a token amount
+        Amounts bool
+        
+        // Whether token amounts read their decimals from the contract's decimals() function
+        TokenDecimals bool
+        
+        // Whether any view function pages through a dynamic array output
+        Paginated bool
+        
+        // Whether view and pure function results are cached in memory
+        Cache bool
+
+        // Whether write tools attach access lists to the transactions they build
+        AccessLists bool
+
+        // Signers supported besides private keys and mnemonics (e.g., "ledger")
+        Signers []string
+
+        // Metadata of the generated package
+        Package PackageInfo
+        
+        // Function tools grouped by category, in the order tools are listed
+        Categories []ir.FunctionGroup
+        
+        // Content hash of the IR the project is generated from
+        IRHash string
+
+        // Template variables given with --set, by name; missing variables are empty
+        Vars map[string]string
+
+        // Environment variables the server reads with the generation options, in the order .env.example lists them
+        Env []EnvVar
 }
 
 // NewTypeScriptTemplateRenderer creates a new TypeScript template renderer
 func NewTypeScriptTemplateRenderer() *TypeScriptTemplateRenderer {
-        // Default to the embedded templates if not specified
+        // Default to the templates embedded in the binary
         return &TypeScriptTemplateRenderer{
-                templateDir: filepath.Join("internal", "template", "typescript"),
+                templates:   TypeScriptTemplates(),
+                runtime:     RuntimeNode,
+                typeMapping: DefaultTypeMapping(),
+                ci:          CINone,
+                transport:   TransportStdio,
+                mode:        ModeServer,
         }
 }
 
 // WithTemplateDir sets a custom template directory
+// Only the templates present in the directory override the current ones; the rest fall back per file
 func (r *TypeScriptTemplateRenderer) WithTemplateDir(dir string) *TypeScriptTemplateRenderer {
-        r.templateDir = dir
+        r.templates = NewOverlayFS(os.DirFS(dir), r.templates)
+        return r
+}
+
+// WithTemplateFS replaces the template file system the renderer loads from
+func (r *TypeScriptTemplateRenderer) WithTemplateFS(templates fs.FS) *TypeScriptTemplateRenderer {
+        r.templates = templates
+        return r
+}
+
+// WithTemplatePack renders the files declared by a template pack instead of the built-in set
+func (r *TypeScriptTemplateRenderer) WithTemplatePack(pack *TemplatePack) *TypeScriptTemplateRenderer {
+        r.templates = pack.Templates
+        r.files = pack.Manifest.Files
+        r.typeMapping = DefaultTypeMapping().merge(pack.Manifest.TypeMapping)
+        return r
+}
+
+// WithRuntime sets the JavaScript runtime the generated server targets
+func (r *TypeScriptTemplateRenderer) WithRuntime(runtime string) *TypeScriptTemplateRenderer {
+        r.runtime = runtime
+        return r
+}
+
+// WithDocker enables generation of container files for deploying the server
+func (r *TypeScriptTemplateRenderer) WithDocker(enabled bool) *TypeScriptTemplateRenderer {
+        r.docker = enabled
+        return r
+}
+
+// WithCI sets the CI provider to generate a workflow for
+func (r *TypeScriptTemplateRenderer) WithCI(provider string) *TypeScriptTemplateRenderer {
+        r.ci = provider
+        return r
+}
+
+// WithWrites exposes payable and nonpayable functions as tools that build, and optionally send, transactions
+func (r *TypeScriptTemplateRenderer) WithWrites(enabled bool) *TypeScriptTemplateRenderer {
+        r.enableWrites = enabled
+        return r
+}
+
+// WithTransport sets the MCP transport the generated server is served over
+func (r *TypeScriptTemplateRenderer) WithTransport(transport string) *TypeScriptTemplateRenderer {
+        r.transport = transport
+        return r
+}
+
+// WithSubscriptions generates subscribe and unsubscribe tools that push decoded events to the client
+func (r *TypeScriptTemplateRenderer) WithSubscriptions(enabled bool) *TypeScriptTemplateRenderer {
+        r.subscriptions = enabled
+        return r
+}
+
+// WithCache generates an in-memory cache of view and pure function results with per-tool TTLs
+func (r *TypeScriptTemplateRenderer) WithCache(enabled bool) *TypeScriptTemplateRenderer {
+        r.cache = enabled
+        return r
+}
+
+// WithAccessLists has write tools attach the access list eth_createAccessList returns to the transactions they build
+// when it saves gas, and simulation tools report it
+func (r *TypeScriptTemplateRenderer) WithAccessLists(enabled bool) *TypeScriptTemplateRenderer {
+        r.accessLists = enabled
+        return r
+}
+
+// WithSigners adds signers the server supports besides private keys and mnemonics, e.g. SignerLedger
+// or SignerWalletConnect
+func (r *TypeScriptTemplateRenderer) WithSigners(signers []string) *TypeScriptTemplateRenderer {
+        r.signers = signers
+        return r
+}
+
+// WithMode sets the output mode (server, types)
+func (r *TypeScriptTemplateRenderer) WithMode(mode string) *TypeScriptTemplateRenderer {
+        r.mode = mode
+        return r
+}
+
+// WithPackage sets the metadata of the generated package (scope, version, license, author, repository)
+func (r *TypeScriptTemplateRenderer) WithPackage(pkg PackageInfo) *TypeScriptTemplateRenderer {
+        r.pkg = pkg
+        return r
+}
+
+// WithVars sets the template variables available to templates as .Vars, e.g. {{ .Vars.org }}
+func (r *TypeScriptTemplateRenderer) WithVars(vars map[string]string) *TypeScriptTemplateRenderer {
+        r.vars = vars
         return r
 }
 
@@ -50,6 +300,151 @@ func getFuncMap() template.FuncMap {
                 return strings.ToUpper(s)
         }
         
+        funcMap["zodSchema"] = ZodSchema
+        funcMap["zodValueSchema"] = ZodValueSchema
+        
+        funcMap["toolInputSchema"] = func(f ir.Function) (string, error) {
+                schema, err := json.MarshalIndent(jsonschema.ForViewInputs(f), "", "  ")
+                return string(schema), err
+        }
+        
+        funcMap["txInputSchema"] = func(f ir.Function) (string, error) {
+                schema, err := json.MarshalIndent(jsonschema.ForTransactionInputs(f), "", "  ")
+                return string(schema), err
+        }
+        
+        funcMap["simulateInputSchema"] = func(f ir.Function) (string, error) {
+                schema, err := json.MarshalIndent(jsonschema.ForSimulationInputs(f), "", "  ")
+                return string(schema), err
+        }
+        
+        funcMap["transactionValue"] = jsonschema.TransactionValue
+        
+        funcMap["transactionSend"] = jsonschema.TransactionSend
+        
+        funcMap["simulationSender"] = jsonschema.SimulationSender
+        
+        funcMap["simulationOverrides"] = jsonschema.SimulationOverrides
+        
+        funcMap["eventFilterSchema"] = func(e ir.Event) (string, error) {
+                schema, err := json.MarshalIndent(jsonschema.ForEventFilter(e), "", "  ")
+                return string(schema), err
+        }
+        
+        funcMap["subscribeSchema"] = func(e ir.Event) (string, error) {
+                schema, err := json.MarshalIndent(jsonschema.ForEventSubscription(e), "", "  ")
+                return string(schema), err
+        }
+        
+        funcMap["filterable"] = jsonschema.IsFilterable
+        
+        funcMap["eventParamName"] = jsonschema.EventParameterName
+        
+        funcMap["eventZodSchema"] = func(p ir.EventParameter) string {
+                return ZodSchema(ir.Parameter{Name: p.Name, Type: p.Type})
+        }
+        
+        funcMap["paginated"] = jsonschema.IsPaginated
+        
+        funcMap["immutable"] = isImmutable
+        
+        funcMap["hasAmounts"] = hasAmounts
+        
+        funcMap["readsDecimals"] = readsDecimals
+        
+        funcMap["decimalsExpr"] = decimalsExpr
+        
+        funcMap["accessNote"] = AccessNote
+        
+        funcMap["apiBaseURL"] = apiBaseURL
+        
+        funcMap["apiOperation"] = apiOperation
+        
+        funcMap["chainIDs"] = func(m ir.ContractMetadata) []uint64 {
+                return m.ChainIDs()
+        }
+        
+        funcMap["deploymentOn"] = func(m ir.ContractMetadata, chainID uint64) ir.Deployment {
+                deployment, _ := m.DeploymentOn(chainID)
+                return deployment
+        }
+        
+        funcMap["accessCheck"] = accessCheck
+        
+        funcMap["signature"] = FunctionSignature
+        
+        funcMap["eventSignature"] = EventSignature
+        
+        funcMap["errorSignature"] = ErrorSignature
+        
+        funcMap["abiType"] = ir.ParameterType.ABIType
+        
+        funcMap["jsString"] = jsString
+        
+        funcMap["sampleInput"] = func(t ir.ParameterType) string {
+                return sampleJSON(SampleInput(t))
+        }
+        
+        funcMap["sampleOutput"] = func(t ir.ParameterType) string {
+                return sampleJSON(SampleOutput(t))
+        }
+        
+        funcMap["parameterSample"] = func(p ir.Parameter) string {
+                return sampleJSON(ParameterSample(p))
+        }
+        
+        funcMap["parameterEncoded"] = func(p ir.Parameter) string {
+                return sampleJSON(parameterEncoded(p))
+        }
+        
+        funcMap["viewParameters"] = func(f ir.Function) []ToolParameter {
+                return ToolParameters(jsonschema.ForViewInputs(f), f.Inputs)
+        }
+        
+        funcMap["txParameters"] = func(f ir.Function) []ToolParameter {
+                return ToolParameters(jsonschema.ForTransactionInputs(f), f.Inputs)
+        }
+        
+        funcMap["logParameters"] = func(e ir.Event) []ToolParameter {
+                return ToolParameters(jsonschema.ForEventFilter(e), eventParameters(e))
+        }
+        
+        funcMap["sampleCall"] = SampleFunctionCall
+        
+        funcMap["sampleLogsCall"] = func(e ir.Event) string {
+                return SampleToolCall("get"+e.Name+"Logs", nil)
+        }
+        
+        funcMap["sampleResponse"] = SampleToolResponse
+        
+        funcMap["sampleTxResponse"] = SampleTransactionResponse
+        
+        funcMap["sampleLogsResponse"] = SampleLogsResponse
+        
+        funcMap["markdownCell"] = markdownCell
+        
+        funcMap["fixtureArgs"] = func(f ir.Function) string {
+                return fixtureArguments(f.Inputs)
+        }
+        
+        funcMap["txFixtureArgs"] = func(f ir.Function) string {
+                // Write tools only build the transaction, so fixtures run without a signer
+                return fixtureArguments(f.Inputs, sampleField{jsonschema.TransactionSend(f), false})
+        }
+        
+        funcMap["toolAnnotations"] = ToolAnnotations
+        
+        funcMap["simulateAnnotations"] = SimulationAnnotations
+        
+        funcMap["logsAnnotations"] = LogsAnnotations
+        
+        // Client configurations launch the server the way it was generated
+        funcMap["clientConfig"] = func(client string, data *templateData) (string, error) {
+                return ClientConfig(client, data.ContractIR, ClientOptions{Runtime: data.Runtime, Transport: data.Transport, EnableWrites: data.EnableWrites})
+        }
+        
+        funcMap["envLine"] = envLine
+        
         funcMap["title"] = func(s string) string {
                 if len(s) == 0 {
                         return s
@@ -60,618 +455,330 @@ func getFuncMap() template.FuncMap {
         return funcMap
 }
 
-// loadTemplate loads a template file from the template directory
-func (r *TypeScriptTemplateRenderer) loadTemplate(name string) (string, error) {
-        templatePath := filepath.Join(r.templateDir, name)
-        
-        // Check if the file exists
-        if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-                // Fall back to embedded templates if file doesn't exist
-                switch name {
-                case "package.json.tmpl":
-                        return packageJSONTemplate, nil
-                case "tsconfig.json.tmpl":
-                        return tsconfigJSONTemplate, nil
-                case "server.ts.tmpl":
-                        return serverTSTemplate, nil
-                case "README.md.tmpl":
-                        return readmeTemplate, nil
-                case "inspector-e2e/e2e-tests.spec.ts.tmpl":
-                        return e2eTestsTemplate, nil
-                case "playwright.config.ts.tmpl":
-                        return playwrightConfigTemplate, nil
-                default:
-                        return "", fmt.Errorf("template %s not found", name)
-                }
-        }
-        
-        // Read the template file
-        content, err := ioutil.ReadFile(templatePath)
-        if err != nil {
-                return "", fmt.Errorf("failed to read template %s: %w", name, err)
-        }
-        
-        return string(content), nil
+// immutableGetters are the signatures of view functions whose values are fixed at deployment
+var immutableGetters = map[string]bool{
+        "name()":     true,
+        "symbol()":   true,
+        "decimals()": true,
 }
 
-// Render generates a TypeScript MCP server from the IR
-func (r *TypeScriptTemplateRenderer) Render(contract *ir.ContractIR) (map[string][]byte, error) {
-        files := make(map[string][]byte)
-
-        // Generate package.json
-        packageJSON, err := r.renderPackageJSON(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render package.json: %w", err)
+// isImmutable reports whether a function's results can be cached indefinitely:
+// pure functions, and getters of values fixed at deployment such as name() and symbol()
+func isImmutable(f ir.Function) bool {
+        switch f.StateMutability {
+        case ir.Pure:
+                return true
+        case ir.View:
+                return immutableGetters[FunctionSignature(f)]
         }
-        files["package.json"] = packageJSON
+        return false
+}
 
-        // Generate tsconfig.json
-        tsconfigJSON, err := r.renderTSConfigJSON(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render tsconfig.json: %w", err)
+// isTool reports whether a function is exposed as a tool: view and pure functions always, and write functions if
+// writes are enabled
+func isTool(f ir.Function, enableWrites bool) bool {
+        if f.IsConstructor || f.IsFallback || f.IsReceive {
+                return false
         }
-        files["tsconfig.json"] = tsconfigJSON
+        return isReadOnly(f) || enableWrites
+}
 
-        // Generate main server file
-        serverTS, err := r.renderServerTS(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render server.ts: %w", err)
+// toolGroups groups the functions exposed as tools by category
+func toolGroups(functions []ir.Function, enableWrites bool) []ir.FunctionGroup {
+        var tools []ir.Function
+        for _, f := range functions {
+                if isTool(f, enableWrites) {
+                        tools = append(tools, f)
+                }
         }
-        files["src/server.ts"] = serverTS
+        return ir.GroupByCategory(tools)
+}
 
-        // Generate README.md
-        readme, err := r.renderReadme(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render README.md: %w", err)
+// hasAmounts reports whether any of the parameters is a token amount
+func hasAmounts(parameters []ir.Parameter) bool {
+        for _, parameter := range parameters {
+                if _, _, ok := parameter.Type.AmountDecimals(); ok {
+                        return true
+                }
         }
-        files["README.md"] = readme
+        return false
+}
 
-        // Generate e2e tests
-        e2eTests, err := r.renderE2ETests(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render e2e tests: %w", err)
+// readsDecimals reports whether any of the parameters is a token amount with decimals read from the contract
+func readsDecimals(parameters []ir.Parameter) bool {
+        for _, parameter := range parameters {
+                if _, fromContract, ok := parameter.Type.AmountDecimals(); ok && fromContract {
+                        return true
+                }
         }
-        files["inspector-e2e/e2e-tests.spec.ts"] = e2eTests
+        return false
+}
 
-        // Generate playwright config
-        playwrightConfig, err := r.renderPlaywrightConfig(contract)
-        if err != nil {
-                return nil, fmt.Errorf("failed to render playwright config: %w", err)
-        }
-        files["playwright.config.ts"] = playwrightConfig
+// decimalsExpr returns the TypeScript expression of a token amount's decimals: the number of decimals,
+// or contractExpr if they are read from the contract; it returns "" if t is not a token amount
+func decimalsExpr(t ir.ParameterType, contractExpr string) string {
+        decimals, fromContract, ok := t.AmountDecimals()
+        switch {
+        case !ok:
+                return ""
+        case fromContract:
+                return contractExpr
+        }
+        return strconv.Itoa(decimals)
+}
 
-        return files, nil
+// loadTemplate loads a template file from the template file system
+func (r *TypeScriptTemplateRenderer) loadTemplate(name string) (string, error) {
+        return readTemplate(r.templates, name)
 }
 
-// renderPackageJSON generates the package.json file
-func (r *TypeScriptTemplateRenderer) renderPackageJSON(contract *ir.ContractIR) ([]byte, error) {
-        // Load the template
-        templateContent, err := r.loadTemplate("package.json.tmpl")
-        if err != nil {
-                return nil, err
+// outputFiles returns the output paths and their templates for the target runtime and the contract's chain
+func (r *TypeScriptTemplateRenderer) outputFiles(chain string) (map[string]string, error) {
+        // Template packs declare their own file set
+        if r.files != nil {
+                return r.files, nil
         }
-        
-        // Parse the template
-        tmpl, err := template.New("package.json").Funcs(getFuncMap()).Parse(templateContent)
-        if err != nil {
-                return nil, err
+
+        // Off-chain APIs are wrapped by a server of their own
+        if ir.IsAPIChain(chain) {
+                return r.apiFiles()
         }
 
-        var buf bytes.Buffer
-        err = tmpl.Execute(&buf, contract)
-        if err != nil {
-                return nil, err
+        switch r.mode {
+        case ModeServer:
+        case ModeTypes:
+                // The types package is published to npm and built with tsc
+                if r.runtime == RuntimeDeno {
+                        return nil, fmt.Errorf("the %s output mode does not support the %s runtime", ModeTypes, RuntimeDeno)
+                }
+                return map[string]string{
+                        "src/index.ts":  "contract.ts.tmpl",
+                        "package.json":  "types/package.json.tmpl",
+                        "tsconfig.json": "types/tsconfig.json.tmpl",
+                        "README.md":     "types/README.md.tmpl",
+                }, nil
+        default:
+                return nil, fmt.Errorf("unsupported output mode: %s", r.mode)
+        }
+
+        files := map[string]string{
+                "src/server.ts":                      "server.ts.tmpl",
+                "src/tools.ts":                       "tools.ts.tmpl",
+                "src/contract.ts":                    "contract.ts.tmpl",
+                "src/resources.ts":                   "resources.ts.tmpl",
+                "src/config.ts":                      "config.ts.tmpl",
+                "src/provider.ts":                    "provider.ts.tmpl",
+                "src/env.ts":                         "env.ts.tmpl",
+                "config.example.json":                "config.example.json.tmpl",
+                ".env.example":                       "env.example.tmpl",
+                ".gitignore":                         "gitignore.tmpl",
+                "tests/config.test.ts":               "tests/config.test.ts.tmpl",
+                "tests/provider.test.ts":             "tests/provider.test.ts.tmpl",
+                "tests/env.test.ts":                  "tests/env.test.ts.tmpl",
+                "tests/tools.test.ts":                "tests/tools.test.ts.tmpl",
+                "tests/resources.test.ts":            "tests/resources.test.ts.tmpl",
+                "README.md":                          "README.md.tmpl",
+                "inspector-e2e/e2e-tests.spec.ts":    "inspector-e2e/e2e-tests.spec.ts.tmpl",
+                "inspector-e2e/tools.spec.ts":        "inspector-e2e/tools.spec.ts.tmpl",
+                "inspector-e2e/fixtures.ts":          "inspector-e2e/fixtures.ts.tmpl",
+                "playwright.config.ts":               "playwright.config.ts.tmpl",
+                ".vscode/mcp.json":                   "clients/vscode.json.tmpl",
+                ".cursor/mcp.json":                   "clients/cursor.json.tmpl",
+                "clients/claude_desktop_config.json": "clients/claude_desktop_config.json.tmpl",
+                "clients/mcp.json":                   "clients/mcp.json.tmpl",
+        }
+
+        switch r.runtime {
+        case RuntimeNode:
+                files["package.json"] = "package.json.tmpl"
+                files["tsconfig.json"] = "tsconfig.json.tmpl"
+                files[".npmignore"] = "npmignore.tmpl"
+        case RuntimeBun:
+                files["package.json"] = "package.json.tmpl"
+                files["tsconfig.json"] = "tsconfig.json.tmpl"
+                files["bunfig.toml"] = "bunfig.toml.tmpl"
+                files[".npmignore"] = "npmignore.tmpl"
+        case RuntimeDeno:
+                // Deno resolves npm dependencies through the import map in deno.json
+                files["deno.json"] = "deno.json.tmpl"
+        default:
+                return nil, fmt.Errorf("unsupported runtime: %s", r.runtime)
         }
 
-        return buf.Bytes(), nil
-}
+        switch r.transport {
+        case TransportStdio:
+        case TransportHTTP:
+                files["src/http.ts"] = "http.ts.tmpl"
+                files["src/auth.ts"] = "auth.ts.tmpl"
+                files["tests/auth.test.ts"] = "tests/auth.test.ts.tmpl"
+        default:
+                return nil, fmt.Errorf("unsupported transport: %s", r.transport)
+        }
 
-// renderTSConfigJSON generates the tsconfig.json file
-func (r *TypeScriptTemplateRenderer) renderTSConfigJSON(contract *ir.ContractIR) ([]byte, error) {
-        // Load the template
-        templateContent, err := r.loadTemplate("tsconfig.json.tmpl")
-        if err != nil {
-                return nil, err
+        if r.subscriptions {
+                // Stateless HTTP requests have no open stream to push notifications over
+                if r.transport != TransportStdio {
+                        return nil, fmt.Errorf("event subscriptions require the %s transport", TransportStdio)
+                }
+                files["src/subscriptions.ts"] = "subscriptions.ts.tmpl"
+                files["tests/subscriptions.test.ts"] = "tests/subscriptions.test.ts.tmpl"
         }
-        
-        // Parse the template
-        tmpl, err := template.New("tsconfig.json").Funcs(getFuncMap()).Parse(templateContent)
-        if err != nil {
-                return nil, err
+
+        if r.accessLists && !r.enableWrites {
+                return nil, fmt.Errorf("access lists require write tools")
         }
 
-        var buf bytes.Buffer
-        err = tmpl.Execute(&buf, contract)
-        if err != nil {
-                return nil, err
+        for _, signer := range r.signers {
+                if !r.enableWrites {
+                        return nil, fmt.Errorf("the %s signer requires write tools", signer)
+                }
+                switch signer {
+                case SignerLedger:
+                        // node-hid, which reaches the device over USB, is a native Node.js addon
+                        if r.runtime != RuntimeNode {
+                                return nil, fmt.Errorf("the %s signer requires the %s runtime", signer, RuntimeNode)
+                        }
+                        files["src/ledger.ts"] = "ledger.ts.tmpl"
+                        files["tests/ledger.test.ts"] = "tests/ledger.test.ts.tmpl"
+                case SignerWalletConnect:
+                        // The WalletConnect client keeps its sessions in files, which Deno only writes with extra permissions
+                        if r.runtime == RuntimeDeno {
+                                return nil, fmt.Errorf("the %s signer requires the %s or %s runtime", signer, RuntimeNode, RuntimeBun)
+                        }
+                        files["src/walletconnect.ts"] = "walletconnect.ts.tmpl"
+                        files["tests/walletconnect.test.ts"] = "tests/walletconnect.test.ts.tmpl"
+                default:
+                        return nil, fmt.Errorf("unsupported signer: %s (expected %s or %s)", signer, SignerLedger, SignerWalletConnect)
+                }
         }
 
-        return buf.Bytes(), nil
-}
+        if r.enableWrites {
+                files["src/signer.ts"] = "signer.ts.tmpl"
+                files["src/fees.ts"] = "fees.ts.tmpl"
+                files["src/transactions.ts"] = "transactions.ts.tmpl"
+                files["tests/signer.test.ts"] = "tests/signer.test.ts.tmpl"
+                files["tests/fees.test.ts"] = "tests/fees.test.ts.tmpl"
+                files["tests/transactions.test.ts"] = "tests/transactions.test.ts.tmpl"
+        }
 
-// renderServerTS generates the main server.ts file
-func (r *TypeScriptTemplateRenderer) renderServerTS(contract *ir.ContractIR) ([]byte, error) {
-        // Load the template
-        templateContent, err := r.loadTemplate("server.ts.tmpl")
-        if err != nil {
-                return nil, err
+        if r.cache {
+                files["src/cache.ts"] = "cache.ts.tmpl"
+                files["tests/cache.test.ts"] = "tests/cache.test.ts.tmpl"
         }
-        
-        // Parse the template
-        tmpl, err := template.New("server.ts").Funcs(getFuncMap()).Parse(templateContent)
-        if err != nil {
-                return nil, err
+
+        if r.docker {
+                files["Dockerfile"] = "docker/Dockerfile.tmpl"
+                files[".dockerignore"] = "docker/dockerignore.tmpl"
+                files["docker-compose.yml"] = "docker/docker-compose.yml.tmpl"
         }
 
-        var buf bytes.Buffer
-        err = tmpl.Execute(&buf, contract)
-        if err != nil {
-                return nil, err
+        switch r.ci {
+        case CINone:
+        case CIGitHub:
+                files[".github/workflows/ci.yml"] = "ci/github.yml.tmpl"
+        case CIGitLab:
+                files[".gitlab-ci.yml"] = "ci/gitlab-ci.yml.tmpl"
+        default:
+                return nil, fmt.Errorf("unsupported CI provider: %s", r.ci)
         }
 
-        return buf.Bytes(), nil
+        return files, nil
 }
 
-// renderReadme generates the README.md file
-func (r *TypeScriptTemplateRenderer) renderReadme(contract *ir.ContractIR) ([]byte, error) {
-        // Load the template
-        templateContent, err := r.loadTemplate("README.md.tmpl")
+// Render generates a TypeScript MCP server from the IR
+// Hidden functions and events are left out of the generated code
+func (r *TypeScriptTemplateRenderer) Render(contract *ir.ContractIR) (map[string][]byte, error) {
+        // The hash covers the whole IR, hidden members included, so any change to it is detected
+        hash, err := ir.ContentHash(contract)
         if err != nil {
                 return nil, err
         }
-        
-        // Create a template with sprig functions
-        tmpl := template.New("README.md").Funcs(getFuncMap())
-
-        // Parse the template
-        tmpl, err = tmpl.Parse(templateContent)
+        contract = contract.Visible()
+        outputs, err := r.outputFiles(contract.Metadata.Chain)
         if err != nil {
                 return nil, err
         }
-
-        // Execute the template
-        var buf bytes.Buffer
-        if err := tmpl.Execute(&buf, contract); err != nil {
+        pkg, err := r.pkg.normalize()
+        if err != nil {
                 return nil, err
         }
 
-        return buf.Bytes(), nil
-}
+        data := &templateData{
+                ContractIR:    contract,
+                Runtime:       r.runtime,
+                Docker:        r.docker,
+                CI:            r.ci,
+                EnableWrites:  r.enableWrites,
+                Transport:     r.transport,
+                Subscriptions: r.subscriptions,
+                Cache:         r.cache,
+                AccessLists:   r.accessLists,
+                Signers:       r.signers,
+                Package:       pkg,
+                IRHash:        hash,
+                Vars:          map[string]string{},
+        }
+        for name, value := range r.vars {
+                data.Vars[name] = value
+        }
+        data.Env = serverEnv(data)
+        // Tools are listed by category, so functions are reordered category by category
+        data.Categories = toolGroups(contract.Functions, r.enableWrites)
+        functions := make([]ir.Function, 0, len(contract.Functions))
+        for _, group := range ir.GroupByCategory(contract.Functions) {
+                functions = append(functions, group.Functions...)
+        }
+        contract.Functions = functions
 
-// renderE2ETests generates the e2e tests file
-func (r *TypeScriptTemplateRenderer) renderE2ETests(contract *ir.ContractIR) ([]byte, error) {
-        // Load the template
-        templateContent, err := r.loadTemplate("inspector-e2e/e2e-tests.spec.ts.tmpl")
-        if err != nil {
-                return nil, err
+        for _, function := range contract.Functions {
+                data.Amounts = data.Amounts || hasAmounts(function.Inputs) || hasAmounts(function.Outputs)
+                data.TokenDecimals = data.TokenDecimals || readsDecimals(function.Inputs) || readsDecimals(function.Outputs)
+                data.Paginated = data.Paginated || jsonschema.IsPaginated(function)
         }
-        
-        // Create a template with sprig functions
-        tmpl := template.New("e2e-tests.spec.ts").Funcs(getFuncMap())
 
-        // Parse the template
-        tmpl, err = tmpl.Parse(templateContent)
-        if err != nil {
-                return nil, err
+        // Render in path order so the first failing template is the same on every run
+        paths := make([]string, 0, len(outputs))
+        for path := range outputs {
+                paths = append(paths, path)
         }
+        sort.Strings(paths)
 
-        // Execute the template
-        var buf bytes.Buffer
-        if err := tmpl.Execute(&buf, contract); err != nil {
-                return nil, err
+        files := make(map[string][]byte)
+        for _, path := range paths {
+                content, err := r.renderTemplate(outputs[path], data)
+                if err != nil {
+                        return nil, fmt.Errorf("failed to render %s: %w", path, err)
+                }
+                files[path] = content
         }
 
-        return buf.Bytes(), nil
+        return files, nil
 }
 
-// renderPlaywrightConfig generates the playwright.config.ts file
-func (r *TypeScriptTemplateRenderer) renderPlaywrightConfig(contract *ir.ContractIR) ([]byte, error) {
+// renderTemplate loads, parses and executes a single template
+func (r *TypeScriptTemplateRenderer) renderTemplate(name string, data *templateData) ([]byte, error) {
         // Load the template
-        templateContent, err := r.loadTemplate("playwright.config.ts.tmpl")
+        templateContent, err := r.loadTemplate(name)
         if err != nil {
                 return nil, err
         }
-        
-        // Create a template with sprig functions
-        tmpl := template.New("playwright.config.ts").Funcs(getFuncMap())
+
+        // Add renderer-specific functions
+        funcMap := getFuncMap()
+        funcMap["mapType"] = r.typeMapping.TypeOf
+        funcMap["typeDeclarations"] = r.typeMapping.Declarations
 
         // Parse the template
-        tmpl, err = tmpl.Parse(templateContent)
+        tmpl, err := template.New(path.Base(strings.TrimSuffix(name, ".tmpl"))).Funcs(funcMap).Parse(templateContent)
         if err != nil {
                 return nil, err
         }
 
         // Execute the template
         var buf bytes.Buffer
-        if err := tmpl.Execute(&buf, contract); err != nil {
+        if err := tmpl.Execute(&buf, data); err != nil {
                 return nil, err
         }
 
         return buf.Bytes(), nil
-}
-
-// Fallback templates in case the files don't exist
-// These are kept for backward compatibility
-
-// e2eTestsTemplate is the template for e2e-tests.spec.ts
-const e2eTestsTemplate = `import { test, expect } from '@playwright/test';
-
-test.describe('MCP Server Tests', () => {
-  test.beforeEach(async ({ page }) => {
-    // Navigate to the MCP Inspector
-    await page.goto('/');
-    
-    // Click the Connect button
-    await page.getByRole('button', { name: 'Connect' }).click();
-    await expect(page.getByText('Connected')).toBeVisible();
-    
-    // Click the List Tools button
-    await page.getByRole('button', { name: 'List Tools' }).click();
-  });
-
-  test('should list available tools', async ({ page }) => {
-    // Check if tools are listed
-    await expect(page.getByText('Tool List')).toBeVisible();
-  });
-});`
-
-// playwrightConfigTemplate is the template for playwright.config.ts
-const playwrightConfigTemplate = `import { defineConfig, devices } from '@playwright/test';
-
-export default defineConfig({
-  testDir: './inspector-e2e',
-  fullyParallel: false,
-  forbidOnly: !!process.env.CI,
-  retries: process.env.CI ? 3 : 2,
-  workers: 1,
-  reporter: 'html',
-  use: {
-    baseURL: "http://localhost:6274",
-    trace: 'on-first-retry',
-    navigationTimeout: 60000,
-    actionTimeout: 30000,
-  },
-  projects: [
-    {
-      name: 'chromium',
-      use: { ...devices['Desktop Chrome'] },
-    },
-  ],
-  webServer: {
-    command: "npx @modelcontextprotocol/inspector node ./dist/server.js",
-    url: "http://localhost:6274",
-    reuseExistingServer: !process.env.CI,
-    timeout: 180000,
-  },
-});`
-
-// packageJSONTemplate is the template for package.json
-const packageJSONTemplate = `{
-  "name": "{{ .Metadata.Name | lower | replace " " "-" }}-mcp-server",
-  "version": "1.0.0",
-  "description": "MCP server for {{ .Metadata.Name }} smart contract",
-  "main": "dist/server.js",
-  "type": "module",
-  "scripts": {
-    "build": "tsc",
-    "start": "node dist/server.js",
-    "dev": "tsc -w"
-  },
-  "dependencies": {
-    "@modelcontextprotocol/sdk": "^0.1.0",
-    "ethers": "^6.7.1",
-    "zod": "^3.22.2",
-    "zod-to-json-schema": "^3.21.4"
-  },
-  "devDependencies": {
-    "@types/node": "^20.5.9",
-    "typescript": "^5.2.2"
-  }
-}
-`
-
-// tsconfigJSONTemplate is the template for tsconfig.json
-const tsconfigJSONTemplate = `{
-  "compilerOptions": {
-    "target": "ES2020",
-    "module": "NodeNext",
-    "moduleResolution": "NodeNext",
-    "esModuleInterop": true,
-    "strict": true,
-    "outDir": "dist",
-    "sourceMap": true,
-    "declaration": true,
-    "skipLibCheck": true
-  },
-  "include": ["src/**/*"]
-}
-`
-
-// serverTSTemplate is the template for server.ts
-const serverTSTemplate = `import { Server } from "@modelcontextprotocol/sdk/server/index.js";
-import { 
-  CallToolRequestSchema, 
-  ListToolsRequestSchema, 
-  Tool, 
-  ToolInput, 
-  TextContent 
-} from "@modelcontextprotocol/sdk/types.js";
-import { StdioServerTransport } from "@modelcontextprotocol/sdk/server/transports/stdio.js";
-import { ethers } from "ethers";
-import { z } from "zod";
-import { zodToJsonSchema } from "zod-to-json-schema";
-
-// Define tool names enum
-enum ToolName {
-{{- range $funcIndex, $func := .Functions -}}
-{{- if not $func.IsConstructor -}}
-{{- if not $func.IsFallback -}}
-{{- if not $func.IsReceive -}}
-{{- if or (eq (printf "%s" $func.StateMutability) "view") (eq (printf "%s" $func.StateMutability) "pure") }}
-  {{$func.Name | upper}} = "{{$func.Name}}",
-{{- end -}}
-{{- end -}}
-{{- end -}}
-{{- end -}}
-{{- end }}
-}
-
-// Define input schemas for each function
-{{- range $funcIndex, $func := .Functions -}}
-{{- if not $func.IsConstructor -}}
-{{- if not $func.IsFallback -}}
-{{- if not $func.IsReceive }}
-const {{$func.Name | title}}Schema = z.object({
-{{- range $func.Inputs}}
-  {{.Name}}: z.string().describe("{{.Description}}"),
-{{- end}}
-});
-{{end -}}
-{{- end -}}
-{{- end -}}
-{{- end }}
-
-// Initialize the contract
-async function initializeContract() {
-  // Connect to provider (replace with your preferred provider)
-  const provider = new ethers.JsonRpcProvider(process.env.RPC_URL || "https://eth.llamarpc.com");
-  
-  // Contract address (can be overridden with environment variable)
-  const contractAddress = process.env.CONTRACT_ADDRESS || "{{.Metadata.Address}}";
-  
-  // Contract ABI
-  const contractABI = [
-    {{- range $funcIndex, $func := .Functions }}
-    {
-      "name": "{{$func.Name}}",
-      "type": "function",
-      "inputs": [
-        {{- range $index, $param := $func.Inputs -}}
-        {{if $index}},{{end}}
-        {
-          "name": "{{$param.Name}}",
-          "type": "{{$param.Type.BaseType}}"
-          {{- if $param.Type.IsArray -}}
-          ,
-          "components": []
-          {{- end -}}
-        }
-        {{- end -}}
-      ],
-      "outputs": [
-        {{- range $index, $param := $func.Outputs -}}
-        {{if $index}},{{end}}
-        {
-          "name": "{{$param.Name}}",
-          "type": "{{$param.Type.BaseType}}"
-          {{- if $param.Type.IsArray -}}
-          ,
-          "components": []
-          {{- end -}}
-        }
-        {{- end -}}
-      ],
-      "stateMutability": "{{$func.StateMutability}}"
-    }{{if not (eq $funcIndex (sub (len $.Functions) 1))}},{{end}}
-    {{- end }}
-  ];
-  
-  // Create contract instance
-  return new ethers.Contract(contractAddress, contractABI, provider);
-}
-
-async function main() {
-  // Initialize the contract
-  const contract = await initializeContract();
-  
-  // Create MCP server
-  const server = new Server(
-    {
-      name: "{{.Metadata.Name}}-mcp-server",
-      version: "1.0.0",
-    },
-    {
-      capabilities: {
-        tools: {},
-      },
-    }
-  );
-  
-  // Register tools
-  server.setRequestHandler(ListToolsRequestSchema, async () => {
-    const tools = [
-      {{- range $funcIndex, $func := .Functions -}}
-      {{- if not $func.IsConstructor -}}
-      {{- if not $func.IsFallback -}}
-      {{- if not $func.IsReceive -}}
-      {{- if or (eq (printf "%s" $func.StateMutability) "view") (eq (printf "%s" $func.StateMutability) "pure") }}
-      {
-        name: ToolName.{{$func.Name | upper}},
-        description: "{{$func.Description}}",
-        inputSchema: zodToJsonSchema({{$func.Name | title}}Schema),
-      },
-      {{- end -}}
-      {{- end -}}
-      {{- end -}}
-      {{- end -}}
-      {{- end }}
-    ];
-    
-    return { tools };
-  });
-  
-  // Handle tool calls
-  server.setRequestHandler(CallToolRequestSchema, async (request) => {
-    const { name, arguments: args } = request.params;
-    
-    try {
-      switch (name) {
-        {{- range $funcIndex, $func := .Functions -}}
-        {{- if not $func.IsConstructor -}}
-        {{- if not $func.IsFallback -}}
-        {{- if not $func.IsReceive -}}
-        {{- if or (eq (printf "%s" $func.StateMutability) "view") (eq (printf "%s" $func.StateMutability) "pure") }}
-        case ToolName.{{$func.Name | upper}}:
-          const {{$func.Name}}Args = {{$func.Name | title}}Schema.parse(args);
-          const {{$func.Name}}Result = await contract.{{$func.Name}}(
-            {{- range $index, $param := $func.Inputs -}}
-            {{if $index}}, {{end}}{{$func.Name}}Args.{{$param.Name}}
-            {{- end -}}
-          );
-          return {
-            content: [
-              {
-                type: "text",
-                text: JSON.stringify({{$func.Name}}Result, (key, value) => {
-                  // Handle BigInt conversion
-                  if (typeof value === 'bigint') {
-                    return value.toString();
-                  }
-                  return value;
-                }, 2),
-              },
-            ],
-          };
-        {{- end -}}
-        {{- end -}}
-        {{- end -}}
-        {{- end -}}
-        {{- end }}
-        
-        default:
-          throw new Error("Unknown tool: " + name);
-      }
-    } catch (error) {
-      console.error("Error calling tool:", error);
-      throw new Error("Error calling " + name + ": " + error.message);
-    }
-  });
-  
-  // Connect to transport
-  const transport = new StdioServerTransport();
-  await server.connect(transport);
-}
-
-main().catch((error) => {
-  console.error("Fatal error:", error);
-  process.exit(1);
-});
-`
-
-// readmeTemplate is the template for README.md
-const readmeTemplate = `# {{.Metadata.Name}} MCP Server
-
-This is an MCP (Model Context Protocol) server for the {{.Metadata.Name}} smart contract.
-
-## Overview
-
-This server provides LLM access to the {{.Metadata.Name}} smart contract through the Model Context Protocol. It exposes the following contract functions as tools:
-
-{{range $funcIndex, $func := .Functions}}
-{{if not $func.IsConstructor}}
-{{if not $func.IsFallback}}
-{{if not $func.IsReceive}}
-{{if or (eq $func.StateMutability "view") (eq $func.StateMutability "pure")}}
-- **{{$func.Name}}**: {{$func.Description}}
-{{end}}
-{{end}}
-{{end}}
-{{end}}
-{{end}}
-
-## Installation
-
-1. Clone this repository
-2. Install dependencies:
-   ` + "```bash" + `
-   npm install
-   ` + "```" + `
-3. Build the server:
-   ` + "```bash" + `
-   npm run build
-   ` + "```" + `
-
-## Configuration
-
-Set the following environment variables:
-
-- RPC_URL: Ethereum RPC URL (default: https://eth.llamarpc.com)
-- CONTRACT_ADDRESS: Contract address (default: {{.Metadata.Address}})
-
-## Usage
-
-Start the server:
-
-` + "```bash" + `
-npm start
-` + "```" + `
-
-The server uses stdio for communication with MCP clients.
-
-## Testing
-
-This server comes with end-to-end tests that verify its functionality using the MCP Inspector.
-
-1. Install Playwright dependencies:
-   ` + "```bash" + `
-   npm run test:install
-   ` + "```" + `
-
-2. Run the tests:
-   ` + "```bash" + `
-   npm test
-   ` + "```" + `
-
-3. View the test report:
-   ` + "```bash" + `
-   npm run test:report
-   ` + "```" + `
-
-4. Run tests with UI:
-   ` + "```bash" + `
-   npm run test:ui
-   ` + "```" + `
-
-## Contract Information
-
-- **Name**: {{.Metadata.Name}}
-- **Chain**: {{.Metadata.Chain}}
-- **Address**: {{.Metadata.Address}}
-
-## Available Functions
-{{range $funcIndex, $func := .Functions}}
-{{if not $func.IsConstructor}}
-{{if not $func.IsFallback}}
-{{if not $func.IsReceive}}
-{{if or (eq (printf "%s" $func.StateMutability) "view") (eq (printf "%s" $func.StateMutability) "pure")}}
-- **{{$func.Name}}**{{if $func.Inputs}} - Parameters: {{range $paramIndex, $param := $func.Inputs}}{{if $paramIndex}}, {{end}}{{$param.Name}} ({{$param.Type}}){{end}}{{end}}{{if $func.Outputs}} - Returns: {{range $outputIndex, $output := $func.Outputs}}{{if $outputIndex}}, {{end}}{{if $output.Name}}{{$output.Name}}{{else}}output{{$outputIndex}}{{end}} ({{$output.Type}}){{end}}{{end}}
-{{end}}
-{{end}}
-{{end}}
-{{end}}
-{{end}}
-
-## License
-
-MIT
-`
\ No newline at end of file
+}
\ No newline at end of file
@@ -1,10 +1,14 @@
 package template
 
 import (
+        "bytes"
+        "encoding/json"
         "os"
         "path/filepath"
+        "sort"
         "strings"
         "testing"
+        "testing/fstest"
 
         "github.com/openhands/mcp-generator/internal/ir"
 )
@@ -87,7 +91,23 @@ func TestTypeScriptTemplateRenderer(t *testing.T) {
                 "package.json",
                 "tsconfig.json",
                 "src/server.ts",
+                "src/tools.ts",
+                "src/contract.ts",
+                "src/resources.ts",
+                "tests/tools.test.ts",
+                "tests/resources.test.ts",
+                "src/config.ts",
+                "config.example.json",
+                "tests/config.test.ts",
+                "src/provider.ts",
+                "tests/provider.test.ts",
                 "README.md",
+                "inspector-e2e/fixtures.ts",
+                "inspector-e2e/tools.spec.ts",
+                ".vscode/mcp.json",
+                ".cursor/mcp.json",
+                "clients/claude_desktop_config.json",
+                "clients/mcp.json",
         }
 
         for _, file := range expectedFiles {
@@ -101,14 +121,46 @@ func TestTypeScriptTemplateRenderer(t *testing.T) {
                 t.Errorf("package.json does not contain the expected contract name")
         }
 
-        // Check that the server.ts contains the balanceOf function but not the transfer function
+        // Check that the tools contain the balanceOf function but not the transfer function
         // (since transfer is nonpayable and not exposed as a tool)
+        toolsTS := string(files["src/tools.ts"])
+        if !contains(toolsTS, "balanceOf") {
+                t.Errorf("tools.ts does not contain the balanceOf function")
+        }
+        if contains(toolsTS, "ToolName.TRANSFER") {
+                t.Errorf("tools.ts contains the transfer function as a tool, but it should not (nonpayable)")
+        }
+        if !contains(toolsTS, `contract.getFunction("balanceOf(address)")`) {
+                t.Errorf("tools.ts does not call balanceOf by its signature")
+        }
+
+        // Check that every function is documented as a resource
+        resourcesTS := string(files["src/resources.ts"])
+        if !contains(resourcesTS, `"transfer": [`) || !contains(resourcesTS, `"- **Signature**: `+"`balanceOf(address)`"+`"`) {
+                t.Errorf("resources.ts does not document every function")
+        }
+
+        // Check that the e2e fixtures call every tool with valid arguments
+        if !contains(string(files["inspector-e2e/fixtures.ts"]), `tool: "balanceOf", arguments: {"account":"0x0000000000000000000000000000000000000001"}, readOnly: true`) {
+                t.Errorf("fixtures.ts does not contain the balanceOf fixture")
+        }
+
+        // Check that networks are loaded from the config file and selectable at runtime
+        if !contains(string(files["config.example.json"]), `"contractAddress": "0x1234567890123456789012345678901234567890"`) {
+                t.Errorf("config.example.json does not contain the contract address")
+        }
         serverTS := string(files["src/server.ts"])
-        if !contains(serverTS, "balanceOf") {
-                t.Errorf("server.ts does not contain the balanceOf function")
+        if !contains(serverTS, "const config = loadConfig();") || !contains(serverTS, "name === SELECT_NETWORK_TOOL") {
+                t.Errorf("server.ts does not load named networks")
+        }
+
+        // Check that a unit test suite is generated for every tool
+        testsTS := string(files["tests/tools.test.ts"])
+        if !contains(testsTS, `describe("balanceOf"`) {
+                t.Errorf("tools.test.ts does not test the balanceOf tool")
         }
-        if contains(serverTS, "ToolName.TRANSFER") {
-                t.Errorf("server.ts contains the transfer function as a tool, but it should not (nonpayable)")
+        if contains(testsTS, `describe("transfer"`) {
+                t.Errorf("tools.test.ts tests the transfer function, but it is not exposed as a tool")
         }
 }
 
@@ -150,6 +202,1349 @@ func TestTypeScriptTemplateRendererWithCustomDir(t *testing.T) {
         }
 }
 
+// TestTypeScriptTemplateRendererWithTemplateFS tests the renderer with a replacement template file system
+func TestTypeScriptTemplateRendererWithTemplateFS(t *testing.T) {
+        templates := fstest.MapFS{
+                "package.json.tmpl":                       {Data: []byte(`{"name": "{{.Metadata.Name}}"}`)},
+                "tsconfig.json.tmpl":                      {Data: []byte(`{}`)},
+                "server.ts.tmpl":                          {Data: []byte(`// {{.Runtime}} server`)},
+                "tools.ts.tmpl":                           {Data: []byte(``)},
+                "contract.ts.tmpl":                        {Data: []byte(``)},
+                "resources.ts.tmpl":                       {Data: []byte(``)},
+                "config.ts.tmpl":                          {Data: []byte(``)},
+                "provider.ts.tmpl":                        {Data: []byte(``)},
+                "config.example.json.tmpl":                {Data: []byte(`{}`)},
+                "tests/config.test.ts.tmpl":               {Data: []byte(``)},
+                "tests/provider.test.ts.tmpl":             {Data: []byte(``)},
+                "tests/env.test.ts.tmpl":                  {Data: []byte(``)},
+                "env.ts.tmpl":                             {Data: []byte(``)},
+                "env.example.tmpl":                        {Data: []byte(``)},
+                "gitignore.tmpl":                          {Data: []byte(``)},
+                "tests/resources.test.ts.tmpl":            {Data: []byte(``)},
+                "tests/tools.test.ts.tmpl":                {Data: []byte(``)},
+                "README.md.tmpl":                          {Data: []byte(`# {{.Metadata.Name}}`)},
+                "inspector-e2e/e2e-tests.spec.ts.tmpl":    {Data: []byte(``)},
+                "inspector-e2e/tools.spec.ts.tmpl":        {Data: []byte(``)},
+                "inspector-e2e/fixtures.ts.tmpl":          {Data: []byte(``)},
+                "playwright.config.ts.tmpl":               {Data: []byte(``)},
+                "clients/vscode.json.tmpl":                {Data: []byte(`{}`)},
+                "clients/cursor.json.tmpl":                {Data: []byte(`{}`)},
+                "clients/claude_desktop_config.json.tmpl": {Data: []byte(`{}`)},
+                "clients/mcp.json.tmpl":                   {Data: []byte(`{}`)},
+                "npmignore.tmpl":                          {Data: []byte(``)},
+        }
+
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithTemplateFS(templates).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        if string(files["src/server.ts"]) != "// node server" {
+                t.Errorf("server.ts was not rendered from the template file system: %q", files["src/server.ts"])
+        }
+
+        // Templates missing from the file system are reported
+        delete(templates, "README.md.tmpl")
+        if _, err := NewTypeScriptTemplateRenderer().WithTemplateFS(templates).Render(contract); err == nil {
+                t.Errorf("Expected an error for a missing template")
+        }
+}
+
+// TestTypeScriptTemplateRendererRuntimes tests the files emitted for each target runtime
+func TestTypeScriptTemplateRendererRuntimes(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:  "TestToken",
+                        Chain: "ethereum",
+                },
+        }
+
+        tests := []struct {
+                runtime       string
+                expectedFiles []string
+                absentFiles   []string
+        }{
+                {RuntimeNode, []string{"package.json", "tsconfig.json", "src/server.ts"}, []string{"deno.json", "bunfig.toml"}},
+                {RuntimeBun, []string{"package.json", "tsconfig.json", "bunfig.toml", "src/server.ts"}, []string{"deno.json"}},
+                {RuntimeDeno, []string{"deno.json", "src/server.ts"}, []string{"package.json", "tsconfig.json"}},
+        }
+
+        for _, tt := range tests {
+                t.Run(tt.runtime, func(t *testing.T) {
+                        files, err := NewTypeScriptTemplateRenderer().WithRuntime(tt.runtime).Render(contract)
+                        if err != nil {
+                                t.Fatalf("Failed to render templates: %v", err)
+                        }
+                        for _, file := range tt.expectedFiles {
+                                if _, ok := files[file]; !ok {
+                                        t.Errorf("Expected file %s not found in rendered output", file)
+                                }
+                        }
+                        for _, file := range tt.absentFiles {
+                                if _, ok := files[file]; ok {
+                                        t.Errorf("Unexpected file %s in rendered output", file)
+                                }
+                        }
+                })
+        }
+
+        // Deno resolves npm packages through the import map
+        files, _ := NewTypeScriptTemplateRenderer().WithRuntime(RuntimeDeno).Render(contract)
+        if !contains(string(files["deno.json"]), `"zod": "npm:zod@`) {
+                t.Errorf("deno.json does not map zod to an npm: specifier")
+        }
+
+        // Unknown runtimes are rejected
+        if _, err := NewTypeScriptTemplateRenderer().WithRuntime("rhino").Render(contract); err == nil {
+                t.Errorf("Expected an error for an unsupported runtime")
+        }
+}
+
+// TestTypeScriptTemplateRendererDocker tests the container files emitted for each runtime
+func TestTypeScriptTemplateRendererDocker(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:    "Test Token",
+                        Address: "0x1234567890123456789012345678901234567890",
+                },
+        }
+
+        // Container files are opt-in
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if _, ok := files["Dockerfile"]; ok {
+                t.Errorf("Dockerfile generated without WithDocker")
+        }
+
+        tests := []struct {
+                runtime    string
+                entrypoint string
+        }{
+                {RuntimeNode, `ENTRYPOINT ["node", "dist/server.js"]`},
+                {RuntimeBun, `ENTRYPOINT ["bun", "run", "src/server.ts"]`},
+                {RuntimeDeno, `"deno", "run", "--cached-only"`},
+        }
+
+        for _, tt := range tests {
+                t.Run(tt.runtime, func(t *testing.T) {
+                        files, err := NewTypeScriptTemplateRenderer().WithRuntime(tt.runtime).WithDocker(true).Render(contract)
+                        if err != nil {
+                                t.Fatalf("Failed to render templates: %v", err)
+                        }
+                        for _, file := range []string{"Dockerfile", ".dockerignore", "docker-compose.yml"} {
+                                if _, ok := files[file]; !ok {
+                                        t.Errorf("Expected file %s not found in rendered output", file)
+                                }
+                        }
+                        if !contains(string(files["Dockerfile"]), tt.entrypoint) {
+                                t.Errorf("Dockerfile does not contain %s", tt.entrypoint)
+                        }
+                        if !contains(string(files["docker-compose.yml"]), "RPC_URL: ${RPC_URL:-") {
+                                t.Errorf("docker-compose.yml does not wire RPC_URL")
+                        }
+                        if !contains(string(files["README.md"]), "docker run -i --rm") {
+                                t.Errorf("README.md does not document running the container")
+                        }
+                })
+        }
+}
+
+// TestTypeScriptTemplateRendererCI tests the CI workflow emitted for each provider
+func TestTypeScriptTemplateRendererCI(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+        }
+
+        tests := []struct {
+                provider string
+                runtime  string
+                file     string
+                expected string
+        }{
+                {CIGitHub, RuntimeNode, ".github/workflows/ci.yml", "NODE_AUTH_TOKEN: ${{ secrets.NPM_TOKEN }}"},
+                {CIGitHub, RuntimeDeno, ".github/workflows/ci.yml", "run: deno task test:unit"},
+                {CIGitLab, RuntimeBun, ".gitlab-ci.yml", "bun run typecheck"},
+        }
+
+        for _, tt := range tests {
+                t.Run(tt.provider+"/"+tt.runtime, func(t *testing.T) {
+                        files, err := NewTypeScriptTemplateRenderer().WithRuntime(tt.runtime).WithCI(tt.provider).Render(contract)
+                        if err != nil {
+                                t.Fatalf("Failed to render templates: %v", err)
+                        }
+                        if !contains(string(files[tt.file]), tt.expected) {
+                                t.Errorf("%s does not contain %q", tt.file, tt.expected)
+                        }
+                        if !contains(string(files["README.md"]), "## Continuous Integration") {
+                                t.Errorf("README.md does not document the CI workflow")
+                        }
+                })
+        }
+
+        // No workflow is generated by default
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        for _, file := range []string{".github/workflows/ci.yml", ".gitlab-ci.yml"} {
+                if _, ok := files[file]; ok {
+                        t.Errorf("Unexpected file %s in rendered output", file)
+                }
+        }
+
+        // Unknown providers are rejected
+        if _, err := NewTypeScriptTemplateRenderer().WithCI("jenkins").Render(contract); err == nil {
+                t.Errorf("Expected an error for an unsupported CI provider")
+        }
+}
+
+// TestTypeScriptTemplateRendererWrites tests exposing write functions as transaction tools
+func TestTypeScriptTemplateRendererWrites(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:  "TestToken",
+                        Chain: "starknet",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "transfer",
+                                StateMutability: ir.Nonpayable,
+                                Inputs: []ir.Parameter{
+                                        {Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+                                        {Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                        {
+                                Name:            "deposit",
+                                StateMutability: ir.Payable,
+                        },
+                        {
+                                Name:            "transferFrom",
+                                StateMutability: ir.Nonpayable,
+                                Inputs: []ir.Parameter{
+                                        {Name: "from", Type: ir.ParameterType{BaseType: "address"}},
+                                        {Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+                                        {Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if contains(string(files["src/tools.ts"]), "ToolName.TRANSFER") {
+                t.Errorf("Write functions exposed as tools without WithWrites")
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                "case ToolName.TRANSFER:",
+                `buildTransaction(contract, "transfer(address,uint256)"`,
+                "case ToolName.DEPOSIT:",
+                "value: depositArgs.value,",
+                "result.fees = await previewFees(",
+                "case ToolName.SIMULATETRANSFER:",
+                `simulateTransaction(contract, "transfer(address,uint256)"`,
+                "const SimulateDepositSchema = DepositSchema.omit({ send: true })",
+                // transferFrom takes a from argument, so the account to simulate it from is the caller
+                `caller: addressSchema.optional().describe("Account to simulate the call from`,
+                "from: simulateTransferFromArgs.caller,",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if !contains(string(files["src/server.ts"]), "createSigner(signerConfig") {
+                t.Errorf("server.ts does not configure a signer")
+        }
+        if !contains(string(files["src/server.ts"]), "new TransactionManager(signer)") || !contains(toolsTS, "options.transactions.send(tx)") {
+                t.Errorf("Write tools do not send transactions through the transaction manager")
+        }
+        for _, file := range []string{"src/signer.ts", "src/fees.ts", "src/transactions.ts", "tests/signer.test.ts", "tests/fees.test.ts", "tests/transactions.test.ts"} {
+                if _, ok := files[file]; !ok {
+                        t.Errorf("Expected file %s not found in rendered output", file)
+                }
+        }
+        if contains(toolsTS, "eth_createAccessList") {
+                t.Errorf("Access lists created without WithAccessLists")
+        }
+        for file, expected := range map[string]string{
+                "src/signer.ts": "ethers.Wallet.fromEncryptedJsonSync(json, config.keystorePassword!)",
+                ".env.example":  "# KEYSTORE_PASSWORD=",
+                ".gitignore":    "keystore*.json",
+                "README.md":     "### Key Management",
+        } {
+                if !contains(string(files[file]), expected) {
+                        t.Errorf("%s does not contain %q", file, expected)
+                }
+        }
+        if contains(string(files["src/signer.ts"]), "${config.keystorePath}") {
+                t.Errorf("Keystore errors echo KEYSTORE_PATH")
+        }
+}
+
+// TestTypeScriptTemplateRendererControlInputClashes tests that the inputs write and simulation tools add are renamed
+// when a function has inputs of the same names
+func TestTypeScriptTemplateRendererControlInputClashes(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{Name: "Relay"},
+                Functions: []ir.Function{
+                        {
+                                Name:            "relay",
+                                StateMutability: ir.Nonpayable,
+                                Inputs: []ir.Parameter{
+                                        {Name: "target", Type: ir.ParameterType{BaseType: "address"}},
+                                        {Name: "send", Type: ir.ParameterType{BaseType: "bool"}},
+                                },
+                        },
+                        {
+                                Name:            "deposit",
+                                StateMutability: ir.Payable,
+                                Inputs: []ir.Parameter{
+                                        {Name: "value", Type: ir.ParameterType{BaseType: "uint256"}},
+                                        {Name: "stateOverrides", Type: ir.ParameterType{BaseType: "bytes"}},
+                                },
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                "  send: z.boolean(),\n  sendTransaction: z.boolean().optional()",
+                "RelaySchema.omit({ sendTransaction: true })",
+                "send: relayArgs.sendTransaction ?? false,",
+                "  value: uintSchema(256),\n  stateOverrides: bytesSchema(),\n  msgValue: uintSchema(256).optional()",
+                "value: depositArgs.msgValue,",
+                "  simulationOverrides: stateOverrideSchema.optional(),",
+                "stateOverrides: simulateDepositArgs.simulationOverrides,",
+                "set sendTransaction to sign and broadcast it.",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        for name, key := range map[string]string{"RelaySchema": "send", "DepositSchema": "value"} {
+                schema := toolsTS[strings.Index(toolsTS, "const "+name+" = z.object({"):]
+                schema = schema[:strings.Index(schema, "});")]
+                if strings.Count(schema, "\n  "+key+": ") != 1 {
+                        t.Errorf("%s has more than one %s key:\n%s", name, key, schema)
+                }
+        }
+}
+
+// TestTypeScriptTemplateRendererAccessLists tests that write tools attach access lists only when enabled with writes
+func TestTypeScriptTemplateRendererAccessLists(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{Name: "TestToken"},
+                Functions: []ir.Function{
+                        {
+                                Name:            "transfer",
+                                StateMutability: ir.Nonpayable,
+                                Inputs: []ir.Parameter{
+                                        {Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+                                        {Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                },
+        }
+
+        if _, err := NewTypeScriptTemplateRenderer().WithAccessLists(true).Render(contract); err == nil {
+                t.Errorf("Expected an error for access lists without write tools")
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).WithAccessLists(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                `provider.send("eth_createAccessList"`,
+                "tx.accessList = accessList;",
+                "result.accessList = await createAccessList(provider, tx);",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if !contains(string(files["tests/tools.test.ts"]), "attaches the access list when it saves gas") {
+                t.Errorf("tools.test.ts does not test access lists")
+        }
+}
+
+// TestTypeScriptTemplateRendererSigners tests that the Ledger signer is generated only with write tools on Node.js, and
+// the WalletConnect signer with write tools on Node.js or Bun
+func TestTypeScriptTemplateRendererSigners(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{Name: "TestToken"},
+                Functions: []ir.Function{
+                        {Name: "pause", StateMutability: ir.Nonpayable},
+                },
+        }
+
+        for name, renderer := range map[string]*TypeScriptTemplateRenderer{
+                "unsupported":           NewTypeScriptTemplateRenderer().WithWrites(true).WithSigners([]string{"trezor"}),
+                "read-only":             NewTypeScriptTemplateRenderer().WithSigners([]string{SignerLedger}),
+                "deno":                  NewTypeScriptTemplateRenderer().WithWrites(true).WithRuntime(RuntimeDeno).WithSigners([]string{SignerLedger}),
+                "bun":                   NewTypeScriptTemplateRenderer().WithWrites(true).WithRuntime(RuntimeBun).WithSigners([]string{SignerLedger}),
+                "walletconnect on deno": NewTypeScriptTemplateRenderer().WithWrites(true).WithRuntime(RuntimeDeno).WithSigners([]string{SignerWalletConnect}),
+        } {
+                if _, err := renderer.Render(contract); err == nil {
+                        t.Errorf("Expected an error for the %s signer", name)
+                }
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).WithSigners([]string{SignerLedger}).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        for _, file := range []string{"src/ledger.ts", "tests/ledger.test.ts"} {
+                if _, ok := files[file]; !ok {
+                        t.Errorf("Missing %s", file)
+                }
+        }
+        for file, expected := range map[string][]string{
+                "src/signer.ts":       {`| "ledger"`, "new LedgerSigner(provider, config.derivationPath)"},
+                "src/transactions.ts": {"sendWithConfirmation(", `GETSIGNINGREQUEST = "getSigningRequest"`},
+                "src/tools.ts":        {"options.transactions.sendWithConfirmation(tx, prompt)"},
+                "package.json":        {`"@ledgerhq/hw-app-eth"`, `"@ledgerhq/hw-transport-node-hid"`},
+                ".env.example":        {"CONFIRMATION_WAIT_MS"},
+        } {
+                for _, text := range expected {
+                        if !contains(string(files[file]), text) {
+                                t.Errorf("%s does not contain %q", file, text)
+                        }
+                }
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if _, ok := files["src/ledger.ts"]; ok || contains(string(files["src/transactions.ts"]), "getSigningRequest") {
+                t.Errorf("Ledger signer generated without WithSigners")
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithWrites(true).WithRuntime(RuntimeBun).WithSigners([]string{SignerWalletConnect}).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        for _, file := range []string{"src/walletconnect.ts", "tests/walletconnect.test.ts"} {
+                if _, ok := files[file]; !ok {
+                        t.Errorf("Missing %s", file)
+                }
+        }
+        if _, ok := files["src/ledger.ts"]; ok {
+                t.Errorf("Ledger signer generated with only the WalletConnect signer")
+        }
+        for file, expected := range map[string][]string{
+                "src/signer.ts":       {"new WalletConnectSigner(provider, new WalletConnection(config.walletConnectProjectId!))"},
+                "src/transactions.ts": {"pairingUri?: string;", "signerAddress("},
+                "src/tools.ts":        {"await signerAddress(options.signer)"},
+                "package.json":        {`"@walletconnect/sign-client"`},
+                ".env.example":        {"WALLETCONNECT_PROJECT_ID"},
+        } {
+                for _, text := range expected {
+                        if !contains(string(files[file]), text) {
+                                t.Errorf("%s does not contain %q", file, text)
+                        }
+                }
+        }
+}
+
+// TestTypeScriptTemplateRendererEvents tests that event log tools are generated for non-anonymous events
+func TestTypeScriptTemplateRendererEvents(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Events: []ir.Event{
+                        {
+                                Name: "Transfer",
+                                Parameters: []ir.EventParameter{
+                                        {Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+                                        {Name: "to", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+                                        {Name: "value", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                        {
+                                Name:       "Anonymous",
+                                Parameters: []ir.EventParameter{{Name: "id", Type: ir.ParameterType{BaseType: "uint256"}, Indexed: true}},
+                                ChainData:  map[string]interface{}{"anonymous": true},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        if !contains(toolsTS, `GETTRANSFERLOGS = "getTransferLogs"`) || !contains(toolsTS, `queryLogs(contract, "Transfer(address,address,uint256)", toTransferFilter(query), query)`) {
+                t.Errorf("tools.ts does not query Transfer logs")
+        }
+        if !contains(string(files["src/contract.ts"]), `"type": "event"`) {
+                t.Errorf("contractABI does not include the events")
+        }
+        if contains(toolsTS, "getAnonymousLogs") {
+                t.Errorf("tools.ts queries logs of an anonymous event")
+        }
+        if !contains(string(files["tests/tools.test.ts"]), `describe("getTransferLogs"`) {
+                t.Errorf("tools.test.ts does not test the getTransferLogs tool")
+        }
+        if !contains(string(files["README.md"]), "filterable by `from`, `to`") {
+                t.Errorf("README.md does not document the event log tools")
+        }
+}
+
+// TestTypeScriptTemplateRendererErrors tests decoding reverts with the contract's custom errors
+func TestTypeScriptTemplateRendererErrors(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Errors: []ir.ContractError{
+                        {
+                                Name: "InsufficientBalance",
+                                Parameters: []ir.Parameter{
+                                        {Name: "available", Type: ir.ParameterType{BaseType: "uint256"}},
+                                        {Name: "required", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        if !contains(string(files["src/contract.ts"]), `"name": "InsufficientBalance",
+    "type": "error",`) {
+                t.Errorf("contractABI does not include the custom errors")
+        }
+        if !contains(toolsTS, "export function decodeRevert(") {
+                t.Errorf("tools.ts does not decode reverts")
+        }
+        if !contains(string(files["tests/tools.test.ts"]), `encodeErrorResult("InsufficientBalance(uint256,uint256)"`) {
+                t.Errorf("tools.test.ts does not test decoding InsufficientBalance")
+        }
+        if !contains(string(files["README.md"]), "(`InsufficientBalance(uint256,uint256)`)") {
+                t.Errorf("README.md does not document the custom errors")
+        }
+}
+
+// TestTypeScriptTemplateRendererAmounts tests converting token amounts with the contract's decimals
+func TestTypeScriptTemplateRendererAmounts(t *testing.T) {
+        amount := ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: ir.DecimalsFromContract}}
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "decimals",
+                                StateMutability: ir.View,
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint8"}}},
+                        },
+                        {
+                                Name:            "balanceOf",
+                                StateMutability: ir.View,
+                                Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address"}}},
+                                Outputs:         []ir.Parameter{{Type: amount}},
+                        },
+                        {
+                                Name:            "burn",
+                                StateMutability: ir.Nonpayable,
+                                Inputs:          []ir.Parameter{{Name: "amount", Type: ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: 18}}}},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                "const amountSchema = z",
+                "amount: amountSchema,",
+                "async function tokenDecimals(contract: ethers.Contract)",
+                "const balanceOfOutput = formatAmount(balanceOfResult, await tokenDecimals(contract));",
+                "ethers.parseUnits(burnArgs.amount, 18),",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if !contains(string(files["tests/tools.test.ts"]), "const TOKEN_DECIMALS = 6;") {
+                t.Errorf("tools.test.ts does not mock the token's decimals")
+        }
+        if !contains(string(files["README.md"]), "### Token Amounts") {
+                t.Errorf("README.md does not document token amounts")
+        }
+}
+
+// TestTypeScriptTemplateRendererAccess tests documenting access restrictions and checking them before sending
+func TestTypeScriptTemplateRendererAccess(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "mint",
+                                StateMutability: ir.Nonpayable,
+                                Inputs:          []ir.Parameter{{Name: "to", Type: ir.ParameterType{BaseType: "address"}}},
+                                Access:          &ir.AccessRestriction{Roles: []string{"MINTER_ROLE"}},
+                        },
+                        {
+                                Name:            "join",
+                                StateMutability: ir.Nonpayable,
+                                Access:          &ir.AccessRestriction{Allowlist: "whitelist"},
+                        },
+                        {
+                                Name:            "transfer",
+                                StateMutability: ir.Nonpayable,
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                `"mint. Restricted to holders of MINTER_ROLE. Builds the transaction`,
+                `"join. Restricted to accounts on the whitelist. Builds the transaction`,
+                `"transfer. Builds the transaction`,
+                `access: {"roles":["MINTER_ROLE"]},`,
+                "result.accessWarning = warning;",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if strings.Count(toolsTS, "access: {") != 1 {
+                t.Errorf("Expected only mint to be checked before sending")
+        }
+        if !contains(string(files["README.md"]), "**Access:** restricted to holders of MINTER_ROLE") {
+                t.Errorf("README.md does not document access restrictions")
+        }
+}
+
+// TestTypeScriptTemplateRendererHiddenAndDeprecated tests leaving out hidden members and marking deprecated ones
+func TestTypeScriptTemplateRendererHiddenAndDeprecated(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {Name: "totalSupply", StateMutability: ir.View, Deprecated: true, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}},
+                        {Name: "debugState", StateMutability: ir.View, Hidden: true, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}},
+                },
+                Events: []ir.Event{
+                        {Name: "Transfer", Deprecated: true},
+                        {Name: "Debug", Hidden: true},
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{`description: "Deprecated. totalSupply"`, `"Deprecated. Query and decode Transfer event logs.`} {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        for _, hidden := range []string{"debugState", "Debug"} {
+                if contains(toolsTS, hidden) || contains(string(files["README.md"]), hidden) {
+                        t.Errorf("Hidden %s was generated", hidden)
+                }
+        }
+        if !contains(string(files["README.md"]), "**Deprecated.**") {
+                t.Errorf("README.md does not mark deprecated tools")
+        }
+        if len(contract.Functions) != 2 {
+                t.Errorf("Rendering changed the contract")
+        }
+}
+
+// TestTypeScriptTemplateRendererCategories tests listing tools category by category
+func TestTypeScriptTemplateRendererCategories(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {Name: "pause", StateMutability: ir.Nonpayable, Category: ir.CategoryAdmin},
+                        {Name: "balanceOf", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}},
+                        {Name: "transfer", StateMutability: ir.Nonpayable, Category: ir.CategoryTransfers},
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                `"read": [ToolName.BALANCEOF],`,
+                `"transfers": [ToolName.TRANSFER],`,
+                `"admin": [ToolName.PAUSE],`,
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if strings.Index(toolsTS, "name: ToolName.TRANSFER,") > strings.Index(toolsTS, "name: ToolName.PAUSE,") {
+                t.Errorf("Expected tools to be listed by category")
+        }
+        readme := string(files["README.md"])
+        if !contains(readme, "#### Read") || strings.Index(readme, "#### Transfers") > strings.Index(readme, "#### Admin") {
+                t.Errorf("README.md does not group tools by category")
+        }
+        if contract.Functions[0].Name != "pause" {
+                t.Errorf("Rendering reordered the contract's functions")
+        }
+}
+
+// TestTypeScriptTemplateRendererIRHash tests stamping the content hash of the IR into the generated code
+func TestTypeScriptTemplateRendererIRHash(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata:  ir.ContractMetadata{Name: "TestToken"},
+                Functions: []ir.Function{{Name: "totalSupply", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}}},
+        }
+        hash, err := ir.ContentHash(contract)
+        if err != nil {
+                t.Fatalf("Failed to hash the IR: %v", err)
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if !contains(string(files["src/contract.ts"]), `export const CONTRACT_IR_HASH = "`+hash+`";`) {
+                t.Errorf("contract.ts does not contain the IR hash %s", hash)
+        }
+        if !contains(string(files["README.md"]), "**IR hash**: `"+hash+"`") {
+                t.Errorf("README.md does not contain the IR hash")
+        }
+}
+
+// TestTypeScriptTemplateRendererDeployments tests generating the address book of a contract deployed on several networks
+func TestTypeScriptTemplateRendererDeployments(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:  "TestToken",
+                        Chain: "ethereum",
+                        Deployments: map[string]ir.Deployment{
+                                "10": {Address: "0x0000000000000000000000000000000000000002", Block: 42},
+                                "1":  {Address: "0x0000000000000000000000000000000000000001"},
+                        },
+                },
+                Functions: []ir.Function{{Name: "totalSupply", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}}},
+        }
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        contractTS := string(files["src/contract.ts"])
+        expected := `  1: { address: "0x0000000000000000000000000000000000000001" },
+  10: { address: "0x0000000000000000000000000000000000000002", block: 42 },
+};`
+        if !contains(contractTS, expected) {
+                t.Errorf("contract.ts does not list the deployments by chain ID:\n%s", contractTS)
+        }
+        if !contains(string(files["src/config.ts"]), "DEPLOYMENTS[network.chainId]?.address") {
+                t.Error("config.ts does not fall back to the deployment of a network's chain")
+        }
+        if !contains(string(files["README.md"]), "  - Chain 10: `0x0000000000000000000000000000000000000002` (deployed in block 42)") {
+                t.Error("README.md does not list the deployments")
+        }
+}
+
+// TestTypeScriptTemplateRendererNetwork tests defaulting the server configuration to a network preset
+func TestTypeScriptTemplateRendererNetwork(t *testing.T) {
+        base, err := ir.LookupNetwork("base")
+        if err != nil {
+                t.Fatal(err)
+        }
+        contract := &ir.ContractIR{
+                Metadata:  ir.ContractMetadata{Name: "TestToken", Chain: "ethereum", Address: "0x0000000000000000000000000000000000000001"},
+                Functions: []ir.Function{{Name: "totalSupply", StateMutability: ir.View, Outputs: []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}}}},
+        }
+        contract.Metadata.SetNetwork(base)
+        files, err := NewTypeScriptTemplateRenderer().WithDocker(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        configTS := string(files["src/config.ts"])
+        for _, expected := range []string{
+                "const chainId = env.CHAIN_ID ? Number(env.CHAIN_ID) : 8453;",
+                `rpcUrl: env.RPC_URL || "https://base-rpc.publicnode.com",`,
+                `explorerUrl: chainId === 8453 ? "https://basescan.org" : undefined,`,
+                "explorerApiUrl: z.string().url().optional(),",
+        } {
+                if !contains(configTS, expected) {
+                        t.Errorf("config.ts does not contain %q", expected)
+                }
+        }
+        var example struct {
+                DefaultNetwork string                            `json:"defaultNetwork"`
+                Networks       map[string]map[string]interface{} `json:"networks"`
+        }
+        if err := json.Unmarshal(files["config.example.json"], &example); err != nil {
+                t.Fatalf("config.example.json is not valid JSON: %v", err)
+        }
+        if example.DefaultNetwork != "base" || example.Networks["base"]["chainId"] != float64(8453) || example.Networks["base"]["explorerApiUrl"] != "https://api.basescan.org/api" {
+                t.Errorf("config.example.json does not describe the Base network: %+v", example)
+        }
+        if !contains(string(files["Dockerfile"]), "ENV RPC_URL=https://base-rpc.publicnode.com") {
+                t.Error("Dockerfile does not default RPC_URL to the network's RPC")
+        }
+        if !contains(string(files["README.md"]), "(default: 8453, base)") {
+                t.Error("README.md does not document the default chain ID")
+        }
+}
+
+// TestTypeScriptTemplateRendererPagination tests paging dynamic array outputs and capping response sizes
+func TestTypeScriptTemplateRendererPagination(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestRegistry",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "getHolders",
+                                StateMutability: ir.View,
+                                Outputs:         []ir.Parameter{{Name: "holders", Type: ir.ParameterType{BaseType: "address", IsArray: true}}},
+                        },
+                        {
+                                Name:            "owner",
+                                StateMutability: ir.View,
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "address"}}},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                "offset: pageOffsetSchema,",
+                "function pageOf<T>(items: ArrayLike<T>, offset = 0, limit = DEFAULT_PAGE_SIZE)",
+                "const getHoldersOutput = pageOf(getHoldersResult, getHoldersArgs.offset, getHoldersArgs.limit);",
+                "JSON.stringify(ownerResult,",
+                "export function limitResponse(result: CallToolResult, maxBytes: number = MAX_RESPONSE_BYTES)",
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if !contains(string(files["src/server.ts"]), "return limitResponse(await callTool(") {
+                t.Errorf("server.ts does not limit tool response sizes")
+        }
+        if !contains(string(files["tests/tools.test.ts"]), "pages through dynamic array outputs") {
+                t.Errorf("tools.test.ts does not test pagination")
+        }
+        if !contains(string(files["README.md"]), "### Paginated Results") {
+                t.Errorf("README.md does not document pagination")
+        }
+        readme := string(files["README.md"])
+        for _, expected := range []string{"## Tool Reference", "### `getHolders`", `"name": "getHolders",`, `"items": [`} {
+                if !contains(readme, expected) {
+                        t.Errorf("README.md tool reference does not contain %q", expected)
+                }
+        }
+}
+
+// TestTypeScriptTemplateRendererCache tests generating the view call cache
+func TestTypeScriptTemplateRendererCache(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "symbol",
+                                StateMutability: ir.View,
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "string"}}},
+                        },
+                        {
+                                Name:            "totalSupply",
+                                StateMutability: ir.View,
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}},
+                        },
+                        {
+                                Name:            "transfer",
+                                StateMutability: ir.Nonpayable,
+                                Inputs:          []ir.Parameter{{Name: "to", Type: ir.ParameterType{BaseType: "address"}}},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if _, ok := files["src/cache.ts"]; ok {
+                t.Errorf("src/cache.ts must only be generated with the cache enabled")
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithCache(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        cacheTS := string(files["src/cache.ts"])
+        if !contains(cacheTS, "CACHEABLE_TOOLS = new Set<string>([\n  ToolName.SYMBOL,\n  ToolName.TOTALSUPPLY,\n]);") {
+                t.Errorf("cache.ts does not cache exactly the view functions:\n%s", cacheTS)
+        }
+        if !contains(cacheTS, "IMMUTABLE_TOOLS = new Set<string>([\n  ToolName.SYMBOL,\n]);") {
+                t.Errorf("cache.ts does not cache symbol() indefinitely")
+        }
+        if !contains(string(files["src/server.ts"]), "await cache.call(") {
+                t.Errorf("server.ts does not call tools through the cache")
+        }
+        if !contains(string(files["src/config.ts"]), "cache: CacheConfigSchema.optional(),") {
+                t.Errorf("config.ts does not accept cache settings")
+        }
+        if _, ok := files["tests/cache.test.ts"]; !ok {
+                t.Errorf("tests/cache.test.ts was not generated")
+        }
+        if !contains(string(files["README.md"]), "### Caching") {
+                t.Errorf("README.md does not document caching")
+        }
+}
+
+// TestTypeScriptTemplateRendererSubscriptions tests generating event subscription tools
+func TestTypeScriptTemplateRendererSubscriptions(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Events: []ir.Event{
+                        {
+                                Name: "Transfer",
+                                Parameters: []ir.EventParameter{
+                                        {Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true},
+                                        {Name: "value", Type: ir.ParameterType{BaseType: "uint256"}},
+                                },
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithSubscriptions(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        for _, file := range []string{"src/subscriptions.ts", "tests/subscriptions.test.ts"} {
+                if _, ok := files[file]; !ok {
+                        t.Errorf("Expected file %s not found in rendered output", file)
+                }
+        }
+
+        subscriptionsTS := string(files["src/subscriptions.ts"])
+        if !contains(subscriptionsTS, `SUBSCRIBETRANSFER = "subscribeTransfer"`) || !contains(subscriptionsTS, `UNSUBSCRIBETRANSFER = "unsubscribeTransfer"`) {
+                t.Errorf("subscriptions.ts does not define the Transfer subscription tools")
+        }
+        serverTS := string(files["src/server.ts"])
+        if !contains(serverTS, "server.sendLoggingMessage(") || !contains(serverTS, "logging: {},") {
+                t.Errorf("server.ts does not push events as logging notifications")
+        }
+        if !contains(string(files["src/config.ts"]), "wsUrl: env.WS_RPC_URL") {
+                t.Errorf("config.ts does not read the WebSocket RPC URL")
+        }
+
+        // Subscriptions are opt-in
+        files, err = NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if _, ok := files["src/subscriptions.ts"]; ok || contains(string(files["src/server.ts"]), "subscriptions") {
+                t.Errorf("Unexpected subscriptions without WithSubscriptions")
+        }
+
+        // Stateless HTTP cannot push notifications
+        if _, err := NewTypeScriptTemplateRenderer().WithSubscriptions(true).WithTransport(TransportHTTP).Render(contract); err == nil {
+                t.Errorf("Expected an error for subscriptions over HTTP")
+        }
+}
+
+// TestTypeScriptTemplateRendererHTTP tests serving the generated server over authenticated HTTP
+func TestTypeScriptTemplateRendererHTTP(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithTransport(TransportHTTP).WithDocker(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        for _, file := range []string{"src/http.ts", "src/auth.ts", "tests/auth.test.ts"} {
+                if _, ok := files[file]; !ok {
+                        t.Errorf("Expected file %s not found in rendered output", file)
+                }
+        }
+
+        serverTS := string(files["src/server.ts"])
+        if !contains(serverTS, "await startHttpServer(createServer);") || contains(serverTS, "StdioServerTransport") {
+                t.Errorf("server.ts is not served over HTTP")
+        }
+        if !contains(string(files["src/http.ts"]), "authenticate(req.headers, auth)") {
+                t.Errorf("http.ts does not authenticate requests")
+        }
+        if !contains(string(files["Dockerfile"]), "EXPOSE 3000") {
+                t.Errorf("Dockerfile does not expose the HTTP port")
+        }
+
+        // stdio remains the default and needs no authentication
+        files, err = NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if _, ok := files["src/auth.ts"]; ok {
+                t.Errorf("Unexpected auth.ts for the stdio transport")
+        }
+
+        if _, err := NewTypeScriptTemplateRenderer().WithTransport("websocket").Render(contract); err == nil {
+                t.Errorf("Expected an error for an unsupported transport")
+        }
+}
+
+// TestTypeScriptTemplateRendererEnv tests that .env.example lists the variables of the generation options
+func TestTypeScriptTemplateRendererEnv(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:    "TestToken",
+                        Address: "0x0000000000000000000000000000000000000001",
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        example := string(files[".env.example"])
+        for _, line := range []string{"RPC_URL=https://eth.llamarpc.com", "CONTRACT_ADDRESS=0x0000000000000000000000000000000000000001", "# RPC_RETRIES=3"} {
+                if !contains(example, "\n"+line+"\n") {
+                        t.Errorf(".env.example does not contain %q:\n%s", line, example)
+                }
+        }
+        for _, name := range []string{"PRIVATE_KEY", "MCP_API_KEYS", "CACHE_TTL_MS"} {
+                if contains(example, name) {
+                        t.Errorf(".env.example lists %s, which the server does not read without its option", name)
+                }
+        }
+        if !contains(string(files["src/env.ts"]), `"MAX_RESPONSE_BYTES",`) || !contains(string(files["src/server.ts"]), `import { dotEnv } from "./env.js";`) {
+                t.Errorf("The server does not load .env")
+        }
+        if !contains(string(files[".gitignore"]), "\n.env\n") {
+                t.Errorf(".gitignore does not keep .env out of version control")
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithWrites(true).WithTransport(TransportHTTP).WithCache(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        example = string(files[".env.example"])
+        for _, line := range []string{"# Hex private key to sign with (secret)\n# PRIVATE_KEY=\n", "\nMCP_API_KEYS=\n", `# DERIVATION_PATH="m/44'/60'/0'/0/0"`, "# CACHE_TTL_MS=15000"} {
+                if !contains(example, line) {
+                        t.Errorf(".env.example does not contain %q:\n%s", line, example)
+                }
+        }
+}
+
+// TestTypeScriptTemplateRendererTypes tests generating the standalone contract types package
+func TestTypeScriptTemplateRendererTypes(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "balanceOf",
+                                StateMutability: ir.View,
+                                Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address"}}},
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}},
+                        },
+                },
+                Events: []ir.Event{
+                        {
+                                Name:       "Transfer",
+                                Parameters: []ir.EventParameter{{Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true}},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().WithMode(ModeTypes).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if len(files) != 4 {
+                t.Errorf("Expected only the 4 package files but got %d", len(files))
+        }
+
+        indexTS := string(files["src/index.ts"])
+        for _, expected := range []string{
+                "export interface BalanceOfParams {\n  account: string;\n}",
+                "export interface BalanceOfResult {\n  result0: string;\n}",
+                "export interface TransferEvent {\n  from: string; // indexed\n}",
+                "export const contractABI = [",
+                "export function createContract(",
+        } {
+                if !contains(indexTS, expected) {
+                        t.Errorf("index.ts does not contain %q", expected)
+                }
+        }
+        if !contains(string(files["package.json"]), `"name": "testtoken-contract-types"`) {
+                t.Errorf("package.json does not name the types package")
+        }
+
+        // The server is built on the same module
+        files, err = NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if string(files["src/contract.ts"]) != indexTS {
+                t.Errorf("src/contract.ts of the server differs from the types package")
+        }
+        if !contains(string(files["src/tools.ts"]), `export * from "./contract.js";`) {
+                t.Errorf("tools.ts does not re-export the contract module")
+        }
+
+        if _, err := NewTypeScriptTemplateRenderer().WithMode(ModeTypes).WithRuntime(RuntimeDeno).Render(contract); err == nil {
+                t.Errorf("Expected an error for the types mode on Deno")
+        }
+        if _, err := NewTypeScriptTemplateRenderer().WithMode("client").Render(contract); err == nil {
+                t.Errorf("Expected an error for an unsupported output mode")
+        }
+}
+
+func TestTypeScriptTemplateRendererPackage(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name: "TestToken",
+                },
+        }
+        pkg := PackageInfo{Scope: "@acme", Version: "v2.1.0", License: "MIT", Author: "Jane Doe <jane@example.com>", Repository: "github:acme/testtoken"}
+
+        files, err := NewTypeScriptTemplateRenderer().WithPackage(pkg).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+
+        var manifest struct {
+                Name       string                     `json:"name"`
+                Version    string                     `json:"version"`
+                License    string                     `json:"license"`
+                Author     string                     `json:"author"`
+                Repository string                     `json:"repository"`
+                Exports    map[string]json.RawMessage `json:"exports"`
+                Bin        map[string]string          `json:"bin"`
+        }
+        if err := json.Unmarshal(files["package.json"], &manifest); err != nil {
+                t.Fatalf("package.json is not valid JSON: %v", err)
+        }
+        if manifest.Name != "@acme/testtoken-mcp-server" || manifest.Version != "2.1.0" || manifest.License != "MIT" || manifest.Author != pkg.Author || manifest.Repository != pkg.Repository {
+                t.Errorf("Unexpected package metadata %+v", manifest)
+        }
+        if root := string(manifest.Exports["."]); !contains(root, `"import"`) || !contains(root, `"./dist/cjs/tools.cjs"`) {
+                t.Errorf("package.json does not export both ESM and CJS builds: %s", root)
+        }
+        if manifest.Bin["testtoken-mcp-server"] != "dist/server.js" || !strings.HasPrefix(string(files["src/server.ts"]), "#!/usr/bin/env node\n") {
+                t.Errorf("The server is not installed as an executable")
+        }
+        if npmignore := string(files[".npmignore"]); !contains(npmignore, "config.json") || !contains(npmignore, "tests") {
+                t.Errorf(".npmignore does not exclude local configuration and tests")
+        }
+
+        // Deno packages are published to JSR under the given scope
+        files, err = NewTypeScriptTemplateRenderer().WithRuntime(RuntimeDeno).WithPackage(pkg).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if !contains(string(files["deno.json"]), `"name": "@acme/testtoken-mcp-server",`) || contains(string(files["src/server.ts"]), "#!") {
+                t.Errorf("Unexpected Deno package:\n%s", files["deno.json"])
+        }
+
+        if _, err := NewTypeScriptTemplateRenderer().WithPackage(PackageInfo{Version: "latest"}).Render(contract); err == nil {
+                t.Errorf("Expected an error for an invalid version")
+        }
+}
+
+// TestTypeScriptTemplateRendererDeterministic tests that rendering the same IR twice yields identical files
+func TestTypeScriptTemplateRendererDeterministic(t *testing.T) {
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:    "TestToken",
+                        Address: "0x1234567890123456789012345678901234567890",
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "balanceOf",
+                                StateMutability: ir.View,
+                                Inputs:          []ir.Parameter{{Name: "account", Type: ir.ParameterType{BaseType: "address", ChainData: map[string]interface{}{"internalType": "address", "indexed": false}}}},
+                                Outputs:         []ir.Parameter{{Type: ir.ParameterType{BaseType: "uint256"}}},
+                        },
+                        {
+                                Name:            "transfer",
+                                StateMutability: ir.Nonpayable,
+                                Inputs:          []ir.Parameter{{Name: "to", Type: ir.ParameterType{BaseType: "address"}}, {Name: "amount", Type: ir.ParameterType{BaseType: "uint256"}}},
+                        },
+                },
+                Events: []ir.Event{
+                        {
+                                Name:       "Transfer",
+                                Parameters: []ir.EventParameter{{Name: "from", Type: ir.ParameterType{BaseType: "address"}, Indexed: true}},
+                        },
+                },
+        }
+
+        render := func() map[string][]byte {
+                files, err := NewTypeScriptTemplateRenderer().WithWrites(true).WithCache(true).WithSubscriptions(true).WithDocker(true).WithCI(CIGitHub).Render(contract)
+                if err != nil {
+                        t.Fatalf("Failed to render templates: %v", err)
+                }
+                return files
+        }
+
+        first, second := render(), render()
+        if len(first) != len(second) {
+                t.Fatalf("Expected %d files but got %d", len(first), len(second))
+        }
+        for path, content := range first {
+                if !bytes.Equal(content, second[path]) {
+                        t.Errorf("%s differs between renders", path)
+                }
+        }
+}
+
+func TestTypeScriptTemplateRendererAPI(t *testing.T) {
+        optional := map[string]interface{}{ir.ParameterInKey: "query", ir.OptionalKey: true}
+        contract := &ir.ContractIR{
+                Metadata: ir.ContractMetadata{
+                        Name:      "Petstore",
+                        Chain:     ir.ChainOpenAPI,
+                        ChainData: map[string]interface{}{ir.BaseURLKey: "https://petstore.example.com/v1"},
+                },
+                Functions: []ir.Function{
+                        {
+                                Name:            "listPets",
+                                Description:     "List pets",
+                                StateMutability: ir.View,
+                                Inputs:          []ir.Parameter{{Name: "limit", Type: ir.ParameterType{BaseType: ir.APIInteger, ChainData: optional}}},
+                                ChainData:       map[string]interface{}{ir.HTTPMethodKey: "GET", ir.HTTPPathKey: "/pets"},
+                        },
+                        {
+                                Name:            "deletePet",
+                                StateMutability: ir.Nonpayable,
+                                Inputs:          []ir.Parameter{{Name: "petId", Type: ir.ParameterType{BaseType: ir.APIString, ChainData: map[string]interface{}{ir.ParameterInKey: "path"}}}},
+                                ChainData:       map[string]interface{}{ir.HTTPMethodKey: "DELETE", ir.HTTPPathKey: "/pets/{petId}"},
+                        },
+                },
+        }
+
+        files, err := NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        var paths []string
+        for path := range files {
+                paths = append(paths, path)
+        }
+        sort.Strings(paths)
+        if got := strings.Join(paths, ","); got != "README.md,package.json,src/server.ts,src/tools.ts,tsconfig.json" {
+                t.Errorf("Unexpected API server files %s", got)
+        }
+
+        toolsTS := string(files["src/tools.ts"])
+        for _, expected := range []string{
+                `export const BASE_URL = process.env.API_BASE_URL ?? "https://petstore.example.com/v1";`,
+                `name: "listPets",`,
+                `"type": "integer"`,
+                `"listPets": {"method":"GET","path":"/pets","in":{"limit":"query"}},`,
+        } {
+                if !contains(toolsTS, expected) {
+                        t.Errorf("tools.ts does not contain %q", expected)
+                }
+        }
+        if contains(toolsTS, `"required": [
+      "limit"`) {
+                t.Errorf("Optional inputs are required in tools.ts")
+        }
+        if contains(toolsTS, "deletePet") {
+                t.Errorf("Expected operations other than GET to need writes enabled")
+        }
+        if !contains(string(files["README.md"]), "- **listPets** (`GET /pets`): List pets") {
+                t.Errorf("README.md does not list the tool with its operation")
+        }
+
+        files, err = NewTypeScriptTemplateRenderer().WithWrites(true).Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if !contains(string(files["src/tools.ts"]), `"deletePet": {"method":"DELETE","path":"/pets/{petId}","in":{"petId":"path"}},`) {
+                t.Errorf("Expected the DELETE operation with writes enabled")
+        }
+
+        // JSON-RPC methods are sent by name
+        contract.Metadata.Chain = ir.ChainOpenRPC
+        contract.Functions = []ir.Function{{
+                Name:            "counterAdd",
+                StateMutability: ir.View,
+                Inputs:          []ir.Parameter{{Name: "amount", Type: ir.ParameterType{BaseType: ir.APINumber}}},
+                ChainData:       map[string]interface{}{ir.RPCMethodKey: "counter_add", ir.ParamsByPositionKey: true},
+        }}
+        files, err = NewTypeScriptTemplateRenderer().Render(contract)
+        if err != nil {
+                t.Fatalf("Failed to render templates: %v", err)
+        }
+        if !contains(string(files["src/tools.ts"]), `"counterAdd": {"method":"counter_add","params":["amount"]},`) {
+                t.Errorf("tools.ts does not send the JSON-RPC method by position")
+        }
+
+        if _, err := NewTypeScriptTemplateRenderer().WithTransport(TransportHTTP).Render(contract); err == nil {
+                t.Errorf("Expected an error for the HTTP transport")
+        }
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
         return strings.Contains(s, substr)
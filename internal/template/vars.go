@@ -0,0 +1,27 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// varNamePattern matches names of template variables, usable in templates as {{ .Vars.name }}
+var varNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseVars parses template variables given as "<name>=<value>", e.g. "org=acme"; later values of a name replace
+// earlier ones
+func ParseVars(values []string) (map[string]string, error) {
+	vars := make(map[string]string, len(values))
+	for _, value := range values {
+		name, varValue, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid template variable %q (expected <name>=<value>)", value)
+		}
+		if !varNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid template variable name %q: use letters, digits and '_', not starting with a digit", name)
+		}
+		vars[name] = varValue
+	}
+	return vars, nil
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"org=acme", "url=https://acme.dev/?a=b", "org=Acme Inc", "empty="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars["org"] != "Acme Inc" || vars["url"] != "https://acme.dev/?a=b" || vars["empty"] != "" || len(vars) != 3 {
+		t.Errorf("Unexpected vars: %v", vars)
+	}
+
+	for _, value := range []string{"org", "my-org=acme", "1st=x", "=x"} {
+		if _, err := ParseVars([]string{value}); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestRenderVars(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"name": "{{ .Metadata.Name }}", "author": "{{ .Vars.org }}{{ with .Vars.missing }} ({{ . }}){{ end }}"}`
+	if err := os.WriteFile(filepath.Join(dir, "package.json.tmpl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	renderer := NewTypeScriptTemplateRenderer().WithTemplateDir(dir).WithVars(map[string]string{"org": "acme"})
+	files, err := renderer.Render(&ir.ContractIR{Metadata: ir.ContractMetadata{Name: "Token", Chain: "ethereum"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg := string(files["package.json"]); pkg != `{"name": "Token", "author": "acme"}` {
+		t.Errorf("Expected package.json to use the variable but got %q", pkg)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+// ZodSchema compiles an IR parameter into a zod validator expression for the generated server
+// The expression relies on the addressSchema, uintSchema, intSchema, bytesSchema, amountSchema and
+// jsonValue helpers emitted by server.ts.tmpl
+// Parameters with a default are optional: relative timestamp defaults are computed when the tool is called.
+func ZodSchema(p ir.Parameter) string {
+	schema := zodType(p.Type)
+	if seconds, ok := p.RelativeDefault(); ok {
+		schema += fmt.Sprintf(".default(() => String(Math.floor(Date.now() / 1000) + %d))", seconds)
+	} else if p.Default != nil {
+		value, _ := marshalUnescaped(p.Default)
+		schema += ".default(" + string(value) + ")"
+	}
+	if p.Description != "" {
+		schema += ".describe(" + jsString(p.Description) + ")"
+	}
+	return schema
+}
+
+// ZodValueSchema compiles the value input of a payable function into a zod validator expression
+// Values are taken in wei; the bounds of the function's value constraint are checked once converted to a bigint.
+func ZodValueSchema(f ir.Function) string {
+	if f.Value == nil {
+		return `uintSchema(256).optional().describe("Optional ETH value to send with the transaction (in wei)")`
+	}
+	schema := "uintSchema(256)"
+	min, max := f.Value.MinWei(), f.Value.MaxWei()
+	var checks []string
+	if min != nil {
+		checks = append(checks, fmt.Sprintf("value >= %sn", min))
+	}
+	if max != nil {
+		checks = append(checks, fmt.Sprintf("value <= %sn", max))
+	}
+	if len(checks) > 0 {
+		message := jsString("Value must be " + f.Value.Bounds())
+		schema += fmt.Sprintf(".refine((value) => %s, { message: %s })", strings.Join(checks, " && "), message)
+	}
+	if !f.Value.Required {
+		schema += ".optional()"
+	}
+	return schema + ".describe(" + jsString(f.Value.Usage()) + ")"
+}
+
+// zodType compiles an IR parameter type into a zod validator expression
+func zodType(t ir.ParameterType) string {
+	schema := zodElementType(t)
+
+	if t.IsArray {
+		schema = "z.array(" + schema + ")"
+		if t.ArraySize > 0 {
+			schema += fmt.Sprintf(".length(%d)", t.ArraySize)
+		}
+	}
+
+	// Agents frequently pass arrays and structs as JSON strings
+	if t.IsArray || len(t.Components) > 0 {
+		schema = "jsonValue(" + schema + ")"
+	}
+
+	return schema
+}
+
+// zodElementType compiles the element type of an IR parameter type
+func zodElementType(t ir.ParameterType) string {
+	if len(t.Components) > 0 {
+		fields := make([]string, len(t.Components))
+		for i, component := range t.Components {
+			name := component.Name
+			if name == "" {
+				name = fmt.Sprintf("field%d", i)
+			}
+			fields[i] = name + ": " + ZodSchema(component)
+		}
+		return "z.object({ " + strings.Join(fields, ", ") + " })"
+	}
+
+	// Token amounts are given in token units and converted once the decimals are known
+	if _, _, ok := t.AmountDecimals(); ok {
+		return "amountSchema"
+	}
+
+	switch base := t.BaseType; {
+	case base == "address":
+		return "addressSchema"
+	case base == "bool":
+		return "z.boolean()"
+	case base == "string":
+		return "z.string()"
+	case base == "bytes":
+		return "bytesSchema()"
+	case strings.HasPrefix(base, "bytes"):
+		if size, ok := typeBits(base, "bytes"); ok {
+			return fmt.Sprintf("bytesSchema(%d)", size)
+		}
+	case strings.HasPrefix(base, "uint"):
+		if bits, ok := typeBits(base, "uint"); ok {
+			return fmt.Sprintf("uintSchema(%d)", bits)
+		}
+	case strings.HasPrefix(base, "int"):
+		if bits, ok := typeBits(base, "int"); ok {
+			return fmt.Sprintf("intSchema(%d)", bits)
+		}
+	}
+
+	// Unknown or chain-specific types are passed through as strings
+	return "z.string()"
+}
+
+// typeBits parses the size suffix of a sized type such as uint64 or bytes32
+// A missing suffix means the default size (256 bits for integers)
+func typeBits(base, prefix string) (int, bool) {
+	suffix := strings.TrimPrefix(base, prefix)
+	if suffix == "" {
+		return 256, prefix != "bytes"
+	}
+	size, err := strconv.Atoi(suffix)
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+	return size, true
+}
+
+// jsString quotes a Go string as a JavaScript string literal
+func jsString(s string) string {
+	quoted, _ := marshalUnescaped(s)
+	return string(quoted)
+}
\ No newline at end of file
@@ -0,0 +1,102 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/openhands/mcp-generator/internal/ir"
+)
+
+func TestZodSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    ir.Parameter
+		expected string
+	}{
+		{"Address", ir.Parameter{Type: ir.ParameterType{BaseType: "address"}}, "addressSchema"},
+		{"Uint Default Size", ir.Parameter{Type: ir.ParameterType{BaseType: "uint"}}, "uintSchema(256)"},
+		{"Uint8", ir.Parameter{Type: ir.ParameterType{BaseType: "uint8"}}, "uintSchema(8)"},
+		{"Int128", ir.Parameter{Type: ir.ParameterType{BaseType: "int128"}}, "intSchema(128)"},
+		{"Bytes", ir.Parameter{Type: ir.ParameterType{BaseType: "bytes"}}, "bytesSchema()"},
+		{"Bytes32", ir.Parameter{Type: ir.ParameterType{BaseType: "bytes32"}}, "bytesSchema(32)"},
+		{"Bool", ir.Parameter{Type: ir.ParameterType{BaseType: "bool"}}, "z.boolean()"},
+		{"Unknown", ir.Parameter{Type: ir.ParameterType{BaseType: "felt252"}}, "z.string()"},
+		{"Token Amount", ir.Parameter{Type: ir.ParameterType{BaseType: "uint256", ChainData: map[string]interface{}{ir.DecimalsKey: 18}}}, "amountSchema"},
+		{
+			"Description",
+			ir.Parameter{Type: ir.ParameterType{BaseType: "string"}, Description: `The "owner" name`},
+			`z.string().describe("The \"owner\" name")`,
+		},
+		{
+			"Default",
+			ir.Parameter{Type: ir.ParameterType{BaseType: "string"}, Default: "latest", Description: "Block tag"},
+			`z.string().default("latest").describe("Block tag")`,
+		},
+		{
+			"Relative Default",
+			ir.Parameter{Type: ir.ParameterType{BaseType: "uint256"}, Unit: ir.UnitTimestamp, Default: "+1200"},
+			"uintSchema(256).default(() => String(Math.floor(Date.now() / 1000) + 1200))",
+		},
+		{
+			"Dynamic Array",
+			ir.Parameter{Type: ir.ParameterType{BaseType: "address", IsArray: true}},
+			"jsonValue(z.array(addressSchema))",
+		},
+		{
+			"Fixed Array",
+			ir.Parameter{Type: ir.ParameterType{BaseType: "uint256", IsArray: true, ArraySize: 3}},
+			"jsonValue(z.array(uintSchema(256)).length(3))",
+		},
+		{
+			"Tuple",
+			ir.Parameter{
+				Type: ir.ParameterType{
+					BaseType: "tuple",
+					Components: []ir.Parameter{
+						{Name: "to", Type: ir.ParameterType{BaseType: "address"}},
+						{Type: ir.ParameterType{BaseType: "bool"}},
+					},
+				},
+			},
+			"jsonValue(z.object({ to: addressSchema, field1: z.boolean() }))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ZodSchema(tt.param); got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestZodValueSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *ir.ValueConstraint
+		expected string
+	}{
+		{"Unconstrained", nil, `uintSchema(256).optional().describe("Optional ETH value to send with the transaction (in wei)")`},
+		{
+			"Bounded",
+			&ir.ValueConstraint{Min: "0.01", Max: "1", Typical: "0.05"},
+			`uintSchema(256).refine((value) => value >= 10000000000000000n && value <= 1000000000000000000n, { message: "Value must be between 0.01 and 1 ETH" })` +
+				`.optional().describe("Optional native currency value to send with the transaction, in wei (1 ETH = 10^18 wei): between 0.01 and 1 ETH, typically 0.05 ETH")`,
+		},
+		{
+			"Required",
+			&ir.ValueConstraint{Min: "2", Currency: "MATIC", Required: true},
+			`uintSchema(256).refine((value) => value >= 2000000000000000000n, { message: "Value must be at least 2 MATIC" })` +
+				`.describe("Native currency value to send with the transaction, in wei (1 MATIC = 10^18 wei): at least 2 MATIC")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := ir.Function{Name: "mint", StateMutability: ir.Payable, Value: tt.value}
+			if got := ZodValueSchema(f); got != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, got)
+			}
+		})
+	}
+}
\ No newline at end of file